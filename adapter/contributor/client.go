@@ -26,6 +26,16 @@ type Mapping struct {
 	VcsUserID     int64
 }
 
+type Profile struct {
+	ContributorID types.ID
+	VcsUserID     int64
+	VcsUsername   string
+	DisplayName   string
+	ProfileImage  string
+	PrivacyMode   string
+	Timezone      string
+}
+
 
 func New(rpcClient *grpc.RPCClient) (*Client, error) {
 	if rpcClient == nil || rpcClient.Conn == nil {
@@ -86,3 +96,35 @@ func (c *Client) GetContributorsByVCS(ctx context.Context, vcsProvider string, u
 
 	return mappings, nil
 }
+
+// GetProfilesByVCS returns the public profiles of the given VCS user IDs.
+// IDs without a registered contributor are not part of the result.
+func (c *Client) GetProfilesByVCS(ctx context.Context, vcsProvider string, userIDs []int64) ([]Profile, error) {
+	req := &contributorpb.GetContributorProfilesByVCSRequest{
+		VcsProvider: vcsProvider,
+		VcsUserIds:  userIDs,
+	}
+
+	res, err := c.contributorService.GetContributorProfilesByVCS(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, fmt.Errorf("empty contributor profiles response")
+	}
+
+	profiles := make([]Profile, 0, len(res.Profiles))
+	for _, p := range res.Profiles {
+		profiles = append(profiles, Profile{
+			ContributorID: types.ID(p.ContributorId),
+			VcsUserID:     p.VcsUserId,
+			VcsUsername:   p.VcsUsername,
+			DisplayName:   p.DisplayName,
+			ProfileImage:  p.ProfileImage,
+			PrivacyMode:   p.PrivacyMode,
+			Timezone:      p.Timezone,
+		})
+	}
+
+	return profiles, nil
+}
@@ -2,10 +2,17 @@ package leaderboardscoring
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"time"
+
 	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/grpc"
 	leaderboardscoringpb "github.com/gocasters/rankr/protobuf/golang/leaderboardscoring/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Client struct {
@@ -43,9 +50,114 @@ func (c *Client) GetLeaderboard(ctx context.Context, getLeaderboardReq *lbscorin
 	return getLeaderboardRes, nil
 }
 
-func protobufToLeaderboardRes(leaderboardPBRes *leaderboardscoringpb.GetLeaderboardResponse) *lbscoring.GetLeaderboardResponse {
-	var rows = make([]lbscoring.LeaderboardRow, 0, len(leaderboardPBRes.Rows))
-	for _, r := range leaderboardPBRes.Rows {
+// WatchLeaderboard passes every update of a leaderboard to handle until ctx is cancelled
+// or handle returns an error. A dropped stream is reopened with the version of the last
+// update, so the server does not resend a page the client already has.
+func (c *Client) WatchLeaderboard(
+	ctx context.Context,
+	watchReq *lbscoring.WatchLeaderboardRequest,
+	handle func(update *lbscoring.LeaderboardUpdate) error,
+) error {
+	resumeVersion := watchReq.ResumeVersion
+	backoff := watchMinBackoff
+
+	for {
+		received, err := c.watchOnce(ctx, watchReq, resumeVersion, func(update *lbscoring.LeaderboardUpdate) error {
+			resumeVersion = update.Version
+			return handle(update)
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var handleErr *watchHandleError
+		if errors.As(err, &handleErr) {
+			return handleErr.err
+		}
+
+		switch status.Code(err) {
+		case codes.OK, codes.Unavailable, codes.Internal, codes.Unknown:
+		default:
+			return err
+		}
+
+		if received {
+			backoff = watchMinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, watchMaxBackoff)
+	}
+}
+
+// watchOnce runs a single stream and reports whether any update was received.
+func (c *Client) watchOnce(
+	ctx context.Context,
+	watchReq *lbscoring.WatchLeaderboardRequest,
+	resumeVersion uint64,
+	handle func(update *lbscoring.LeaderboardUpdate) error,
+) (bool, error) {
+	stream, err := c.leaderboardScoringClient.WatchLeaderboard(ctx, &leaderboardscoringpb.WatchLeaderboardRequest{
+		Timeframe:     lbscoring.ToProtoTimeframe(watchReq.Timeframe),
+		ProjectId:     watchReq.ProjectID,
+		PageSize:      watchReq.PageSize,
+		MinIntervalMs: int32(watchReq.MinInterval / time.Millisecond),
+		ResumeVersion: resumeVersion,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		updatePB, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return received, nil
+		}
+		if err != nil {
+			return received, err
+		}
+
+		received = true
+		if err := handle(protobufToLeaderboardUpdate(updatePB)); err != nil {
+			return received, &watchHandleError{err: err}
+		}
+	}
+}
+
+const (
+	watchMinBackoff = time.Second
+	watchMaxBackoff = 30 * time.Second
+)
+
+// watchHandleError marks errors returned by the caller's handler, they end the watch.
+type watchHandleError struct {
+	err error
+}
+
+func (e *watchHandleError) Error() string {
+	return e.err.Error()
+}
+
+func protobufToLeaderboardUpdate(updatePB *leaderboardscoringpb.LeaderboardUpdate) *lbscoring.LeaderboardUpdate {
+	return &lbscoring.LeaderboardUpdate{
+		Timeframe:       lbscoring.FromProtoTimeframe(updatePB.Timeframe),
+		ProjectID:       updatePB.ProjectId,
+		LeaderboardRows: protobufToLeaderboardRows(updatePB.Rows),
+		RankingMode:     lbscoring.FromProtoRankingMode(updatePB.RankingMode),
+		Version:         updatePB.Version,
+		Initial:         updatePB.Initial,
+	}
+}
+
+func protobufToLeaderboardRows(rowsPB []*leaderboardscoringpb.LeaderboardRow) []lbscoring.LeaderboardRow {
+	var rows = make([]lbscoring.LeaderboardRow, 0, len(rowsPB))
+	for _, r := range rowsPB {
 		row := lbscoring.LeaderboardRow{
 			Rank:   int64(r.Rank),
 			UserID: r.UserId,
@@ -54,14 +166,163 @@ func protobufToLeaderboardRes(leaderboardPBRes *leaderboardscoringpb.GetLeaderbo
 		rows = append(rows, row)
 	}
 
+	return rows
+}
+
+func protobufToLeaderboardRes(leaderboardPBRes *leaderboardscoringpb.GetLeaderboardResponse) *lbscoring.GetLeaderboardResponse {
 	var getLeaderboardRes = &lbscoring.GetLeaderboardResponse{
 		Timeframe:       lbscoring.FromProtoTimeframe(leaderboardPBRes.Timeframe),
 		ProjectID:       leaderboardPBRes.ProjectId,
-		LeaderboardRows: rows,
+		LeaderboardRows: protobufToLeaderboardRows(leaderboardPBRes.Rows),
+		RankingMode:     lbscoring.FromProtoRankingMode(leaderboardPBRes.RankingMode),
 	}
 	return getLeaderboardRes
 }
 
+func (c *Client) GetLeaderboardAsOf(ctx context.Context, asOfReq *lbscoring.GetLeaderboardAsOfRequest) (*lbscoring.GetLeaderboardAsOfResponse, error) {
+	asOfPBRes, err := c.leaderboardScoringClient.GetLeaderboardAsOf(ctx, &leaderboardscoringpb.GetLeaderboardAsOfRequest{
+		ProjectId: asOfReq.ProjectID,
+		AsOf:      timestamppb.New(asOfReq.AsOf),
+		PageSize:  asOfReq.PageSize,
+		Offset:    asOfReq.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lbscoring.GetLeaderboardAsOfResponse{
+		ProjectID:       asOfPBRes.ProjectId,
+		SnapshotAt:      asOfPBRes.GetSnapshotAt().AsTime(),
+		LeaderboardRows: protobufToLeaderboardRows(asOfPBRes.Rows),
+	}, nil
+}
+
+func (c *Client) GetUserRankHistory(ctx context.Context, historyReq *lbscoring.GetUserRankHistoryRequest) (*lbscoring.GetUserRankHistoryResponse, error) {
+	historyPBReq := &leaderboardscoringpb.GetUserRankHistoryRequest{
+		UserId:    historyReq.UserID,
+		ProjectId: historyReq.ProjectID,
+	}
+	if !historyReq.From.IsZero() {
+		historyPBReq.From = timestamppb.New(historyReq.From)
+	}
+	if !historyReq.To.IsZero() {
+		historyPBReq.To = timestamppb.New(historyReq.To)
+	}
+
+	historyPBRes, err := c.leaderboardScoringClient.GetUserRankHistory(ctx, historyPBReq)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]lbscoring.RankHistoryPoint, 0, len(historyPBRes.Points))
+	for _, p := range historyPBRes.Points {
+		points = append(points, lbscoring.RankHistoryPoint{
+			SnapshotAt: p.GetSnapshotAt().AsTime(),
+			Rank:       int64(p.Rank),
+			Score:      int64(p.Score),
+		})
+	}
+
+	return &lbscoring.GetUserRankHistoryResponse{
+		UserID:    historyPBRes.UserId,
+		ProjectID: historyPBRes.ProjectId,
+		Points:    points,
+	}, nil
+}
+
+func (c *Client) ListUserBadges(ctx context.Context, badgesReq lbscoring.ListUserBadgesRequest) ([]lbscoring.BadgeAward, error) {
+	badgesPBRes, err := c.leaderboardScoringClient.ListUserBadges(ctx, &leaderboardscoringpb.ListUserBadgesRequest{
+		UserId: badgesReq.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	awards := make([]lbscoring.BadgeAward, 0, len(badgesPBRes.Badges))
+	for _, b := range badgesPBRes.Badges {
+		awards = append(awards, lbscoring.BadgeAward{
+			UserID:      badgesPBRes.UserId,
+			BadgeID:     b.Id,
+			Name:        b.Name,
+			Description: b.Description,
+			ProjectID:   b.GetProjectId(),
+			EventID:     b.EventId,
+			AwardedAt:   b.GetAwardedAt().AsTime(),
+		})
+	}
+
+	return awards, nil
+}
+
+func (c *Client) GetUserStreak(ctx context.Context, streakReq lbscoring.GetUserStreakRequest) (lbscoring.Streak, error) {
+	streakPBRes, err := c.leaderboardScoringClient.GetUserStreak(ctx, &leaderboardscoringpb.GetUserStreakRequest{
+		UserId: streakReq.UserID,
+	})
+	if err != nil {
+		return lbscoring.Streak{}, err
+	}
+
+	streak := lbscoring.Streak{
+		UserID:   streakPBRes.UserId,
+		Current:  streakPBRes.CurrentStreak,
+		Longest:  streakPBRes.LongestStreak,
+		Timezone: streakPBRes.Timezone,
+	}
+	if day := streakPBRes.GetLastActiveDay(); day != "" {
+		lastActiveDay, err := time.Parse(time.DateOnly, day)
+		if err != nil {
+			return lbscoring.Streak{}, fmt.Errorf("parse last active day: %w", err)
+		}
+		streak.LastActiveDay = lastActiveDay
+	}
+
+	return streak, nil
+}
+
+func (c *Client) ExplainScore(ctx context.Context, explainReq lbscoring.ExplainScoreRequest) (lbscoring.ScoreExplanation, error) {
+	explainPBRes, err := c.leaderboardScoringClient.ExplainScore(ctx, &leaderboardscoringpb.ExplainScoreRequest{
+		UserId:    explainReq.UserID,
+		Timeframe: lbscoring.ToProtoTimeframe(explainReq.Timeframe),
+		ProjectId: explainReq.ProjectID,
+		Period:    explainReq.Period,
+	})
+	if err != nil {
+		return lbscoring.ScoreExplanation{}, err
+	}
+
+	explanation := lbscoring.ScoreExplanation{
+		UserID:      explainPBRes.UserId,
+		Timeframe:   lbscoring.FromProtoTimeframe(explainPBRes.Timeframe),
+		ProjectID:   explainPBRes.GetProjectId(),
+		Total:       explainPBRes.Total,
+		Events:      explainPBRes.Events,
+		ByEventType: protobufToScoreGroups(explainPBRes.ByEventType),
+		ByProject:   protobufToScoreGroups(explainPBRes.ByProject),
+		ByDay:       protobufToScoreGroups(explainPBRes.ByDay),
+	}
+	if explainPBRes.From != nil {
+		explanation.From = explainPBRes.From.AsTime()
+	}
+	if explainPBRes.To != nil {
+		explanation.To = explainPBRes.To.AsTime()
+	}
+
+	return explanation, nil
+}
+
+func protobufToScoreGroups(groupsPB []*leaderboardscoringpb.ScoreGroup) []lbscoring.ScoreGroup {
+	groups := make([]lbscoring.ScoreGroup, 0, len(groupsPB))
+	for _, g := range groupsPB {
+		groups = append(groups, lbscoring.ScoreGroup{
+			Key:    g.Key,
+			Points: g.Points,
+			Events: g.Events,
+		})
+	}
+
+	return groups
+}
+
 func (c *Client) Close() {
 	if c.rpcClient != nil {
 		c.rpcClient.Close()
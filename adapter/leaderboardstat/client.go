@@ -49,9 +49,26 @@ func (c *Client) GetContributorStats(ctx context.Context, contributorStatsReq *l
 		TotalScore:    statPbRes.TotalScore,
 		ProjectsScore: slice.MapFromUint64Float64ToIDFloat64(statPbRes.ProjectsScore),
 		ScoreHistory:  convertScoreHistory(statPbRes.ScoreHistory),
+		Analytics:     convertAnalytics(statPbRes.GetAnalytics()),
 	}, nil
 }
 
+func convertAnalytics(pbAnalytics *leaderboardstatpb.ContributorAnalytics) lbstat.ContributorAnalytics {
+	if pbAnalytics == nil {
+		return lbstat.ContributorAnalytics{}
+	}
+
+	return lbstat.ContributorAnalytics{
+		GlobalPercentile:      pbAnalytics.GlobalPercentile,
+		ProjectPercentiles:    slice.MapFromUint64Float64ToIDFloat64(pbAnalytics.ProjectPercentiles),
+		WeeklyRankChange:      pbAnalytics.WeeklyRankChange,
+		MonthlyRankChange:     pbAnalytics.MonthlyRankChange,
+		WeeklyVelocity:        pbAnalytics.WeeklyVelocity,
+		MonthlyVelocity:       pbAnalytics.MonthlyVelocity,
+		ProjectedMonthEndRank: uint(pbAnalytics.ProjectedMonthEndRank),
+	}
+}
+
 func convertScoreHistory(pbMap map[uint64]*leaderboardstatpb.ProjectScoreHistory) map[types.ID][]lbstat.ScoreEntry {
 	result := make(map[types.ID][]lbstat.ScoreEntry, len(pbMap))
 
@@ -69,3 +86,22 @@ func convertScoreHistory(pbMap map[uint64]*leaderboardstatpb.ProjectScoreHistory
 
 	return result
 }
+
+func (c *Client) GetContributorPeriodScores(ctx context.Context, periodReq lbstat.ContributorPeriodScoresRequest) (lbstat.ContributorPeriodScores, error) {
+	periodPbRes, err := c.leaderboardStatClient.GetContributorPeriodScores(ctx, &leaderboardstatpb.ContributorPeriodScoresRequest{
+		ContributorId: uint64(periodReq.ContributorID),
+		Timeframe:     periodReq.Timeframe,
+		Period:        periodReq.Period,
+	})
+	if err != nil {
+		return lbstat.ContributorPeriodScores{}, err
+	}
+
+	return lbstat.ContributorPeriodScores{
+		ContributorID: types.ID(periodPbRes.ContributorId),
+		Timeframe:     periodPbRes.Timeframe,
+		Period:        periodPbRes.Period,
+		TotalScore:    periodPbRes.TotalScore,
+		ProjectsScore: slice.MapFromUint64Float64ToIDFloat64(periodPbRes.ProjectsScore),
+	}, nil
+}
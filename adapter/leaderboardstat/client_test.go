@@ -16,18 +16,24 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // Mock gRPC server for testing
 type mockLeaderboardStatServer struct {
 	leaderboardstatpb.UnimplementedLeaderboardStatServiceServer
 	getContributorStatsFunc func(ctx context.Context, req *leaderboardstatpb.ContributorStatRequest) (*leaderboardstatpb.ContributorStatResponse, error)
+	getPeriodScoresFunc     func(ctx context.Context, req *leaderboardstatpb.ContributorPeriodScoresRequest) (*leaderboardstatpb.ContributorPeriodScoresResponse, error)
 }
 
 func (m *mockLeaderboardStatServer) GetContributorStats(ctx context.Context, req *leaderboardstatpb.ContributorStatRequest) (*leaderboardstatpb.ContributorStatResponse, error) {
 	return m.getContributorStatsFunc(ctx, req)
 }
 
+func (m *mockLeaderboardStatServer) GetContributorPeriodScores(ctx context.Context, req *leaderboardstatpb.ContributorPeriodScoresRequest) (*leaderboardstatpb.ContributorPeriodScoresResponse, error) {
+	return m.getPeriodScoresFunc(ctx, req)
+}
+
 func startTestServer(t *testing.T, server leaderboardstatpb.LeaderboardStatServiceServer) (*grpc.Server, string) {
 	lis, err := net.Listen("tcp", ":0")
 	require.NoError(t, err)
@@ -102,6 +108,13 @@ func TestClient_GetContributorStats_Success(t *testing.T) {
 				GlobalRank:    5,
 				TotalScore:    1000.0,
 				ProjectsScore: map[uint64]float64{1: 500.0, 2: 500.0},
+				Analytics: &leaderboardstatpb.ContributorAnalytics{
+					GlobalPercentile:      90,
+					ProjectPercentiles:    map[uint64]float64{1: 75},
+					WeeklyRankChange:      proto.Int64(2),
+					WeeklyVelocity:        12.5,
+					ProjectedMonthEndRank: 3,
+				},
 			}, nil
 		},
 	}
@@ -126,6 +139,14 @@ func TestClient_GetContributorStats_Success(t *testing.T) {
 	assert.Equal(t, float64(1000), resp.TotalScore)
 	assert.Len(t, resp.ProjectsScore, 2)
 	assert.Equal(t, float64(500), resp.ProjectsScore[types.ID(1)])
+
+	assert.Equal(t, float64(90), resp.Analytics.GlobalPercentile)
+	assert.Equal(t, float64(75), resp.Analytics.ProjectPercentiles[types.ID(1)])
+	require.NotNil(t, resp.Analytics.WeeklyRankChange)
+	assert.Equal(t, int64(2), *resp.Analytics.WeeklyRankChange)
+	assert.Nil(t, resp.Analytics.MonthlyRankChange, "no snapshot is 30 days old")
+	assert.Equal(t, 12.5, resp.Analytics.WeeklyVelocity)
+	assert.Equal(t, uint(3), resp.Analytics.ProjectedMonthEndRank)
 }
 
 func TestClient_GetContributorStats_ServerError(t *testing.T) {
@@ -187,6 +208,38 @@ func TestClient_GetContributorStats_ContextCancellation(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestClient_GetContributorPeriodScores_Success(t *testing.T) {
+	mockServer := &mockLeaderboardStatServer{
+		getPeriodScoresFunc: func(ctx context.Context, req *leaderboardstatpb.ContributorPeriodScoresRequest) (*leaderboardstatpb.ContributorPeriodScoresResponse, error) {
+			return &leaderboardstatpb.ContributorPeriodScoresResponse{
+				ContributorId: req.ContributorId,
+				Timeframe:     req.Timeframe,
+				Period:        "2025-W23",
+				TotalScore:    70.0,
+				ProjectsScore: map[uint64]float64{1001: 50.0, 0: 20.0},
+			}, nil
+		},
+	}
+
+	server, addr := startTestServer(t, mockServer)
+	defer server.Stop()
+
+	client := createTestClient(t, addr)
+	defer client.Close()
+
+	resp, err := client.GetContributorPeriodScores(context.Background(), lbstat.ContributorPeriodScoresRequest{
+		ContributorID: 123,
+		Timeframe:     "weekly",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.ID(123), resp.ContributorID)
+	assert.Equal(t, "weekly", resp.Timeframe)
+	assert.Equal(t, "2025-W23", resp.Period)
+	assert.Equal(t, float64(70), resp.TotalScore)
+	assert.Equal(t, float64(50), resp.ProjectsScore[types.ID(1001)])
+}
+
 func TestClient_Close(t *testing.T) {
 	// Create a real connection to test Close functionality
 	lis, err := net.Listen("tcp", ":0")
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 // StorageTypeEnum defines valid storage types
@@ -116,6 +117,18 @@ type Adapter struct {
 	logger Logger
 }
 
+// StoredMsg is a message read back from the stream, it is not consumed by reading it.
+type StoredMsg struct {
+	Sequence uint64
+	Subject  string
+	Header   nats.Header
+	Data     []byte
+	Time     time.Time
+}
+
+// ErrMsgNotFound is returned for sequences that are not (or no longer) in the stream
+var ErrMsgNotFound = errors.New("message not found")
+
 func New(config Config, logger Logger) (*Adapter, error) {
 	config.SetDefaults()
 	if errs := config.Validate(); len(errs) > 0 {
@@ -210,6 +223,16 @@ func (a *Adapter) Publish(ctx context.Context, subject string, data []byte) erro
 	return nil
 }
 
+// PublishMsg publishes a message with headers to msg.Subject
+func (a *Adapter) PublishMsg(ctx context.Context, msg *nats.Msg) error {
+	_, err := a.js.PublishMsg(msg, nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("publish to subject %s: %w", msg.Subject, err)
+	}
+
+	return nil
+}
+
 // PublishAsync publishes a message asynchronously
 func (a *Adapter) PublishAsync(subject string, data []byte) (nats.PubAckFuture, error) {
 	future, err := a.js.PublishAsync(subject, data)
@@ -256,6 +279,108 @@ func (a *Adapter) GetStreamInfo() (*nats.StreamInfo, error) {
 	return a.js.StreamInfo(a.config.StreamName)
 }
 
+// stream returns a handle of the stream for direct message access
+func (a *Adapter) stream(ctx context.Context) (jetstream.Stream, error) {
+	js, err := jetstream.New(a.conn)
+	if err != nil {
+		return nil, fmt.Errorf("get JetStream context: %w", err)
+	}
+
+	stream, err := js.Stream(ctx, a.config.StreamName)
+	if err != nil {
+		return nil, fmt.Errorf("get stream %s: %w", a.config.StreamName, err)
+	}
+
+	return stream, nil
+}
+
+// ReadMsgs returns up to limit stored messages of subject, oldest first, starting at
+// sequence fromSeq. Messages are read one by one without a consumer, so this also works
+// on work queue streams and never changes what consumers receive.
+func (a *Adapter) ReadMsgs(ctx context.Context, subject string, fromSeq uint64, limit int) ([]StoredMsg, error) {
+	stream, err := a.stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]StoredMsg, 0, limit)
+	for seq := max(fromSeq, 1); len(msgs) < limit; {
+		raw, err := stream.GetMsg(ctx, seq, jetstream.WithGetMsgSubject(subject))
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read message after %d: %w", seq, err)
+		}
+
+		msgs = append(msgs, storedMsg(raw))
+		seq = raw.Sequence + 1
+	}
+
+	return msgs, nil
+}
+
+// GetMsg returns the stored message with the given stream sequence.
+func (a *Adapter) GetMsg(ctx context.Context, seq uint64) (StoredMsg, error) {
+	stream, err := a.stream(ctx)
+	if err != nil {
+		return StoredMsg{}, err
+	}
+
+	raw, err := stream.GetMsg(ctx, seq)
+	if errors.Is(err, jetstream.ErrMsgNotFound) {
+		return StoredMsg{}, ErrMsgNotFound
+	}
+	if err != nil {
+		return StoredMsg{}, fmt.Errorf("get message %d: %w", seq, err)
+	}
+
+	return storedMsg(raw), nil
+}
+
+// DeleteMsg removes the stored message with the given stream sequence.
+func (a *Adapter) DeleteMsg(ctx context.Context, seq uint64) error {
+	stream, err := a.stream(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.DeleteMsg(ctx, seq); err != nil {
+		// The API error only comes back as text, look the message up to tell a missing one apart
+		if _, getErr := stream.GetMsg(ctx, seq); errors.Is(getErr, jetstream.ErrMsgNotFound) {
+			return ErrMsgNotFound
+		}
+		return fmt.Errorf("delete message %d: %w", seq, err)
+	}
+
+	return nil
+}
+
+// CountMsgs returns the number of stored messages of subject.
+func (a *Adapter) CountMsgs(ctx context.Context, subject string) (uint64, error) {
+	stream, err := a.stream(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := stream.Info(ctx, jetstream.WithSubjectFilter(subject))
+	if err != nil {
+		return 0, fmt.Errorf("stream info: %w", err)
+	}
+
+	return info.State.Subjects[subject], nil
+}
+
+func storedMsg(raw *jetstream.RawStreamMsg) StoredMsg {
+	return StoredMsg{
+		Sequence: raw.Sequence,
+		Subject:  raw.Subject,
+		Header:   raw.Header,
+		Data:     raw.Data,
+		Time:     raw.Time,
+	}
+}
+
 // Close gracefully closes the connection
 func (a *Adapter) Close() error {
 	if a.conn != nil {
@@ -832,3 +832,57 @@ func TestAdapter_LoggerIntegration(t *testing.T) {
 	// For now, just verify the handlers are set up
 	assert.NotNil(t, adapter.conn)
 }
+
+// Test stored message access
+func TestAdapter_StoredMessages(t *testing.T) {
+	ts := startTestServer(t)
+	defer ts.Shutdown()
+
+	adapter, err := New(Config{
+		URL:             ts.url,
+		StreamName:      "STORED_STREAM",
+		StreamSubjects:  []string{"events", "events.dlq"},
+		StorageType:     StorageMemory,
+		RetentionPolicy: RetentionWorkQueue,
+	}, NewMockLogger())
+	require.NoError(t, err)
+	defer adapter.Close()
+
+	ctx := context.Background()
+	require.NoError(t, adapter.Publish(ctx, "events", []byte("ok")))
+	for _, data := range []string{"a", "b", "c"} {
+		msg := nats.NewMsg("events.dlq")
+		msg.Header.Set("Reason", "failed "+data)
+		msg.Data = []byte(data)
+		require.NoError(t, adapter.PublishMsg(ctx, msg))
+	}
+
+	count, err := adapter.CountMsgs(ctx, "events.dlq")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	msgs, err := adapter.ReadMsgs(ctx, "events.dlq", 0, 2)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, []byte("a"), msgs[0].Data)
+	assert.Equal(t, "failed a", msgs[0].Header.Get("Reason"))
+	assert.Equal(t, []byte("b"), msgs[1].Data)
+
+	rest, err := adapter.ReadMsgs(ctx, "events.dlq", msgs[1].Sequence+1, 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, []byte("c"), rest[0].Data)
+
+	msg, err := adapter.GetMsg(ctx, msgs[0].Sequence)
+	require.NoError(t, err)
+	assert.Equal(t, "events.dlq", msg.Subject)
+
+	require.NoError(t, adapter.DeleteMsg(ctx, msgs[0].Sequence))
+	assert.ErrorIs(t, adapter.DeleteMsg(ctx, msgs[0].Sequence), ErrMsgNotFound)
+	_, err = adapter.GetMsg(ctx, msgs[0].Sequence)
+	assert.ErrorIs(t, err, ErrMsgNotFound)
+
+	count, err = adapter.CountMsgs(ctx, "events.dlq")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
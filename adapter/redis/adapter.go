@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/labstack/gommon/log"
@@ -14,10 +15,25 @@ type Config struct {
 	Port     int    `koanf:"port"`
 	Password string `koanf:"password"`
 	DB       int    `koanf:"db"`
+	// ClusterAddrs switches to Redis Cluster ("host:port" seed nodes); Host, Port and DB are ignored
+	ClusterAddrs []string `koanf:"cluster_addrs"`
+}
+
+func (config Config) IsCluster() bool {
+	return len(config.ClusterAddrs) > 0
 }
 
 func (config Config) Validate() map[string]error {
 	errs := map[string]error{}
+	if config.IsCluster() {
+		for _, addr := range config.ClusterAddrs {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				errs["cluster_addrs"] = fmt.Errorf("invalid redis cluster address %q: %v", addr, err)
+			}
+		}
+		return errs
+	}
+
 	if config.Host == "" {
 		errs["host"] = fmt.Errorf("redis host is empty")
 	}
@@ -45,7 +61,8 @@ func FormatValidationErrors(errs map[string]error) string {
 }
 
 type Adapter struct {
-	client *redis.Client
+	client  *redis.Client
+	cluster *redis.ClusterClient
 }
 
 func New(ctx context.Context, config Config) (*Adapter, error) {
@@ -57,6 +74,10 @@ func New(ctx context.Context, config Config) (*Adapter, error) {
 		return nil, fmt.Errorf("invalid redis configuration: %s", FormatValidationErrors(validationErrors))
 	}
 
+	if config.IsCluster() {
+		return newCluster(ctx, config)
+	}
+
 	addr := fmt.Sprintf("%s:%d", config.Host, config.Port)
 
 	rdb := redis.NewClient(&redis.Options{
@@ -80,12 +101,52 @@ func New(ctx context.Context, config Config) (*Adapter, error) {
 	return &Adapter{client: rdb}, nil
 }
 
+func newCluster(ctx context.Context, config Config) (*Adapter, error) {
+	rdb := redis.NewClusterClient(&redis.ClusterOptions{
+		Addrs:    config.ClusterAddrs,
+		Password: config.Password,
+	})
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		log.Errorf("Failed to connect to Redis Cluster at %v: %v", config.ClusterAddrs, err)
+
+		if cErr := rdb.Close(); cErr != nil {
+			log.Errorf("Error closing Redis Cluster client after connection failure: %v", cErr)
+		}
+
+		return nil, fmt.Errorf("redis cluster connection failed: %w", err)
+	}
+
+	log.Infof("✅ Redis Cluster is up and running at %v", config.ClusterAddrs)
+
+	return &Adapter{cluster: rdb}, nil
+}
+
+// Client returns the standalone client, it is nil in cluster mode. Code that must also
+// run on Redis Cluster should use UniversalClient.
 func (a *Adapter) Client() *redis.Client {
 	return a.client
 }
 
+// UniversalClient returns the cluster client in cluster mode, the standalone client otherwise.
+func (a *Adapter) UniversalClient() redis.UniversalClient {
+	if a.cluster != nil {
+		return a.cluster
+	}
+
+	return a.client
+}
+
 func (a *Adapter) Close() error {
-	if a == nil || a.client == nil {
+	if a == nil {
+		return nil
+	}
+
+	if a.cluster != nil {
+		return a.cluster.Close()
+	}
+
+	if a.client == nil {
 		return nil
 	}
 
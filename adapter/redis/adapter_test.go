@@ -224,3 +224,43 @@ func TestAdapter_Close(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestKeySlot(t *testing.T) {
+	// Reference values from CLUSTER KEYSLOT
+	assert.Equal(t, 12182, KeySlot("foo"))
+	assert.Equal(t, 5061, KeySlot("bar"))
+	assert.Equal(t, KeySlot("user1000"), KeySlot("{user1000}.following"))
+	assert.Equal(t, KeySlot("leaderboard:{1001}:all_time"), KeySlot("leaderboard:{1001}:daily:2025-06-01"))
+	// An empty tag hashes the whole key
+	assert.Equal(t, int(crc16("foo{}{bar}"))%ClusterSlots, KeySlot("foo{}{bar}"))
+}
+
+func TestGroupBySlot(t *testing.T) {
+	keys := []string{
+		"leaderboard:{global}:all_time",
+		"leaderboard:{1001}:all_time",
+		"leaderboard:{global}:monthly:2025-06",
+		"leaderboard:{1001}:monthly:2025-06",
+	}
+
+	assert.Equal(t, [][]string{
+		{"leaderboard:{global}:all_time", "leaderboard:{global}:monthly:2025-06"},
+		{"leaderboard:{1001}:all_time", "leaderboard:{1001}:monthly:2025-06"},
+	}, GroupBySlot(keys))
+	assert.Empty(t, GroupBySlot(nil))
+}
+
+func TestConfigValidate_Cluster(t *testing.T) {
+	assert.Empty(t, Config{ClusterAddrs: []string{"redis-1:7000", "redis-2:7000"}}.Validate())
+
+	vErrors := Config{ClusterAddrs: []string{"redis-1"}}.Validate()
+	assert.Contains(t, vErrors, "cluster_addrs")
+}
+
+func TestAdapter_UniversalClient(t *testing.T) {
+	client, _ := redismock.NewClientMock()
+	defer client.Close()
+
+	adapter := &Adapter{client: client}
+	assert.Equal(t, client, adapter.UniversalClient())
+}
@@ -0,0 +1,61 @@
+package redis
+
+import "strings"
+
+// ClusterSlots is the number of hash slots of a Redis Cluster
+const ClusterSlots = 16384
+
+// HashTag wraps part of a key in braces, so every key sharing the tag is stored in the
+// same hash slot on Redis Cluster, e.g. "leaderboard:" + HashTag("1001") + ":daily".
+func HashTag(tag string) string {
+	return "{" + tag + "}"
+}
+
+// KeySlot returns the Redis Cluster hash slot of key. Like Redis, only the first
+// non-empty {hash tag} is hashed when the key has one.
+func KeySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+
+	return int(crc16(key)) % ClusterSlots
+}
+
+// GroupBySlot splits keys into groups that share a hash slot. Groups and the keys in
+// them keep the order of their first appearance, so callers can map results back.
+func GroupBySlot(keys []string) [][]string {
+	index := make(map[int]int)
+	groups := make([][]string, 0, 2)
+
+	for _, key := range keys {
+		slot := KeySlot(key)
+		i, ok := index[slot]
+		if !ok {
+			i = len(groups)
+			index[slot] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], key)
+	}
+
+	return groups
+}
+
+// crc16 is CRC-16/XMODEM, the checksum Redis Cluster uses for key slots
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
@@ -0,0 +1,175 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	adjustUserID    string
+	adjustProjectID string
+	adjustTimeframe string
+	adjustPeriod    string
+	adjustPoints    int64
+	adjustReason    string
+	adjustActor     string
+	adjustID        int64
+	adjustLimit     int
+)
+
+var adjustCmd = &cobra.Command{
+	Use:   "adjust",
+	Short: "Grant or remove points by hand, with an audit trail",
+	Long: `Adjustments grant points for offline work, such as conference talks or mentoring, or
+remove points after abuse. They are scored like events of their project, limited to the
+boards of their timeframe scope, and every adjustment is kept in the audit trail. An
+adjustment is undone by reversing it, never by deleting it.`,
+}
+
+var adjustCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Apply a positive or negative adjustment",
+	Example: `  leaderboardscoring_service adjust create --user 7 --project 1001 --points 50 --reason "Talk at GopherCon 2025"
+  leaderboardscoring_service adjust create --user 7 --project 1001 --timeframe monthly --period 2025-06 --points -20 --reason "Spam PRs"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		withAdjustmentTool(func(ctx context.Context, tool *leaderboardscoringapp.AdjustmentTool) {
+			adjustment, err := tool.Service.CreateAdjustment(ctx, leaderboardscoring.CreateAdjustmentRequest{
+				UserID:    adjustUserID,
+				ProjectID: adjustProjectID,
+				Timeframe: adjustTimeframe,
+				Period:    adjustPeriod,
+				Points:    adjustPoints,
+				Reason:    adjustReason,
+				Actor:     adjustActor,
+			})
+			reportAdjustment("Applied", adjustment, err)
+		})
+	},
+}
+
+var adjustReverseCmd = &cobra.Command{
+	Use:     "reverse",
+	Short:   "Take the points of an adjustment back",
+	Example: `  leaderboardscoring_service adjust reverse --id 42 --reason "granted to the wrong user"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		withAdjustmentTool(func(ctx context.Context, tool *leaderboardscoringapp.AdjustmentTool) {
+			reversal, err := tool.Service.ReverseAdjustment(ctx, leaderboardscoring.ReverseAdjustmentRequest{
+				ID:     adjustID,
+				Reason: adjustReason,
+				Actor:  adjustActor,
+			})
+			reportAdjustment("Reversed with", reversal, err)
+		})
+	},
+}
+
+var adjustListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List adjustments, latest first",
+	Example: `  leaderboardscoring_service adjust list --user 7 --limit 20`,
+	Run: func(cmd *cobra.Command, args []string) {
+		withAdjustmentTool(listAdjustments)
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{adjustCreateCmd, adjustListCmd} {
+		cmd.Flags().StringVar(&adjustUserID, "user", "", "User ID")
+		cmd.Flags().StringVar(&adjustProjectID, "project", "", "Project ID")
+	}
+	adjustCreateCmd.Flags().StringVar(&adjustTimeframe, "timeframe", leaderboardscoring.AdjustmentScopeAll,
+		"Boards to adjust: all, all_time, yearly, monthly, weekly or daily")
+	adjustCreateCmd.Flags().StringVar(&adjustPeriod, "period", "", "Past period of a period timeframe, e.g. 2025-06 (default current)")
+	adjustCreateCmd.Flags().Int64Var(&adjustPoints, "points", 0, "Points to grant, negative to remove")
+
+	adjustReverseCmd.Flags().Int64Var(&adjustID, "id", 0, "ID of the adjustment to reverse")
+
+	for _, cmd := range []*cobra.Command{adjustCreateCmd, adjustReverseCmd} {
+		cmd.Flags().StringVar(&adjustReason, "reason", "", "Why the points change, kept in the audit trail (required)")
+		cmd.Flags().StringVar(&adjustActor, "actor", "cli:"+os.Getenv("USER"), "Who is running the command, kept in the audit trail")
+	}
+	adjustListCmd.Flags().IntVar(&adjustLimit, "limit", leaderboardscoring.DefaultAdjustmentPageSize, "Maximum number of adjustments to list")
+
+	adjustCmd.AddCommand(adjustCreateCmd, adjustReverseCmd, adjustListCmd)
+	RootCmd.AddCommand(adjustCmd)
+}
+
+func withAdjustmentTool(run func(ctx context.Context, tool *leaderboardscoringapp.AdjustmentTool)) {
+	cfg := loadAppConfig()
+
+	if err := logger.Init(cfg.Logger); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer func() {
+		if err := logger.Close(); err != nil {
+			log.Printf("logger close error: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+	tool, err := leaderboardscoringapp.NewAdjustmentTool(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize adjustment tool: %v", err)
+	}
+	defer tool.Close()
+
+	run(ctx, tool)
+}
+
+func listAdjustments(ctx context.Context, tool *leaderboardscoringapp.AdjustmentTool) {
+	adjustments, err := tool.Service.ListAdjustments(ctx, leaderboardscoring.ListAdjustmentsRequest{
+		UserID:    adjustUserID,
+		ProjectID: adjustProjectID,
+		PageSize:  int32(adjustLimit),
+	})
+	if err != nil {
+		log.Printf("Failed to list adjustments: %v", err)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCREATED AT\tUSER\tPROJECT\tSCOPE\tPOINTS\tACTOR\tSTATUS\tREASON")
+	for _, a := range adjustments {
+		scope := a.Timeframe
+		if a.Period != "" {
+			scope += " " + a.Period
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%+d\t%s\t%s\t%s\n",
+			a.ID, a.CreatedAt.Format(time.RFC3339), a.UserID, a.ProjectID, scope, a.Points,
+			a.Actor, adjustmentStatus(a), a.Reason)
+	}
+	_ = w.Flush()
+
+	log.Printf("%d adjustments listed", len(adjustments))
+}
+
+func adjustmentStatus(a leaderboardscoring.Adjustment) string {
+	switch {
+	case a.ReversalOf != 0:
+		return "reverses " + strconv.FormatInt(a.ReversalOf, 10)
+	case a.ReversedBy != 0:
+		return "reversed by " + strconv.FormatInt(a.ReversedBy, 10)
+	default:
+		return "applied"
+	}
+}
+
+func reportAdjustment(action string, adjustment leaderboardscoring.Adjustment, err error) {
+	if err != nil {
+		log.Printf("Failed to adjust score: %v", err)
+		return
+	}
+
+	log.Printf("%s adjustment %d: %+d points for user %s on %s boards of project %s",
+		action, adjustment.ID, adjustment.Points, adjustment.UserID, adjustment.Timeframe, adjustment.ProjectID)
+}
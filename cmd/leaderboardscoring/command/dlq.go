@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dlqSequence  uint64
+	dlqAll       bool
+	dlqEventID   string
+	dlqUserID    string
+	dlqProjectID string
+	dlqReason    string
+	dlqNote      string
+	dlqActor     string
+	dlqLimit     int
+)
+
+var dlqCmd = &cobra.Command{
+	Use:   "dlq",
+	Short: "Inspect and drain the dead letter queue of processed score events",
+	Long: `Processed score events that could not be persisted after their last delivery attempt
+end up in the dead letter queue. These commands list them with their failure reason, persist
+them again, or discard them. Every reprocessed or discarded letter gets an audit record.`,
+}
+
+var dlqListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List dead letters, oldest first",
+	Example: `  leaderboardscoring_service dlq list --reason "connection refused" --limit 20`,
+	Run: func(cmd *cobra.Command, args []string) {
+		withDLQTool(listDeadLetters)
+	},
+}
+
+var dlqReprocessCmd = &cobra.Command{
+	Use:   "reprocess",
+	Short: "Persist dead letters again and remove them from the queue",
+	Example: `  leaderboardscoring_service dlq reprocess --seq 42
+  leaderboardscoring_service dlq reprocess --project 1001
+  leaderboardscoring_service dlq reprocess --all`,
+	Run: func(cmd *cobra.Command, args []string) {
+		withDLQTool(func(ctx context.Context, tool *leaderboardscoringapp.DLQTool) {
+			res, err := tool.Service.ReprocessDeadLetters(ctx, dlqActionRequest())
+			reportDeadLetterAction("reprocessed", res, err)
+		})
+	},
+}
+
+var dlqDiscardCmd = &cobra.Command{
+	Use:     "discard",
+	Short:   "Remove dead letters from the queue without persisting them",
+	Example: `  leaderboardscoring_service dlq discard --seq 42 --note "duplicate of a replayed event"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		withDLQTool(func(ctx context.Context, tool *leaderboardscoringapp.DLQTool) {
+			res, err := tool.Service.DiscardDeadLetters(ctx, dlqActionRequest())
+			reportDeadLetterAction("discarded", res, err)
+		})
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{dlqListCmd, dlqReprocessCmd, dlqDiscardCmd} {
+		cmd.Flags().StringVar(&dlqEventID, "event-id", "", "Only letters of this event ID")
+		cmd.Flags().StringVar(&dlqUserID, "user", "", "Only letters of this user ID")
+		cmd.Flags().StringVar(&dlqProjectID, "project", "", "Only letters of this project ID")
+		cmd.Flags().StringVar(&dlqReason, "reason", "", "Only letters whose failure reason contains this text")
+	}
+	dlqListCmd.Flags().IntVar(&dlqLimit, "limit", leaderboardscoring.DefaultDeadLetterPageSize, "Maximum number of letters to list")
+
+	for _, cmd := range []*cobra.Command{dlqReprocessCmd, dlqDiscardCmd} {
+		cmd.Flags().Uint64Var(&dlqSequence, "seq", 0, "Only the letter with this stream sequence")
+		cmd.Flags().BoolVar(&dlqAll, "all", false, "All letters in the queue")
+		cmd.Flags().StringVar(&dlqNote, "note", "", "Note kept in the audit record (required to discard)")
+		cmd.Flags().StringVar(&dlqActor, "actor", "cli:"+os.Getenv("USER"), "Who is running the command, kept in the audit record")
+	}
+
+	dlqCmd.AddCommand(dlqListCmd, dlqReprocessCmd, dlqDiscardCmd)
+	RootCmd.AddCommand(dlqCmd)
+}
+
+func withDLQTool(run func(ctx context.Context, tool *leaderboardscoringapp.DLQTool)) {
+	cfg := loadAppConfig()
+
+	if err := logger.Init(cfg.Logger); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer func() {
+		if err := logger.Close(); err != nil {
+			log.Printf("logger close error: %v", err)
+		}
+	}()
+
+	tool, err := leaderboardscoringapp.NewDLQTool(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize DLQ tool: %v", err)
+	}
+	defer tool.Close()
+
+	run(context.Background(), tool)
+}
+
+func dlqFilter() leaderboardscoring.DeadLetterFilter {
+	return leaderboardscoring.DeadLetterFilter{
+		EventID:   dlqEventID,
+		UserID:    dlqUserID,
+		ProjectID: dlqProjectID,
+		Reason:    dlqReason,
+	}
+}
+
+func dlqActionRequest() leaderboardscoring.DeadLetterActionRequest {
+	return leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{
+			Sequence: dlqSequence,
+			All:      dlqAll,
+			Filter:   dlqFilter(),
+		},
+		Actor: dlqActor,
+		Note:  dlqNote,
+	}
+}
+
+func listDeadLetters(ctx context.Context, tool *leaderboardscoringapp.DLQTool) {
+	res, err := tool.Service.ListDeadLetters(ctx, leaderboardscoring.ListDeadLettersRequest{
+		Filter: dlqFilter(),
+		Limit:  dlqLimit,
+	})
+	if err != nil {
+		log.Printf("Failed to list dead letters: %v", err)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SEQ\tFAILED AT\tDELIVERIES\tEVENT ID\tUSER\tPROJECT\tREASON")
+	for _, letter := range res.DeadLetters {
+		eventID, userID, projectID := "-", "-", "-"
+		if letter.Event != nil {
+			eventID, userID, projectID = letter.Event.EventID, letter.Event.UserID, letter.Event.ProjectID
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			letter.Sequence, letter.FailedAt.Format(time.RFC3339), letter.DeliveryCount,
+			eventID, userID, projectID, letter.Reason)
+	}
+	_ = w.Flush()
+
+	log.Printf("%d dead letters listed, %d in the queue", len(res.DeadLetters), res.Depth)
+}
+
+func reportDeadLetterAction(action string, res leaderboardscoring.DeadLetterActionResponse, err error) {
+	for _, skip := range res.Skipped {
+		log.Printf("Skipped dead letter %d: %s", skip.Sequence, skip.Reason)
+	}
+	if err != nil {
+		log.Printf("Stopped after %d letters were %s: %v", res.Affected, action, err)
+		return
+	}
+
+	log.Printf("%d dead letters %s", res.Affected, action)
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportTimeframe string
+	exportProject   string
+	exportPeriod    string
+	exportFormat    string
+	exportOutput    string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a leaderboard to CSV, JSON Lines or XLSX",
+	Long: `This command writes a whole leaderboard, with contributor names and points per
+event type, to a file or to stdout. Past periods are rebuilt from the stored score events.`,
+	Example: `  leaderboardscoring_service export --timeframe monthly --project 1001 --period 2025-06 --format xlsx --output june.xlsx`,
+	// The error reaches main, which prints it and exits non-zero
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return export()
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportTimeframe, "timeframe", "all_time", "Leaderboard timeframe (all_time, yearly, monthly, weekly, daily, trending)")
+	exportCmd.Flags().StringVar(&exportProject, "project", "", "Project ID (empty for the global leaderboard)")
+	exportCmd.Flags().StringVar(&exportPeriod, "period", "", "Past period, e.g. 2025-06-01, 2025-W23, 2025-06 or 2025 (empty for the current one)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "Output format (csv, jsonl, xlsx)")
+	exportCmd.Flags().StringVar(&exportOutput, "output", "", "Output file (default stdout)")
+	RootCmd.AddCommand(exportCmd)
+}
+
+func export() error {
+	cfg := loadAppConfig()
+
+	if err := logger.Init(cfg.Logger); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer func() {
+		if err := logger.Close(); err != nil {
+			log.Printf("logger close error: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	exporter, err := leaderboardscoringapp.NewExporter(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize exporter: %v", err)
+	}
+	defer exporter.Close()
+
+	req := &leaderboardscoring.ExportLeaderboardRequest{
+		Timeframe: exportTimeframe,
+		Period:    exportPeriod,
+		Format:    leaderboardscoring.ExportFormat(exportFormat),
+	}
+	if exportProject != "" {
+		req.ProjectID = &exportProject
+	}
+
+	if exportOutput == "" {
+		if err := exporter.Service.ExportLeaderboard(ctx, req, os.Stdout); err != nil {
+			return fmt.Errorf("failed to export leaderboard: %w", err)
+		}
+		return nil
+	}
+
+	if err := exportToFile(exportOutput, func(out io.Writer) error {
+		return exporter.Service.ExportLeaderboard(ctx, req, out)
+	}); err != nil {
+		return fmt.Errorf("failed to export leaderboard: %w", err)
+	}
+
+	log.Printf("Leaderboard exported to %s", exportOutput)
+
+	return nil
+}
+
+// exportToFile writes to a temporary file next to path and renames it to path once write
+// succeeded, a failed export leaves no partial file and an existing one untouched
+func exportToFile(path string, write func(out io.Writer) error) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", path, err)
+	}
+	defer func() {
+		// Nothing to remove after the rename
+		_ = os.Remove(file.Name())
+	}()
+
+	// CreateTemp makes the file private, an export is as readable as one os.Create makes
+	if err := file.Chmod(0o644); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to set permissions of %s: %w", file.Name(), err)
+	}
+
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Name(), err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to move export to %s: %w", path, err)
+	}
+
+	return nil
+}
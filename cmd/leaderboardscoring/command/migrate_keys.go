@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"log"
+
+	"github.com/gocasters/rankr/adapter/redis"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/redisrepository"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var migrateKeysDryRun bool
+
+var migrateKeysCmd = &cobra.Command{
+	Use:   "migrate-keys",
+	Short: "Move Redis leaderboards to the Redis Cluster key layout",
+	Long: `This command renames leaderboard keys from the legacy layout (leaderboard:1001:all_time)
+to the hash-tagged layout (leaderboard:{1001}:all_time). Run it once after deploying the version
+that writes the new keys; scores written to the new keys in the meantime are kept.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrateKeys()
+	},
+}
+
+func init() {
+	migrateKeysCmd.Flags().BoolVar(&migrateKeysDryRun, "dry-run", false, "Only count the keys that would be migrated")
+	RootCmd.AddCommand(migrateKeysCmd)
+}
+
+func migrateKeys() {
+	cfg := loadAppConfig()
+
+	if err := logger.Init(cfg.Logger); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer func() {
+		if err := logger.Close(); err != nil {
+			log.Printf("logger close error: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	redisAdapter, err := redis.New(ctx, cfg.Redis)
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer redisAdapter.Close()
+
+	result, err := redisrepository.NewKeyMigrator(redisAdapter.UniversalClient()).Migrate(ctx, migrateKeysDryRun)
+	if err != nil {
+		log.Printf("Key migration stopped after %d of %d keys: %v", result.Migrated, result.Scanned, err)
+		return
+	}
+
+	if migrateKeysDryRun {
+		log.Printf("%d legacy leaderboard keys would be migrated", result.Scanned)
+		return
+	}
+
+	log.Printf("Migrated %d leaderboard keys (%d members)", result.Migrated, result.Members)
+}
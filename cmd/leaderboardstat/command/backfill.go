@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var backfillProjectsCmd = &cobra.Command{
+	Use:   "backfill-projects",
+	Short: "Attribute the scores stored without a project to their project",
+	Long: `This command moves the scores stored before the daily aggregation read the project
+boards to their project. A contributor is attributed only when all of their all-time points
+come from a single project, the scores of everyone else stay unattributed on project 0.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		backfillProjects()
+	},
+}
+
+func backfillProjects() {
+	cfg := loadAppConfig()
+
+	if err := logger.Init(cfg.Logger); err != nil {
+		panic("failed to initialize logger: " + err.Error())
+	}
+	defer logger.Close()
+
+	leaderboardLogger := logger.L()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	statSvc, closeSvc, err := newStatService(ctx, cfg)
+	if err != nil {
+		leaderboardLogger.Error("failed to set up leaderboardstat service", "error", err)
+		return
+	}
+	defer closeSvc()
+
+	attributed, err := statSvc.BackfillProjectAttribution(ctx)
+	if err != nil {
+		leaderboardLogger.Error("project attribution backfill failed", "error", err, "attributed", attributed)
+		return
+	}
+
+	leaderboardLogger.Info("project attribution backfill completed", "attributed", attributed)
+}
+
+func init() {
+	RootCmd.AddCommand(backfillProjectsCmd)
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var rollupTimeframe string
+var rollupPeriod string
+
+var rollupCmd = &cobra.Command{
+	Use:   "rollup",
+	Short: "Rebuild the weekly, monthly or yearly scores of a period",
+	Long: `This command rebuilds the rows of one weekly, monthly or yearly period of
+user_project_scores from its daily rows. It replaces the period, so it can run again for
+any past period, e.g. rollup --timeframe weekly --period 2025-W23.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rollup()
+	},
+}
+
+func rollup() {
+	cfg := loadAppConfig()
+
+	if err := logger.Init(cfg.Logger); err != nil {
+		panic("failed to initialize logger: " + err.Error())
+	}
+	defer logger.Close()
+
+	leaderboardLogger := logger.L()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	statSvc, closeSvc, err := newStatService(ctx, cfg)
+	if err != nil {
+		leaderboardLogger.Error("failed to set up leaderboardstat service", "error", err)
+		return
+	}
+	defer closeSvc()
+
+	rows, err := statSvc.RollupScores(ctx, leaderboardstat.RollupRequest{
+		Timeframe: rollupTimeframe,
+		Period:    rollupPeriod,
+	})
+	if err != nil {
+		leaderboardLogger.Error("rollup failed", "error", err)
+		return
+	}
+
+	leaderboardLogger.Info("rollup completed", "timeframe", rollupTimeframe, "period", rollupPeriod, "rows", rows)
+}
+
+func init() {
+	rollupCmd.Flags().StringVar(&rollupTimeframe, "timeframe", "", "weekly, monthly or yearly")
+	rollupCmd.Flags().StringVar(&rollupPeriod, "period", "", "period to rebuild, e.g. 2025-W23, 2025-06 or 2025")
+	RootCmd.AddCommand(rollupCmd)
+}
@@ -2,9 +2,11 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"github.com/gocasters/rankr/adapter/leaderboardscoring"
 	"github.com/gocasters/rankr/adapter/project"
 	"github.com/gocasters/rankr/adapter/redis"
+	"github.com/gocasters/rankr/leaderboardstatapp"
 	"github.com/gocasters/rankr/leaderboardstatapp/repository"
 	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
 	"github.com/gocasters/rankr/pkg/cachemanager"
@@ -36,62 +38,83 @@ func runScheduler() {
 	leaderboardLogger := logger.L()
 	leaderboardLogger.Info("Running daily score calculation manually...")
 
-	databaseConn, err := database.Connect(cfg.PostgresDB)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	statSvc, closeSvc, err := newStatService(ctx, cfg)
 	if err != nil {
-		leaderboardLogger.Error("failed to connect to database", "error", err)
+		leaderboardLogger.Error("failed to set up leaderboardstat service", "error", err)
 		return
 	}
-	defer databaseConn.Close()
+	defer closeSvc()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-	defer cancel()
+	if err := statSvc.SetPublicLeaderboard(ctx); err != nil {
+		leaderboardLogger.Error("SetPublicLeaderboard failed", "error", err)
+		return
+	}
+
+	leaderboardLogger.Info("SetPublicLeaderboard completed successfully!")
+}
+
+// newStatService sets up the leaderboardstat service without servers, closeFn releases its
+// connections
+func newStatService(ctx context.Context, cfg leaderboardstatapp.Config) (svc leaderboardstat.Service, closeFn func(), err error) {
+	leaderboardLogger := logger.L()
+
+	var closers []func()
+	closeFn = func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			closeFn()
+		}
+	}()
+
+	databaseConn, err := database.Connect(cfg.PostgresDB)
+	if err != nil {
+		return svc, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	closers = append(closers, databaseConn.Close)
 
-	// Create minimal service setup without servers
 	redisAdapter, err := redis.New(ctx, cfg.Redis)
 	if err != nil {
-		leaderboardLogger.Error("failed to initialize Redis", "err", err)
-		return
+		return svc, nil, fmt.Errorf("failed to initialize Redis: %w", err)
 	}
 	cache := cachemanager.NewCacheManager(redisAdapter)
 
 	// Initialize gRPC client for leaderboardscoring
 	rpcClient, err := grpc.NewClient(cfg.LeaderboardScoringRPC, leaderboardLogger)
 	if err != nil {
-		leaderboardLogger.Error("failed to create RPC client!", "error", err)
-		return
+		return svc, nil, fmt.Errorf("failed to create RPC client: %w", err)
 	}
-	defer rpcClient.Close()
+	closers = append(closers, rpcClient.Close)
 
 	lbScoringClient, err := leaderboardscoring.New(rpcClient)
 	if err != nil {
-		leaderboardLogger.Error("failed to create leaderboardscoring client", "error", err)
-		return
+		return svc, nil, fmt.Errorf("failed to create leaderboardscoring client: %w", err)
 	}
 
 	projectRPCClient, err := grpc.NewClient(cfg.ProjectRPC, leaderboardLogger)
 	if err != nil {
-		leaderboardLogger.Error("failed to create project RPC client", "error", err)
-		return
+		return svc, nil, fmt.Errorf("failed to create project RPC client: %w", err)
 	}
-	defer projectRPCClient.Close()
+	closers = append(closers, projectRPCClient.Close)
 
 	projectClient, err := project.New(projectRPCClient)
 	if err != nil {
-		leaderboardLogger.Error("failed to create project client", "error", err)
-		return
+		return svc, nil, fmt.Errorf("failed to create project client: %w", err)
 	}
 
 	statRepo := repository.NewLeaderboardstatRepo(cfg.Repository, databaseConn)
 	statValidator := leaderboardstat.NewValidator(statRepo)
 	redisLeaderboardRepo := repository.NewRedisLeaderboardRepository(redisAdapter.Client())
-	statSvc := leaderboardstat.NewService(statRepo, statValidator, *cache, redisLeaderboardRepo, lbScoringClient, projectClient)
-
-	if err := statSvc.SetPublicLeaderboard(ctx); err != nil {
-		leaderboardLogger.Error("SetPublicLeaderboard failed", "error", err)
-		return
-	}
+	// The jobs serve no public responses, they need no identity resolver
+	svc = leaderboardstat.NewService(statRepo, statValidator, *cache, redisLeaderboardRepo, lbScoringClient, projectClient, nil)
 
-	leaderboardLogger.Info("SetPublicLeaderboard completed successfully!")
+	return svc, closeFn, nil
 }
 
 func init() {
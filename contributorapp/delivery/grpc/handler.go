@@ -84,3 +84,43 @@ func (h Handler) GetContributorsByVCS(ctx context.Context, req *contributorpb.Ge
 		Contributors: mappings,
 	}, nil
 }
+
+func (h Handler) GetContributorProfilesByVCS(ctx context.Context, req *contributorpb.GetContributorProfilesByVCSRequest) (*contributorpb.GetContributorProfilesByVCSResponse, error) {
+	log := logger.L()
+	log.Debug("gRPC GetContributorProfilesByVCS request received", slog.Int("count", len(req.VcsUserIds)))
+
+	if !contributor.IsValidVcsProvider(req.VcsProvider) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid vcs_provider: %s", req.VcsProvider)
+	}
+
+	if len(req.VcsUserIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "vcs_user_ids cannot be empty")
+	}
+
+	serviceResp, err := h.svc.GetContributorProfilesByVCS(ctx, contributor.GetContributorProfilesByVCSRequest{
+		VcsProvider: contributor.VcsProvider(req.VcsProvider),
+		UserIDs:     req.VcsUserIds,
+	})
+	if err != nil {
+		log.Error("failed to get contributor profiles by VCS", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to retrieve contributor profiles")
+	}
+
+	profiles := make([]*contributorpb.ContributorProfile, 0, len(serviceResp.Profiles))
+	for _, p := range serviceResp.Profiles {
+		profiles = append(profiles, &contributorpb.ContributorProfile{
+			ContributorId: p.ContributorID,
+			VcsUserId:     p.VcsUserID,
+			VcsUsername:   p.VcsUsername,
+			DisplayName:   p.DisplayName,
+			ProfileImage:  p.ProfileImage,
+			PrivacyMode:   string(p.PrivacyMode),
+			Timezone:      p.Timezone,
+		})
+	}
+
+	return &contributorpb.GetContributorProfilesByVCSResponse{
+		VcsProvider: string(serviceResp.VcsProvider),
+		Profiles:    profiles,
+	}, nil
+}
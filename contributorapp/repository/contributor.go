@@ -36,7 +36,7 @@ func NewContributorRepo(config Config, db *database.Database, logger *slog.Logge
 }
 
 func (repo ContributorRepo) GetContributorByID(ctx context.Context, id types.ID) (*contributor.Contributor, error) {
-	query := "SELECT id, github_id, github_username, email, password, role, is_verified, two_factor_enabled, privacy_mode, display_name, profile_image, bio, created_at, updated_at FROM contributors WHERE id=$1"
+	query := "SELECT id, github_id, github_username, email, password, role, is_verified, two_factor_enabled, privacy_mode, display_name, profile_image, bio, timezone, created_at, updated_at FROM contributors WHERE id=$1"
 	row := repo.PostgresSQL.Pool.QueryRow(ctx, query, id)
 
 	var contrib contributor.Contributor
@@ -55,6 +55,7 @@ func (repo ContributorRepo) GetContributorByID(ctx context.Context, id types.ID)
 		&displayName,
 		&profileImage,
 		&bio,
+		&contrib.Timezone,
 		&contrib.CreatedAt,
 		&contrib.UpdatedAt,
 	)
@@ -72,7 +73,7 @@ func (repo ContributorRepo) GetContributorByID(ctx context.Context, id types.ID)
 }
 
 func (repo ContributorRepo) GetContributorByGitHubUsername(ctx context.Context, username string) (*contributor.Contributor, error) {
-	query := "SELECT id, github_id, github_username, email, password, role, is_verified, two_factor_enabled, privacy_mode, display_name, profile_image, bio, created_at, updated_at FROM contributors WHERE github_username=$1"
+	query := "SELECT id, github_id, github_username, email, password, role, is_verified, two_factor_enabled, privacy_mode, display_name, profile_image, bio, timezone, created_at, updated_at FROM contributors WHERE github_username=$1"
 	row := repo.PostgresSQL.Pool.QueryRow(ctx, query, username)
 
 	var contrib contributor.Contributor
@@ -91,6 +92,7 @@ func (repo ContributorRepo) GetContributorByGitHubUsername(ctx context.Context,
 		&displayName,
 		&profileImage,
 		&bio,
+		&contrib.Timezone,
 		&contrib.CreatedAt,
 		&contrib.UpdatedAt,
 	)
@@ -159,9 +161,10 @@ func (repo ContributorRepo) UpdateProfileContributor(ctx context.Context, contri
 		    bio=$5,
 		    privacy_mode=$6,
 		    email=$7,
+		    timezone=COALESCE(NULLIF($9, ''), timezone),
 		    updated_at=NOW()
 		WHERE id=$8
-		RETURNING id, github_id, github_username, display_name, profile_image, bio, privacy_mode, email, created_at, updated_at;
+		RETURNING id, github_id, github_username, display_name, profile_image, bio, privacy_mode, email, timezone, created_at, updated_at;
 	`
 
 	err := repo.PostgresSQL.Pool.QueryRow(ctx, query,
@@ -173,6 +176,7 @@ func (repo ContributorRepo) UpdateProfileContributor(ctx context.Context, contri
 		contri.PrivacyMode,
 		contri.Email,
 		contri.ID,
+		contri.Timezone,
 	).Scan(
 		&updated.ID,
 		&githubID,
@@ -182,6 +186,7 @@ func (repo ContributorRepo) UpdateProfileContributor(ctx context.Context, contri
 		&updated.Bio,
 		&updated.PrivacyMode,
 		&updated.Email,
+		&updated.Timezone,
 		&updated.CreatedAt,
 		&updated.UpdatedAt,
 	)
@@ -292,3 +297,46 @@ func (repo ContributorRepo) FindByVCSUsernames(ctx context.Context, provider con
 
 	return contributors, nil
 }
+
+func (repo ContributorRepo) FindByVCSUserIDs(ctx context.Context, provider contributor.VcsProvider, userIDs []int64) ([]*contributor.Contributor, error) {
+	if len(userIDs) == 0 {
+		return []*contributor.Contributor{}, nil
+	}
+
+	query := `
+		SELECT id, github_id, github_username, privacy_mode,
+		       COALESCE(display_name, ''), COALESCE(profile_image, ''), timezone
+		FROM contributors
+		WHERE github_id = ANY($1)
+	`
+
+	rows, err := repo.PostgresSQL.Pool.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contributors by user ids: %w", err)
+	}
+	defer rows.Close()
+
+	var contributors []*contributor.Contributor
+	for rows.Next() {
+		var c contributor.Contributor
+		err := rows.Scan(
+			&c.ID,
+			&c.GitHubID,
+			&c.GitHubUsername,
+			&c.PrivacyMode,
+			&c.DisplayName,
+			&c.ProfileImage,
+			&c.Timezone,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contributor: %w", err)
+		}
+		contributors = append(contributors, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contributors: %w", err)
+	}
+
+	return contributors, nil
+}
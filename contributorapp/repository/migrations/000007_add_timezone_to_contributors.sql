@@ -0,0 +1,8 @@
+-- +migrate Up
+-- IANA timezone of the contributor, streaks count days in it
+ALTER TABLE contributors
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- +migrate Down
+ALTER TABLE contributors
+    DROP COLUMN IF EXISTS timezone;
@@ -17,6 +17,7 @@ type Contributor struct {
 	DisplayName    string      `json:"display_name,omitempty" db:"display_name"`
 	ProfileImage   string      `json:"profile_image,omitempty" db:"profile_image"`
 	Bio            string      `json:"bio,omitempty" db:"bio"`
+	Timezone       string      `json:"timezone" db:"timezone"`
 	CreatedAt      time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at" db:"updated_at"`
 }
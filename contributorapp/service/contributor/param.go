@@ -13,6 +13,7 @@ type GetProfileResponse struct {
 	ProfileImage   string      `json:"profile_image,omitempty"`
 	Bio            string      `json:"bio,omitempty"`
 	PrivacyMode    PrivacyMode `json:"privacy_mode"`
+	Timezone       string      `json:"timezone"`
 	CreatedAt      time.Time   `json:"created_at"`
 }
 
@@ -38,6 +39,8 @@ type UpdateProfileRequest struct {
 	ProfileImage   string      `json:"profile_image,omitempty"`
 	Bio            string      `json:"bio,omitempty"`
 	PrivacyMode    PrivacyMode `json:"privacy_mode,omitempty"`
+	// Timezone is an IANA name such as "Europe/Berlin", empty keeps the current one
+	Timezone string `json:"timezone,omitempty"`
 }
 
 type UpdateProfileResponse struct {
@@ -48,6 +51,7 @@ type UpdateProfileResponse struct {
 	ProfileImage   string      `json:"profile_image,omitempty"`
 	Bio            string      `json:"bio,omitempty"`
 	PrivacyMode    PrivacyMode `json:"privacy_mode"`
+	Timezone       string      `json:"timezone"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
 }
@@ -124,3 +128,23 @@ type GetContributorsByVCSResponse struct {
 	VcsProvider  VcsProvider          `json:"vcs_provider"`
 	Contributors []ContributorMapping `json:"contributors"`
 }
+
+type GetContributorProfilesByVCSRequest struct {
+	VcsProvider VcsProvider `json:"vcs_provider"`
+	UserIDs     []int64     `json:"user_ids"`
+}
+
+type ContributorProfile struct {
+	ContributorID int64       `json:"contributor_id"`
+	VcsUserID     int64       `json:"vcs_user_id"`
+	VcsUsername   string      `json:"vcs_username"`
+	DisplayName   string      `json:"display_name"`
+	ProfileImage  string      `json:"profile_image"`
+	PrivacyMode   PrivacyMode `json:"privacy_mode"`
+	Timezone      string      `json:"timezone"`
+}
+
+type GetContributorProfilesByVCSResponse struct {
+	VcsProvider VcsProvider          `json:"vcs_provider"`
+	Profiles    []ContributorProfile `json:"profiles"`
+}
@@ -25,6 +25,7 @@ type Repository interface {
 	UpdatePassword(ctx context.Context, id types.ID, hashedPassword string) error
 
 	FindByVCSUsernames(ctx context.Context, provider VcsProvider, usernames []string) ([]*Contributor, error)
+	FindByVCSUserIDs(ctx context.Context, provider VcsProvider, userIDs []int64) ([]*Contributor, error)
 }
 
 type Service struct {
@@ -65,6 +66,7 @@ func (s Service) GetProfile(ctx context.Context, id types.ID) (GetProfileRespons
 		ProfileImage: contributor.ProfileImage,
 		Bio:          contributor.Bio,
 		PrivacyMode:  contributor.PrivacyMode,
+		Timezone:     contributor.Timezone,
 		CreatedAt:    contributor.CreatedAt,
 	}, nil
 }
@@ -128,6 +130,7 @@ func (s Service) UpdateProfile(ctx context.Context, req UpdateProfileRequest) (U
 		ProfileImage:   req.ProfileImage,
 		Bio:            req.Bio,
 		PrivacyMode:    req.PrivacyMode,
+		Timezone:       req.Timezone,
 	}
 
 	resContributor, err := s.repository.UpdateProfileContributor(ctx, contributor)
@@ -144,6 +147,7 @@ func (s Service) UpdateProfile(ctx context.Context, req UpdateProfileRequest) (U
 		ProfileImage:   resContributor.ProfileImage,
 		Bio:            resContributor.Bio,
 		PrivacyMode:    resContributor.PrivacyMode,
+		Timezone:       resContributor.Timezone,
 		CreatedAt:      resContributor.CreatedAt,
 		UpdatedAt:      resContributor.UpdatedAt,
 	}, nil
@@ -326,3 +330,29 @@ func (s Service) GetContributorsByVCS(ctx context.Context, req GetContributorsBy
 		Contributors: mappings,
 	}, nil
 }
+
+func (s Service) GetContributorProfilesByVCS(ctx context.Context, req GetContributorProfilesByVCSRequest) (GetContributorProfilesByVCSResponse, error) {
+	contributors, err := s.repository.FindByVCSUserIDs(ctx, req.VcsProvider, req.UserIDs)
+	if err != nil {
+		logger.L().Error("get_contributor_profiles_by_vcs", "error", err)
+		return GetContributorProfilesByVCSResponse{}, err
+	}
+
+	profiles := make([]ContributorProfile, 0, len(contributors))
+	for _, c := range contributors {
+		profiles = append(profiles, ContributorProfile{
+			ContributorID: c.ID,
+			VcsUserID:     c.GitHubID,
+			VcsUsername:   c.GitHubUsername,
+			DisplayName:   c.DisplayName,
+			ProfileImage:  c.ProfileImage,
+			PrivacyMode:   c.PrivacyMode,
+			Timezone:      c.Timezone,
+		})
+	}
+
+	return GetContributorProfilesByVCSResponse{
+		VcsProvider: req.VcsProvider,
+		Profiles:    profiles,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gocasters/rankr/pkg/validator"
 	types "github.com/gocasters/rankr/type"
+	"time"
 )
 
 const (
@@ -15,6 +16,7 @@ const (
 	ErrValidationLength3To100  = "must be between 3 and 100 characters"
 	ErrValidationLength6To72   = "must be between 6 and 72 characters"
 	ErrValidationInvalidIDType = "ID must be uint64"
+	ErrValidationTimezone      = "must be an IANA timezone such as 'Europe/Berlin'"
 )
 
 type ValidatorContributorRepository interface {
@@ -69,6 +71,7 @@ func (v Validator) ValidateUpdateProfileRequest(ctx context.Context, req UpdateP
 				validation.In(PrivacyModeReal, PrivacyModeAnonymous).Error(ErrValidationEnumPrivacy),
 			),
 		),
+		validation.Field(&req.Timezone, validation.Length(0, 64), validation.By(checkTimezone)),
 	)
 }
 
@@ -101,3 +104,16 @@ func (v Validator) ValidateUpsertContributorRequest(req UpsertContributorRequest
 
 	return nil
 }
+
+func checkTimezone(value interface{}) error {
+	tz, _ := value.(string)
+	if tz == "" {
+		return nil
+	}
+
+	if _, err := time.LoadLocation(tz); err != nil {
+		return errors.New(ErrValidationTimezone)
+	}
+
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	lbclient "github.com/gocasters/rankr/adapter/leaderboardscoring"
@@ -24,6 +26,8 @@ func main() {
 	pageSize := flag.Int("limit", 10, "Number of records to fetch")
 	offset := flag.Int("offset", 0, "Offset for pagination")
 	timeout := flag.Duration("timeout", 5*time.Second, "Request timeout duration")
+	watch := flag.Bool("watch", false, "Keep the connection open and print the board whenever it changes")
+	interval := flag.Duration("interval", time.Second, "Minimum time between two updates in watch mode")
 	flag.Parse()
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -69,16 +73,26 @@ func main() {
 	}
 	defer client.Close()
 
-	// Context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
-	defer cancel()
-
 	// Build request
 	var pidPtr *string
 	if *projectID != "" {
 		pidPtr = projectID
 	}
 
+	if *watch {
+		watchLeaderboard(client, &lbscoring.WatchLeaderboardRequest{
+			Timeframe:   *timeframe,
+			ProjectID:   pidPtr,
+			PageSize:    int32(*pageSize),
+			MinInterval: *interval,
+		})
+		return
+	}
+
+	// Context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
 	req := &lbscoring.GetLeaderboardRequest{
 		Timeframe: *timeframe,
 		ProjectID: pidPtr,
@@ -102,16 +116,43 @@ func main() {
 	}
 
 	// Display result
-	fmt.Printf("\nLeaderboard: %s\n", res.Timeframe)
-	if res.ProjectID != nil {
-		fmt.Printf("Project ID: %s\n", *res.ProjectID)
+	printLeaderboard(res.Timeframe, res.ProjectID, res.RankingMode, res.LeaderboardRows)
+}
+
+// watchLeaderboard prints the board on every update until Ctrl+C.
+// The adapter reopens dropped streams and resumes from the last received version.
+func watchLeaderboard(client *lbclient.Client, req *lbscoring.WatchLeaderboardRequest) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("\nWatching leaderboard (timeframe=%s), press Ctrl+C to stop\n", req.Timeframe)
+
+	err := client.WatchLeaderboard(ctx, req, func(update *lbscoring.LeaderboardUpdate) error {
+		kind := "update"
+		if update.Initial {
+			kind = "initial page"
+		}
+		fmt.Printf("\n[%s] %s (version %d)\n", time.Now().Format(time.TimeOnly), kind, update.Version)
+
+		printLeaderboard(update.Timeframe, update.ProjectID, update.RankingMode, update.LeaderboardRows)
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("WatchLeaderboard RPC failed: %v", err)
+	}
+}
+
+func printLeaderboard(timeframe string, projectID *string, mode lbscoring.RankingMode, rows []lbscoring.LeaderboardRow) {
+	fmt.Printf("\nLeaderboard: %s (%s ranking)\n", timeframe, mode)
+	if projectID != nil {
+		fmt.Printf("Project ID: %s\n", *projectID)
 	}
 	fmt.Println("----------------------------------------")
 	fmt.Printf("%-6s %-12s %-10s\n", "Rank", "UserID", "Score")
 	fmt.Println("----------------------------------------")
-	for _, row := range res.LeaderboardRows {
+	for _, row := range rows {
 		fmt.Printf("%-6d %-12s %-10d\n", row.Rank, row.UserID, row.Score)
 	}
 	fmt.Println("----------------------------------------")
-	fmt.Printf("Total rows: %d\n\n", len(res.LeaderboardRows))
+	fmt.Printf("Total rows: %d\n\n", len(rows))
 }
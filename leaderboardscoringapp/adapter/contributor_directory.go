@@ -0,0 +1,65 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gocasters/rankr/adapter/contributor"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+)
+
+// Leaderboard members are GitHub user IDs
+const vcsProviderGitHub = "GITHUB"
+
+const privacyModeAnonymous = "anonymous"
+
+// ContributorDirectory resolves leaderboard user IDs through the contributor service.
+type ContributorDirectory struct {
+	client *contributor.Client
+}
+
+func NewContributorDirectory(client *contributor.Client) ContributorDirectory {
+	return ContributorDirectory{client: client}
+}
+
+func (d ContributorDirectory) GetProfiles(ctx context.Context, userIDs []string) (map[string]leaderboardscoring.ContributorProfile, error) {
+	vcsUserIDs := make([]int64, 0, len(userIDs))
+	for _, id := range userIDs {
+		vcsUserID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			// Not a VCS user ID, it cannot have a contributor profile
+			continue
+		}
+		vcsUserIDs = append(vcsUserIDs, vcsUserID)
+	}
+
+	profiles := make(map[string]leaderboardscoring.ContributorProfile, len(vcsUserIDs))
+	if len(vcsUserIDs) == 0 {
+		return profiles, nil
+	}
+
+	res, err := d.client.GetProfilesByVCS(ctx, vcsProviderGitHub, vcsUserIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get contributor profiles: %w", err)
+	}
+
+	for _, p := range res {
+		userID := strconv.FormatInt(p.VcsUserID, 10)
+
+		displayName := p.DisplayName
+		if displayName == "" {
+			displayName = p.VcsUsername
+		}
+
+		profiles[userID] = leaderboardscoring.ContributorProfile{
+			UserID:      userID,
+			Username:    p.VcsUsername,
+			DisplayName: displayName,
+			Anonymous:   p.PrivacyMode == privacyModeAnonymous,
+			Timezone:    p.Timezone,
+		}
+	}
+
+	return profiles, nil
+}
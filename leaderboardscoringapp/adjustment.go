@@ -0,0 +1,104 @@
+package leaderboardscoringapp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocasters/rankr/adapter/natsadapter"
+	"github.com/gocasters/rankr/adapter/redis"
+	postgrerepository "github.com/gocasters/rankr/leaderboardscoringapp/repository/database"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/topicsname"
+)
+
+// AdjustmentTool holds the subset of the application needed to adjust scores from the
+// command line: PostgreSQL for the audit trail, Redis for the boards and the processed
+// events stream for persistence.
+type AdjustmentTool struct {
+	Service      *leaderboardscoring.AdjustmentService
+	databaseConn *database.Database
+	redisAdapter *redis.Adapter
+	natsAdapter  *natsadapter.Adapter
+}
+
+func NewAdjustmentTool(ctx context.Context, config Config) (*AdjustmentTool, error) {
+	// The memory backend keeps the boards inside the serving process, only its admin API
+	// can reach them
+	if config.LeaderboardCache.Backend == LeaderboardCacheMemory {
+		return nil, fmt.Errorf("the %s leaderboard cache backend cannot be adjusted from the command line, use POST /v1/admin/adjustments", LeaderboardCacheMemory)
+	}
+
+	if err := config.LeaderboardScoring.Ranking.Validate(); err != nil {
+		return nil, err
+	}
+
+	databaseConn, err := database.Connect(config.PostgresDB)
+	if err != nil {
+		return nil, fmt.Errorf("connect to PostgreSQL: %w", err)
+	}
+
+	redisAdapter, err := redis.New(ctx, config.Redis)
+	if err != nil {
+		databaseConn.Close()
+		return nil, fmt.Errorf("connect to Redis: %w", err)
+	}
+
+	if config.NatsAdapter.StreamName == "" {
+		config.NatsAdapter.StreamName = topicsname.StreamNameLeaderboardscoringProcessedEvents
+	}
+	if config.NatsAdapter.StreamSubjects == nil {
+		config.NatsAdapter.StreamSubjects = []string{
+			topicsname.TopicProcessedScoreEvents,
+			topicsname.TopicProcessedScoreEventsDLQ,
+		}
+	}
+	natsAdapter, err := natsadapter.New(config.NatsAdapter, logger.L())
+	if err != nil {
+		_ = redisAdapter.Close()
+		databaseConn.Close()
+		return nil, fmt.Errorf("connect to NATS: %w", err)
+	}
+
+	leaderboard, _, err := newLeaderboardCache(config.LeaderboardCache, redisAdapter)
+	if err != nil {
+		_ = natsAdapter.Close()
+		_ = redisAdapter.Close()
+		databaseConn.Close()
+		return nil, err
+	}
+
+	validator := leaderboardscoring.NewValidator()
+	scorer := leaderboardscoring.NewService(
+		config.LeaderboardScoring,
+		postgrerepository.NewPostgreSQLRepository(databaseConn, config.DatabaseRetry),
+		leaderboard,
+		natsAdapter,
+		topicsname.TopicProcessedScoreEvents,
+		validator,
+		nil,
+		nil,
+		nil,
+	)
+
+	service := leaderboardscoring.NewAdjustmentService(
+		config.LeaderboardScoring,
+		postgrerepository.NewAdjustmentRepository(databaseConn, config.DatabaseRetry),
+		scorer,
+		validator,
+	)
+
+	return &AdjustmentTool{
+		Service:      service,
+		databaseConn: databaseConn,
+		redisAdapter: redisAdapter,
+		natsAdapter:  natsAdapter,
+	}, nil
+}
+
+func (t *AdjustmentTool) Close() {
+	_ = t.natsAdapter.Close()
+	_ = t.redisAdapter.Close()
+	t.databaseConn.Close()
+}
@@ -3,24 +3,34 @@ package leaderboardscoringapp
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/gocasters/rankr/adapter/contributor"
 	"github.com/gocasters/rankr/adapter/nats"
 	"github.com/gocasters/rankr/adapter/natsadapter"
 	"github.com/gocasters/rankr/adapter/redis"
+	"github.com/gocasters/rankr/leaderboardscoringapp/adapter"
 	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/consumer/batchprocessor"
 	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/consumer/rawevent"
 	leaderboardGRPC "github.com/gocasters/rankr/leaderboardscoringapp/delivery/grpc"
 	leaderboardHTTP "github.com/gocasters/rankr/leaderboardscoringapp/delivery/http"
+	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/publisher/badgeevent"
+	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/publisher/rankupdate"
+	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/publisher/seasonevent"
 	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/scheduler"
 	postgrerepository "github.com/gocasters/rankr/leaderboardscoringapp/repository/database"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/memoryrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/natsrepository"
 	"github.com/gocasters/rankr/leaderboardscoringapp/repository/redisrepository"
 	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/database"
 	"github.com/gocasters/rankr/pkg/grpc"
 	"github.com/gocasters/rankr/pkg/httpserver"
 	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
 	"github.com/gocasters/rankr/pkg/topicsname"
+	"go.opentelemetry.io/otel"
 	"log/slog"
 	"net/http"
 	"os"
@@ -33,6 +43,10 @@ type Application struct {
 	HTTPServer            leaderboardHTTP.Server
 	LeaderboardGrpcServer leaderboardGRPC.Server
 	LeaderboardSvc        *leaderboardscoring.Service
+	DLQSvc                *leaderboardscoring.DLQService
+	SeasonSvc             *leaderboardscoring.SeasonService
+	AchievementSvc        *leaderboardscoring.AchievementService
+	StreakSvc             *leaderboardscoring.StreakService
 	WMRouter              *message.Router
 	WMLogger              watermill.LoggerAdapter
 	Config                Config
@@ -42,6 +56,9 @@ type Application struct {
 	NatsWMAdapter         *nats.Adapter
 	NatsAdapter           *natsadapter.Adapter
 	BatchProcessor        *batchprocessor.Processor
+	RankUpdateCoalescer   *rankupdate.Coalescer
+	MemoryCache           *memoryrepository.MemoryLeaderboardRepository
+	ContributorClient     *contributor.Client
 	Scheduler             scheduler.Scheduler
 }
 
@@ -52,6 +69,26 @@ func Setup(ctx context.Context, config Config) *Application {
 		config.StreamNameRawEvents = topicsname.StreamNameRawEvents
 	}
 
+	if err := config.LeaderboardScoring.Ranking.Validate(); err != nil {
+		log.Error("invalid ranking configuration", slog.String("error", err.Error()))
+		panic(err)
+	}
+
+	if err := config.LeaderboardScoring.Achievements.Validate(); err != nil {
+		log.Error("invalid achievements configuration", slog.String("error", err.Error()))
+		panic(err)
+	}
+
+	if err := config.LeaderboardScoring.Streaks.Validate(); err != nil {
+		log.Error("invalid streaks configuration", slog.String("error", err.Error()))
+		panic(err)
+	}
+
+	if err := config.Privacy.Validate(); err != nil {
+		log.Error("invalid privacy configuration", slog.String("error", err.Error()))
+		panic(err)
+	}
+
 	// Initialize PostgreSQL connection
 	databaseConn, err := database.Connect(config.PostgresDB)
 	if err != nil {
@@ -107,19 +144,114 @@ func Setup(ctx context.Context, config Config) *Application {
 
 	// Initialize repositories
 	persistence := postgrerepository.NewPostgreSQLRepository(databaseConn, config.DatabaseRetry)
-	leaderboard := redisrepository.NewRedisLeaderboardRepository(redisAdapter.Client())
+	leaderboard, memoryCache, err := newLeaderboardCache(config.LeaderboardCache, redisAdapter)
+	if err != nil {
+		databaseConn.Close()
+		_ = redisAdapter.Close()
+		_ = natsWMAdapter.Close()
+		_ = natsAdapter.Close()
+		log.Error("failed to initialize leaderboard cache",
+			slog.String("error", err.Error()))
+		panic(err)
+	}
+	log.Info("leaderboard cache initialized",
+		slog.String("backend", config.LeaderboardCache.Backend))
 	lbScoringValidator := leaderboardscoring.NewValidator()
 
+	// Initialize rank update coalescer (publishes leaderboard.updated for realtime clients)
+	var rankUpdateCoalescer *rankupdate.Coalescer
+	var rankNotifier leaderboardscoring.RankChangeNotifier
+	if config.RankUpdate.Enabled {
+		rankUpdateCoalescer = rankupdate.NewCoalescer(
+			natsWMAdapter.Publisher(),
+			topicsname.TopicLeaderboardUpdated,
+			config.RankUpdate,
+		)
+		rankNotifier = rankUpdateCoalescer
+		log.Info("rank update coalescer initialized",
+			slog.String("topic", topicsname.TopicLeaderboardUpdated),
+			slog.Duration("window", config.RankUpdate.Window))
+	}
+
+	// Initialize contributor directory (display names for exports) and the privacy_mode
+	// of the contributors on the public responses
+	contributorClient, contributorDirectory := newContributorDirectory(config.ContributorRPC)
+	identities := newIdentityResolver(config.Privacy, contributorClient)
+
 	// Initialize leaderboard scoring service
 	lbScoringService := leaderboardscoring.NewService(
+		config.LeaderboardScoring,
 		persistence,
 		leaderboard,
 		natsAdapter,
 		topicsname.TopicProcessedScoreEvents,
 		lbScoringValidator,
+		rankNotifier,
+		contributorDirectory,
+		identities,
 	)
 	log.Info("leaderboard scoring service initialized")
 
+	// Initialize dead letter queue of processed events
+	deadLetters := natsrepository.NewDeadLetterQueue(natsAdapter, topicsname.TopicProcessedScoreEventsDLQ)
+	dlqService := leaderboardscoring.NewDLQService(
+		deadLetters,
+		persistence,
+		postgrerepository.NewDeadLetterAuditRepository(databaseConn, config.DatabaseRetry),
+		lbScoringValidator,
+	)
+
+	// Initialize leaderboard history, read from the snapshot table
+	historyService := leaderboardscoring.NewHistoryService(
+		config.LeaderboardScoring.SnapshotRetention,
+		postgrerepository.NewSnapshotRepository(databaseConn, config.DatabaseRetry),
+		lbScoringValidator,
+	)
+
+	// Initialize seasons, their season-ended events go out with the leaderboard updates
+	seasonService := leaderboardscoring.NewSeasonService(
+		config.LeaderboardScoring.Seasons,
+		postgrerepository.NewSeasonRepository(databaseConn, config.DatabaseRetry),
+		leaderboard,
+		seasonevent.NewPublisher(natsWMAdapter.Publisher(), topicsname.TopicSeasonEnded),
+		lbScoringValidator,
+	)
+
+	// Initialize achievements, badges are evaluated against the raw event stream
+	achievementService := leaderboardscoring.NewAchievementService(
+		config.LeaderboardScoring.Achievements,
+		postgrerepository.NewAchievementRepository(databaseConn, config.DatabaseRetry),
+		badgeevent.NewPublisher(natsWMAdapter.Publisher(), topicsname.TopicBadgeAwarded),
+		lbScoringValidator,
+	)
+	log.Info("achievement service initialized",
+		slog.Int("badges", len(config.LeaderboardScoring.Achievements.Badges)))
+
+	// Initialize streaks, days are counted in the timezone of the contributor
+	streakService := leaderboardscoring.NewStreakService(
+		config.LeaderboardScoring.Streaks,
+		postgrerepository.NewStreakRepository(databaseConn, config.DatabaseRetry),
+		lbScoringService,
+		contributorDirectory,
+		lbScoringValidator,
+	)
+
+	// Initialize score explanations, read from the persisted score events
+	explainService := leaderboardscoring.NewExplainService(
+		config.LeaderboardScoring,
+		postgrerepository.NewExplainRepository(databaseConn, config.DatabaseRetry),
+		leaderboard,
+		lbScoringValidator,
+	)
+
+	// Initialize manual score adjustments, scored like events of their project
+	adjustmentService := leaderboardscoring.NewAdjustmentService(
+		config.LeaderboardScoring,
+		postgrerepository.NewAdjustmentRepository(databaseConn, config.DatabaseRetry),
+		lbScoringService,
+		lbScoringValidator,
+	)
+
 	// Initialize HTTP server
 	httpServer, err := httpserver.New(config.HTTPServer)
 	if err != nil {
@@ -127,7 +259,19 @@ func Setup(ctx context.Context, config Config) *Application {
 			slog.String("error", err.Error()))
 		panic(err)
 	}
-	leaderboardHttpServer := leaderboardHTTP.New(httpServer)
+	leaderboardHttpServer := leaderboardHTTP.New(
+		httpServer,
+		lbScoringService,
+		dlqService,
+		historyService,
+		seasonService,
+		achievementService,
+		streakService,
+		explainService,
+		adjustmentService,
+		identities,
+		config.Admin,
+	)
 
 	// Initialize gRPC server
 	rpcServer, err := grpc.NewServer(config.RPCServer)
@@ -136,10 +280,14 @@ func Setup(ctx context.Context, config Config) *Application {
 			slog.String("error", err.Error()))
 		panic(err)
 	}
-	leaderboardGrpcHandler := leaderboardGRPC.NewHandler(lbScoringService)
+	leaderboardGrpcHandler := leaderboardGRPC.NewHandler(lbScoringService, historyService, achievementService, streakService, explainService)
 	leaderboardGrpcServer := leaderboardGRPC.New(rpcServer, leaderboardGrpcHandler)
 
-	// Create NATS pull consumer for batch processing
+	// Create NATS pull consumer for batch processing, the DLQ subject of the same stream
+	// is left to the DLQ tooling
+	if config.PullConsumer.Subject == "" {
+		config.PullConsumer.Subject = topicsname.TopicProcessedScoreEvents
+	}
 	pullConsumer, err := natsAdapter.CreatePullConsumer(config.PullConsumer)
 	if err != nil {
 		log.Error("failed to create NATS pull consumer",
@@ -154,21 +302,28 @@ func Setup(ctx context.Context, config Config) *Application {
 	// Initialize batch processor
 	processor := batchprocessor.NewProcessor(
 		pullConsumer,
-		natsAdapter,
+		deadLetters,
 		persistence,
 		config.BatchProcessor,
 	)
+	if err := processor.RegisterMetrics(otel.Meter("leaderboardscoring")); err != nil {
+		log.Warn("failed to register batch processor metrics", slog.String("error", err.Error()))
+	}
 	log.Info("batch processor initialized",
 		slog.Duration("tick_interval", config.BatchProcessor.TickInterval),
 		slog.Duration("metrics_interval", config.BatchProcessor.MetricsInterval))
 
 	// Initialize Scheduler
-	sch := scheduler.New(lbScoringService, config.SchedulerCfg)
+	sch := scheduler.New(lbScoringService, historyService, seasonService, achievementService, streakService, config.SchedulerCfg)
 
 	return &Application{
 		HTTPServer:            leaderboardHttpServer,
 		LeaderboardGrpcServer: leaderboardGrpcServer,
 		LeaderboardSvc:        lbScoringService,
+		DLQSvc:                dlqService,
+		SeasonSvc:             seasonService,
+		AchievementSvc:        achievementService,
+		StreakSvc:             streakService,
 		WMRouter:              nil,
 		WMLogger:              wmLogger,
 		Config:                config,
@@ -178,6 +333,9 @@ func Setup(ctx context.Context, config Config) *Application {
 		NatsWMAdapter:         natsWMAdapter,
 		NatsAdapter:           natsAdapter,
 		BatchProcessor:        processor,
+		RankUpdateCoalescer:   rankUpdateCoalescer,
+		MemoryCache:           memoryCache,
+		ContributorClient:     contributorClient,
 		Scheduler:             sch,
 	}
 }
@@ -193,6 +351,8 @@ func (app *Application) Start() {
 	app.startWatermill(ctx, &wg)
 	app.startGRPCServer(&wg)
 	app.startBatchProcessor(ctx, &wg)
+	app.startRankUpdateCoalescer(ctx, &wg)
+	app.startMemoryCache(ctx, &wg)
 	app.startScheduler(ctx, &wg)
 
 	log.Info("leaderboard scoring application is ready and running")
@@ -268,8 +428,8 @@ func (app *Application) setupWatermill() {
 		panic(err)
 	}
 
-	checker := rawevent.NewIdempotencyChecker(app.RedisAdapter.Client(), app.Config.RawEventConsumer)
-	rawEventHandler := rawevent.NewHandler(app.LeaderboardSvc, checker)
+	checker := rawevent.NewIdempotencyChecker(app.RedisAdapter.UniversalClient(), app.Config.RawEventConsumer)
+	rawEventHandler := rawevent.NewHandler(app.LeaderboardSvc, app.SeasonSvc, app.AchievementSvc, app.StreakSvc, checker)
 
 	router.AddConsumerHandler(
 		"RawEventHandler",
@@ -323,6 +483,54 @@ func (app *Application) startBatchProcessor(ctx context.Context, wg *sync.WaitGr
 	}()
 }
 
+func (app *Application) startRankUpdateCoalescer(ctx context.Context, wg *sync.WaitGroup) {
+	if app.RankUpdateCoalescer == nil {
+		return
+	}
+
+	log := logger.L()
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		log.Info("starting rank update coalescer")
+
+		if err := app.RankUpdateCoalescer.Start(ctx); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error("rank update coalescer failed",
+					slog.String("error", err.Error()))
+			}
+		}
+
+		log.Info("rank update coalescer stopped")
+	}()
+}
+
+func (app *Application) startMemoryCache(ctx context.Context, wg *sync.WaitGroup) {
+	if app.MemoryCache == nil {
+		return
+	}
+
+	log := logger.L()
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		log.Info("starting in-memory leaderboard cache maintenance")
+
+		if err := app.MemoryCache.Start(ctx); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error("in-memory leaderboard cache failed",
+					slog.String("error", err.Error()))
+			}
+		}
+
+		log.Info("in-memory leaderboard cache stopped")
+	}()
+}
+
 func (app *Application) startScheduler(ctx context.Context, wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func() {
@@ -437,5 +645,60 @@ func (app *Application) shutdownResources(ctx context.Context, wg *sync.WaitGrou
 			slog.String("error", err.Error()))
 	}
 
+	if app.ContributorClient != nil {
+		log.Info("closing contributor RPC client")
+		app.ContributorClient.Close()
+	}
+
 	log.Info("all resources released")
 }
+
+// newLeaderboardCache returns the configured leaderboard cache. The in-memory cache is
+// also returned on its own, so its snapshots and expiry sweeps can be started.
+func newLeaderboardCache(cfg LeaderboardCacheConfig, redisAdapter *redis.Adapter) (leaderboardscoring.LeaderboardCache, *memoryrepository.MemoryLeaderboardRepository, error) {
+	switch cfg.Backend {
+	case "", LeaderboardCacheRedis:
+		return redisrepository.NewRedisLeaderboardRepository(redisAdapter.UniversalClient()), nil, nil
+	case LeaderboardCacheMemory:
+		memoryCache := memoryrepository.NewMemoryLeaderboardRepository(cfg.Memory)
+		if err := memoryCache.LoadSnapshot(); err != nil {
+			return nil, nil, fmt.Errorf("load leaderboard snapshot: %w", err)
+		}
+		return memoryCache, memoryCache, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown leaderboard cache backend %q", cfg.Backend)
+	}
+}
+
+// newContributorDirectory connects to the contributor service. When it is unavailable the
+// application starts anyway and exports are written without display names.
+func newContributorDirectory(cfg grpc.ClientConfig) (*contributor.Client, leaderboardscoring.ContributorDirectory) {
+	log := logger.L()
+
+	rpcClient, err := grpc.NewClient(cfg, log)
+	if err != nil {
+		log.Warn("failed to initialize contributor RPC client; exports will not include display names",
+			slog.String("error", err.Error()))
+		return nil, nil
+	}
+
+	contributorClient, err := contributor.New(rpcClient)
+	if err != nil {
+		rpcClient.Close()
+		log.Warn("failed to initialize contributor client; exports will not include display names",
+			slog.String("error", err.Error()))
+		return nil, nil
+	}
+
+	return contributorClient, adapter.NewContributorDirectory(contributorClient)
+}
+
+// newIdentityResolver masks anonymous contributors with the profiles of the contributor
+// service. Without a client every contributor is masked.
+func newIdentityResolver(cfg privacy.Config, client *contributor.Client) *privacy.IdentityResolver {
+	if client == nil {
+		return privacy.NewIdentityResolver(cfg, nil)
+	}
+
+	return privacy.NewIdentityResolver(cfg, client)
+}
@@ -6,12 +6,17 @@ import (
 	"github.com/gocasters/rankr/adapter/redis"
 	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/consumer/batchprocessor"
 	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/consumer/rawevent"
+	leaderboardHTTP "github.com/gocasters/rankr/leaderboardscoringapp/delivery/http"
+	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/publisher/rankupdate"
 	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/scheduler"
 	postgrerepository "github.com/gocasters/rankr/leaderboardscoringapp/repository/database"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/memoryrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/database"
 	"github.com/gocasters/rankr/pkg/grpc"
 	"github.com/gocasters/rankr/pkg/httpserver"
 	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
 	"time"
 )
 
@@ -20,6 +25,9 @@ type Config struct {
 	HTTPServer httpserver.Config `koanf:"http_server"`
 	RPCServer  grpc.ServerConfig `koanf:"rpc_server"`
 
+	// Users allowed to call the /v1/admin routes
+	Admin leaderboardHTTP.AdminConfig `koanf:"admin"`
+
 	// Scheduler configurations
 	SchedulerCfg scheduler.Config `koanf:"scheduler_cfg"`
 
@@ -27,16 +35,26 @@ type Config struct {
 	PostgresDB database.Config `koanf:"postgres_db"`
 	Redis      redis.Config    `koanf:"redis"`
 
+	// Leaderboard cache backend, Redis unless configured otherwise
+	LeaderboardCache LeaderboardCacheConfig `koanf:"leaderboard_cache"`
+
 	// NATS configurations
 	WatermillNats nats.Config                    `koanf:"watermill_nats"` // For raw events (push-based)
 	NatsAdapter   natsadapter.Config             `koanf:"nats_adapter"`   // For processed events (native)
 	PullConsumer  natsadapter.PullConsumerConfig `koanf:"pull_consumer"`  // Pull consumer config
 
+	// Contributor service, used to resolve display names in exports
+	ContributorRPC grpc.ClientConfig `koanf:"contributor_rpc"`
+	// Privacy hides the contributors in privacy_mode "anonymous" on the public responses
+	Privacy privacy.Config `koanf:"privacy"`
+
 	// Application configurations
-	Logger           logger.Config                 `koanf:"logger"`
-	RawEventConsumer rawevent.Config               `koanf:"raw_event_consumer"`
-	BatchProcessor   batchprocessor.Config         `koanf:"batch_processor"`
-	DatabaseRetry    postgrerepository.RetryConfig `koanf:"database_retry"`
+	LeaderboardScoring leaderboardscoring.Config     `koanf:"leaderboard_scoring"`
+	Logger             logger.Config                 `koanf:"logger"`
+	RawEventConsumer   rawevent.Config               `koanf:"raw_event_consumer"`
+	BatchProcessor     batchprocessor.Config         `koanf:"batch_processor"`
+	RankUpdate         rankupdate.Config             `koanf:"rank_update"`
+	DatabaseRetry      postgrerepository.RetryConfig `koanf:"database_retry"`
 
 	// Topics
 	StreamNameRawEvents string `koanf:"stream_name_raw_events"`
@@ -47,3 +65,15 @@ type Config struct {
 	// Database migration
 	PathOfMigration string `koanf:"path_of_migration"`
 }
+
+const (
+	LeaderboardCacheRedis  = "redis"
+	LeaderboardCacheMemory = "memory"
+)
+
+type LeaderboardCacheConfig struct {
+	// Backend is "redis" (default) or "memory". The memory backend keeps leaderboards in
+	// process, it is only suitable for a single instance.
+	Backend string                  `koanf:"backend"`
+	Memory  memoryrepository.Config `koanf:"memory"`
+}
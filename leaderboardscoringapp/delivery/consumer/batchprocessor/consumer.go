@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/logger"
-	"github.com/gocasters/rankr/pkg/topicsname"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/metric"
 	"log/slog"
 	"time"
 )
@@ -19,8 +19,10 @@ type PullConsumer interface {
 	Close() error
 }
 
-type Publisher interface {
-	Publish(ctx context.Context, subject string, data []byte) error
+// DeadLetterQueue receives the messages that failed their last delivery attempt
+type DeadLetterQueue interface {
+	Add(ctx context.Context, letter leaderboardscoring.DeadLetter) error
+	Depth(ctx context.Context) (int64, error)
 }
 
 type Config struct {
@@ -29,20 +31,20 @@ type Config struct {
 }
 type Processor struct {
 	consumer    PullConsumer
-	publisher   Publisher
+	dlq         DeadLetterQueue
 	persistence leaderboardscoring.EventPersistence
 	config      Config
 }
 
 func NewProcessor(
 	consumer PullConsumer,
-	publisher Publisher,
+	dlq DeadLetterQueue,
 	persistence leaderboardscoring.EventPersistence,
 	config Config,
 ) *Processor {
 	return &Processor{
 		consumer:    consumer,
-		publisher:   publisher,
+		dlq:         dlq,
 		persistence: persistence,
 		config:      config,
 	}
@@ -85,7 +87,7 @@ func (p *Processor) Start(ctx context.Context) error {
 			}
 
 		case <-metricsTicker.C:
-			p.logMetrics()
+			p.logMetrics(ctx)
 		}
 	}
 }
@@ -132,6 +134,17 @@ func (p *Processor) processBatch(ctx context.Context) error {
 			continue
 		}
 
+		// The event ID is the idempotency key of the persisted row
+		if event.EventID == "" {
+			log.Warn(
+				"Processed event without event ID",
+				slog.String("subject", msg.Subject),
+				slog.String("user_id", event.UserID),
+			)
+			invalidMsgs = append(invalidMsgs, msg)
+			continue
+		}
+
 		validMsgs = append(validMsgs, msg)
 		events = append(events, event)
 	}
@@ -156,11 +169,12 @@ func (p *Processor) processBatch(ctx context.Context) error {
 
 			// If message reached max delivery attempts → send to DLQ; otherwise NAK for retry
 			if !p.consumer.CanRetry(validMsg) {
-				if pErr := p.publisher.Publish(ctx, topicsname.TopicProcessedScoreEventsDLQ, validMsg.Data); pErr != nil {
-					log.Error("failed to publish processed score event to DLQ", slog.String("error", pErr.Error()))
+				if dErr := p.deadLetter(ctx, validMsg, err); dErr != nil {
+					log.Error("failed to publish processed score event to DLQ", slog.String("error", dErr.Error()))
 					continue
 				}
 				_ = validMsg.Term()
+				continue
 			}
 
 			if nakErr := validMsg.Nak(); nakErr != nil {
@@ -193,8 +207,22 @@ func (p *Processor) processBatch(ctx context.Context) error {
 	return nil
 }
 
+// deadLetter moves msg to the DLQ with the error of its last delivery attempt
+func (p *Processor) deadLetter(ctx context.Context, msg *nats.Msg, cause error) error {
+	letter := leaderboardscoring.DeadLetter{
+		Data:     msg.Data,
+		Reason:   cause.Error(),
+		FailedAt: time.Now().UTC(),
+	}
+	if meta, err := msg.Metadata(); err == nil {
+		letter.DeliveryCount = meta.NumDelivered
+	}
+
+	return p.dlq.Add(ctx, letter)
+}
+
 // logMetrics logs consumer statistics
-func (p *Processor) logMetrics() {
+func (p *Processor) logMetrics(ctx context.Context) {
 	info, err := p.consumer.GetConsumerInfo()
 	if err != nil {
 		logger.L().Error(
@@ -204,11 +232,39 @@ func (p *Processor) logMetrics() {
 		return
 	}
 
+	depth, err := p.dlq.Depth(ctx)
+	if err != nil {
+		logger.L().Error(
+			"Failed to get DLQ depth",
+			slog.String("error", err.Error()),
+		)
+	}
+
 	logger.L().Info(
 		"Consumer processed events metrics.",
 		slog.Uint64("pending", info.NumPending),
 		slog.Int("ack_pending", info.NumAckPending),
 		slog.Int("redelivered", info.NumRedelivered),
 		slog.Int("waiting", info.NumWaiting),
+		slog.Int64("dlq_depth", depth),
 	)
 }
+
+// RegisterMetrics exports the DLQ depth as the leaderboardscoring.dlq.depth gauge
+func (p *Processor) RegisterMetrics(meter metric.Meter) error {
+	_, err := meter.Int64ObservableGauge(
+		"leaderboardscoring.dlq.depth",
+		metric.WithDescription("Processed score events waiting in the dead letter queue"),
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			depth, err := p.dlq.Depth(ctx)
+			if err != nil {
+				return err
+			}
+
+			observer.Observe(depth)
+			return nil
+		}),
+	)
+
+	return err
+}
@@ -2,6 +2,7 @@ package rawevent
 
 import (
 	"errors"
+	"fmt"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/logger"
@@ -10,14 +11,34 @@ import (
 	"log/slog"
 )
 
+// The stages of a raw event, each is retried on its own
+const (
+	stageScore  = "score"
+	stageSeason = "season"
+	stageBadges = "badges"
+	stageStreak = "streak"
+)
+
 type Handler struct {
 	leaderboardSvc     *leaderboardscoring.Service
+	seasonSvc          *leaderboardscoring.SeasonService
+	achievementSvc     *leaderboardscoring.AchievementService
+	streakSvc          *leaderboardscoring.StreakService
 	idempotencyChecker *IdempotencyChecker
 }
 
-func NewHandler(svc *leaderboardscoring.Service, checker *IdempotencyChecker) Handler {
+func NewHandler(
+	svc *leaderboardscoring.Service,
+	seasonSvc *leaderboardscoring.SeasonService,
+	achievementSvc *leaderboardscoring.AchievementService,
+	streakSvc *leaderboardscoring.StreakService,
+	checker *IdempotencyChecker,
+) Handler {
 	return Handler{
 		leaderboardSvc:     svc,
+		seasonSvc:          seasonSvc,
+		achievementSvc:     achievementSvc,
+		streakSvc:          streakSvc,
 		idempotencyChecker: checker,
 	}
 }
@@ -39,34 +60,75 @@ func (h Handler) HandleEvent(msg *message.Message) error {
 		return rErr // NACK
 	}
 
-	processEventFunc := func() error {
-		return h.leaderboardSvc.ProcessScoreEvent(msg.Context(), eventReq)
+	// Each stage is marked processed on its own: a redelivery retries only the stages that
+	// failed, so the regular boards are not counted twice when a season update failed
+	stages := []struct {
+		name    string
+		process func() error
+	}{
+		{name: stageScore, process: func() error {
+			return h.leaderboardSvc.ProcessScoreEvent(msg.Context(), eventReq)
+		}},
+		{name: stageSeason, process: func() error {
+			return h.seasonSvc.ScoreEvent(msg.Context(), eventReq)
+		}},
+		{name: stageBadges, process: func() error {
+			awarded, err := h.achievementSvc.EvaluateEvent(msg.Context(), eventReq)
+			for _, award := range awarded {
+				logger.Info(
+					"Badge awarded",
+					slog.String("user_id", award.UserID),
+					slog.String("badge_id", award.BadgeID),
+					slog.String("event_id", award.EventID),
+				)
+			}
+			return err
+		}},
+		{name: stageStreak, process: func() error {
+			_, err := h.streakSvc.RecordEvent(msg.Context(), eventReq)
+			return err
+		}},
 	}
 
-	// Wrap the business logic with the idempotency check.
-	err := h.idempotencyChecker.CheckEvent(msg.Context(), eventReq.ID, processEventFunc)
+	var errs []error
+	for _, stage := range stages {
+		// Wrap the business logic with the idempotency check.
+		err := h.idempotencyChecker.CheckEvent(msg.Context(), stageEventID(eventReq.ID, stage.name), stage.process)
 
-	switch {
-	case err == nil:
-		// Success
-		logger.Debug("Event processed successfully", slog.String("event_id", eventReq.ID))
-		return nil // ACK
+		switch {
+		case err == nil:
+			logger.Debug("Event stage processed successfully", slog.String("event_id", eventReq.ID), slog.String("stage", stage.name))
 
-	case errors.Is(err, ErrEventAlreadyProcessed):
-		// This is not an error, it's an expected outcome for duplicates.
-		logger.Info("Event skipped: already processed", slog.String("event_id", eventReq.ID))
-		return nil // ACK
+		case errors.Is(err, ErrEventAlreadyProcessed):
+			// This is not an error, it's an expected outcome for duplicates.
+			logger.Info("Event stage skipped: already processed", slog.String("event_id", eventReq.ID), slog.String("stage", stage.name))
 
-	case errors.Is(err, ErrEventLocked):
-		logger.Info("Event skipped: currently locked by another processor", slog.String("event_id", eventReq.ID))
-		return nil // we ACK to remove it, as another worker is responsible.
+		case errors.Is(err, ErrEventLocked):
+			// Another worker holds the event and runs its remaining stages
+			logger.Info("Event skipped: currently locked by another processor", slog.String("event_id", eventReq.ID), slog.String("stage", stage.name))
+			return errors.Join(errs...)
 
-	default:
-		logger.Error(
-			"Error processing event",
-			slog.String("event_id", eventReq.ID),
-			slog.String("error", err.Error()),
-		)
-		return err // NACK and requeue
+		default:
+			logger.Error(
+				"Error processing event",
+				slog.String("event_id", eventReq.ID),
+				slog.String("stage", stage.name),
+				slog.String("error", err.Error()),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", stage.name, err))
+		}
 	}
+
+	// NACK and requeue when a stage failed, the redelivery skips the processed stages
+	return errors.Join(errs...)
+}
+
+// stageEventID is the idempotency ID of a stage of an event. The regular boards keep the
+// plain event ID.
+func stageEventID(eventID, stage string) string {
+	if stage == stageScore {
+		return eventID
+	}
+
+	return eventID + ":" + stage
 }
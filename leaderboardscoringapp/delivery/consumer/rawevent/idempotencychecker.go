@@ -20,11 +20,11 @@ type Config struct {
 }
 
 type IdempotencyChecker struct {
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	config      Config
 }
 
-func NewIdempotencyChecker(client *redis.Client, config Config) *IdempotencyChecker {
+func NewIdempotencyChecker(client redis.UniversalClient, config Config) *IdempotencyChecker {
 	return &IdempotencyChecker{
 		redisClient: client,
 		config:      config,
@@ -6,20 +6,37 @@ import (
 	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/logger"
 	leaderboardscoringpb "github.com/gocasters/rankr/protobuf/golang/leaderboardscoring/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log/slog"
+	"time"
 )
 
 type Handler struct {
 	leaderboardscoringpb.UnimplementedLeaderboardScoringServiceServer
 	leaderboardScoringSvc *leaderboardscoring.Service
+	historySvc            *leaderboardscoring.HistoryService
+	achievementSvc        *leaderboardscoring.AchievementService
+	streakSvc             *leaderboardscoring.StreakService
+	explainSvc            *leaderboardscoring.ExplainService
 }
 
-func NewHandler(leaderboardScoringSvc *leaderboardscoring.Service) Handler {
+func NewHandler(
+	leaderboardScoringSvc *leaderboardscoring.Service,
+	historySvc *leaderboardscoring.HistoryService,
+	achievementSvc *leaderboardscoring.AchievementService,
+	streakSvc *leaderboardscoring.StreakService,
+	explainSvc *leaderboardscoring.ExplainService,
+) Handler {
 	return Handler{
 		UnimplementedLeaderboardScoringServiceServer: leaderboardscoringpb.UnimplementedLeaderboardScoringServiceServer{},
 		leaderboardScoringSvc:                        leaderboardScoringSvc,
+		historySvc:                                   historySvc,
+		achievementSvc:                               achievementSvc,
+		streakSvc:                                    streakSvc,
+		explainSvc:                                   explainSvc,
 	}
 }
 
@@ -60,9 +77,277 @@ func (h Handler) GetLeaderboard(ctx context.Context, req *leaderboardscoringpb.G
 	return leaderboardPBRes, nil
 }
 
-func leaderboardResToProtobuf(leaderboardRes leaderboardscoring.GetLeaderboardResponse) *leaderboardscoringpb.GetLeaderboardResponse {
-	rows := make([]*leaderboardscoringpb.LeaderboardRow, 0, len(leaderboardRes.LeaderboardRows))
-	for _, r := range leaderboardRes.LeaderboardRows {
+func (h Handler) WatchLeaderboard(req *leaderboardscoringpb.WatchLeaderboardRequest, stream grpc.ServerStreamingServer[leaderboardscoringpb.LeaderboardUpdate]) error {
+	log := logger.L()
+	log.Info("gRPC WatchLeaderboard stream opened", slog.Any("request", req))
+
+	var projectIDPtr *string
+	if pid := req.GetProjectId(); pid != "" {
+		projectIDPtr = &pid
+	}
+
+	watchReq := &leaderboardscoring.WatchLeaderboardRequest{
+		Timeframe:     leaderboardscoring.FromProtoTimeframe(req.GetTimeframe()),
+		ProjectID:     projectIDPtr,
+		PageSize:      req.GetPageSize(),
+		MinInterval:   time.Duration(req.GetMinIntervalMs()) * time.Millisecond,
+		ResumeVersion: req.GetResumeVersion(),
+	}
+
+	err := h.leaderboardScoringSvc.WatchLeaderboard(stream.Context(), watchReq, func(update leaderboardscoring.LeaderboardUpdate) error {
+		return stream.Send(leaderboardUpdateToProtobuf(update))
+	})
+	if err != nil {
+		if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+			return status.Error(codes.InvalidArgument, "Invalid request parameters provided.")
+		}
+
+		log.Warn("WatchLeaderboard stream ended with error", slog.String("error", err.Error()))
+		return err
+	}
+
+	log.Info("gRPC WatchLeaderboard stream closed", slog.Any("request", req))
+	return nil
+}
+
+func (h Handler) GetLeaderboardAsOf(ctx context.Context, req *leaderboardscoringpb.GetLeaderboardAsOfRequest) (*leaderboardscoringpb.GetLeaderboardAsOfResponse, error) {
+	log := logger.L()
+	log.Info("gRPC GetLeaderboardAsOf request received", slog.Any("request", req))
+
+	var projectIDPtr *string
+	if pid := req.GetProjectId(); pid != "" {
+		projectIDPtr = &pid
+	}
+
+	asOfReq := &leaderboardscoring.GetLeaderboardAsOfRequest{
+		ProjectID: projectIDPtr,
+		AsOf:      timestampToTime(req.GetAsOf()),
+		PageSize:  req.GetPageSize(),
+		Offset:    req.GetOffset(),
+	}
+
+	asOfRes, err := h.historySvc.GetLeaderboardAsOf(ctx, asOfReq)
+	if err != nil {
+		log.Error(
+			"failed to get historical leaderboard from service",
+			slog.String("error", err.Error()),
+			slog.Any("request", req),
+		)
+
+		return nil, historyErrToStatus(err)
+	}
+
+	return &leaderboardscoringpb.GetLeaderboardAsOfResponse{
+		ProjectId:  asOfRes.ProjectID,
+		SnapshotAt: timestamppb.New(asOfRes.SnapshotAt),
+		Rows:       leaderboardRowsToProtobuf(asOfRes.LeaderboardRows),
+	}, nil
+}
+
+func (h Handler) GetUserRankHistory(ctx context.Context, req *leaderboardscoringpb.GetUserRankHistoryRequest) (*leaderboardscoringpb.GetUserRankHistoryResponse, error) {
+	log := logger.L()
+	log.Info("gRPC GetUserRankHistory request received", slog.Any("request", req))
+
+	var projectIDPtr *string
+	if pid := req.GetProjectId(); pid != "" {
+		projectIDPtr = &pid
+	}
+
+	historyReq := &leaderboardscoring.GetUserRankHistoryRequest{
+		UserID:    req.GetUserId(),
+		ProjectID: projectIDPtr,
+		From:      timestampToTime(req.GetFrom()),
+		To:        timestampToTime(req.GetTo()),
+	}
+
+	historyRes, err := h.historySvc.GetUserRankHistory(ctx, historyReq)
+	if err != nil {
+		log.Error(
+			"failed to get user rank history from service",
+			slog.String("error", err.Error()),
+			slog.Any("request", req),
+		)
+
+		return nil, historyErrToStatus(err)
+	}
+
+	points := make([]*leaderboardscoringpb.RankHistoryPoint, 0, len(historyRes.Points))
+	for _, p := range historyRes.Points {
+		points = append(points, &leaderboardscoringpb.RankHistoryPoint{
+			SnapshotAt: timestamppb.New(p.SnapshotAt),
+			Rank:       uint64(p.Rank),
+			Score:      uint64(p.Score),
+		})
+	}
+
+	return &leaderboardscoringpb.GetUserRankHistoryResponse{
+		UserId:    historyRes.UserID,
+		ProjectId: historyRes.ProjectID,
+		Points:    points,
+	}, nil
+}
+
+func (h Handler) ListUserBadges(ctx context.Context, req *leaderboardscoringpb.ListUserBadgesRequest) (*leaderboardscoringpb.ListUserBadgesResponse, error) {
+	log := logger.L()
+	log.Info("gRPC ListUserBadges request received", slog.Any("request", req))
+
+	awards, err := h.achievementSvc.ListUserBadges(ctx, leaderboardscoring.ListUserBadgesRequest{UserID: req.GetUserId()})
+	if err != nil {
+		log.Error(
+			"failed to list user badges from service",
+			slog.String("error", err.Error()),
+			slog.Any("request", req),
+		)
+
+		if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+			return nil, status.Error(codes.InvalidArgument, "Invalid request parameters provided.")
+		}
+		return nil, status.Error(codes.Internal, "An unexpected internal error occurred.")
+	}
+
+	badges := make([]*leaderboardscoringpb.Badge, 0, len(awards))
+	for _, award := range awards {
+		badge := &leaderboardscoringpb.Badge{
+			Id:          award.BadgeID,
+			Name:        award.Name,
+			Description: award.Description,
+			EventId:     award.EventID,
+			AwardedAt:   timestamppb.New(award.AwardedAt),
+		}
+		if award.ProjectID != "" {
+			projectID := award.ProjectID
+			badge.ProjectId = &projectID
+		}
+		badges = append(badges, badge)
+	}
+
+	return &leaderboardscoringpb.ListUserBadgesResponse{
+		UserId: req.GetUserId(),
+		Badges: badges,
+	}, nil
+}
+
+func (h Handler) GetUserStreak(ctx context.Context, req *leaderboardscoringpb.GetUserStreakRequest) (*leaderboardscoringpb.GetUserStreakResponse, error) {
+	log := logger.L()
+	log.Info("gRPC GetUserStreak request received", slog.Any("request", req))
+
+	streak, err := h.streakSvc.GetUserStreak(ctx, leaderboardscoring.GetUserStreakRequest{UserID: req.GetUserId()})
+	if err != nil {
+		log.Error(
+			"failed to get user streak from service",
+			slog.String("error", err.Error()),
+			slog.Any("request", req),
+		)
+
+		if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+			return nil, status.Error(codes.InvalidArgument, "Invalid request parameters provided.")
+		}
+		return nil, status.Error(codes.Internal, "An unexpected internal error occurred.")
+	}
+
+	res := &leaderboardscoringpb.GetUserStreakResponse{
+		UserId:        req.GetUserId(),
+		CurrentStreak: streak.Current,
+		LongestStreak: streak.Longest,
+		Timezone:      streak.Timezone,
+	}
+	if !streak.LastActiveDay.IsZero() {
+		res.LastActiveDay = streak.LastActiveDay.Format(time.DateOnly)
+	}
+
+	return res, nil
+}
+
+func (h Handler) ExplainScore(ctx context.Context, req *leaderboardscoringpb.ExplainScoreRequest) (*leaderboardscoringpb.ExplainScoreResponse, error) {
+	log := logger.L()
+	log.Info("gRPC ExplainScore request received", slog.Any("request", req))
+
+	explanation, err := h.explainSvc.ExplainScore(ctx, leaderboardscoring.ExplainScoreRequest{
+		UserID:    req.GetUserId(),
+		Timeframe: leaderboardscoring.FromProtoTimeframe(req.GetTimeframe()),
+		ProjectID: req.ProjectId,
+		Period:    req.GetPeriod(),
+	})
+	if err != nil {
+		log.Error(
+			"failed to explain score from service",
+			slog.String("error", err.Error()),
+			slog.Any("request", req),
+		)
+
+		if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+			return nil, status.Error(codes.InvalidArgument, "Invalid request parameters provided.")
+		}
+		return nil, status.Error(codes.Internal, "An unexpected internal error occurred.")
+	}
+
+	res := &leaderboardscoringpb.ExplainScoreResponse{
+		UserId:      explanation.UserID,
+		Timeframe:   req.GetTimeframe(),
+		ProjectId:   req.ProjectId,
+		Total:       explanation.Total,
+		Events:      explanation.Events,
+		ByEventType: scoreGroupsToProtobuf(explanation.ByEventType),
+		ByProject:   scoreGroupsToProtobuf(explanation.ByProject),
+		ByDay:       scoreGroupsToProtobuf(explanation.ByDay),
+	}
+	if !explanation.From.IsZero() {
+		res.From = timestamppb.New(explanation.From)
+	}
+	if !explanation.To.IsZero() {
+		res.To = timestamppb.New(explanation.To)
+	}
+
+	return res, nil
+}
+
+func scoreGroupsToProtobuf(groups []leaderboardscoring.ScoreGroup) []*leaderboardscoringpb.ScoreGroup {
+	result := make([]*leaderboardscoringpb.ScoreGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, &leaderboardscoringpb.ScoreGroup{
+			Key:    g.Key,
+			Points: g.Points,
+			Events: g.Events,
+		})
+	}
+
+	return result
+}
+
+// timestampToTime returns the zero time for an unset timestamp
+func timestampToTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
+
+func historyErrToStatus(err error) error {
+	switch {
+	case errors.Is(err, leaderboardscoring.ErrInvalidArguments):
+		return status.Error(codes.InvalidArgument, "Invalid request parameters provided.")
+	case errors.Is(err, leaderboardscoring.ErrLeaderboardNotFound):
+		return status.Error(codes.NotFound, "No snapshot of the leaderboard exists at that time.")
+	default:
+		return status.Error(codes.Internal, "An unexpected internal error occurred.")
+	}
+}
+
+func leaderboardUpdateToProtobuf(update leaderboardscoring.LeaderboardUpdate) *leaderboardscoringpb.LeaderboardUpdate {
+	return &leaderboardscoringpb.LeaderboardUpdate{
+		Timeframe:   leaderboardscoring.ToProtoTimeframe(update.Timeframe),
+		ProjectId:   update.ProjectID,
+		Rows:        leaderboardRowsToProtobuf(update.LeaderboardRows),
+		RankingMode: leaderboardscoring.ToProtoRankingMode(update.RankingMode),
+		Version:     update.Version,
+		Initial:     update.Initial,
+	}
+}
+
+func leaderboardRowsToProtobuf(leaderboardRows []leaderboardscoring.LeaderboardRow) []*leaderboardscoringpb.LeaderboardRow {
+	rows := make([]*leaderboardscoringpb.LeaderboardRow, 0, len(leaderboardRows))
+	for _, r := range leaderboardRows {
 		leaderboardRow := &leaderboardscoringpb.LeaderboardRow{
 			Rank:   uint64(r.Rank),
 			UserId: r.UserID,
@@ -72,10 +357,15 @@ func leaderboardResToProtobuf(leaderboardRes leaderboardscoring.GetLeaderboardRe
 		rows = append(rows, leaderboardRow)
 	}
 
+	return rows
+}
+
+func leaderboardResToProtobuf(leaderboardRes leaderboardscoring.GetLeaderboardResponse) *leaderboardscoringpb.GetLeaderboardResponse {
 	leaderboardPBRes := &leaderboardscoringpb.GetLeaderboardResponse{
-		Timeframe: leaderboardscoring.ToProtoTimeframe(leaderboardRes.Timeframe),
-		ProjectId: leaderboardRes.ProjectID,
-		Rows:      rows,
+		Timeframe:   leaderboardscoring.ToProtoTimeframe(leaderboardRes.Timeframe),
+		ProjectId:   leaderboardRes.ProjectID,
+		Rows:        leaderboardRowsToProtobuf(leaderboardRes.LeaderboardRows),
+		RankingMode: leaderboardscoring.ToProtoRankingMode(leaderboardRes.RankingMode),
 	}
 	return leaderboardPBRes
 }
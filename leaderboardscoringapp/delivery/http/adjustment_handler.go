@@ -0,0 +1,190 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type adjustmentResponse struct {
+	ID          int64     `json:"id"`
+	EventID     string    `json:"event_id"`
+	UserID      string    `json:"user_id"`
+	ProjectID   string    `json:"project_id"`
+	Timeframe   string    `json:"timeframe"`
+	Period      string    `json:"period,omitempty"`
+	Points      int64     `json:"points"`
+	Reason      string    `json:"reason"`
+	Actor       string    `json:"actor"`
+	EffectiveAt time.Time `json:"effective_at"`
+	ReversalOf  int64     `json:"reversal_of,omitempty"`
+	ReversedBy  int64     `json:"reversed_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type adjustmentBody struct {
+	UserID    string `json:"user_id"`
+	ProjectID string `json:"project_id"`
+	Timeframe string `json:"timeframe"`
+	Period    string `json:"period"`
+	Points    int64  `json:"points"`
+	Reason    string `json:"reason"`
+}
+
+type reverseAdjustmentBody struct {
+	Reason string `json:"reason"`
+}
+
+func toAdjustmentResponse(adjustment leaderboardscoring.Adjustment) adjustmentResponse {
+	return adjustmentResponse{
+		ID:          adjustment.ID,
+		EventID:     adjustment.EventID(),
+		UserID:      adjustment.UserID,
+		ProjectID:   adjustment.ProjectID,
+		Timeframe:   adjustment.Timeframe,
+		Period:      adjustment.Period,
+		Points:      adjustment.Points,
+		Reason:      adjustment.Reason,
+		Actor:       adjustment.Actor,
+		EffectiveAt: adjustment.EffectiveAt,
+		ReversalOf:  adjustment.ReversalOf,
+		ReversedBy:  adjustment.ReversedBy,
+		CreatedAt:   adjustment.CreatedAt,
+	}
+}
+
+func adjustmentID(c echo.Context) (int64, error) {
+	return strconv.ParseInt(c.Param("id"), 10, 64)
+}
+
+// listAdjustments returns the audit trail of manual adjustments, latest first.
+//
+// GET /v1/admin/adjustments?user_id=7&project_id=1001&offset=0&page_size=50
+func (h Handler) listAdjustments(c echo.Context) error {
+	offset, pageSize, err := pageParams(c, leaderboardscoring.DefaultAdjustmentPageSize)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	adjustments, err := h.AdjustmentService.ListAdjustments(c.Request().Context(), leaderboardscoring.ListAdjustmentsRequest{
+		UserID:    c.QueryParam("user_id"),
+		ProjectID: c.QueryParam("project_id"),
+		Offset:    offset,
+		PageSize:  pageSize,
+	})
+	if err != nil {
+		return adjustmentError(c, err)
+	}
+
+	res := make([]adjustmentResponse, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		res = append(res, toAdjustmentResponse(adjustment))
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"adjustments": res})
+}
+
+// getAdjustment returns a single adjustment.
+//
+// GET /v1/admin/adjustments/:id
+func (h Handler) getAdjustment(c echo.Context) error {
+	id, err := adjustmentID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "id must be a number"})
+	}
+
+	adjustment, err := h.AdjustmentService.GetAdjustment(c.Request().Context(), id)
+	if err != nil {
+		return adjustmentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toAdjustmentResponse(adjustment))
+}
+
+// createAdjustment grants or removes points by hand, the caller is kept as the actor.
+//
+// POST /v1/admin/adjustments {"user_id": "7", "project_id": "1001", "timeframe": "all",
+// "points": 50, "reason": "Talk at GopherCon 2025"}
+func (h Handler) createAdjustment(c echo.Context) error {
+	var body adjustmentBody
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	adjustment, err := h.AdjustmentService.CreateAdjustment(c.Request().Context(), leaderboardscoring.CreateAdjustmentRequest{
+		UserID:    body.UserID,
+		ProjectID: body.ProjectID,
+		Timeframe: body.Timeframe,
+		Period:    body.Period,
+		Points:    body.Points,
+		Reason:    body.Reason,
+		Actor:     actor(c),
+	})
+	if err != nil {
+		return adjustmentWriteError(c, adjustment, err)
+	}
+
+	return c.JSON(http.StatusCreated, toAdjustmentResponse(adjustment))
+}
+
+// reverseAdjustment takes the points of an adjustment back.
+//
+// POST /v1/admin/adjustments/:id/reverse {"reason": "granted to the wrong user"}
+func (h Handler) reverseAdjustment(c echo.Context) error {
+	id, err := adjustmentID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "id must be a number"})
+	}
+
+	var body reverseAdjustmentBody
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	reversal, err := h.AdjustmentService.ReverseAdjustment(c.Request().Context(), leaderboardscoring.ReverseAdjustmentRequest{
+		ID:     id,
+		Reason: body.Reason,
+		Actor:  actor(c),
+	})
+	if err != nil {
+		return adjustmentWriteError(c, reversal, err)
+	}
+
+	return c.JSON(http.StatusCreated, toAdjustmentResponse(reversal))
+}
+
+// adjustmentWriteError reports an adjustment that was recorded but failed to score along
+// with its record, it has to be reversed or checked by hand
+func adjustmentWriteError(c echo.Context, adjustment leaderboardscoring.Adjustment, err error) error {
+	if adjustment.ID == 0 {
+		return adjustmentError(c, err)
+	}
+
+	logger.L().Error("adjustment recorded but not applied",
+		slog.Int64("adjustment_id", adjustment.ID),
+		slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, echo.Map{
+		"error":      "adjustment was recorded but not applied",
+		"adjustment": toAdjustmentResponse(adjustment),
+	})
+}
+
+func adjustmentError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, leaderboardscoring.ErrInvalidArguments):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	case errors.Is(err, leaderboardscoring.ErrAdjustmentNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, leaderboardscoring.ErrAdjustmentReversed):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	}
+
+	logger.L().Error("adjustment request failed", slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to handle adjustment request"})
+}
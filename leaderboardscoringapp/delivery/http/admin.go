@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	types "github.com/gocasters/rankr/type"
+	"github.com/labstack/echo/v4"
+)
+
+// AdminConfig lists the users allowed to call the /v1/admin routes
+type AdminConfig struct {
+	UserIDs []string `koanf:"user_ids"`
+}
+
+// requireAdmin rejects requests of users missing from the admin list. The user comes from
+// the X-User-Info claim checked by the router, routes behind it read it with actor.
+func (s Server) requireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	admins := make(map[string]struct{}, len(s.Admin.UserIDs))
+	for _, id := range s.Admin.UserIDs {
+		admins[id] = struct{}{}
+	}
+
+	return func(c echo.Context) error {
+		id := actor(c)
+		if id == "" {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+		}
+		if _, ok := admins[id]; !ok {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "forbidden"})
+		}
+
+		return next(c)
+	}
+}
+
+// actor returns the ID of the user making the request, empty without a user claim
+func actor(c echo.Context) string {
+	claim, ok := c.Get("userInfo").(*types.UserClaim)
+	if !ok || claim == nil {
+		return ""
+	}
+
+	return claim.ID.String()
+}
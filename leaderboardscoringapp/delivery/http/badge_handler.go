@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type badgeRuleResponse struct {
+	ID          string                         `json:"id"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Events      []leaderboardscoring.EventName `json:"events"`
+	Kind        leaderboardscoring.BadgeKind   `json:"kind"`
+	Threshold   int64                          `json:"threshold"`
+	StreakUnit  leaderboardscoring.StreakUnit  `json:"streak_unit,omitempty"`
+	Scope       leaderboardscoring.BadgeScope  `json:"scope,omitempty"`
+}
+
+type badgeAwardResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	EventID     string    `json:"event_id"`
+	AwardedAt   time.Time `json:"awarded_at"`
+}
+
+// listBadges returns the badges contributors can earn.
+//
+// GET /v1/badges
+func (h Handler) listBadges(c echo.Context) error {
+	rules := h.AchievementService.ListBadges()
+
+	badges := make([]badgeRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		badges = append(badges, badgeRuleResponse{
+			ID:          rule.ID,
+			Name:        rule.Name,
+			Description: rule.Description,
+			Events:      rule.Events,
+			Kind:        rule.Kind,
+			Threshold:   rule.Threshold,
+			StreakUnit:  rule.StreakUnit,
+			Scope:       rule.Scope,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"badges": badges})
+}
+
+// listUserBadges returns the badges a user holds, earliest first.
+//
+// GET /v1/users/:user_id/badges
+func (h Handler) listUserBadges(c echo.Context) error {
+	req := leaderboardscoring.ListUserBadgesRequest{UserID: c.Param("user_id")}
+	if ok, err := h.visibleUser(c, req.UserID); !ok {
+		return err
+	}
+
+	awards, err := h.AchievementService.ListUserBadges(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+
+		logger.L().Error("list user badges failed", slog.String("error", err.Error()))
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to read badges"})
+	}
+
+	badges := make([]badgeAwardResponse, 0, len(awards))
+	for _, award := range awards {
+		badges = append(badges, badgeAwardResponse{
+			ID:          award.BadgeID,
+			Name:        award.Name,
+			Description: award.Description,
+			ProjectID:   award.ProjectID,
+			EventID:     award.EventID,
+			AwardedAt:   award.AwardedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"user_id": req.UserID, "badges": badges})
+}
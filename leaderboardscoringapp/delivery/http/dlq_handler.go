@@ -0,0 +1,210 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type deadLetterResponse struct {
+	Sequence      uint64    `json:"sequence"`
+	Reason        string    `json:"reason"`
+	DeliveryCount uint64    `json:"delivery_count"`
+	FailedAt      time.Time `json:"failed_at"`
+	EventID       string    `json:"event_id,omitempty"`
+	UserID        string    `json:"user_id,omitempty"`
+	ProjectID     string    `json:"project_id,omitempty"`
+	Payload       string    `json:"payload"`
+	Reprocessable bool      `json:"reprocessable"`
+}
+
+type deadLetterActionBody struct {
+	Sequence  uint64 `json:"sequence"`
+	All       bool   `json:"all"`
+	EventID   string `json:"event_id"`
+	UserID    string `json:"user_id"`
+	ProjectID string `json:"project_id"`
+	Reason    string `json:"reason"`
+	Note      string `json:"note"`
+}
+
+func toDeadLetterResponse(letter leaderboardscoring.DeadLetter) deadLetterResponse {
+	res := deadLetterResponse{
+		Sequence:      letter.Sequence,
+		Reason:        letter.Reason,
+		DeliveryCount: letter.DeliveryCount,
+		FailedAt:      letter.FailedAt,
+		Payload:       string(letter.Data),
+		Reprocessable: letter.Event != nil,
+	}
+	if letter.Event != nil {
+		res.EventID = letter.Event.EventID
+		res.UserID = letter.Event.UserID
+		res.ProjectID = letter.Event.ProjectID
+	}
+
+	return res
+}
+
+func deadLetterFilter(c echo.Context) leaderboardscoring.DeadLetterFilter {
+	return leaderboardscoring.DeadLetterFilter{
+		EventID:   c.QueryParam("event_id"),
+		UserID:    c.QueryParam("user_id"),
+		ProjectID: c.QueryParam("project_id"),
+		Reason:    c.QueryParam("reason"),
+	}
+}
+
+// listDeadLetters returns a page of dead letters, oldest first.
+//
+// GET /v1/admin/dlq?user_id=7&reason=timeout&after=120&limit=50
+func (h Handler) listDeadLetters(c echo.Context) error {
+	req := leaderboardscoring.ListDeadLettersRequest{Filter: deadLetterFilter(c)}
+
+	var err error
+	if after := c.QueryParam("after"); after != "" {
+		if req.After, err = strconv.ParseUint(after, 10, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "after must be a sequence number"})
+		}
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "limit must be a number"})
+		}
+	}
+
+	res, err := h.DLQService.ListDeadLetters(c.Request().Context(), req)
+	if err != nil {
+		return h.dlqError(c, err)
+	}
+
+	letters := make([]deadLetterResponse, 0, len(res.DeadLetters))
+	for _, letter := range res.DeadLetters {
+		letters = append(letters, toDeadLetterResponse(letter))
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"dead_letters": letters,
+		"next_after":   res.NextAfter,
+		"depth":        res.Depth,
+	})
+}
+
+// getDeadLetter returns a single dead letter.
+//
+// GET /v1/admin/dlq/:sequence
+func (h Handler) getDeadLetter(c echo.Context) error {
+	sequence, err := strconv.ParseUint(c.Param("sequence"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "sequence must be a number"})
+	}
+
+	letter, err := h.DLQService.GetDeadLetter(c.Request().Context(), sequence)
+	if err != nil {
+		return h.dlqError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toDeadLetterResponse(letter))
+}
+
+// deadLetterStats returns the number of letters in the queue.
+//
+// GET /v1/admin/dlq/stats
+func (h Handler) deadLetterStats(c echo.Context) error {
+	depth, err := h.DLQService.Depth(c.Request().Context())
+	if err != nil {
+		return h.dlqError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"depth": depth})
+}
+
+// reprocessDeadLetters persists the selected letters again.
+//
+// POST /v1/admin/dlq/reprocess {"sequence": 42} | {"all": true} | {"user_id": "7", "reason": "timeout"}
+func (h Handler) reprocessDeadLetters(c echo.Context) error {
+	req, err := deadLetterActionRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	res, err := h.DLQService.ReprocessDeadLetters(c.Request().Context(), req)
+	return h.deadLetterActionResult(c, res, err)
+}
+
+// discardDeadLetters drops the selected letters, a note is required for the audit record.
+//
+// POST /v1/admin/dlq/discard {"sequence": 42, "note": "test event from staging"}
+func (h Handler) discardDeadLetters(c echo.Context) error {
+	req, err := deadLetterActionRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	res, err := h.DLQService.DiscardDeadLetters(c.Request().Context(), req)
+	return h.deadLetterActionResult(c, res, err)
+}
+
+func deadLetterActionRequest(c echo.Context) (leaderboardscoring.DeadLetterActionRequest, error) {
+	var body deadLetterActionBody
+	if err := c.Bind(&body); err != nil {
+		return leaderboardscoring.DeadLetterActionRequest{}, err
+	}
+
+	return leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{
+			Sequence: body.Sequence,
+			All:      body.All,
+			Filter: leaderboardscoring.DeadLetterFilter{
+				EventID:   body.EventID,
+				UserID:    body.UserID,
+				ProjectID: body.ProjectID,
+				Reason:    body.Reason,
+			},
+		},
+		Actor: actor(c),
+		Note:  body.Note,
+	}, nil
+}
+
+// deadLetterActionResult reports partial progress as well, a failed run may already have
+// moved some letters
+func (h Handler) deadLetterActionResult(c echo.Context, res leaderboardscoring.DeadLetterActionResponse, err error) error {
+	skipped := make([]echo.Map, 0, len(res.Skipped))
+	for _, skip := range res.Skipped {
+		skipped = append(skipped, echo.Map{"sequence": skip.Sequence, "reason": skip.Reason})
+	}
+	body := echo.Map{"affected": res.Affected, "skipped": skipped}
+
+	if err != nil {
+		if errors.Is(err, leaderboardscoring.ErrInvalidArguments) || errors.Is(err, leaderboardscoring.ErrDeadLetterNotFound) {
+			return h.dlqError(c, err)
+		}
+
+		logger.L().Error("dead letter action failed",
+			slog.Int("affected", res.Affected),
+			slog.String("error", err.Error()))
+		body["error"] = "failed to process dead letters"
+		return c.JSON(http.StatusInternalServerError, body)
+	}
+
+	return c.JSON(http.StatusOK, body)
+}
+
+func (h Handler) dlqError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, leaderboardscoring.ErrInvalidArguments):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	case errors.Is(err, leaderboardscoring.ErrDeadLetterNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	}
+
+	logger.L().Error("dead letter queue request failed", slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to read dead letter queue"})
+}
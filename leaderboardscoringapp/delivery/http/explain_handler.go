@@ -0,0 +1,143 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type scoreGroupResponse struct {
+	Key    string `json:"key"`
+	Points int64  `json:"points"`
+	Events int64  `json:"events"`
+}
+
+type scoreEventResponse struct {
+	EventID        string                       `json:"event_id"`
+	EventType      leaderboardscoring.EventName `json:"event_type"`
+	Points         int64                        `json:"points"`
+	Rule           string                       `json:"rule,omitempty"`
+	ProjectID      string                       `json:"project_id"`
+	RepositoryID   uint64                       `json:"repository_id"`
+	RepositoryName string                       `json:"repository_name"`
+	// Number is the pull request or issue number, Ref the pushed branch or the event that
+	// extended a streak
+	Number     int32     `json:"number,omitempty"`
+	Ref        string    `json:"ref,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+	ScoredAt   time.Time `json:"scored_at"`
+}
+
+func toScoreGroups(groups []leaderboardscoring.ScoreGroup) []scoreGroupResponse {
+	res := make([]scoreGroupResponse, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, scoreGroupResponse{Key: group.Key, Points: group.Points, Events: group.Events})
+	}
+
+	return res
+}
+
+func explainScoreRequest(c echo.Context) leaderboardscoring.ExplainScoreRequest {
+	req := leaderboardscoring.ExplainScoreRequest{
+		UserID:    c.Param("user_id"),
+		Timeframe: c.QueryParam("timeframe"),
+		Period:    c.QueryParam("period"),
+	}
+	if projectID := c.QueryParam("project_id"); projectID != "" {
+		req.ProjectID = &projectID
+	}
+
+	return req
+}
+
+// explainScore splits a user's score on a board by event type, project and day.
+//
+// GET /v1/users/:user_id/score/explain?timeframe=monthly&project_id=1001&period=2025-06
+func (h Handler) explainScore(c echo.Context) error {
+	if ok, err := h.visibleUser(c, c.Param("user_id")); !ok {
+		return err
+	}
+
+	res, err := h.ExplainService.ExplainScore(c.Request().Context(), explainScoreRequest(c))
+	if err != nil {
+		return explainError(c, err)
+	}
+
+	body := echo.Map{
+		"user_id":         res.UserID,
+		"leaderboard_key": res.LeaderboardKey,
+		"timeframe":       res.Timeframe,
+		"project_id":      res.ProjectID,
+		"timezone":        res.Timezone,
+		"total":           res.Total,
+		"events":          res.Events,
+		"board_score":     res.BoardScore,
+		"by_event_type":   toScoreGroups(res.ByEventType),
+		"by_project":      toScoreGroups(res.ByProject),
+		"by_day":          toScoreGroups(res.ByDay),
+	}
+	if !res.From.IsZero() {
+		body["from"], body["to"] = res.From, res.To
+	}
+
+	return c.JSON(http.StatusOK, body)
+}
+
+// listScoreEvents returns the events behind a user's score on a board, latest first.
+//
+// GET /v1/users/:user_id/score/events?timeframe=monthly&event_type=issue_closed&project=1001&day=2025-06-02
+func (h Handler) listScoreEvents(c echo.Context) error {
+	if ok, err := h.visibleUser(c, c.Param("user_id")); !ok {
+		return err
+	}
+
+	offset, pageSize, err := pageParams(c, leaderboardscoring.DefaultScoreEventsPageSize)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	events, err := h.ExplainService.ListScoreEvents(c.Request().Context(), leaderboardscoring.ListScoreEventsRequest{
+		ExplainScoreRequest: explainScoreRequest(c),
+		EventName:           leaderboardscoring.EventName(c.QueryParam("event_type")),
+		Project:             c.QueryParam("project"),
+		Day:                 c.QueryParam("day"),
+		Offset:              offset,
+		PageSize:            pageSize,
+	})
+	if err != nil {
+		return explainError(c, err)
+	}
+
+	res := make([]scoreEventResponse, 0, len(events))
+	for _, event := range events {
+		res = append(res, scoreEventResponse{
+			EventID:        event.EventID,
+			EventType:      event.EventName,
+			Points:         event.Score,
+			Rule:           event.Rule,
+			ProjectID:      event.ProjectID,
+			RepositoryID:   event.RepositoryID,
+			RepositoryName: event.RepositoryName,
+			Number:         event.SourceNumber,
+			Ref:            event.SourceRef,
+			OccurredAt:     event.Timestamp,
+			ScoredAt:       event.ProcessedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"events": res})
+}
+
+func explainError(c echo.Context, err error) error {
+	if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	logger.L().Error("explain score failed", slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to explain score"})
+}
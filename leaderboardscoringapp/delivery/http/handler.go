@@ -1,16 +1,118 @@
 package http
 
 import (
-	"github.com/labstack/echo/v4"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
+	"github.com/labstack/echo/v4"
 )
 
-type Handler struct{}
+type Handler struct {
+	LeaderboardService *leaderboardscoring.Service
+	DLQService         *leaderboardscoring.DLQService
+	HistoryService     *leaderboardscoring.HistoryService
+	SeasonService      *leaderboardscoring.SeasonService
+	AchievementService *leaderboardscoring.AchievementService
+	StreakService      *leaderboardscoring.StreakService
+	ExplainService     *leaderboardscoring.ExplainService
+	AdjustmentService  *leaderboardscoring.AdjustmentService
+	// Identities masks the anonymous contributors on the public responses
+	Identities *privacy.IdentityResolver
+}
 
-func NewHandler() Handler {
-	return Handler{}
+func NewHandler(
+	lbService *leaderboardscoring.Service,
+	dlqService *leaderboardscoring.DLQService,
+	historyService *leaderboardscoring.HistoryService,
+	seasonService *leaderboardscoring.SeasonService,
+	achievementService *leaderboardscoring.AchievementService,
+	streakService *leaderboardscoring.StreakService,
+	explainService *leaderboardscoring.ExplainService,
+	adjustmentService *leaderboardscoring.AdjustmentService,
+	identities *privacy.IdentityResolver,
+) Handler {
+	return Handler{
+		LeaderboardService: lbService,
+		DLQService:         dlqService,
+		HistoryService:     historyService,
+		SeasonService:      seasonService,
+		AchievementService: achievementService,
+		StreakService:      streakService,
+		ExplainService:     explainService,
+		AdjustmentService:  adjustmentService,
+		Identities:         identities,
+	}
 }
 
 func (h Handler) HealthCheck(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
+
+// exportLeaderboard streams a whole leaderboard as a file download.
+//
+// GET /v1/leaderboards/export?timeframe=monthly&project_id=1001&period=2025-06&format=xlsx
+func (h Handler) exportLeaderboard(c echo.Context) error {
+	req := &leaderboardscoring.ExportLeaderboardRequest{
+		Timeframe: c.QueryParam("timeframe"),
+		Period:    c.QueryParam("period"),
+		Format:    leaderboardscoring.ExportFormat(c.QueryParam("format")),
+		Viewer:    privacy.ViewerOf(c),
+	}
+	if projectID := c.QueryParam("project_id"); projectID != "" {
+		req.ProjectID = &projectID
+	}
+	if req.Format == "" {
+		req.Format = leaderboardscoring.ExportFormatCSV
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, req.Format.ContentType())
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", req.FileName()))
+
+	err := h.LeaderboardService.ExportLeaderboard(c.Request().Context(), req, res)
+	if err == nil {
+		return nil
+	}
+
+	// Once rows have been streamed the status is sent, the client sees a truncated file
+	if res.Committed {
+		logger.L().Error("leaderboard export aborted",
+			slog.String("file", req.FileName()),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	res.Header().Del(echo.HeaderContentDisposition)
+	if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to export leaderboard"})
+}
+
+// pageParams reads offset and page_size, page_size defaults to defaultPageSize
+func pageParams(c echo.Context, defaultPageSize int32) (offset, pageSize int32, err error) {
+	pageSize = defaultPageSize
+	if value := c.QueryParam("page_size"); value != "" {
+		size, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, 0, errors.New("page_size must be a number")
+		}
+		pageSize = int32(size)
+	}
+	if value := c.QueryParam("offset"); value != "" {
+		off, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, 0, errors.New("offset must be a number")
+		}
+		offset = int32(off)
+	}
+
+	return offset, pageSize, nil
+}
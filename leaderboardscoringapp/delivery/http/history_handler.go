@@ -0,0 +1,144 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+const historyDateLayout = "2006-01-02"
+
+type historyRowResponse struct {
+	Rank int64 `json:"rank"`
+	publicUser
+	Score int64 `json:"score"`
+}
+
+type rankHistoryPointResponse struct {
+	SnapshotAt time.Time `json:"snapshot_at"`
+	Rank       int64     `json:"rank"`
+	Score      int64     `json:"score"`
+}
+
+// parseHistoryTime accepts RFC 3339 times and plain dates. A date stands for the end of
+// that UTC day when endOfDay is set and for its start otherwise.
+func parseHistoryTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.Parse(historyDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a date (YYYY-MM-DD) nor an RFC 3339 time", value)
+	}
+	if endOfDay {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+
+	return day, nil
+}
+
+// getLeaderboardAsOf returns a page of the all-time board as of the latest snapshot taken
+// at or before as_of. A date means the board at the end of that day.
+//
+// GET /v1/leaderboards/history?as_of=2025-06-01&project_id=1001&offset=0&page_size=50
+func (h Handler) getLeaderboardAsOf(c echo.Context) error {
+	offset, pageSize, err := pageParams(c, leaderboardscoring.DefaultHistoryPageSize)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	req := &leaderboardscoring.GetLeaderboardAsOfRequest{Offset: offset, PageSize: pageSize}
+	if projectID := c.QueryParam("project_id"); projectID != "" {
+		req.ProjectID = &projectID
+	}
+	if req.AsOf, err = parseHistoryTime(c.QueryParam("as_of"), true); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "as_of: " + err.Error()})
+	}
+
+	res, err := h.HistoryService.GetLeaderboardAsOf(c.Request().Context(), req)
+	if err != nil {
+		return historyError(c, err)
+	}
+
+	userIDs := make([]string, 0, len(res.LeaderboardRows))
+	for _, row := range res.LeaderboardRows {
+		userIDs = append(userIDs, row.UserID)
+	}
+	identities := h.userIdentities(c, userIDs)
+
+	rows := make([]historyRowResponse, 0, len(res.LeaderboardRows))
+	for _, row := range res.LeaderboardRows {
+		rows = append(rows, historyRowResponse{
+			Rank:       row.Rank,
+			publicUser: toPublicUser(row.UserID, identities),
+			Score:      row.Score,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"project_id":  res.ProjectID,
+		"snapshot_at": res.SnapshotAt,
+		"rows":        rows,
+	})
+}
+
+// getUserRankHistory returns the rank and score of a user in the snapshots of the all-time
+// board taken in [from, to), 90 days up to now by default.
+//
+// GET /v1/leaderboards/history/users/:user_id?project_id=1001&from=2025-03-01&to=2025-06-01
+func (h Handler) getUserRankHistory(c echo.Context) error {
+	req := &leaderboardscoring.GetUserRankHistoryRequest{UserID: c.Param("user_id")}
+	if ok, err := h.visibleUser(c, req.UserID); !ok {
+		return err
+	}
+	if projectID := c.QueryParam("project_id"); projectID != "" {
+		req.ProjectID = &projectID
+	}
+
+	var err error
+	if from := c.QueryParam("from"); from != "" {
+		if req.From, err = parseHistoryTime(from, false); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "from: " + err.Error()})
+		}
+	}
+	if to := c.QueryParam("to"); to != "" {
+		if req.To, err = parseHistoryTime(to, false); err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "to: " + err.Error()})
+		}
+	}
+
+	res, err := h.HistoryService.GetUserRankHistory(c.Request().Context(), req)
+	if err != nil {
+		return historyError(c, err)
+	}
+
+	points := make([]rankHistoryPointResponse, 0, len(res.Points))
+	for _, p := range res.Points {
+		points = append(points, rankHistoryPointResponse{SnapshotAt: p.SnapshotAt, Rank: p.Rank, Score: p.Score})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"user_id":    res.UserID,
+		"project_id": res.ProjectID,
+		"points":     points,
+	})
+}
+
+func historyError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, leaderboardscoring.ErrInvalidArguments):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	case errors.Is(err, leaderboardscoring.ErrLeaderboardNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "no snapshot of the leaderboard exists at that time"})
+	}
+
+	logger.L().Error("leaderboard history request failed", slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to read leaderboard history"})
+}
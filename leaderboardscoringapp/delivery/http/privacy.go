@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/privacy"
+	types "github.com/gocasters/rankr/type"
+	"github.com/labstack/echo/v4"
+)
+
+// userIdentities returns the identities of leaderboard users as the caller may see them.
+// Users missing from the result, e.g. without a resolver, are shown as is.
+func (h Handler) userIdentities(c echo.Context, userIDs []string) map[string]privacy.Identity {
+	if h.Identities == nil {
+		return nil
+	}
+
+	return leaderboardscoring.UserIdentities(c.Request().Context(), h.Identities, userIDs, privacy.ViewerOf(c))
+}
+
+// visibleUser answers 404 for an anonymous contributor the caller may not see, the
+// response would link its user ID to its activity. ok is false once the response is
+// written. The message names no user, it must not tell which IDs are anonymous.
+func (h Handler) visibleUser(c echo.Context, userID string) (ok bool, err error) {
+	if h.Identities == nil {
+		return true, nil
+	}
+
+	id, parseErr := strconv.ParseUint(userID, 10, 64)
+	if parseErr != nil {
+		// Not a VCS user ID, it cannot belong to a contributor
+		return true, nil
+	}
+
+	// A failed lookup masks the user, like it masks every user of a board
+	identities := h.Identities.Identities(c.Request().Context(), []types.ID{types.ID(id)}, privacy.ViewerOf(c))
+	if identities[types.ID(id)].Masked() {
+		return false, c.JSON(http.StatusNotFound, echo.Map{"error": "user not found"})
+	}
+
+	return true, nil
+}
+
+// publicUser is the user of a public row, an anonymous contributor the caller may not see
+// has no user_id and a pseudonym as display_name
+type publicUser struct {
+	UserID      string `json:"user_id,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Anonymous   bool   `json:"anonymous"`
+}
+
+func toPublicUser(userID string, identities map[string]privacy.Identity) publicUser {
+	identity, ok := identities[userID]
+	if !ok {
+		return publicUser{UserID: userID}
+	}
+	if identity.Masked() {
+		return publicUser{DisplayName: identity.DisplayName, Anonymous: true}
+	}
+
+	return publicUser{UserID: userID, DisplayName: identity.DisplayName, Anonymous: identity.Anonymous}
+}
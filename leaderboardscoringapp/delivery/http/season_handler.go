@@ -0,0 +1,243 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+const defaultSeasonPageSize = 50
+
+type seasonResponse struct {
+	ID           int64                                  `json:"id"`
+	Name         string                                 `json:"name"`
+	Status       leaderboardscoring.SeasonStatus        `json:"status"`
+	StartsAt     time.Time                              `json:"starts_at"`
+	EndsAt       time.Time                              `json:"ends_at"`
+	ProjectIDs   []string                               `json:"project_ids"`
+	ScoringRules map[leaderboardscoring.EventName]int64 `json:"scoring_rules"`
+	RankingMode  leaderboardscoring.RankingMode         `json:"ranking_mode"`
+	WinnerCount  int                                    `json:"winner_count"`
+	Participants int64                                  `json:"participants"`
+	ClosedAt     *time.Time                             `json:"closed_at,omitempty"`
+}
+
+type seasonBody struct {
+	Name         string                                 `json:"name"`
+	StartsAt     time.Time                              `json:"starts_at"`
+	EndsAt       time.Time                              `json:"ends_at"`
+	ProjectIDs   []string                               `json:"project_ids"`
+	ScoringRules map[leaderboardscoring.EventName]int64 `json:"scoring_rules"`
+	RankingMode  leaderboardscoring.RankingMode         `json:"ranking_mode"`
+	WinnerCount  *int                                   `json:"winner_count"`
+}
+
+func toSeasonResponse(season leaderboardscoring.Season, now time.Time) seasonResponse {
+	res := seasonResponse{
+		ID:           season.ID,
+		Name:         season.Name,
+		Status:       season.Status(now),
+		StartsAt:     season.StartsAt,
+		EndsAt:       season.EndsAt,
+		ProjectIDs:   season.ProjectIDs,
+		ScoringRules: season.ScoringRules,
+		RankingMode:  season.RankingMode,
+		WinnerCount:  season.WinnerCount,
+		Participants: season.Participants,
+	}
+	if !season.ClosedAt.IsZero() {
+		closedAt := season.ClosedAt
+		res.ClosedAt = &closedAt
+	}
+
+	return res
+}
+
+type seasonStandingResponse struct {
+	Rank int64 `json:"rank"`
+	publicUser
+	Score  int64 `json:"score"`
+	Winner bool  `json:"winner"`
+}
+
+func seasonID(c echo.Context) (int64, error) {
+	return strconv.ParseInt(c.Param("id"), 10, 64)
+}
+
+// listSeasons returns seasons, latest start first.
+//
+// GET /v1/seasons?status=closed&offset=0&page_size=20
+func (h Handler) listSeasons(c echo.Context) error {
+	offset, pageSize, err := pageParams(c, defaultSeasonPageSize)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	seasons, err := h.SeasonService.ListSeasons(c.Request().Context(), leaderboardscoring.ListSeasonsRequest{
+		Status:   leaderboardscoring.SeasonStatus(c.QueryParam("status")),
+		Offset:   offset,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return seasonError(c, err)
+	}
+
+	now := time.Now()
+	res := make([]seasonResponse, 0, len(seasons))
+	for _, season := range seasons {
+		res = append(res, toSeasonResponse(season, now))
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"seasons": res})
+}
+
+// getSeason returns a single season.
+//
+// GET /v1/seasons/:id
+func (h Handler) getSeason(c echo.Context) error {
+	id, err := seasonID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "id must be a number"})
+	}
+
+	season, err := h.SeasonService.GetSeason(c.Request().Context(), id)
+	if err != nil {
+		return seasonError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toSeasonResponse(season, time.Now()))
+}
+
+// getSeasonStandings returns a page of the season board, final once the season is closed.
+//
+// GET /v1/seasons/:id/standings?offset=0&page_size=50
+func (h Handler) getSeasonStandings(c echo.Context) error {
+	id, err := seasonID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "id must be a number"})
+	}
+
+	offset, pageSize, err := pageParams(c, defaultSeasonPageSize)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	res, err := h.SeasonService.GetSeasonStandings(c.Request().Context(), leaderboardscoring.GetSeasonStandingsRequest{
+		SeasonID: id,
+		Offset:   offset,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return seasonError(c, err)
+	}
+
+	userIDs := make([]string, 0, len(res.Standings))
+	for _, standing := range res.Standings {
+		userIDs = append(userIDs, standing.UserID)
+	}
+	identities := h.userIdentities(c, userIDs)
+
+	standings := make([]seasonStandingResponse, 0, len(res.Standings))
+	for _, standing := range res.Standings {
+		standings = append(standings, seasonStandingResponse{
+			Rank:       standing.Rank,
+			publicUser: toPublicUser(standing.UserID, identities),
+			Score:      standing.Score,
+			Winner:     standing.Winner,
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"season":    toSeasonResponse(res.Season, time.Now()),
+		"final":     res.Final,
+		"standings": standings,
+	})
+}
+
+// createSeason creates a season.
+//
+// POST /v1/admin/seasons {"name": "Hacktoberfest 2025", "starts_at": "2025-10-01T00:00:00Z",
+// "ends_at": "2025-11-01T00:00:00Z", "project_ids": ["1001"], "scoring_rules": {"pull_request_closed": 10}}
+func (h Handler) createSeason(c echo.Context) error {
+	var body seasonBody
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	season, err := h.SeasonService.CreateSeason(c.Request().Context(), body.request())
+	if err != nil {
+		return seasonError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, toSeasonResponse(season, time.Now()))
+}
+
+// updateSeason replaces the definition of a season that is not closed.
+//
+// PUT /v1/admin/seasons/:id
+func (h Handler) updateSeason(c echo.Context) error {
+	id, err := seasonID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "id must be a number"})
+	}
+
+	var body seasonBody
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request body"})
+	}
+
+	season, err := h.SeasonService.UpdateSeason(c.Request().Context(), id, body.request())
+	if err != nil {
+		return seasonError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toSeasonResponse(season, time.Now()))
+}
+
+// deleteSeason deletes a season that is not closed.
+//
+// DELETE /v1/admin/seasons/:id
+func (h Handler) deleteSeason(c echo.Context) error {
+	id, err := seasonID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "id must be a number"})
+	}
+
+	if err := h.SeasonService.DeleteSeason(c.Request().Context(), id); err != nil {
+		return seasonError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (b seasonBody) request() leaderboardscoring.SeasonRequest {
+	return leaderboardscoring.SeasonRequest{
+		Name:         b.Name,
+		StartsAt:     b.StartsAt,
+		EndsAt:       b.EndsAt,
+		ProjectIDs:   b.ProjectIDs,
+		ScoringRules: b.ScoringRules,
+		RankingMode:  b.RankingMode,
+		WinnerCount:  b.WinnerCount,
+	}
+}
+
+func seasonError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, leaderboardscoring.ErrInvalidArguments):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	case errors.Is(err, leaderboardscoring.ErrSeasonNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+	case errors.Is(err, leaderboardscoring.ErrSeasonClosed), errors.Is(err, leaderboardscoring.ErrSeasonNameTaken):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	}
+
+	logger.L().Error("season request failed", slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to handle season request"})
+}
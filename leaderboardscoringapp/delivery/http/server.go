@@ -3,18 +3,34 @@ package http
 import (
 	"context"
 
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/httpserver"
+	"github.com/gocasters/rankr/pkg/privacy"
 )
 
 type Server struct {
 	HTTPServer *httpserver.Server
 	Handler    Handler
+	Admin      AdminConfig
 }
 
-func New(server *httpserver.Server) Server {
+func New(
+	server *httpserver.Server,
+	lbService *leaderboardscoring.Service,
+	dlqService *leaderboardscoring.DLQService,
+	historyService *leaderboardscoring.HistoryService,
+	seasonService *leaderboardscoring.SeasonService,
+	achievementService *leaderboardscoring.AchievementService,
+	streakService *leaderboardscoring.StreakService,
+	explainService *leaderboardscoring.ExplainService,
+	adjustmentService *leaderboardscoring.AdjustmentService,
+	identities *privacy.IdentityResolver,
+	admin AdminConfig,
+) Server {
 	return Server{
 		HTTPServer: server,
-		Handler:    NewHandler(),
+		Handler:    NewHandler(lbService, dlqService, historyService, seasonService, achievementService, streakService, explainService, adjustmentService, identities),
+		Admin:      admin,
 	}
 }
 
@@ -36,4 +52,30 @@ func (s Server) RegisterRoutes() {
 
 	v1 := router.Group("/v1")
 	v1.GET("/health-check", s.healthCheck)
+	v1.GET("/leaderboards/export", s.Handler.exportLeaderboard)
+	v1.GET("/leaderboards/history", s.Handler.getLeaderboardAsOf)
+	v1.GET("/leaderboards/history/users/:user_id", s.Handler.getUserRankHistory)
+	v1.GET("/seasons", s.Handler.listSeasons)
+	v1.GET("/seasons/:id", s.Handler.getSeason)
+	v1.GET("/seasons/:id/standings", s.Handler.getSeasonStandings)
+	v1.GET("/badges", s.Handler.listBadges)
+	v1.GET("/users/:user_id/badges", s.Handler.listUserBadges)
+	v1.GET("/streaks", s.Handler.listStreaks)
+	v1.GET("/users/:user_id/streak", s.Handler.getUserStreak)
+	v1.GET("/users/:user_id/score/explain", s.Handler.explainScore)
+	v1.GET("/users/:user_id/score/events", s.Handler.listScoreEvents)
+
+	admin := v1.Group("/admin", s.requireAdmin)
+	admin.GET("/dlq", s.Handler.listDeadLetters)
+	admin.GET("/dlq/stats", s.Handler.deadLetterStats)
+	admin.GET("/dlq/:sequence", s.Handler.getDeadLetter)
+	admin.POST("/dlq/reprocess", s.Handler.reprocessDeadLetters)
+	admin.POST("/dlq/discard", s.Handler.discardDeadLetters)
+	admin.POST("/seasons", s.Handler.createSeason)
+	admin.PUT("/seasons/:id", s.Handler.updateSeason)
+	admin.DELETE("/seasons/:id", s.Handler.deleteSeason)
+	admin.GET("/adjustments", s.Handler.listAdjustments)
+	admin.GET("/adjustments/:id", s.Handler.getAdjustment)
+	admin.POST("/adjustments", s.Handler.createAdjustment)
+	admin.POST("/adjustments/:id/reverse", s.Handler.reverseAdjustment)
 }
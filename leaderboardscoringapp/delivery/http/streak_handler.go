@@ -0,0 +1,100 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+const defaultStreakPageSize = 50
+
+type streakResponse struct {
+	Rank int64 `json:"rank,omitempty"`
+	publicUser
+	CurrentStreak int64     `json:"current_streak"`
+	LongestStreak int64     `json:"longest_streak"`
+	LastActiveDay string    `json:"last_active_day,omitempty"`
+	Timezone      string    `json:"timezone,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+}
+
+func toStreakResponse(streak leaderboardscoring.Streak) streakResponse {
+	res := streakResponse{
+		publicUser:    publicUser{UserID: streak.UserID},
+		CurrentStreak: streak.Current,
+		LongestStreak: streak.Longest,
+		Timezone:      streak.Timezone,
+		UpdatedAt:     streak.UpdatedAt,
+	}
+	if !streak.LastActiveDay.IsZero() {
+		res.LastActiveDay = streak.LastActiveDay.Format(time.DateOnly)
+	}
+
+	return res
+}
+
+// listStreaks returns the streak leaderboard, longest current streak first.
+//
+// GET /v1/streaks?offset=0&page_size=20
+func (h Handler) listStreaks(c echo.Context) error {
+	offset, pageSize, err := pageParams(c, defaultStreakPageSize)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	streaks, err := h.StreakService.ListStreaks(c.Request().Context(), leaderboardscoring.ListStreaksRequest{
+		Offset:   offset,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return streakError(c, err)
+	}
+
+	userIDs := make([]string, 0, len(streaks))
+	for _, streak := range streaks {
+		userIDs = append(userIDs, streak.UserID)
+	}
+	identities := h.userIdentities(c, userIDs)
+
+	res := make([]streakResponse, 0, len(streaks))
+	for i, streak := range streaks {
+		row := toStreakResponse(streak)
+		row.Rank = int64(offset) + int64(i) + 1
+		row.publicUser = toPublicUser(streak.UserID, identities)
+		res = append(res, row)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"streaks": res})
+}
+
+// getUserStreak returns the streak of a user, zero when the user has none.
+//
+// GET /v1/users/:user_id/streak
+func (h Handler) getUserStreak(c echo.Context) error {
+	if ok, err := h.visibleUser(c, c.Param("user_id")); !ok {
+		return err
+	}
+
+	streak, err := h.StreakService.GetUserStreak(c.Request().Context(), leaderboardscoring.GetUserStreakRequest{
+		UserID: c.Param("user_id"),
+	})
+	if err != nil {
+		return streakError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toStreakResponse(streak))
+}
+
+func streakError(c echo.Context, err error) error {
+	if errors.Is(err, leaderboardscoring.ErrInvalidArguments) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	logger.L().Error("read streaks failed", slog.String("error", err.Error()))
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "failed to read streaks"})
+}
@@ -0,0 +1,34 @@
+package badgeevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+)
+
+// Publisher publishes badge-awarded events as JSON. The message UUID is derived from the
+// user and badge, so consumers can drop an event that was published again after a retry.
+type Publisher struct {
+	publisher message.Publisher
+	topic     string
+}
+
+func NewPublisher(publisher message.Publisher, topic string) *Publisher {
+	return &Publisher{publisher: publisher, topic: topic}
+}
+
+// PublishBadgeAwarded implements leaderboardscoring.BadgeAwardedPublisher
+func (p *Publisher) PublishBadgeAwarded(ctx context.Context, event leaderboardscoring.BadgeAwarded) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal badge awarded event: %w", err)
+	}
+
+	msg := message.NewMessage(fmt.Sprintf("badge-awarded-%s-%s", event.UserID, event.BadgeID), payload)
+	msg.SetContext(ctx)
+
+	return p.publisher.Publish(p.topic, msg)
+}
@@ -0,0 +1,162 @@
+package rankupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+)
+
+type Config struct {
+	Enabled bool          `koanf:"enabled"`
+	Window  time.Duration `koanf:"window"` // Window How long rank changes are merged before publishing (e.g., 1s)
+}
+
+// LeaderboardUpdatedEvent is published once per leaderboard key and window.
+// Clients apply Changes in order on top of the board they already show.
+type LeaderboardUpdatedEvent struct {
+	LeaderboardKey string                          `json:"leaderboard_key"`
+	Changes        []leaderboardscoring.RankChange `json:"changes"`
+	Timestamp      time.Time                       `json:"timestamp"`
+}
+
+// Coalescer merges rank changes per leaderboard key and user during a short window,
+// so a burst of events for the same user results in a single diff.
+type Coalescer struct {
+	publisher message.Publisher
+	topic     string
+	config    Config
+
+	mu      sync.Mutex
+	pending map[string]map[string]*leaderboardscoring.RankChange // key -> user -> change
+}
+
+func NewCoalescer(publisher message.Publisher, topic string, config Config) *Coalescer {
+	return &Coalescer{
+		publisher: publisher,
+		topic:     topic,
+		config:    config,
+		pending:   make(map[string]map[string]*leaderboardscoring.RankChange),
+	}
+}
+
+// Notify implements leaderboardscoring.RankChangeNotifier
+func (c *Coalescer) Notify(changes []leaderboardscoring.RankChange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, change := range changes {
+		users, ok := c.pending[change.LeaderboardKey]
+		if !ok {
+			users = make(map[string]*leaderboardscoring.RankChange)
+			c.pending[change.LeaderboardKey] = users
+		}
+
+		existing, ok := users[change.UserID]
+		if !ok {
+			ch := change
+			ch.Overtaken = append([]string(nil), change.Overtaken...)
+			users[change.UserID] = &ch
+			continue
+		}
+
+		// Keep the rank from the start of the window, take everything else from the latest change
+		existing.NewRank = change.NewRank
+		existing.Score = change.Score
+		existing.Overtaken = mergeOvertaken(existing.Overtaken, change.Overtaken)
+	}
+}
+
+func (c *Coalescer) Start(ctx context.Context) error {
+	var defaultWindow = time.Second
+
+	log := logger.L()
+
+	if c.config.Window <= 0 {
+		log.Warn(fmt.Sprintf("invalid rank update window: must be > 0, got %s", c.config.Window),
+			slog.String("default set", defaultWindow.String()))
+		c.config.Window = defaultWindow
+	}
+
+	ticker := time.NewTicker(c.config.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Publish what is left so the last changes of the window are not lost
+			c.Flush()
+			log.Info("rank update coalescer stopped by context")
+			return ctx.Err()
+
+		case <-ticker.C:
+			c.Flush()
+		}
+	}
+}
+
+// Flush publishes one event per leaderboard key with all pending changes
+func (c *Coalescer) Flush() {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[string]map[string]*leaderboardscoring.RankChange)
+	c.mu.Unlock()
+
+	now := time.Now().UTC()
+	for key, users := range pending {
+		event := LeaderboardUpdatedEvent{
+			LeaderboardKey: key,
+			Changes:        make([]leaderboardscoring.RankChange, 0, len(users)),
+			Timestamp:      now,
+		}
+		for _, change := range users {
+			event.Changes = append(event.Changes, *change)
+		}
+
+		// Apply from the top of the board down
+		sort.Slice(event.Changes, func(i, j int) bool {
+			return event.Changes[i].NewRank < event.Changes[j].NewRank
+		})
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			logger.L().Error("failed to marshal leaderboard updated event", slog.String("error", err.Error()))
+			continue
+		}
+
+		msg := message.NewMessage(watermill.NewUUID(), payload)
+		if err := c.publisher.Publish(c.topic, msg); err != nil {
+			logger.L().Error("failed to publish leaderboard updated event",
+				slog.String("key", key),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+func mergeOvertaken(current, next []string) []string {
+	seen := make(map[string]struct{}, len(current)+len(next))
+	merged := make([]string, 0, len(current)+len(next))
+
+	for _, list := range [][]string{current, next} {
+		for _, userID := range list {
+			if len(merged) == leaderboardscoring.MaxOvertakenPerChange {
+				return merged
+			}
+			if _, ok := seen[userID]; ok {
+				continue
+			}
+			seen[userID] = struct{}{}
+			merged = append(merged, userID)
+		}
+	}
+
+	return merged
+}
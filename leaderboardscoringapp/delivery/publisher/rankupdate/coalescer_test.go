@@ -0,0 +1,116 @@
+package rankupdate_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/publisher/rankupdate"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPublisher struct {
+	mu       sync.Mutex
+	topics   []string
+	messages []*message.Message
+}
+
+func (m *mockPublisher) Publish(topic string, messages ...*message.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, msg := range messages {
+		m.topics = append(m.topics, topic)
+		m.messages = append(m.messages, msg)
+	}
+	return nil
+}
+
+func (m *mockPublisher) Close() error { return nil }
+
+func (m *mockPublisher) events(t *testing.T) map[string]rankupdate.LeaderboardUpdatedEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events := make(map[string]rankupdate.LeaderboardUpdatedEvent, len(m.messages))
+	for _, msg := range m.messages {
+		var event rankupdate.LeaderboardUpdatedEvent
+		require.NoError(t, json.Unmarshal(msg.Payload, &event))
+		events[event.LeaderboardKey] = event
+	}
+	return events
+}
+
+func TestCoalescer_MergesChangesWithinWindow(t *testing.T) {
+	publisher := &mockPublisher{}
+	coalescer := rankupdate.NewCoalescer(publisher, "leaderboard.updated", rankupdate.Config{Window: time.Second})
+
+	coalescer.Notify([]leaderboardscoring.RankChange{
+		{LeaderboardKey: "leaderboard:global:all_time", UserID: "7", OldRank: 9, NewRank: 6, Score: 40, Overtaken: []string{"3", "4", "5"}},
+		{LeaderboardKey: "leaderboard:1001:all_time", UserID: "7", OldRank: 0, NewRank: 2, Score: 5},
+	})
+	coalescer.Notify([]leaderboardscoring.RankChange{
+		{LeaderboardKey: "leaderboard:global:all_time", UserID: "7", OldRank: 6, NewRank: 4, Score: 47, Overtaken: []string{"5", "8"}},
+		{LeaderboardKey: "leaderboard:global:all_time", UserID: "2", OldRank: 12, NewRank: 11, Score: 20, Overtaken: []string{"9"}},
+	})
+
+	coalescer.Flush()
+
+	require.Len(t, publisher.messages, 2)
+	assert.Equal(t, []string{"leaderboard.updated", "leaderboard.updated"}, publisher.topics)
+
+	events := publisher.events(t)
+
+	global := events["leaderboard:global:all_time"]
+	require.Len(t, global.Changes, 2)
+	assert.Equal(t, leaderboardscoring.RankChange{
+		LeaderboardKey: "leaderboard:global:all_time",
+		UserID:         "7",
+		OldRank:        9,
+		NewRank:        4,
+		Score:          47,
+		Overtaken:      []string{"3", "4", "5", "8"},
+	}, global.Changes[0])
+	assert.Equal(t, "2", global.Changes[1].UserID)
+
+	project := events["leaderboard:1001:all_time"]
+	require.Len(t, project.Changes, 1)
+	assert.Equal(t, int64(0), project.Changes[0].OldRank)
+	assert.Equal(t, int64(2), project.Changes[0].NewRank)
+}
+
+func TestCoalescer_FlushWithoutChangesPublishesNothing(t *testing.T) {
+	publisher := &mockPublisher{}
+	coalescer := rankupdate.NewCoalescer(publisher, "leaderboard.updated", rankupdate.Config{Window: time.Second})
+
+	coalescer.Notify([]leaderboardscoring.RankChange{
+		{LeaderboardKey: "leaderboard:global:all_time", UserID: "1", OldRank: 2, NewRank: 1, Score: 10},
+	})
+	coalescer.Flush()
+	coalescer.Flush()
+
+	assert.Len(t, publisher.messages, 1)
+}
+
+func TestCoalescer_CapsOvertakenList(t *testing.T) {
+	publisher := &mockPublisher{}
+	coalescer := rankupdate.NewCoalescer(publisher, "leaderboard.updated", rankupdate.Config{Window: time.Second})
+
+	for i := 0; i < 5; i++ {
+		overtaken := make([]string, 0, 5)
+		for j := 0; j < 5; j++ {
+			overtaken = append(overtaken, string(rune('a'+i*5+j)))
+		}
+		coalescer.Notify([]leaderboardscoring.RankChange{
+			{LeaderboardKey: "leaderboard:global:all_time", UserID: "1", OldRank: 100, NewRank: 1, Score: 10, Overtaken: overtaken},
+		})
+	}
+	coalescer.Flush()
+
+	events := publisher.events(t)
+	assert.Len(t, events["leaderboard:global:all_time"].Changes[0].Overtaken, leaderboardscoring.MaxOvertakenPerChange)
+}
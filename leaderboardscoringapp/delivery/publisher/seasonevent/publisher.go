@@ -0,0 +1,34 @@
+package seasonevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+)
+
+// Publisher publishes season-ended events as JSON, one message per closed season. The
+// message UUID is derived from the season ID, so consumers can drop a repeated event.
+type Publisher struct {
+	publisher message.Publisher
+	topic     string
+}
+
+func NewPublisher(publisher message.Publisher, topic string) *Publisher {
+	return &Publisher{publisher: publisher, topic: topic}
+}
+
+// PublishSeasonEnded implements leaderboardscoring.SeasonEndedPublisher
+func (p *Publisher) PublishSeasonEnded(ctx context.Context, event leaderboardscoring.SeasonEnded) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal season ended event: %w", err)
+	}
+
+	msg := message.NewMessage(fmt.Sprintf("season-ended-%d", event.SeasonID), payload)
+	msg.SetContext(ctx)
+
+	return p.publisher.Publish(p.topic, msg)
+}
@@ -14,14 +14,35 @@ import (
 type Config struct {
 	SnapshotCrontab           string        `koanf:"snapshot_crontab"`
 	SnapshotJobContextTimeout time.Duration `koanf:"snapshot_job_context_timeout"`
+	// SnapshotPruneCrontab schedules the snapshot retention policy, empty disables it
+	SnapshotPruneCrontab string `koanf:"snapshot_prune_crontab"`
+	// SeasonCloseCrontab schedules freezing the standings of ended seasons, empty disables it
+	SeasonCloseCrontab string `koanf:"season_close_crontab"`
+	// BadgePublishCrontab schedules retrying badge-awarded events that failed to publish,
+	// empty disables it
+	BadgePublishCrontab string `koanf:"badge_publish_crontab"`
+	// StreakResetCrontab schedules resetting broken streaks, empty disables it. Run it
+	// hourly, streaks break at midnight in the timezone of each contributor.
+	StreakResetCrontab string `koanf:"streak_reset_crontab"`
 }
 type Scheduler struct {
 	sch            gocron.Scheduler
 	leaderboardSvc *leaderboardscoring.Service
+	historySvc     *leaderboardscoring.HistoryService
+	seasonSvc      *leaderboardscoring.SeasonService
+	achievementSvc *leaderboardscoring.AchievementService
+	streakSvc      *leaderboardscoring.StreakService
 	cfg            Config
 }
 
-func New(leaderboardSvc *leaderboardscoring.Service, schedulerCfg Config) Scheduler {
+func New(
+	leaderboardSvc *leaderboardscoring.Service,
+	historySvc *leaderboardscoring.HistoryService,
+	seasonSvc *leaderboardscoring.SeasonService,
+	achievementSvc *leaderboardscoring.AchievementService,
+	streakSvc *leaderboardscoring.StreakService,
+	schedulerCfg Config,
+) Scheduler {
 
 	sch, err := gocron.NewScheduler(gocron.WithLocation(time.Local))
 	if err != nil {
@@ -32,6 +53,10 @@ func New(leaderboardSvc *leaderboardscoring.Service, schedulerCfg Config) Schedu
 	return Scheduler{
 		sch:            sch,
 		leaderboardSvc: leaderboardSvc,
+		historySvc:     historySvc,
+		seasonSvc:      seasonSvc,
+		achievementSvc: achievementSvc,
+		streakSvc:      streakSvc,
 		cfg:            schedulerCfg,
 	}
 }
@@ -46,6 +71,22 @@ func (s *Scheduler) Start(ctx context.Context, wg *sync.WaitGroup) {
 		log.Error("failed to create snapshot job", slog.String("error", err.Error()))
 	}
 
+	if err := s.snapshotPruneJob(ctx); err != nil {
+		log.Error("failed to create snapshot prune job", slog.String("error", err.Error()))
+	}
+
+	if err := s.seasonCloseJob(ctx); err != nil {
+		log.Error("failed to create season close job", slog.String("error", err.Error()))
+	}
+
+	if err := s.badgePublishJob(ctx); err != nil {
+		log.Error("failed to create badge publish job", slog.String("error", err.Error()))
+	}
+
+	if err := s.streakResetJob(ctx); err != nil {
+		log.Error("failed to create streak reset job", slog.String("error", err.Error()))
+	}
+
 	s.sch.Start()
 
 	<-ctx.Done()
@@ -101,3 +142,183 @@ func (s *Scheduler) snapshotLeaderboardTask(parentCtx context.Context) {
 
 	log.Debug("snapshotLeaderboardTask completed successfully")
 }
+
+func (s *Scheduler) snapshotPruneJob(parentCtx context.Context) error {
+	log := logger.L()
+
+	if s.cfg.SnapshotPruneCrontab == "" {
+		log.Info("snapshot_prune_crontab is empty, snapshots are kept forever")
+		return nil
+	}
+
+	pruneJob, err := s.sch.NewJob(
+		gocron.CronJob(s.cfg.SnapshotPruneCrontab, false),
+		gocron.NewTask(func() { s.pruneSnapshotsTask(parentCtx) }),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithName("prune-snapshots"),
+		gocron.WithTags("leaderboardscoring-service"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot prune job: %w", err)
+	}
+
+	log.Info("snapshotPrune job created",
+		slog.String("name", pruneJob.Name()),
+		slog.String("uuid", pruneJob.ID().String()),
+		slog.Any("tags", pruneJob.Tags()),
+	)
+
+	return nil
+}
+
+func (s *Scheduler) pruneSnapshotsTask(parentCtx context.Context) {
+	log := logger.L()
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.SnapshotJobContextTimeout)
+	defer cancel()
+
+	res, err := s.historySvc.PruneSnapshots(ctx, time.Now())
+	if err != nil {
+		log.Warn("can not successfully run pruneSnapshotsTask", slog.String("error", err.Error()))
+		return
+	}
+
+	log.Info("snapshots pruned",
+		slog.Int64("thinned_daily", res.ThinnedDaily),
+		slog.Int64("thinned_weekly", res.ThinnedWeekly),
+		slog.Int64("expired", res.Expired),
+	)
+}
+
+func (s *Scheduler) seasonCloseJob(parentCtx context.Context) error {
+	log := logger.L()
+
+	if s.cfg.SeasonCloseCrontab == "" {
+		log.Warn("season_close_crontab is empty, ended seasons are not closed")
+		return nil
+	}
+
+	closeJob, err := s.sch.NewJob(
+		gocron.CronJob(s.cfg.SeasonCloseCrontab, false),
+		gocron.NewTask(func() { s.closeSeasonsTask(parentCtx) }),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithName("close-seasons"),
+		gocron.WithTags("leaderboardscoring-service"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create season close job: %w", err)
+	}
+
+	log.Info("seasonClose job created",
+		slog.String("name", closeJob.Name()),
+		slog.String("uuid", closeJob.ID().String()),
+		slog.Any("tags", closeJob.Tags()),
+	)
+
+	return nil
+}
+
+func (s *Scheduler) closeSeasonsTask(parentCtx context.Context) {
+	log := logger.L()
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.SnapshotJobContextTimeout)
+	defer cancel()
+
+	res, err := s.seasonSvc.CloseEndedSeasons(ctx, time.Now())
+	if len(res.Closed) > 0 || len(res.Announced) > 0 {
+		log.Info("seasons closed",
+			slog.Any("closed", res.Closed),
+			slog.Any("announced", res.Announced),
+		)
+	}
+	if err != nil {
+		log.Warn("can not successfully run closeSeasonsTask", slog.String("error", err.Error()))
+	}
+}
+
+func (s *Scheduler) badgePublishJob(parentCtx context.Context) error {
+	log := logger.L()
+
+	if s.cfg.BadgePublishCrontab == "" {
+		log.Warn("badge_publish_crontab is empty, failed badge-awarded events are not retried")
+		return nil
+	}
+
+	publishJob, err := s.sch.NewJob(
+		gocron.CronJob(s.cfg.BadgePublishCrontab, false),
+		gocron.NewTask(func() { s.publishBadgeAwardsTask(parentCtx) }),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithName("publish-badge-awards"),
+		gocron.WithTags("leaderboardscoring-service"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create badge publish job: %w", err)
+	}
+
+	log.Info("badgePublish job created",
+		slog.String("name", publishJob.Name()),
+		slog.String("uuid", publishJob.ID().String()),
+		slog.Any("tags", publishJob.Tags()),
+	)
+
+	return nil
+}
+
+func (s *Scheduler) publishBadgeAwardsTask(parentCtx context.Context) {
+	log := logger.L()
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.SnapshotJobContextTimeout)
+	defer cancel()
+
+	published, err := s.achievementSvc.PublishPendingAwards(ctx, time.Now())
+	if published > 0 {
+		log.Info("pending badge awards published", slog.Int("published", published))
+	}
+	if err != nil {
+		log.Warn("can not successfully run publishBadgeAwardsTask", slog.String("error", err.Error()))
+	}
+}
+
+func (s *Scheduler) streakResetJob(parentCtx context.Context) error {
+	log := logger.L()
+
+	if s.cfg.StreakResetCrontab == "" {
+		log.Warn("streak_reset_crontab is empty, broken streaks are only reset by the next event")
+		return nil
+	}
+
+	resetJob, err := s.sch.NewJob(
+		gocron.CronJob(s.cfg.StreakResetCrontab, false),
+		gocron.NewTask(func() { s.resetStreaksTask(parentCtx) }),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithName("reset-broken-streaks"),
+		gocron.WithTags("leaderboardscoring-service"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create streak reset job: %w", err)
+	}
+
+	log.Info("streakReset job created",
+		slog.String("name", resetJob.Name()),
+		slog.String("uuid", resetJob.ID().String()),
+		slog.Any("tags", resetJob.Tags()),
+	)
+
+	return nil
+}
+
+func (s *Scheduler) resetStreaksTask(parentCtx context.Context) {
+	log := logger.L()
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.SnapshotJobContextTimeout)
+	defer cancel()
+
+	reset, err := s.streakSvc.ResetBrokenStreaks(ctx, time.Now())
+	if err != nil {
+		log.Warn("can not successfully run resetStreaksTask", slog.String("error", err.Error()))
+		return
+	}
+	if reset > 0 {
+		log.Info("broken streaks reset", slog.Int64("reset", reset))
+	}
+}
@@ -0,0 +1,61 @@
+package leaderboardscoringapp
+
+import (
+	"fmt"
+
+	"github.com/gocasters/rankr/adapter/natsadapter"
+	postgrerepository "github.com/gocasters/rankr/leaderboardscoringapp/repository/database"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/natsrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/topicsname"
+)
+
+// DLQTool holds the subset of the application needed to inspect and drain the dead letter
+// queue of processed events from the command line: PostgreSQL and the processed events stream.
+type DLQTool struct {
+	Service      *leaderboardscoring.DLQService
+	databaseConn *database.Database
+	natsAdapter  *natsadapter.Adapter
+}
+
+func NewDLQTool(config Config) (*DLQTool, error) {
+	databaseConn, err := database.Connect(config.PostgresDB)
+	if err != nil {
+		return nil, fmt.Errorf("connect to PostgreSQL: %w", err)
+	}
+
+	if config.NatsAdapter.StreamName == "" {
+		config.NatsAdapter.StreamName = topicsname.StreamNameLeaderboardscoringProcessedEvents
+	}
+	if config.NatsAdapter.StreamSubjects == nil {
+		config.NatsAdapter.StreamSubjects = []string{
+			topicsname.TopicProcessedScoreEvents,
+			topicsname.TopicProcessedScoreEventsDLQ,
+		}
+	}
+	natsAdapter, err := natsadapter.New(config.NatsAdapter, logger.L())
+	if err != nil {
+		databaseConn.Close()
+		return nil, fmt.Errorf("connect to NATS: %w", err)
+	}
+
+	service := leaderboardscoring.NewDLQService(
+		natsrepository.NewDeadLetterQueue(natsAdapter, topicsname.TopicProcessedScoreEventsDLQ),
+		postgrerepository.NewPostgreSQLRepository(databaseConn, config.DatabaseRetry),
+		postgrerepository.NewDeadLetterAuditRepository(databaseConn, config.DatabaseRetry),
+		leaderboardscoring.NewValidator(),
+	)
+
+	return &DLQTool{
+		Service:      service,
+		databaseConn: databaseConn,
+		natsAdapter:  natsAdapter,
+	}, nil
+}
+
+func (t *DLQTool) Close() {
+	_ = t.natsAdapter.Close()
+	t.databaseConn.Close()
+}
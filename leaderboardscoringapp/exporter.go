@@ -0,0 +1,78 @@
+package leaderboardscoringapp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocasters/rankr/adapter/contributor"
+	"github.com/gocasters/rankr/adapter/redis"
+	postgrerepository "github.com/gocasters/rankr/leaderboardscoringapp/repository/database"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+)
+
+// Exporter holds the subset of the application needed to export leaderboards from the
+// command line: PostgreSQL, Redis and the contributor service, without any consumers.
+type Exporter struct {
+	Service           *leaderboardscoring.Service
+	databaseConn      *database.Database
+	redisAdapter      *redis.Adapter
+	contributorClient *contributor.Client
+}
+
+func NewExporter(ctx context.Context, config Config) (*Exporter, error) {
+	if err := config.LeaderboardScoring.Ranking.Validate(); err != nil {
+		return nil, err
+	}
+	if err := config.Privacy.Validate(); err != nil {
+		return nil, err
+	}
+
+	databaseConn, err := database.Connect(config.PostgresDB)
+	if err != nil {
+		return nil, fmt.Errorf("connect to PostgreSQL: %w", err)
+	}
+
+	redisAdapter, err := redis.New(ctx, config.Redis)
+	if err != nil {
+		databaseConn.Close()
+		return nil, fmt.Errorf("connect to Redis: %w", err)
+	}
+
+	// With the memory backend the boards live in the serving process, read its last snapshot
+	leaderboard, _, err := newLeaderboardCache(config.LeaderboardCache, redisAdapter)
+	if err != nil {
+		_ = redisAdapter.Close()
+		databaseConn.Close()
+		return nil, err
+	}
+
+	contributorClient, contributorDirectory := newContributorDirectory(config.ContributorRPC)
+
+	service := leaderboardscoring.NewService(
+		config.LeaderboardScoring,
+		postgrerepository.NewPostgreSQLRepository(databaseConn, config.DatabaseRetry),
+		leaderboard,
+		nil,
+		"",
+		leaderboardscoring.NewValidator(),
+		nil,
+		contributorDirectory,
+		newIdentityResolver(config.Privacy, contributorClient),
+	)
+
+	return &Exporter{
+		Service:           service,
+		databaseConn:      databaseConn,
+		redisAdapter:      redisAdapter,
+		contributorClient: contributorClient,
+	}, nil
+}
+
+func (e *Exporter) Close() {
+	if e.contributorClient != nil {
+		e.contributorClient.Close()
+	}
+	_ = e.redisAdapter.Close()
+	e.databaseConn.Close()
+}
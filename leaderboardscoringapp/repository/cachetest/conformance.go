@@ -0,0 +1,391 @@
+// Package cachetest holds the conformance suite every leaderboardscoring.LeaderboardCache
+// implementation must pass, so the Redis and in-memory backends stay interchangeable.
+package cachetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns an empty cache for one subtest.
+type Factory func(t *testing.T) leaderboardscoring.LeaderboardCache
+
+// Run runs the conformance suite against the caches returned by newCache. Keys are
+// prefixed with the subtest name, so backends may share state between subtests.
+func Run(t *testing.T, newCache Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string)
+	}{
+		{"IncrementsAccumulate", testIncrementsAccumulate},
+		{"OrdersByScoreThenMember", testOrdersByScoreThenMember},
+		{"RangesByRank", testRangesByRank},
+		{"NegativeIncrementsReorder", testNegativeIncrementsReorder},
+		{"DecaysTrendingScores", testDecaysTrendingScores},
+		{"CountsTrendingEventOnce", testCountsTrendingEventOnce},
+		{"RebasesTrendingBoard", testRebasesTrendingBoard},
+		{"GetUserRanks", testGetUserRanks},
+		{"ExpireAtInPastDeletesKey", testExpireAtInPastDeletesKey},
+		{"KeepsFirstExpiry", testKeepsFirstExpiry},
+		{"IgnoresInvalidScores", testIgnoresInvalidScores},
+		{"CountHigherScores", testCountHigherScores},
+		{"FirstReachedOrdersTiesByTime", testFirstReachedOrdersTiesByTime},
+		{"FirstReachedKeepsWholeScores", testFirstReachedKeepsWholeScores},
+		{"ClampsAtZero", testClampsAtZero},
+		{"ClampsTrendingAtZero", testClampsTrendingAtZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix := "conformance:" + t.Name() + ":"
+			tt.run(t, newCache(t), func(name string) string { return prefix + name })
+		})
+	}
+}
+
+func upsert(t *testing.T, cache leaderboardscoring.LeaderboardCache, userID string, score int64, expireAt time.Time, keys ...string) {
+	t.Helper()
+
+	require.NoError(t, cache.UpsertScores(context.Background(), &leaderboardscoring.UpsertScore{
+		Keys:     keys,
+		UserID:   userID,
+		Score:    score,
+		ExpireAt: expireAt,
+	}))
+}
+
+func read(t *testing.T, cache leaderboardscoring.LeaderboardCache, key string, start, stop int64) []leaderboardscoring.LeaderboardEntry {
+	t.Helper()
+
+	result, err := cache.GetLeaderboard(context.Background(), &leaderboardscoring.LeaderboardQuery{Key: key, Start: start, Stop: stop})
+	require.NoError(t, err)
+
+	return result.LeaderboardRows
+}
+
+func testIncrementsAccumulate(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	global, project := key("global"), key("project")
+
+	upsert(t, cache, "1", 10, time.Time{}, global, project)
+	upsert(t, cache, "1", 5, time.Time{}, global)
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 15}}, read(t, cache, global, 0, 9))
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 10}}, read(t, cache, project, 0, 9))
+}
+
+func testOrdersByScoreThenMember(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("board")
+
+	upsert(t, cache, "a", 10, time.Time{}, board)
+	upsert(t, cache, "c", 20, time.Time{}, board)
+	upsert(t, cache, "b", 10, time.Time{}, board)
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "c", Score: 20},
+		{Rank: 2, UserID: "b", Score: 10},
+		{Rank: 3, UserID: "a", Score: 10},
+	}, read(t, cache, board, 0, 9))
+}
+
+func testRangesByRank(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("board")
+	for i, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		upsert(t, cache, id, int64(50-i*10), time.Time{}, board)
+	}
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 3, UserID: "u3", Score: 30},
+		{Rank: 4, UserID: "u4", Score: 20},
+	}, read(t, cache, board, 2, 3))
+
+	assert.Len(t, read(t, cache, board, 3, 100), 2)
+	assert.Empty(t, read(t, cache, board, 5, 9))
+	assert.Empty(t, read(t, cache, key("missing"), 0, 9))
+}
+
+func testNegativeIncrementsReorder(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("board")
+
+	upsert(t, cache, "1", 30, time.Time{}, board)
+	upsert(t, cache, "2", 20, time.Time{}, board)
+	upsert(t, cache, "1", -15, time.Time{}, board)
+
+	rows := read(t, cache, board, 0, 9)
+	require.Len(t, rows, 2)
+	assert.Equal(t, leaderboardscoring.LeaderboardEntry{Rank: 1, UserID: "2", Score: 20}, rows[0])
+	assert.Equal(t, leaderboardscoring.LeaderboardEntry{Rank: 2, UserID: "1", Score: 15}, rows[1])
+}
+
+func upsertTrending(t *testing.T, cache leaderboardscoring.LeaderboardCache, userID, eventID string, score int64, halfLives float64, keys ...string) {
+	t.Helper()
+
+	require.NoError(t, cache.UpsertTrendingScores(context.Background(), &leaderboardscoring.TrendingScore{
+		Keys:      keys,
+		UserID:    userID,
+		Score:     score,
+		HalfLives: halfLives,
+		EventID:   eventID,
+		DedupeTTL: time.Hour,
+	}))
+}
+
+func readTrending(t *testing.T, cache leaderboardscoring.LeaderboardCache, key string, halfLives float64) []leaderboardscoring.LeaderboardEntry {
+	t.Helper()
+
+	result, err := cache.GetLeaderboard(context.Background(), &leaderboardscoring.LeaderboardQuery{
+		Key: key, Start: 0, Stop: -1, Trending: true, HalfLives: halfLives,
+	})
+	require.NoError(t, err)
+
+	return result.LeaderboardRows
+}
+
+func testDecaysTrendingScores(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("trending")
+
+	upsertTrending(t, cache, "1", "e1", 20, 3, board)
+	upsertTrending(t, cache, "1", "e2", 20, 4, board)
+	upsertTrending(t, cache, "2", "e3", 8, 5, board)
+
+	// 20*2^3 + 20*2^4 = 480 is 15 one half-life after the second event
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "1", Score: 15},
+		{Rank: 2, UserID: "2", Score: 8},
+	}, readTrending(t, cache, board, 5))
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "1", Score: 8},
+		{Rank: 2, UserID: "2", Score: 4},
+	}, readTrending(t, cache, board, 6))
+}
+
+func testCountsTrendingEventOnce(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	first, second := key("first"), key("second")
+
+	upsertTrending(t, cache, "1", "e1", 10, 0, first)
+	// A redelivery counts only on the board that missed the event
+	upsertTrending(t, cache, "1", "e1", 10, 0, first, second)
+	upsertTrending(t, cache, "1", "e2", 5, 0, first)
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 15}}, readTrending(t, cache, first, 0))
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 10}}, readTrending(t, cache, second, 0))
+
+	// Without an event ID every call counts
+	upsertTrending(t, cache, "2", "", 1, 0, second)
+	upsertTrending(t, cache, "2", "", 1, 0, second)
+	assert.Equal(t, int64(2), readTrending(t, cache, second, 0)[1].Score)
+}
+
+func testRebasesTrendingBoard(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("trending")
+	rebaseAt := float64(leaderboardscoring.TrendingRebaseHalfLives + 1)
+
+	upsertTrending(t, cache, "1", "e1", 1024, rebaseAt-5, board)
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 32}}, readTrending(t, cache, board, rebaseAt))
+
+	// The boost outgrows the base, the board is scaled down and reads the same
+	upsertTrending(t, cache, "2", "e2", 10, rebaseAt, board)
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "1", Score: 32},
+		{Rank: 2, UserID: "2", Score: 10},
+	}, readTrending(t, cache, board, rebaseAt))
+
+	// 2^2000 would overflow a float64 without the rebase
+	upsertTrending(t, cache, "3", "e3", 5, 2000, board)
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "3", Score: 5},
+		{Rank: 2, UserID: "2", Score: 0},
+		{Rank: 3, UserID: "1", Score: 0},
+	}, readTrending(t, cache, board, 2000))
+}
+
+func testGetUserRanks(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	first, second, missing := key("first"), key("second"), key("missing")
+
+	upsert(t, cache, "1", 10, time.Time{}, first, second)
+	upsert(t, cache, "2", 20, time.Time{}, first)
+
+	ranks, err := cache.GetUserRanks(context.Background(), []string{first, second, missing}, "1")
+	require.NoError(t, err)
+	assert.Equal(t, []leaderboardscoring.UserRank{
+		{Key: first, Rank: 2, Score: 10},
+		{Key: second, Rank: 1, Score: 10},
+		{Key: missing},
+	}, ranks)
+
+	ranks, err = cache.GetUserRanks(context.Background(), nil, "1")
+	require.NoError(t, err)
+	assert.Empty(t, ranks)
+}
+
+func testExpireAtInPastDeletesKey(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("daily")
+
+	upsert(t, cache, "1", 10, time.Now().Add(-time.Minute), board)
+
+	assert.Empty(t, read(t, cache, board, 0, 9))
+}
+
+func testKeepsFirstExpiry(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("daily")
+
+	upsert(t, cache, "1", 10, time.Now().Add(time.Hour), board)
+	// The key already has a TTL, a later expiry must not replace it
+	upsert(t, cache, "1", 5, time.Now().Add(-time.Minute), board)
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 15}}, read(t, cache, board, 0, 9))
+}
+
+func testIgnoresInvalidScores(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("board")
+	ctx := context.Background()
+
+	require.NoError(t, cache.UpsertScores(ctx, nil))
+	require.NoError(t, cache.UpsertScores(ctx, &leaderboardscoring.UpsertScore{UserID: "1", Score: 10}))
+	require.NoError(t, cache.UpsertScores(ctx, &leaderboardscoring.UpsertScore{Keys: []string{board}, Score: 10}))
+	require.NoError(t, cache.UpsertTrendingScores(ctx, nil))
+
+	assert.Empty(t, read(t, cache, board, 0, 9))
+}
+
+func upsertReached(t *testing.T, cache leaderboardscoring.LeaderboardCache, userID string, score int64, reachedAt time.Time, key string) {
+	t.Helper()
+
+	require.NoError(t, cache.UpsertScores(context.Background(), &leaderboardscoring.UpsertScore{
+		Keys:      []string{key},
+		UserID:    userID,
+		Score:     score,
+		ReachedAt: reachedAt,
+	}))
+}
+
+func testCountHigherScores(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board, reached := key("board"), key("reached")
+	ctx := context.Background()
+
+	for id, score := range map[string]int64{"1": 30, "2": 20, "3": 20, "4": 10} {
+		upsert(t, cache, id, score, time.Time{}, board)
+	}
+
+	for _, tt := range []struct {
+		score    int64
+		distinct bool
+		want     int64
+	}{
+		{score: 30, want: 0},
+		{score: 20, want: 1},
+		{score: 10, want: 3},
+		{score: 10, distinct: true, want: 2},
+		{score: 5, distinct: true, want: 3},
+	} {
+		count, err := cache.CountHigherScores(ctx, board, tt.score, tt.distinct)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, count, "score %d, distinct %v", tt.score, tt.distinct)
+	}
+
+	// The reach time fraction must not count a tied member as higher
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	upsertReached(t, cache, "1", 20, at, reached)
+	upsertReached(t, cache, "2", 20, at.Add(time.Hour), reached)
+	upsertReached(t, cache, "3", 10, at, reached)
+
+	count, err := cache.CountHigherScores(ctx, reached, 20, false)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	count, err = cache.CountHigherScores(ctx, reached, 10, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = cache.CountHigherScores(ctx, key("missing"), 0, true)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func testFirstReachedOrdersTiesByTime(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("board")
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// "b" reaches 20 first, "a" would win the tie by member order
+	upsertReached(t, cache, "b", 20, at, board)
+	upsertReached(t, cache, "a", 15, at.Add(-time.Hour), board)
+	upsertReached(t, cache, "a", 5, at.Add(time.Minute), board)
+	upsertReached(t, cache, "c", 25, at.Add(time.Hour), board)
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "c", Score: 25},
+		{Rank: 2, UserID: "b", Score: 20},
+		{Rank: 3, UserID: "a", Score: 20},
+	}, read(t, cache, board, 0, 9))
+}
+
+func testFirstReachedKeepsWholeScores(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("daily")
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, cache.UpsertScores(context.Background(), &leaderboardscoring.UpsertScore{
+			Keys:      []string{board},
+			UserID:    "1",
+			Score:     7,
+			ExpireAt:  time.Now().Add(time.Hour),
+			ReachedAt: at.Add(time.Duration(i) * time.Second),
+		}))
+	}
+	upsertReached(t, cache, "1", -400, at.Add(time.Hour), board)
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: -50}}, read(t, cache, board, 0, 9))
+
+	ranks, err := cache.GetUserRanks(context.Background(), []string{board}, "1")
+	require.NoError(t, err)
+	assert.Equal(t, []leaderboardscoring.UserRank{{Key: board, Rank: 1, Score: -50}}, ranks)
+}
+
+func testClampsAtZero(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	allTime, daily, reached := key("all_time"), key("daily"), key("reached")
+	ctx := context.Background()
+	clamped := func(userID string, score int64, expireAt, reachedAt time.Time, keys ...string) {
+		t.Helper()
+		require.NoError(t, cache.UpsertScores(ctx, &leaderboardscoring.UpsertScore{
+			Keys: keys, UserID: userID, Score: score, ExpireAt: expireAt, ReachedAt: reachedAt, ClampAtZero: true,
+		}))
+	}
+
+	upsert(t, cache, "1", 30, time.Time{}, allTime)
+	upsert(t, cache, "2", 20, time.Time{}, allTime)
+	clamped("1", -10, time.Time{}, time.Time{}, allTime)
+	clamped("2", -50, time.Time{}, time.Time{}, allTime)
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "1", Score: 20},
+		{Rank: 2, UserID: "2", Score: 0},
+	}, read(t, cache, allTime, 0, 9))
+
+	// A board the user is missing from stays as it is
+	clamped("1", -10, time.Now().Add(time.Hour), time.Time{}, daily)
+	assert.Empty(t, read(t, cache, daily, 0, 9))
+
+	upsertReached(t, cache, "1", 10, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), reached)
+	clamped("1", -25, time.Time{}, time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC), reached)
+	clamped("2", -5, time.Time{}, time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC), reached)
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 0}}, read(t, cache, reached, 0, 9))
+}
+
+func testClampsTrendingAtZero(t *testing.T, cache leaderboardscoring.LeaderboardCache, key func(string) string) {
+	board := key("trending")
+	clamped := func(userID, eventID string, score int64) {
+		t.Helper()
+		require.NoError(t, cache.UpsertTrendingScores(context.Background(), &leaderboardscoring.TrendingScore{
+			Keys: []string{board}, UserID: userID, Score: score, EventID: eventID, DedupeTTL: time.Hour, ClampAtZero: true,
+		}))
+	}
+
+	upsertTrending(t, cache, "1", "e1", 8, 0, board)
+	clamped("1", "e2", -20)
+	clamped("2", "e3", -20)
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 0}}, readTrending(t, cache, board, 0))
+}
@@ -0,0 +1,141 @@
+package postgrerepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+const badgeAwardColumns = `user_id, badge_id, project_id, event_id, awarded_at, published_at`
+
+func NewAchievementRepository(db *database.Database, config RetryConfig) leaderboardscoring.AchievementStore {
+	return &PostgreSQLRepository{
+		postgreSQL:  db,
+		retryConfig: config,
+	}
+}
+
+// RecordBadgeProgress is not retried, the increment is not idempotent
+func (db PostgreSQLRepository) RecordBadgeProgress(ctx context.Context, userID, badgeID, scopeKey string, period int64) (leaderboardscoring.BadgeProgress, error) {
+	var progress leaderboardscoring.BadgeProgress
+
+	err := db.postgreSQL.Pool.QueryRow(ctx, `
+		INSERT INTO achievement_progress (user_id, badge_id, scope_key, event_count, streak_period, streak_length)
+		VALUES ($1, $2, $3, 1, $4, 1)
+		ON CONFLICT (user_id, badge_id, scope_key) DO UPDATE
+		SET event_count   = achievement_progress.event_count + 1,
+		    streak_length = CASE
+		        WHEN EXCLUDED.streak_period = achievement_progress.streak_period + 1
+		            THEN achievement_progress.streak_length + 1
+		        WHEN EXCLUDED.streak_period > achievement_progress.streak_period + 1
+		            THEN 1
+		        ELSE achievement_progress.streak_length
+		    END,
+		    streak_period = GREATEST(achievement_progress.streak_period, EXCLUDED.streak_period),
+		    updated_at    = NOW()
+		RETURNING event_count, streak_length`,
+		userID, badgeID, scopeKey, period,
+	).Scan(&progress.Count, &progress.Streak)
+	if err != nil {
+		return leaderboardscoring.BadgeProgress{}, fmt.Errorf("upsert achievement progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+func (db PostgreSQLRepository) AwardBadge(ctx context.Context, award leaderboardscoring.BadgeAward) (bool, error) {
+	var projectID *string
+	if award.ProjectID != "" {
+		projectID = &award.ProjectID
+	}
+
+	tag, err := db.postgreSQL.Pool.Exec(ctx, `
+		INSERT INTO badge_award (user_id, badge_id, project_id, event_id, awarded_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, badge_id) DO NOTHING`,
+		award.UserID, award.BadgeID, projectID, award.EventID, award.AwardedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("insert badge award: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (db PostgreSQLRepository) ListUserBadges(ctx context.Context, userID string) ([]leaderboardscoring.BadgeAward, error) {
+	return db.queryBadgeAwards(ctx, `
+		SELECT `+badgeAwardColumns+`
+		FROM badge_award
+		WHERE user_id = $1
+		ORDER BY awarded_at, badge_id`,
+		userID,
+	)
+}
+
+func (db PostgreSQLRepository) ListUnpublishedBadgeAwards(ctx context.Context, limit int) ([]leaderboardscoring.BadgeAward, error) {
+	return db.queryBadgeAwards(ctx, `
+		SELECT `+badgeAwardColumns+`
+		FROM badge_award
+		WHERE published_at IS NULL
+		ORDER BY awarded_at
+		LIMIT $1`,
+		limit,
+	)
+}
+
+func (db PostgreSQLRepository) MarkBadgeAwardPublished(ctx context.Context, userID, badgeID string, at time.Time) error {
+	_, err := db.postgreSQL.Pool.Exec(ctx, `
+		UPDATE badge_award SET published_at = $3 WHERE user_id = $1 AND badge_id = $2`,
+		userID, badgeID, at,
+	)
+	if err != nil {
+		return fmt.Errorf("mark badge award published: %w", err)
+	}
+
+	return nil
+}
+
+func (db PostgreSQLRepository) queryBadgeAwards(ctx context.Context, query string, args ...interface{}) ([]leaderboardscoring.BadgeAward, error) {
+	rows, err := db.postgreSQL.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query badge awards: %w", err)
+	}
+	defer rows.Close()
+
+	var awards []leaderboardscoring.BadgeAward
+	for rows.Next() {
+		award, err := scanBadgeAward(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan badge award: %w", err)
+		}
+		awards = append(awards, award)
+	}
+
+	return awards, rows.Err()
+}
+
+func scanBadgeAward(row pgx.Row) (leaderboardscoring.BadgeAward, error) {
+	var (
+		award       leaderboardscoring.BadgeAward
+		projectID   *string
+		publishedAt *time.Time
+	)
+
+	err := row.Scan(&award.UserID, &award.BadgeID, &projectID, &award.EventID, &award.AwardedAt, &publishedAt)
+	if err != nil {
+		return leaderboardscoring.BadgeAward{}, err
+	}
+
+	if projectID != nil {
+		award.ProjectID = *projectID
+	}
+	if publishedAt != nil {
+		award.PublishedAt = *publishedAt
+	}
+
+	return award, nil
+}
@@ -0,0 +1,143 @@
+package postgrerepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/gocasters/rankr/pkg/statuscode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// adjustmentColumns reads an adjustment with the ID of its reversal, aliased as a
+const adjustmentColumns = `a.id, a.user_id, a.project_id, a.timeframe, a.period, a.points, a.reason, a.actor,
+	a.effective_at, a.reversal_of, r.id, a.created_at`
+
+const adjustmentFrom = `score_adjustment a LEFT JOIN score_adjustment r ON r.reversal_of = a.id`
+
+func NewAdjustmentRepository(db *database.Database, config RetryConfig) leaderboardscoring.AdjustmentStore {
+	return &PostgreSQLRepository{
+		postgreSQL:  db,
+		retryConfig: config,
+	}
+}
+
+// CreateAdjustment is not retried, a retry after a lost reply would store it twice
+func (db PostgreSQLRepository) CreateAdjustment(ctx context.Context, adjustment leaderboardscoring.Adjustment) (leaderboardscoring.Adjustment, error) {
+	var reversalOf *int64
+	if adjustment.ReversalOf != 0 {
+		reversalOf = &adjustment.ReversalOf
+	}
+
+	err := db.postgreSQL.Pool.QueryRow(ctx, `
+		INSERT INTO score_adjustment (user_id, project_id, timeframe, period, points, reason, actor, effective_at, reversal_of)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at`,
+		adjustment.UserID, adjustment.ProjectID, adjustment.Timeframe, nullableString(adjustment.Period),
+		adjustment.Points, adjustment.Reason, adjustment.Actor, adjustment.EffectiveAt.UTC(), reversalOf,
+	).Scan(&adjustment.ID, &adjustment.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == statuscode.ErrCodeUniqueViolation {
+			return leaderboardscoring.Adjustment{}, leaderboardscoring.ErrAdjustmentReversed
+		}
+		return leaderboardscoring.Adjustment{}, fmt.Errorf("insert adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+func (db PostgreSQLRepository) GetAdjustment(ctx context.Context, id int64) (leaderboardscoring.Adjustment, error) {
+	adjustment, err := scanAdjustment(db.postgreSQL.Pool.QueryRow(ctx, `
+		SELECT `+adjustmentColumns+`
+		FROM `+adjustmentFrom+`
+		WHERE a.id = $1`,
+		id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return leaderboardscoring.Adjustment{}, leaderboardscoring.ErrAdjustmentNotFound
+	}
+	if err != nil {
+		return leaderboardscoring.Adjustment{}, fmt.Errorf("query adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+func (db PostgreSQLRepository) ListAdjustments(ctx context.Context, filter leaderboardscoring.AdjustmentFilter, offset, limit int) ([]leaderboardscoring.Adjustment, error) {
+	args := []interface{}{offset, limit}
+
+	var conditions []string
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("a.user_id = $%d", len(args)))
+	}
+	if filter.ProjectID != "" {
+		args = append(args, filter.ProjectID)
+		conditions = append(conditions, fmt.Sprintf("a.project_id = $%d", len(args)))
+	}
+
+	var where string
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.postgreSQL.Pool.Query(ctx, `
+		SELECT `+adjustmentColumns+`
+		FROM `+adjustmentFrom+`
+		`+where+`
+		ORDER BY a.created_at DESC, a.id DESC
+		OFFSET $1 LIMIT $2`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	var adjustments []leaderboardscoring.Adjustment
+	for rows.Next() {
+		adjustment, err := scanAdjustment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan adjustment: %w", err)
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	return adjustments, rows.Err()
+}
+
+func scanAdjustment(row pgx.Row) (leaderboardscoring.Adjustment, error) {
+	var (
+		adjustment leaderboardscoring.Adjustment
+		period     *string
+		reversalOf *int64
+		reversedBy *int64
+	)
+
+	err := row.Scan(
+		&adjustment.ID, &adjustment.UserID, &adjustment.ProjectID, &adjustment.Timeframe, &period,
+		&adjustment.Points, &adjustment.Reason, &adjustment.Actor, &adjustment.EffectiveAt,
+		&reversalOf, &reversedBy, &adjustment.CreatedAt,
+	)
+	if err != nil {
+		return leaderboardscoring.Adjustment{}, err
+	}
+
+	if period != nil {
+		adjustment.Period = *period
+	}
+	if reversalOf != nil {
+		adjustment.ReversalOf = *reversalOf
+	}
+	if reversedBy != nil {
+		adjustment.ReversedBy = *reversedBy
+	}
+	adjustment.EffectiveAt = adjustment.EffectiveAt.UTC()
+
+	return adjustment, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gocasters/rankr/pkg/statuscode"
 	"log/slog"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
@@ -57,6 +58,130 @@ func (db PostgreSQLRepository) AddSnapshot(ctx context.Context, snapshots []lead
 	})
 }
 
+// GetUserScores ranks users by the sum of their processed events matching filter.
+// Ties are ordered by user ID descending, the same as ZREVRANGE on a Redis leaderboard,
+// or by the time of the last event when filter.OrderByReachTime is set.
+func (db PostgreSQLRepository) GetUserScores(ctx context.Context, filter leaderboardscoring.ScoreEventFilter, offset, limit int) ([]leaderboardscoring.LeaderboardEntry, error) {
+	where, args := scoreEventConditions(filter)
+	args = append(args, offset, limit)
+
+	orderBy := "total_score DESC, user_id DESC"
+	if filter.OrderByReachTime {
+		orderBy = "total_score DESC, MAX(event_timestamp) ASC, user_id DESC"
+	}
+
+	query := fmt.Sprintf(`
+        SELECT user_id, SUM(score_delta) AS total_score
+        FROM processed_score_events
+        %s
+        GROUP BY user_id
+        ORDER BY %s
+        OFFSET $%d LIMIT $%d
+    `, where, orderBy, len(args)-1, len(args))
+
+	rows, err := db.postgreSQL.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user scores: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]leaderboardscoring.LeaderboardEntry, 0, limit)
+	for rows.Next() {
+		entry := leaderboardscoring.LeaderboardEntry{Rank: int64(offset + len(entries) + 1)}
+		if err := rows.Scan(&entry.UserID, &entry.Score); err != nil {
+			return nil, fmt.Errorf("scan user score: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user scores: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetScoreBreakdown sums the points of the given users per event type.
+func (db PostgreSQLRepository) GetScoreBreakdown(ctx context.Context, filter leaderboardscoring.ScoreEventFilter, userIDs []string) (map[string]map[leaderboardscoring.EventName]int64, error) {
+	breakdowns := make(map[string]map[leaderboardscoring.EventName]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return breakdowns, nil
+	}
+
+	where, args := scoreEventConditions(filter, "user_id = ANY($%d)")
+	args = append([]interface{}{userIDs}, args...)
+
+	query := fmt.Sprintf(`
+        SELECT user_id, event_type, SUM(score_delta)
+        FROM processed_score_events
+        %s
+        GROUP BY user_id, event_type
+    `, where)
+
+	rows, err := db.postgreSQL.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query score breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			userID    string
+			eventType string
+			score     int64
+		)
+		if err := rows.Scan(&userID, &eventType, &score); err != nil {
+			return nil, fmt.Errorf("scan score breakdown: %w", err)
+		}
+
+		if breakdowns[userID] == nil {
+			breakdowns[userID] = make(map[leaderboardscoring.EventName]int64)
+		}
+		breakdowns[userID][leaderboardscoring.EventName(eventType)] = score
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate score breakdown: %w", err)
+	}
+
+	return breakdowns, nil
+}
+
+// scoreEventConditions builds the WHERE clause of filter. Each leading condition is a
+// format string with one %d for its placeholder number; their arguments are expected
+// to come first in the final argument list.
+func scoreEventConditions(filter leaderboardscoring.ScoreEventFilter, leading ...string) (string, []interface{}) {
+	conditions := make([]string, 0, len(leading)+4)
+	for i, c := range leading {
+		conditions = append(conditions, fmt.Sprintf(c, i+1))
+	}
+
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(leading)+len(args)))
+	}
+
+	if filter.ProjectID != "" {
+		add("project_id = $%d", filter.ProjectID)
+	}
+	if filter.Timeframe != "" {
+		add("(scope_timeframe IS NULL OR scope_timeframe = $%d)", filter.Timeframe)
+	}
+	if !filter.From.IsZero() {
+		add("event_timestamp >= $%d", filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		add("event_timestamp < $%d", filter.To.UTC())
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
 // retryOperation - Generic retry logic
 func (db PostgreSQLRepository) retryOperation(ctx context.Context, operation func() error) error {
 	var lastErr error
@@ -105,6 +230,12 @@ func (db PostgreSQLRepository) retryOperation(ctx context.Context, operation fun
 	return fmt.Errorf("failed after %d attempts: %w", maxRetries, lastErr)
 }
 
+// insertBatchProcessedScoreEvent upserts processed events keyed by their source event ID,
+// so a batch redelivered by JetStream does not create duplicate rows.
+//
+// Events are copied into a temp table first; when the same event ID appears more than
+// once in a batch, the earliest processed copy wins. An event stored by an earlier batch
+// keeps its processed_at.
 func (db PostgreSQLRepository) insertBatchProcessedScoreEvent(ctx context.Context, events []leaderboardscoring.ProcessedScoreEvent) error {
 	tx, err := db.postgreSQL.Pool.Begin(ctx)
 	if err != nil {
@@ -112,26 +243,98 @@ func (db PostgreSQLRepository) insertBatchProcessedScoreEvent(ctx context.Contex
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	columns := []string{"user_id", "event_type", "event_timestamp", "score_delta"}
+	// Step 1: Create temp table
+	_, err = tx.Exec(ctx, `
+        CREATE TEMP TABLE temp_processed_score_events (
+            event_id VARCHAR(255) NOT NULL,
+            user_id VARCHAR(100) NOT NULL,
+            event_type VARCHAR(50) NOT NULL,
+            score_delta BIGINT NOT NULL,
+            project_id VARCHAR(100) NOT NULL,
+            repository_id BIGINT NOT NULL,
+            repository_name VARCHAR(255) NOT NULL,
+            source_number INTEGER,
+            source_ref VARCHAR(255),
+            scoring_rule VARCHAR(64),
+            scope_timeframe VARCHAR(20),
+            event_timestamp TIMESTAMP NOT NULL,
+            processed_at TIMESTAMP NOT NULL
+        ) ON COMMIT DROP
+    `)
+	if err != nil {
+		return fmt.Errorf("create temp table: %w", err)
+	}
+
+	// Step 2: Bulk insert to temp table using CopyFrom
+	columns := []string{
+		"event_id", "user_id", "event_type", "score_delta", "project_id",
+		"repository_id", "repository_name", "source_number", "source_ref", "scoring_rule",
+		"scope_timeframe", "event_timestamp", "processed_at",
+	}
 
 	rows := make([][]interface{}, len(events))
 	for i, event := range events {
+		processedAt := event.ProcessedAt
+		if processedAt.IsZero() {
+			processedAt = time.Now().UTC()
+		}
+
 		rows[i] = []interface{}{
+			event.EventID,
 			event.UserID,
 			event.EventName.String(),
-			event.Timestamp,
 			event.Score,
+			event.ProjectID,
+			int64(event.RepositoryID),
+			event.RepositoryName,
+			nullableInt32(event.SourceNumber),
+			nullableString(event.SourceRef),
+			nullableString(event.Rule),
+			nullableString(event.ScopeTimeframe),
+			event.Timestamp,
+			processedAt,
 		}
 	}
 
 	_, err = tx.CopyFrom(
 		ctx,
-		pgx.Identifier{"processed_score_events"},
+		pgx.Identifier{"temp_processed_score_events"},
 		columns,
 		pgx.CopyFromRows(rows),
 	)
 	if err != nil {
-		return fmt.Errorf("copy from: %w", err)
+		return fmt.Errorf("copy to temp table: %w", err)
+	}
+
+	// Step 3: Upsert by event ID, keeping the first processing time of an event. Within the
+	// batch the earliest copy wins, a stored event keeps its processed_at.
+	_, err = tx.Exec(ctx, `
+        INSERT INTO processed_score_events (
+            event_id, user_id, event_type, score_delta, project_id,
+            repository_id, repository_name, source_number, source_ref, scoring_rule,
+            scope_timeframe, event_timestamp, processed_at
+        )
+        SELECT DISTINCT ON (event_id)
+            event_id, user_id, event_type, score_delta, project_id,
+            repository_id, repository_name, source_number, source_ref, scoring_rule,
+            scope_timeframe, event_timestamp, processed_at
+        FROM temp_processed_score_events
+        ORDER BY event_id, processed_at ASC
+        ON CONFLICT (event_id) DO UPDATE SET
+            user_id         = EXCLUDED.user_id,
+            event_type      = EXCLUDED.event_type,
+            score_delta     = EXCLUDED.score_delta,
+            project_id      = EXCLUDED.project_id,
+            repository_id   = EXCLUDED.repository_id,
+            repository_name = EXCLUDED.repository_name,
+            source_number   = EXCLUDED.source_number,
+            source_ref      = EXCLUDED.source_ref,
+            scoring_rule    = EXCLUDED.scoring_rule,
+            scope_timeframe = EXCLUDED.scope_timeframe,
+            event_timestamp = EXCLUDED.event_timestamp
+    `)
+	if err != nil {
+		return fmt.Errorf("upsert processed score events: %w", err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -0,0 +1,53 @@
+package postgrerepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+func NewDeadLetterAuditRepository(db *database.Database, config RetryConfig) leaderboardscoring.DeadLetterAudit {
+	return &PostgreSQLRepository{
+		postgreSQL:  db,
+		retryConfig: config,
+	}
+}
+
+// AddDeadLetterAudits stores the audit records of dead letters in one batch
+func (db PostgreSQLRepository) AddDeadLetterAudits(ctx context.Context, records []leaderboardscoring.DeadLetterAuditRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	columns := []string{"sequence", "event_id", "action", "failure_reason", "delivery_count", "note", "actor", "payload", "created_at"}
+	rows := make([][]interface{}, len(records))
+	for i, record := range records {
+		var eventID *string
+		if record.EventID != "" {
+			eventID = &record.EventID
+		}
+
+		rows[i] = []interface{}{
+			int64(record.Sequence),
+			eventID,
+			string(record.Action),
+			record.FailureReason,
+			int64(record.DeliveryCount),
+			record.Note,
+			record.Actor,
+			record.Payload,
+			record.CreatedAt,
+		}
+	}
+
+	return db.retryOperation(ctx, func() error {
+		if _, err := db.postgreSQL.Pool.CopyFrom(ctx, pgx.Identifier{"dead_letter_audit"}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return fmt.Errorf("insert dead letter audit: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,126 @@
+package postgrerepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+)
+
+func NewExplainRepository(db *database.Database, config RetryConfig) leaderboardscoring.ScoreExplanationStore {
+	return &PostgreSQLRepository{
+		postgreSQL:  db,
+		retryConfig: config,
+	}
+}
+
+// SumUserScore groups the user's events by type, project and the local date of the
+// event. event_timestamp holds UTC without a time zone.
+func (db PostgreSQLRepository) SumUserScore(ctx context.Context, userID string, filter leaderboardscoring.ScoreEventFilter, loc *time.Location) ([]leaderboardscoring.ScoreSum, error) {
+	where, args := scoreEventConditions(filter, "user_id = $%d")
+	args = append([]interface{}{userID}, args...)
+	args = append(args, loc.String())
+
+	query := fmt.Sprintf(`
+        SELECT event_type, COALESCE(project_id, ''),
+               ((event_timestamp AT TIME ZONE 'UTC') AT TIME ZONE $%d)::date AS day,
+               SUM(score_delta), COUNT(*)
+        FROM processed_score_events
+        %s
+        GROUP BY 1, 2, 3
+    `, len(args), where)
+
+	rows, err := db.postgreSQL.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user score sums: %w", err)
+	}
+	defer rows.Close()
+
+	var sums []leaderboardscoring.ScoreSum
+	for rows.Next() {
+		var (
+			sum       leaderboardscoring.ScoreSum
+			eventType string
+		)
+		if err := rows.Scan(&eventType, &sum.ProjectID, &sum.Day, &sum.Points, &sum.Events); err != nil {
+			return nil, fmt.Errorf("scan user score sum: %w", err)
+		}
+		sum.EventName = leaderboardscoring.EventName(eventType)
+		sums = append(sums, sum)
+	}
+
+	return sums, rows.Err()
+}
+
+func (db PostgreSQLRepository) ListUserScoreEvents(ctx context.Context, userID string, filter leaderboardscoring.ScoreEventFilter, eventName leaderboardscoring.EventName, offset, limit int) ([]leaderboardscoring.ProcessedScoreEvent, error) {
+	leading := []string{"user_id = $%d"}
+	args := []interface{}{userID}
+	if eventName != "" {
+		leading = append(leading, "event_type = $%d")
+		args = append(args, eventName.String())
+	}
+
+	where, filterArgs := scoreEventConditions(filter, leading...)
+	args = append(args, filterArgs...)
+	args = append(args, offset, limit)
+
+	query := fmt.Sprintf(`
+        SELECT id, COALESCE(event_id, ''), user_id, event_type, score_delta,
+               COALESCE(project_id, ''), COALESCE(repository_id, 0), COALESCE(repository_name, ''),
+               COALESCE(source_number, 0), COALESCE(source_ref, ''), COALESCE(scoring_rule, ''),
+               event_timestamp, processed_at
+        FROM processed_score_events
+        %s
+        ORDER BY event_timestamp DESC, id DESC
+        OFFSET $%d LIMIT $%d
+    `, where, len(args)-1, len(args))
+
+	rows, err := db.postgreSQL.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user score events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]leaderboardscoring.ProcessedScoreEvent, 0, limit)
+	for rows.Next() {
+		var (
+			event        leaderboardscoring.ProcessedScoreEvent
+			eventType    string
+			repositoryID int64
+		)
+		err := rows.Scan(
+			&event.ID, &event.EventID, &event.UserID, &eventType, &event.Score,
+			&event.ProjectID, &repositoryID, &event.RepositoryName,
+			&event.SourceNumber, &event.SourceRef, &event.Rule,
+			&event.Timestamp, &event.ProcessedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan user score event: %w", err)
+		}
+		event.EventName = leaderboardscoring.EventName(eventType)
+		event.RepositoryID = uint64(repositoryID)
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// nullableString stores an empty string as NULL
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+// nullableInt32 stores zero as NULL
+func nullableInt32(value int32) *int32 {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,28 @@
+-- NOTE:
+-- event_id is nullable only for rows persisted before this migration; the
+-- service always sets it for new events. PostgreSQL treats NULLs as distinct,
+-- so the legacy rows do not conflict with each other.
+-- event_timestamp now holds the original event time, processed_at the time the
+-- event was scored.
+
+-- +migrate Up
+ALTER TABLE processed_score_events
+    ADD COLUMN event_id        VARCHAR(255),
+    ADD COLUMN project_id      VARCHAR(100),
+    ADD COLUMN repository_id   BIGINT,
+    ADD COLUMN repository_name VARCHAR(255),
+    ADD CONSTRAINT uniq_processed_score_events_event_id UNIQUE (event_id);
+
+-- to rebuild the history of a single project
+CREATE INDEX idx_score_events_project_event_timestamp
+    ON processed_score_events (project_id, event_timestamp);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_score_events_project_event_timestamp;
+
+ALTER TABLE processed_score_events
+    DROP CONSTRAINT IF EXISTS uniq_processed_score_events_event_id,
+    DROP COLUMN IF EXISTS repository_name,
+    DROP COLUMN IF EXISTS repository_id,
+    DROP COLUMN IF EXISTS project_id,
+    DROP COLUMN IF EXISTS event_id;
@@ -0,0 +1,26 @@
+-- NOTE:
+-- One row per dead letter that left the queue of processed score events. payload
+-- keeps the message as published, it may not be valid JSON. event_id is NULL for
+-- letters that did not hold a processed score event.
+
+-- +migrate Up
+CREATE TABLE dead_letter_audit
+(
+    id             BIGSERIAL PRIMARY KEY,
+    sequence       BIGINT       NOT NULL,
+    event_id       VARCHAR(255),
+    action         VARCHAR(20)  NOT NULL CHECK (action IN ('reprocessed', 'discarded')),
+    failure_reason TEXT         NOT NULL,
+    delivery_count BIGINT       NOT NULL DEFAULT 0,
+    note           TEXT         NOT NULL DEFAULT '',
+    actor          VARCHAR(100) NOT NULL,
+    payload        BYTEA        NOT NULL,
+    created_at     TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+-- to find what happened to an event
+CREATE INDEX idx_dead_letter_audit_event_id
+    ON dead_letter_audit (event_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS dead_letter_audit;
@@ -0,0 +1,27 @@
+-- NOTE:
+-- Indexes for the history queries of the snapshot table:
+-- * a board as of a date seeks the latest snapshot_timestamp of a key, then reads
+--   one snapshot in rank order;
+-- * a user's rank history reads the rows of one user and key in time order, the
+--   INCLUDE columns let it skip the table.
+-- Both replace an index of the same leading columns from 00002.
+
+-- +migrate Up
+CREATE INDEX idx_snapshot_leaderboard_key_ts_rank
+    ON snapshot (leaderboard_key, snapshot_timestamp, rank);
+
+CREATE INDEX idx_snapshot_user_leaderboard_key_ts_history
+    ON snapshot (user_id, leaderboard_key, snapshot_timestamp) INCLUDE (rank, total_score);
+
+DROP INDEX IF EXISTS idx_snapshot_leaderboard_key_ts;
+DROP INDEX IF EXISTS idx_snapshot_user_leaderboard_key_ts_desc;
+
+-- +migrate Down
+CREATE INDEX IF NOT EXISTS idx_snapshot_user_leaderboard_key_ts_desc
+    ON snapshot (user_id, leaderboard_key, snapshot_timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_snapshot_leaderboard_key_ts
+    ON snapshot (leaderboard_key, snapshot_timestamp);
+
+DROP INDEX IF EXISTS idx_snapshot_user_leaderboard_key_ts_history;
+DROP INDEX IF EXISTS idx_snapshot_leaderboard_key_ts_rank;
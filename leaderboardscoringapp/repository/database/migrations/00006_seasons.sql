@@ -0,0 +1,54 @@
+-- NOTE:
+-- A season is a time-boxed competition with its own board. Empty project_ids covers
+-- all projects, scoring_rules maps event types to the points that replace the default
+-- ones. closed_at is set once the final standings are frozen into season_standing,
+-- announced_at once the season-ended event was published.
+
+-- +migrate Up
+CREATE TABLE season
+(
+    id            BIGSERIAL PRIMARY KEY,
+    name          VARCHAR(100) NOT NULL,
+    starts_at     TIMESTAMP    NOT NULL,
+    ends_at       TIMESTAMP    NOT NULL,
+    project_ids   TEXT[]       NOT NULL DEFAULT '{}',
+    scoring_rules JSONB        NOT NULL DEFAULT '{}',
+    ranking_mode  VARCHAR(20)  NOT NULL DEFAULT 'ordinal',
+    winner_count  INT          NOT NULL DEFAULT 3 CHECK (winner_count >= 0),
+    participants  BIGINT       NOT NULL DEFAULT 0,
+    closed_at     TIMESTAMP,
+    announced_at  TIMESTAMP,
+    created_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uniq_season_name UNIQUE (name),
+    CONSTRAINT chk_season_window CHECK (ends_at > starts_at)
+);
+
+-- to list seasons latest first
+CREATE INDEX idx_season_starts_at
+    ON season (starts_at DESC);
+
+-- to find the seasons that still take events or wait to be closed
+CREATE INDEX idx_season_open
+    ON season (ends_at)
+    WHERE closed_at IS NULL;
+
+CREATE TABLE season_standing
+(
+    season_id BIGINT       NOT NULL REFERENCES season (id) ON DELETE CASCADE,
+    rank      BIGINT       NOT NULL,
+    user_id   VARCHAR(100) NOT NULL,
+    score     BIGINT       NOT NULL CHECK (score >= 0),
+    winner    BOOLEAN      NOT NULL DEFAULT FALSE,
+
+    PRIMARY KEY (season_id, user_id)
+);
+
+-- to page through the final standings of a season
+CREATE INDEX idx_season_standing_season_rank
+    ON season_standing (season_id, rank);
+
+-- +migrate Down
+DROP TABLE IF EXISTS season_standing;
+DROP TABLE IF EXISTS season;
@@ -0,0 +1,41 @@
+-- NOTE:
+-- achievement_progress counts the matching events of a badge rule per user and scope
+-- ("global" or a project ID). streak_period is the latest day or week (since the Unix
+-- epoch) with an event, streak_length the number of consecutive periods up to it.
+-- badge_award holds at most one row per user and badge, published_at is set once the
+-- badge-awarded event was published.
+
+-- +migrate Up
+CREATE TABLE achievement_progress
+(
+    user_id       VARCHAR(100) NOT NULL,
+    badge_id      VARCHAR(64)  NOT NULL,
+    scope_key     VARCHAR(100) NOT NULL,
+    event_count   BIGINT       NOT NULL DEFAULT 0,
+    streak_period BIGINT       NOT NULL DEFAULT 0,
+    streak_length BIGINT       NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, badge_id, scope_key)
+);
+
+CREATE TABLE badge_award
+(
+    user_id      VARCHAR(100) NOT NULL,
+    badge_id     VARCHAR(64)  NOT NULL,
+    project_id   VARCHAR(100),
+    event_id     VARCHAR(255) NOT NULL,
+    awarded_at   TIMESTAMP    NOT NULL,
+    published_at TIMESTAMP,
+
+    PRIMARY KEY (user_id, badge_id)
+);
+
+-- to retry the badge-awarded events that failed to publish
+CREATE INDEX idx_badge_award_unpublished
+    ON badge_award (awarded_at)
+    WHERE published_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS badge_award;
+DROP TABLE IF EXISTS achievement_progress;
@@ -0,0 +1,56 @@
+-- NOTE:
+-- contributor_streak holds one row per contributor. last_active_day is the latest local
+-- date, in the contributor's timezone, with a scored event. The reset job sets
+-- current_streak to zero once that date is before yesterday in the same timezone.
+-- Streak milestone bonuses are stored as 'streak_bonus' score events.
+
+-- +migrate Up
+CREATE TABLE contributor_streak
+(
+    user_id         VARCHAR(100) PRIMARY KEY,
+    current_streak  BIGINT       NOT NULL DEFAULT 0,
+    longest_streak  BIGINT       NOT NULL DEFAULT 0,
+    last_active_day DATE         NOT NULL,
+    timezone        VARCHAR(64)  NOT NULL DEFAULT 'UTC',
+    updated_at      TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+-- serves the streak leaderboard
+CREATE INDEX idx_contributor_streak_current
+    ON contributor_streak (current_streak DESC, longest_streak DESC, user_id)
+    WHERE current_streak > 0;
+
+ALTER TABLE processed_score_events
+    DROP CONSTRAINT IF EXISTS processed_score_events_event_type_check;
+ALTER TABLE processed_score_events
+    ADD CONSTRAINT processed_score_events_event_type_check CHECK (
+        event_type IN (
+                       'pull_request_opened',
+                       'pull_request_closed',
+                       'pull_request_review',
+                       'issue_opened',
+                       'issue_closed',
+                       'issue_comment',
+                       'commit_push',
+                       'streak_bonus'
+            )
+        );
+
+-- +migrate Down
+DELETE FROM processed_score_events WHERE event_type = 'streak_bonus';
+ALTER TABLE processed_score_events
+    DROP CONSTRAINT IF EXISTS processed_score_events_event_type_check;
+ALTER TABLE processed_score_events
+    ADD CONSTRAINT processed_score_events_event_type_check CHECK (
+        event_type IN (
+                       'pull_request_opened',
+                       'pull_request_closed',
+                       'pull_request_review',
+                       'issue_opened',
+                       'issue_closed',
+                       'issue_comment',
+                       'commit_push'
+            )
+        );
+
+DROP TABLE IF EXISTS contributor_streak;
@@ -0,0 +1,23 @@
+-- NOTE:
+-- The source columns let a score be explained event by event. source_number is the pull
+-- request or issue number, source_ref the pushed branch or, for a streak bonus, the event
+-- that extended the streak. scoring_rule names the rule that awarded score_delta.
+-- All three are NULL for rows persisted before this migration.
+
+-- +migrate Up
+ALTER TABLE processed_score_events
+    ADD COLUMN source_number INTEGER,
+    ADD COLUMN source_ref    VARCHAR(255),
+    ADD COLUMN scoring_rule  VARCHAR(64);
+
+-- to explain the score of a single user
+CREATE INDEX idx_score_events_user_event_timestamp
+    ON processed_score_events (user_id, event_timestamp DESC);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_score_events_user_event_timestamp;
+
+ALTER TABLE processed_score_events
+    DROP COLUMN IF EXISTS scoring_rule,
+    DROP COLUMN IF EXISTS source_ref,
+    DROP COLUMN IF EXISTS source_number;
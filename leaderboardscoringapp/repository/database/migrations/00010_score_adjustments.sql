@@ -0,0 +1,73 @@
+-- NOTE:
+-- score_adjustment is the audit trail of the points admins granted or removed by hand.
+-- timeframe is the scope ('all' for every board), period the period key of a period
+-- scope in the project's timezone. A reversal is an adjustment with the negated points
+-- and reversal_of set, the unique index allows one reversal per adjustment.
+-- Each adjustment is scored as a 'score_adjustment' event with event_id
+-- 'adjustment-<id>'. scope_timeframe keeps the scope on the event, NULL for every board.
+
+-- +migrate Up
+CREATE TABLE score_adjustment
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      VARCHAR(100) NOT NULL,
+    project_id   VARCHAR(100) NOT NULL,
+    timeframe    VARCHAR(20)  NOT NULL,
+    period       VARCHAR(20),
+    points       BIGINT       NOT NULL CHECK (points <> 0),
+    reason       VARCHAR(255) NOT NULL,
+    actor        VARCHAR(100) NOT NULL,
+    effective_at TIMESTAMP    NOT NULL,
+    reversal_of  BIGINT REFERENCES score_adjustment (id),
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_score_adjustment_reversal_of
+    ON score_adjustment (reversal_of)
+    WHERE reversal_of IS NOT NULL;
+
+CREATE INDEX idx_score_adjustment_user_created
+    ON score_adjustment (user_id, created_at DESC);
+
+ALTER TABLE processed_score_events
+    ADD COLUMN scope_timeframe VARCHAR(20);
+
+ALTER TABLE processed_score_events
+    DROP CONSTRAINT IF EXISTS processed_score_events_event_type_check;
+ALTER TABLE processed_score_events
+    ADD CONSTRAINT processed_score_events_event_type_check CHECK (
+        event_type IN (
+                       'pull_request_opened',
+                       'pull_request_closed',
+                       'pull_request_review',
+                       'issue_opened',
+                       'issue_closed',
+                       'issue_comment',
+                       'commit_push',
+                       'streak_bonus',
+                       'score_adjustment'
+            )
+        );
+
+-- +migrate Down
+DELETE FROM processed_score_events WHERE event_type = 'score_adjustment';
+ALTER TABLE processed_score_events
+    DROP CONSTRAINT IF EXISTS processed_score_events_event_type_check;
+ALTER TABLE processed_score_events
+    ADD CONSTRAINT processed_score_events_event_type_check CHECK (
+        event_type IN (
+                       'pull_request_opened',
+                       'pull_request_closed',
+                       'pull_request_review',
+                       'issue_opened',
+                       'issue_closed',
+                       'issue_comment',
+                       'commit_push',
+                       'streak_bonus'
+            )
+        );
+
+ALTER TABLE processed_score_events
+    DROP COLUMN IF EXISTS scope_timeframe;
+
+DROP TABLE IF EXISTS score_adjustment;
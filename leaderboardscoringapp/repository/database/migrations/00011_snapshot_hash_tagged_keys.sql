@@ -0,0 +1,30 @@
+-- NOTE:
+-- Leaderboard keys carry their scope as a Redis Cluster hash tag since the key layout
+-- change ("leaderboard:1001:all_time" became "leaderboard:{1001}:all_time"). The snapshots
+-- taken before keep the legacy keys, they are rewritten so the history queries and the
+-- restore find them. A legacy row whose user and timestamp also exist under the new key
+-- is a duplicate, the row of the new key wins.
+
+-- +migrate Up
+DELETE FROM snapshot legacy
+USING snapshot tagged
+WHERE legacy.leaderboard_key ~ '^leaderboard:[^:{}]+:'
+  AND tagged.leaderboard_key = regexp_replace(legacy.leaderboard_key, '^leaderboard:([^:{}]+):', 'leaderboard:{\1}:')
+  AND tagged.user_id = legacy.user_id
+  AND tagged.snapshot_timestamp = legacy.snapshot_timestamp;
+
+UPDATE snapshot
+SET leaderboard_key = regexp_replace(leaderboard_key, '^leaderboard:([^:{}]+):', 'leaderboard:{\1}:')
+WHERE leaderboard_key ~ '^leaderboard:[^:{}]+:';
+
+-- +migrate Down
+DELETE FROM snapshot tagged
+USING snapshot legacy
+WHERE tagged.leaderboard_key ~ '^leaderboard:\{[^:{}]+\}:'
+  AND legacy.leaderboard_key = regexp_replace(tagged.leaderboard_key, '^leaderboard:\{([^:{}]+)\}:', 'leaderboard:\1:')
+  AND legacy.user_id = tagged.user_id
+  AND legacy.snapshot_timestamp = tagged.snapshot_timestamp;
+
+UPDATE snapshot
+SET leaderboard_key = regexp_replace(leaderboard_key, '^leaderboard:\{([^:{}]+)\}:', 'leaderboard:\1:')
+WHERE leaderboard_key ~ '^leaderboard:\{[^:{}]+\}:';
@@ -0,0 +1,302 @@
+package postgrerepository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/gocasters/rankr/pkg/statuscode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const seasonColumns = `id, name, starts_at, ends_at, project_ids, scoring_rules, ranking_mode, winner_count,
+	participants, closed_at, announced_at, created_at, updated_at`
+
+func NewSeasonRepository(db *database.Database, config RetryConfig) leaderboardscoring.SeasonStore {
+	return &PostgreSQLRepository{
+		postgreSQL:  db,
+		retryConfig: config,
+	}
+}
+
+func (db PostgreSQLRepository) CreateSeason(ctx context.Context, season leaderboardscoring.Season) (leaderboardscoring.Season, error) {
+	rules, err := json.Marshal(scoringRules(season))
+	if err != nil {
+		return leaderboardscoring.Season{}, fmt.Errorf("marshal scoring rules: %w", err)
+	}
+
+	row := db.postgreSQL.Pool.QueryRow(ctx, `
+		INSERT INTO season (name, starts_at, ends_at, project_ids, scoring_rules, ranking_mode, winner_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+seasonColumns,
+		season.Name, season.StartsAt, season.EndsAt, projectIDs(season), rules, string(season.RankingMode), season.WinnerCount,
+	)
+
+	created, err := scanSeason(row)
+	if err != nil {
+		return leaderboardscoring.Season{}, seasonWriteError("insert season", err)
+	}
+
+	return created, nil
+}
+
+func (db PostgreSQLRepository) UpdateSeason(ctx context.Context, season leaderboardscoring.Season) (leaderboardscoring.Season, error) {
+	rules, err := json.Marshal(scoringRules(season))
+	if err != nil {
+		return leaderboardscoring.Season{}, fmt.Errorf("marshal scoring rules: %w", err)
+	}
+
+	row := db.postgreSQL.Pool.QueryRow(ctx, `
+		UPDATE season
+		SET name = $2, starts_at = $3, ends_at = $4, project_ids = $5, scoring_rules = $6,
+		    ranking_mode = $7, winner_count = $8, updated_at = NOW()
+		WHERE id = $1 AND closed_at IS NULL
+		RETURNING `+seasonColumns,
+		season.ID, season.Name, season.StartsAt, season.EndsAt, projectIDs(season), rules,
+		string(season.RankingMode), season.WinnerCount,
+	)
+
+	updated, err := scanSeason(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return leaderboardscoring.Season{}, leaderboardscoring.ErrSeasonNotFound
+	}
+	if err != nil {
+		return leaderboardscoring.Season{}, seasonWriteError("update season", err)
+	}
+
+	return updated, nil
+}
+
+func (db PostgreSQLRepository) DeleteSeason(ctx context.Context, id int64) error {
+	tag, err := db.postgreSQL.Pool.Exec(ctx, `DELETE FROM season WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete season: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return leaderboardscoring.ErrSeasonNotFound
+	}
+
+	return nil
+}
+
+func (db PostgreSQLRepository) GetSeason(ctx context.Context, id int64) (leaderboardscoring.Season, error) {
+	row := db.postgreSQL.Pool.QueryRow(ctx, `SELECT `+seasonColumns+` FROM season WHERE id = $1`, id)
+
+	season, err := scanSeason(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return leaderboardscoring.Season{}, leaderboardscoring.ErrSeasonNotFound
+	}
+	if err != nil {
+		return leaderboardscoring.Season{}, fmt.Errorf("query season: %w", err)
+	}
+
+	return season, nil
+}
+
+func (db PostgreSQLRepository) ListSeasons(ctx context.Context, status leaderboardscoring.SeasonStatus, now time.Time, offset, limit int) ([]leaderboardscoring.Season, error) {
+	args := []interface{}{offset, limit}
+
+	var condition string
+	switch status {
+	case leaderboardscoring.SeasonUpcoming:
+		condition = "WHERE closed_at IS NULL AND starts_at > $3"
+	case leaderboardscoring.SeasonActive:
+		condition = "WHERE closed_at IS NULL AND starts_at <= $3 AND ends_at > $3"
+	case leaderboardscoring.SeasonClosing:
+		condition = "WHERE closed_at IS NULL AND ends_at <= $3"
+	case leaderboardscoring.SeasonClosed:
+		condition = "WHERE closed_at IS NOT NULL"
+	}
+	if strings.Contains(condition, "$3") {
+		args = append(args, now.UTC())
+	}
+
+	return db.querySeasons(ctx, `
+		SELECT `+seasonColumns+`
+		FROM season
+		`+condition+`
+		ORDER BY starts_at DESC, id DESC
+		OFFSET $1 LIMIT $2`,
+		args...,
+	)
+}
+
+func (db PostgreSQLRepository) ListOpenSeasons(ctx context.Context) ([]leaderboardscoring.Season, error) {
+	return db.querySeasons(ctx, `SELECT `+seasonColumns+` FROM season WHERE closed_at IS NULL ORDER BY ends_at`)
+}
+
+func (db PostgreSQLRepository) ListUnannouncedSeasons(ctx context.Context) ([]leaderboardscoring.Season, error) {
+	return db.querySeasons(ctx, `
+		SELECT `+seasonColumns+`
+		FROM season
+		WHERE closed_at IS NOT NULL AND announced_at IS NULL
+		ORDER BY closed_at`)
+}
+
+// CloseSeason stores the final standings and sets closed_at in one transaction
+func (db PostgreSQLRepository) CloseSeason(ctx context.Context, id int64, standings []leaderboardscoring.SeasonStanding, closedAt time.Time) error {
+	return db.retryOperation(ctx, func() error {
+		tx, err := db.postgreSQL.Pool.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("begin transaction: %w", err)
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		// The row lock keeps two instances from freezing the same season
+		tag, err := tx.Exec(ctx, `
+			UPDATE season
+			SET closed_at = $2, participants = $3, updated_at = NOW()
+			WHERE id = $1 AND closed_at IS NULL`,
+			id, closedAt, int64(len(standings)),
+		)
+		if err != nil {
+			return fmt.Errorf("close season: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return leaderboardscoring.ErrSeasonClosed
+		}
+
+		rows := make([][]interface{}, len(standings))
+		for i, standing := range standings {
+			rows[i] = []interface{}{id, standing.Rank, standing.UserID, standing.Score, standing.Winner}
+		}
+
+		columns := []string{"season_id", "rank", "user_id", "score", "winner"}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"season_standing"}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return fmt.Errorf("insert season standings: %w", err)
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+func (db PostgreSQLRepository) GetSeasonStandings(ctx context.Context, id int64, offset, limit int) ([]leaderboardscoring.SeasonStanding, error) {
+	return db.querySeasonStandings(ctx, `
+		SELECT rank, user_id, score, winner
+		FROM season_standing
+		WHERE season_id = $1
+		ORDER BY rank, user_id DESC
+		OFFSET $2 LIMIT $3`,
+		id, offset, limit,
+	)
+}
+
+func (db PostgreSQLRepository) GetSeasonWinners(ctx context.Context, id int64) ([]leaderboardscoring.SeasonStanding, error) {
+	return db.querySeasonStandings(ctx, `
+		SELECT rank, user_id, score, winner
+		FROM season_standing
+		WHERE season_id = $1 AND winner
+		ORDER BY rank, user_id DESC`,
+		id,
+	)
+}
+
+func (db PostgreSQLRepository) MarkSeasonAnnounced(ctx context.Context, id int64, at time.Time) error {
+	if _, err := db.postgreSQL.Pool.Exec(ctx, `UPDATE season SET announced_at = $2 WHERE id = $1`, id, at); err != nil {
+		return fmt.Errorf("mark season announced: %w", err)
+	}
+
+	return nil
+}
+
+func (db PostgreSQLRepository) querySeasons(ctx context.Context, query string, args ...interface{}) ([]leaderboardscoring.Season, error) {
+	rows, err := db.postgreSQL.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query seasons: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []leaderboardscoring.Season
+	for rows.Next() {
+		season, err := scanSeason(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan season: %w", err)
+		}
+		seasons = append(seasons, season)
+	}
+
+	return seasons, rows.Err()
+}
+
+func (db PostgreSQLRepository) querySeasonStandings(ctx context.Context, query string, args ...interface{}) ([]leaderboardscoring.SeasonStanding, error) {
+	rows, err := db.postgreSQL.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query season standings: %w", err)
+	}
+	defer rows.Close()
+
+	var standings []leaderboardscoring.SeasonStanding
+	for rows.Next() {
+		var standing leaderboardscoring.SeasonStanding
+		if err := rows.Scan(&standing.Rank, &standing.UserID, &standing.Score, &standing.Winner); err != nil {
+			return nil, fmt.Errorf("scan season standing: %w", err)
+		}
+		standings = append(standings, standing)
+	}
+
+	return standings, rows.Err()
+}
+
+func scanSeason(row pgx.Row) (leaderboardscoring.Season, error) {
+	var (
+		season      leaderboardscoring.Season
+		rules       []byte
+		mode        string
+		closedAt    *time.Time
+		announcedAt *time.Time
+	)
+
+	err := row.Scan(
+		&season.ID, &season.Name, &season.StartsAt, &season.EndsAt, &season.ProjectIDs, &rules, &mode,
+		&season.WinnerCount, &season.Participants, &closedAt, &announcedAt, &season.CreatedAt, &season.UpdatedAt,
+	)
+	if err != nil {
+		return leaderboardscoring.Season{}, err
+	}
+
+	season.RankingMode = leaderboardscoring.RankingMode(mode)
+	if closedAt != nil {
+		season.ClosedAt = *closedAt
+	}
+	if announcedAt != nil {
+		season.AnnouncedAt = *announcedAt
+	}
+	if len(rules) > 0 {
+		if err := json.Unmarshal(rules, &season.ScoringRules); err != nil {
+			return leaderboardscoring.Season{}, fmt.Errorf("unmarshal scoring rules: %w", err)
+		}
+	}
+
+	return season, nil
+}
+
+func scoringRules(season leaderboardscoring.Season) map[leaderboardscoring.EventName]int64 {
+	if season.ScoringRules == nil {
+		return map[leaderboardscoring.EventName]int64{}
+	}
+
+	return season.ScoringRules
+}
+
+func projectIDs(season leaderboardscoring.Season) []string {
+	if season.ProjectIDs == nil {
+		return []string{}
+	}
+
+	return season.ProjectIDs
+}
+
+func seasonWriteError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == statuscode.ErrCodeUniqueViolation {
+		return leaderboardscoring.ErrSeasonNameTaken
+	}
+
+	return fmt.Errorf("%s: %w", op, err)
+}
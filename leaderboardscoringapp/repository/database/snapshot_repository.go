@@ -0,0 +1,138 @@
+package postgrerepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+func NewSnapshotRepository(db *database.Database, config RetryConfig) leaderboardscoring.SnapshotStore {
+	return &PostgreSQLRepository{
+		postgreSQL:  db,
+		retryConfig: config,
+	}
+}
+
+func (db PostgreSQLRepository) LatestSnapshotAt(ctx context.Context, key string, asOf time.Time) (time.Time, bool, error) {
+	var at time.Time
+	err := db.postgreSQL.Pool.QueryRow(ctx, `
+		SELECT snapshot_timestamp
+		FROM snapshot
+		WHERE leaderboard_key = $1 AND snapshot_timestamp <= $2
+		ORDER BY snapshot_timestamp DESC
+		LIMIT 1`,
+		key, asOf,
+	).Scan(&at)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("query latest snapshot: %w", err)
+	}
+
+	return at, true, nil
+}
+
+func (db PostgreSQLRepository) GetSnapshotRows(ctx context.Context, key string, at time.Time, offset, limit int) ([]leaderboardscoring.LeaderboardEntry, error) {
+	rows, err := db.postgreSQL.Pool.Query(ctx, `
+		SELECT rank, user_id, total_score
+		FROM snapshot
+		WHERE leaderboard_key = $1 AND snapshot_timestamp = $2
+		ORDER BY rank, user_id DESC
+		OFFSET $3 LIMIT $4`,
+		key, at, offset, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query snapshot rows: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]leaderboardscoring.LeaderboardEntry, 0, limit)
+	for rows.Next() {
+		var entry leaderboardscoring.LeaderboardEntry
+		if err := rows.Scan(&entry.Rank, &entry.UserID, &entry.Score); err != nil {
+			return nil, fmt.Errorf("scan snapshot row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (db PostgreSQLRepository) GetUserSnapshots(ctx context.Context, key, userID string, from, to time.Time) ([]leaderboardscoring.SnapshotRow, error) {
+	rows, err := db.postgreSQL.Pool.Query(ctx, `
+		SELECT id, rank, total_score, snapshot_timestamp
+		FROM snapshot
+		WHERE user_id = $1 AND leaderboard_key = $2
+		  AND snapshot_timestamp >= $3 AND snapshot_timestamp < $4
+		ORDER BY snapshot_timestamp`,
+		userID, key, from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query user snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []leaderboardscoring.SnapshotRow
+	for rows.Next() {
+		snapshot := leaderboardscoring.SnapshotRow{UserID: userID, LeaderboardKey: key}
+		if err := rows.Scan(&snapshot.ID, &snapshot.Rank, &snapshot.TotalScore, &snapshot.SnapshotTimestamp); err != nil {
+			return nil, fmt.Errorf("scan user snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// ThinSnapshots deletes every snapshot taken before before that is not the latest one of its
+// leaderboard key in its day or ISO week.
+func (db PostgreSQLRepository) ThinSnapshots(ctx context.Context, before time.Time, bucket leaderboardscoring.SnapshotBucket) (int64, error) {
+	var deleted int64
+	err := db.retryOperation(ctx, func() error {
+		tag, err := db.postgreSQL.Pool.Exec(ctx, `
+			DELETE FROM snapshot s
+			USING (
+				SELECT leaderboard_key,
+				       date_trunc($2::text, snapshot_timestamp) AS bucket,
+				       MAX(snapshot_timestamp)                  AS kept
+				FROM snapshot
+				WHERE snapshot_timestamp < $1
+				GROUP BY leaderboard_key, date_trunc($2::text, snapshot_timestamp)
+			) k
+			WHERE s.leaderboard_key = k.leaderboard_key
+			  AND s.snapshot_timestamp < $1
+			  AND date_trunc($2::text, s.snapshot_timestamp) = k.bucket
+			  AND s.snapshot_timestamp < k.kept`,
+			before, string(bucket),
+		)
+		if err != nil {
+			return fmt.Errorf("thin snapshots: %w", err)
+		}
+		deleted = tag.RowsAffected()
+
+		return nil
+	})
+
+	return deleted, err
+}
+
+func (db PostgreSQLRepository) DeleteSnapshotsBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := db.retryOperation(ctx, func() error {
+		tag, err := db.postgreSQL.Pool.Exec(ctx, `DELETE FROM snapshot WHERE snapshot_timestamp < $1`, before)
+		if err != nil {
+			return fmt.Errorf("delete snapshots: %w", err)
+		}
+		deleted = tag.RowsAffected()
+
+		return nil
+	})
+
+	return deleted, err
+}
@@ -0,0 +1,123 @@
+package postgrerepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+const streakColumns = `user_id, current_streak, longest_streak, last_active_day, timezone, updated_at`
+
+func NewStreakRepository(db *database.Database, config RetryConfig) leaderboardscoring.StreakStore {
+	return &PostgreSQLRepository{
+		postgreSQL:  db,
+		retryConfig: config,
+	}
+}
+
+// RecordActiveDay locks the current row so concurrent events of a user see each other's
+// day. It is not retried, the streak update is not idempotent.
+func (db PostgreSQLRepository) RecordActiveDay(ctx context.Context, userID string, day time.Time, timezone string) (leaderboardscoring.Streak, bool, error) {
+	var (
+		streak   leaderboardscoring.Streak
+		advanced bool
+	)
+
+	err := db.postgreSQL.Pool.QueryRow(ctx, `
+		WITH previous AS (
+			SELECT last_active_day FROM contributor_streak WHERE user_id = $1 FOR UPDATE
+		)
+		INSERT INTO contributor_streak (user_id, current_streak, longest_streak, last_active_day, timezone)
+		VALUES ($1, 1, 1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET current_streak  = CASE
+		        WHEN EXCLUDED.last_active_day = contributor_streak.last_active_day + 1
+		            THEN contributor_streak.current_streak + 1
+		        WHEN EXCLUDED.last_active_day > contributor_streak.last_active_day + 1
+		            THEN 1
+		        ELSE contributor_streak.current_streak
+		    END,
+		    longest_streak  = GREATEST(contributor_streak.longest_streak, CASE
+		        WHEN EXCLUDED.last_active_day = contributor_streak.last_active_day + 1
+		            THEN contributor_streak.current_streak + 1
+		        ELSE 1
+		    END),
+		    last_active_day = GREATEST(contributor_streak.last_active_day, EXCLUDED.last_active_day),
+		    timezone        = EXCLUDED.timezone,
+		    updated_at      = NOW()
+		RETURNING `+streakColumns+`,
+		    COALESCE((SELECT last_active_day FROM previous) < $2, TRUE)`,
+		userID, day, timezone,
+	).Scan(&streak.UserID, &streak.Current, &streak.Longest, &streak.LastActiveDay, &streak.Timezone, &streak.UpdatedAt, &advanced)
+	if err != nil {
+		return leaderboardscoring.Streak{}, false, fmt.Errorf("upsert contributor streak: %w", err)
+	}
+
+	return streak, advanced, nil
+}
+
+func (db PostgreSQLRepository) GetStreak(ctx context.Context, userID string) (leaderboardscoring.Streak, bool, error) {
+	var streak leaderboardscoring.Streak
+
+	err := db.postgreSQL.Pool.QueryRow(ctx, `
+		SELECT `+streakColumns+`
+		FROM contributor_streak
+		WHERE user_id = $1`,
+		userID,
+	).Scan(&streak.UserID, &streak.Current, &streak.Longest, &streak.LastActiveDay, &streak.Timezone, &streak.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return leaderboardscoring.Streak{}, false, nil
+	}
+	if err != nil {
+		return leaderboardscoring.Streak{}, false, fmt.Errorf("get contributor streak: %w", err)
+	}
+
+	return streak, true, nil
+}
+
+func (db PostgreSQLRepository) ListStreaks(ctx context.Context, now time.Time, offset, limit int) ([]leaderboardscoring.Streak, error) {
+	rows, err := db.postgreSQL.Pool.Query(ctx, `
+		SELECT `+streakColumns+`
+		FROM contributor_streak
+		WHERE current_streak > 0
+		  AND last_active_day >= ($1::timestamptz AT TIME ZONE timezone)::date - 1
+		ORDER BY current_streak DESC, longest_streak DESC, user_id
+		OFFSET $2 LIMIT $3`,
+		now, offset, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query contributor streaks: %w", err)
+	}
+	defer rows.Close()
+
+	var streaks []leaderboardscoring.Streak
+	for rows.Next() {
+		var streak leaderboardscoring.Streak
+		if err := rows.Scan(&streak.UserID, &streak.Current, &streak.Longest, &streak.LastActiveDay, &streak.Timezone, &streak.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan contributor streak: %w", err)
+		}
+		streaks = append(streaks, streak)
+	}
+
+	return streaks, rows.Err()
+}
+
+func (db PostgreSQLRepository) ResetBrokenStreaks(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := db.postgreSQL.Pool.Exec(ctx, `
+		UPDATE contributor_streak
+		SET current_streak = 0, updated_at = NOW()
+		WHERE current_streak > 0
+		  AND last_active_day < ($1::timestamptz AT TIME ZONE timezone)::date - 1`,
+		now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("reset broken streaks: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
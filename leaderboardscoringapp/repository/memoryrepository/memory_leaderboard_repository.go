@@ -0,0 +1,509 @@
+package memoryrepository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+)
+
+const (
+	defaultSweepInterval    = time.Minute
+	defaultSnapshotInterval = 5 * time.Minute
+
+	snapshotVersion = 1
+)
+
+type Config struct {
+	// SnapshotPath is the file the leaderboards are restored from on start and saved to
+	// periodically and on shutdown. Empty keeps them in memory only.
+	SnapshotPath     string        `koanf:"snapshot_path"`
+	SnapshotInterval time.Duration `koanf:"snapshot_interval"`
+	// SweepInterval is how often expired keys are freed, they are never read once expired
+	SweepInterval time.Duration `koanf:"sweep_interval"`
+}
+
+// MemoryLeaderboardRepository keeps leaderboards in process memory, with the same
+// semantics as RedisLeaderboardRepository. It is meant for single-node setups and tests.
+type MemoryLeaderboardRepository struct {
+	config Config
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	sets     map[string]*sortedSet
+	expireAt map[string]time.Time
+	// trendingBases holds the base of each rebased trending board, trendingEvents the
+	// expiry of the event markers, by leaderboardscoring.TrendingEventKey
+	trendingBases  map[string]float64
+	trendingEvents map[string]time.Time
+}
+
+func NewMemoryLeaderboardRepository(config Config) *MemoryLeaderboardRepository {
+	return &MemoryLeaderboardRepository{
+		config:         config,
+		now:            time.Now,
+		sets:           make(map[string]*sortedSet),
+		expireAt:       make(map[string]time.Time),
+		trendingBases:  make(map[string]float64),
+		trendingEvents: make(map[string]time.Time),
+	}
+}
+
+// expired must be called with mu held
+func (r *MemoryLeaderboardRepository) expired(key string, now time.Time) bool {
+	at, ok := r.expireAt[key]
+	return ok && !at.After(now)
+}
+
+// lookup returns the live set of key, or nil. It must be called with mu held.
+func (r *MemoryLeaderboardRepository) lookup(key string, now time.Time) *sortedSet {
+	if r.expired(key, now) {
+		return nil
+	}
+
+	return r.sets[key]
+}
+
+// update applies fn to every key, creating the keys as needed. A zero expireAt leaves the
+// keys without TTL, otherwise it is set on keys that don't have one yet.
+func (r *MemoryLeaderboardRepository) update(keys []string, expireAt time.Time, fn func(set *sortedSet)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for _, key := range keys {
+		if r.expired(key, now) {
+			r.deleteKey(key)
+		}
+
+		set, ok := r.sets[key]
+		if !ok {
+			set = newSortedSet()
+			r.sets[key] = set
+		}
+		fn(set)
+
+		if expireAt.IsZero() {
+			continue
+		}
+		if _, hasTTL := r.expireAt[key]; hasTTL {
+			continue
+		}
+
+		// Like EXPIREAT, a time in the past deletes the key right away
+		if !expireAt.After(now) {
+			r.deleteKey(key)
+			continue
+		}
+		r.expireAt[key] = expireAt
+	}
+}
+
+// updateMembers replaces the score of userID on the keys it is a member of with
+// fn(current score), like update does for a new key it sets the expiry of a key without one
+func (r *MemoryLeaderboardRepository) updateMembers(keys []string, userID string, expireAt time.Time, fn func(current float64) float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for _, key := range keys {
+		set := r.lookup(key, now)
+		if set == nil {
+			continue
+		}
+		current, ok := set.scores[userID]
+		if !ok {
+			continue
+		}
+		set.add(userID, fn(current))
+
+		if _, hasTTL := r.expireAt[key]; hasTTL || expireAt.IsZero() {
+			continue
+		}
+		if !expireAt.After(now) {
+			r.deleteKey(key)
+			continue
+		}
+		r.expireAt[key] = expireAt
+	}
+}
+
+func (r *MemoryLeaderboardRepository) deleteKey(key string) {
+	delete(r.sets, key)
+	delete(r.expireAt, key)
+}
+
+func (r *MemoryLeaderboardRepository) UpsertScores(_ context.Context, score *leaderboardscoring.UpsertScore) error {
+	if score == nil || len(score.Keys) == 0 || score.UserID == "" {
+		return nil
+	}
+
+	if score.ClampAtZero {
+		clamped := func(current float64) float64 {
+			return max(current+float64(score.Score), 0)
+		}
+		if !score.ReachedAt.IsZero() {
+			fraction := leaderboardscoring.FirstReachedFraction(score.ReachedAt)
+			clamped = func(current float64) float64 {
+				return max(math.Floor(current)+float64(score.Score), 0) + fraction
+			}
+		}
+
+		r.updateMembers(score.Keys, score.UserID, score.ExpireAt, clamped)
+		return nil
+	}
+
+	if score.ReachedAt.IsZero() {
+		r.update(score.Keys, score.ExpireAt, func(set *sortedSet) {
+			set.incrBy(score.UserID, float64(score.Score))
+		})
+		return nil
+	}
+
+	// first_reached boards keep the whole total and replace the reach time fraction
+	fraction := leaderboardscoring.FirstReachedFraction(score.ReachedAt)
+	r.update(score.Keys, score.ExpireAt, func(set *sortedSet) {
+		total := math.Floor(set.score(score.UserID)) + float64(score.Score)
+		set.add(score.UserID, total+fraction)
+	})
+	return nil
+}
+
+// UpsertTrendingScores adds a boosted contribution to the trending leaderboards (no TTL),
+// once per event and board, and rebases a board like RedisLeaderboardRepository does
+func (r *MemoryLeaderboardRepository) UpsertTrendingScores(_ context.Context, score *leaderboardscoring.TrendingScore) error {
+	if score == nil || len(score.Keys) == 0 || score.UserID == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for _, key := range score.Keys {
+		if score.EventID != "" && score.DedupeTTL > 0 {
+			event := leaderboardscoring.TrendingEventKey(key, score.EventID)
+			if at, ok := r.trendingEvents[event]; ok && at.After(now) {
+				continue
+			}
+			r.trendingEvents[event] = now.Add(score.DedupeTTL)
+		}
+
+		set, ok := r.sets[key]
+		if !ok {
+			set = newSortedSet()
+			r.sets[key] = set
+		}
+
+		base := r.trendingBases[key]
+		if rebased := leaderboardscoring.TrendingRebase(score.HalfLives, base); rebased != base {
+			scale := math.Exp2(base - rebased)
+			for _, m := range set.members() {
+				set.add(m.Member, m.Score*scale)
+			}
+			base = rebased
+			r.trendingBases[key] = base
+		}
+
+		weight := leaderboardscoring.TrendingWeight(score.Score, score.HalfLives, base)
+		if !score.ClampAtZero {
+			set.incrBy(score.UserID, weight)
+			continue
+		}
+		if current, ok := set.scores[score.UserID]; ok {
+			set.add(score.UserID, max(current+weight, 0))
+		}
+	}
+	return nil
+}
+
+func (r *MemoryLeaderboardRepository) GetLeaderboard(_ context.Context, leaderboard *leaderboardscoring.LeaderboardQuery) (leaderboardscoring.LeaderboardQueryResult, error) {
+	r.mu.RLock()
+	var members []member
+	if set := r.lookup(leaderboard.Key, r.now()); set != nil {
+		members = set.revRange(leaderboard.Start, leaderboard.Stop)
+	}
+	base := r.trendingBases[leaderboard.Key]
+	r.mu.RUnlock()
+
+	rows := make([]leaderboardscoring.LeaderboardEntry, 0, len(members))
+	for i, m := range members {
+		score := int64(math.Floor(m.Score))
+		if leaderboard.Trending {
+			score = int64(math.Round(leaderboardscoring.TrendingDecay(m.Score, leaderboard.HalfLives, base)))
+		}
+
+		rows = append(rows, leaderboardscoring.LeaderboardEntry{
+			Rank:   leaderboard.Start + int64(i) + 1,
+			UserID: m.Member,
+			Score:  score,
+		})
+	}
+
+	return leaderboardscoring.LeaderboardQueryResult{LeaderboardRows: rows}, nil
+}
+
+// GetUserRanks returns the user's 1-based rank and score on each key.
+// Keys the user is not a member of get rank 0.
+func (r *MemoryLeaderboardRepository) GetUserRanks(_ context.Context, keys []string, userID string) ([]leaderboardscoring.UserRank, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.now()
+	ranks := make([]leaderboardscoring.UserRank, len(keys))
+	for i, key := range keys {
+		ranks[i] = leaderboardscoring.UserRank{Key: key}
+
+		set := r.lookup(key, now)
+		if set == nil {
+			continue
+		}
+
+		rank, score, ok := set.revRank(userID)
+		if !ok {
+			continue
+		}
+
+		ranks[i].Rank = rank + 1
+		ranks[i].Score = int64(math.Floor(score))
+	}
+
+	return ranks, nil
+}
+
+// CountHigherScores counts the members, or distinct whole scores, of at least score+1.
+func (r *MemoryLeaderboardRepository) CountHigherScores(_ context.Context, key string, score int64, distinct bool) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := r.lookup(key, r.now())
+	if set == nil {
+		return 0, nil
+	}
+
+	if distinct {
+		return set.countDistinctFloorsAtLeast(float64(score + 1)), nil
+	}
+
+	return set.countAtLeast(float64(score + 1)), nil
+}
+
+// Sweep frees all expired keys and returns how many were removed. Expired trending event
+// markers are freed as well, they are not counted.
+func (r *MemoryLeaderboardRepository) Sweep() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	removed := 0
+	for key := range r.expireAt {
+		if r.expired(key, now) {
+			r.deleteKey(key)
+			removed++
+		}
+	}
+	for event, at := range r.trendingEvents {
+		if !at.After(now) {
+			delete(r.trendingEvents, event)
+		}
+	}
+
+	return removed
+}
+
+// Start sweeps expired keys and saves snapshots until ctx is cancelled, then saves a
+// final snapshot.
+func (r *MemoryLeaderboardRepository) Start(ctx context.Context) error {
+	sweepInterval := r.config.SweepInterval
+	if sweepInterval <= 0 {
+		sweepInterval = defaultSweepInterval
+	}
+	sweepTicker := time.NewTicker(sweepInterval)
+	defer sweepTicker.Stop()
+
+	var snapshotTick <-chan time.Time
+	if r.config.SnapshotPath != "" {
+		snapshotInterval := r.config.SnapshotInterval
+		if snapshotInterval <= 0 {
+			snapshotInterval = defaultSnapshotInterval
+		}
+		snapshotTicker := time.NewTicker(snapshotInterval)
+		defer snapshotTicker.Stop()
+		snapshotTick = snapshotTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if r.config.SnapshotPath == "" {
+				return ctx.Err()
+			}
+			if err := r.SaveSnapshot(); err != nil {
+				return fmt.Errorf("final snapshot: %w", err)
+			}
+			return ctx.Err()
+
+		case <-sweepTicker.C:
+			r.Sweep()
+
+		case <-snapshotTick:
+			if err := r.SaveSnapshot(); err != nil {
+				logger.L().Error("failed to save leaderboard snapshot",
+					slog.String("path", r.config.SnapshotPath),
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+type snapshot struct {
+	Version        int                  `json:"version"`
+	TakenAt        time.Time            `json:"taken_at"`
+	Keys           []snapshotKey        `json:"keys"`
+	TrendingBases  map[string]float64   `json:"trending_bases,omitempty"`
+	TrendingEvents map[string]time.Time `json:"trending_events,omitempty"`
+}
+
+type snapshotKey struct {
+	Key      string     `json:"key"`
+	ExpireAt *time.Time `json:"expire_at,omitempty"`
+	Members  []member   `json:"members"`
+}
+
+// WriteSnapshot writes all live keys to w as JSON.
+func (r *MemoryLeaderboardRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	now := r.now()
+	snap := snapshot{Version: snapshotVersion, TakenAt: now.UTC(), Keys: make([]snapshotKey, 0, len(r.sets))}
+	for key, set := range r.sets {
+		if r.expired(key, now) {
+			continue
+		}
+
+		sk := snapshotKey{Key: key, Members: set.members()}
+		if at, ok := r.expireAt[key]; ok {
+			sk.ExpireAt = &at
+		}
+		snap.Keys = append(snap.Keys, sk)
+	}
+	snap.TrendingBases = maps.Clone(r.trendingBases)
+	snap.TrendingEvents = make(map[string]time.Time, len(r.trendingEvents))
+	for event, at := range r.trendingEvents {
+		if at.After(now) {
+			snap.TrendingEvents[event] = at
+		}
+	}
+	r.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// ReadSnapshot replaces the content of the repository with a snapshot read from r.
+// Keys that expired in the meantime are skipped.
+func (r *MemoryLeaderboardRepository) ReadSnapshot(rd io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(rd).Decode(&snap); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+
+	sets := make(map[string]*sortedSet, len(snap.Keys))
+	expireAt := make(map[string]time.Time)
+	now := r.now()
+	for _, sk := range snap.Keys {
+		if sk.ExpireAt != nil {
+			if !sk.ExpireAt.After(now) {
+				continue
+			}
+			expireAt[sk.Key] = *sk.ExpireAt
+		}
+
+		set := newSortedSet()
+		for _, m := range sk.Members {
+			set.incrBy(m.Member, m.Score)
+		}
+		sets[sk.Key] = set
+	}
+
+	bases := make(map[string]float64, len(snap.TrendingBases))
+	maps.Copy(bases, snap.TrendingBases)
+	events := make(map[string]time.Time, len(snap.TrendingEvents))
+	for event, at := range snap.TrendingEvents {
+		if at.After(now) {
+			events[event] = at
+		}
+	}
+
+	r.mu.Lock()
+	r.sets, r.expireAt = sets, expireAt
+	r.trendingBases, r.trendingEvents = bases, events
+	r.mu.Unlock()
+
+	return nil
+}
+
+// SaveSnapshot writes a snapshot to the configured path. The file is replaced atomically,
+// a crash while saving keeps the previous snapshot.
+func (r *MemoryLeaderboardRepository) SaveSnapshot() error {
+	path := r.config.SnapshotPath
+	if path == "" {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create snapshot file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := r.WriteSnapshot(tmp); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close snapshot: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadSnapshot restores the snapshot at the configured path. A missing file is not an error.
+func (r *MemoryLeaderboardRepository) LoadSnapshot() error {
+	path := r.config.SnapshotPath
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	return r.ReadSnapshot(file)
+}
@@ -0,0 +1,157 @@
+package memoryrepository_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/cachetest"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/memoryrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLeaderboardRepository_Conformance(t *testing.T) {
+	cachetest.Run(t, func(t *testing.T) leaderboardscoring.LeaderboardCache {
+		return memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{})
+	})
+}
+
+func TestMemoryLeaderboardRepository_MatchesSortedOrder(t *testing.T) {
+	repo := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{})
+	ctx := context.Background()
+
+	// Random increments, including negative ones, against a plain map as reference
+	want := make(map[string]int64)
+	for i := 0; i < 5_000; i++ {
+		userID := strconv.Itoa(rand.IntN(300))
+		delta := rand.Int64N(100) - 30
+		want[userID] += delta
+		require.NoError(t, repo.UpsertScores(ctx, &leaderboardscoring.UpsertScore{Keys: []string{"board"}, UserID: userID, Score: delta}))
+	}
+
+	ids := make([]string, 0, len(want))
+	for id := range want {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if want[ids[i]] != want[ids[j]] {
+			return want[ids[i]] > want[ids[j]]
+		}
+		return ids[i] > ids[j]
+	})
+
+	result, err := repo.GetLeaderboard(ctx, &leaderboardscoring.LeaderboardQuery{Key: "board", Start: 0, Stop: -1})
+	require.NoError(t, err)
+	require.Len(t, result.LeaderboardRows, len(ids))
+
+	for i, row := range result.LeaderboardRows {
+		require.Equal(t, ids[i], row.UserID, "rank %d", i+1)
+		require.Equal(t, want[ids[i]], row.Score)
+	}
+
+	for _, pos := range []int{0, 17, len(ids) - 1} {
+		ranks, err := repo.GetUserRanks(ctx, []string{"board"}, ids[pos])
+		require.NoError(t, err)
+		assert.Equal(t, int64(pos+1), ranks[0].Rank)
+	}
+}
+
+func TestMemoryLeaderboardRepository_SnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboards.json")
+	cfg := memoryrepository.Config{SnapshotPath: path}
+	ctx := context.Background()
+
+	repo := memoryrepository.NewMemoryLeaderboardRepository(cfg)
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, repo.UpsertScores(ctx, &leaderboardscoring.UpsertScore{
+			Keys:     []string{"leaderboard:global:all_time", "leaderboard:global:daily:2025-06-01"},
+			UserID:   fmt.Sprint(i),
+			Score:    int64(i * 10),
+			ExpireAt: time.Now().Add(time.Hour),
+		}))
+	}
+	trending := &leaderboardscoring.TrendingScore{
+		Keys: []string{"leaderboard:global:trending"}, UserID: "1", Score: 3, HalfLives: 70, EventID: "e1", DedupeTTL: time.Hour,
+	}
+	require.NoError(t, repo.UpsertTrendingScores(ctx, trending))
+	require.NoError(t, repo.SaveSnapshot())
+
+	restored := memoryrepository.NewMemoryLeaderboardRepository(cfg)
+	require.NoError(t, restored.LoadSnapshot())
+
+	// The trending board keeps its base and remembers the event it counted
+	require.NoError(t, restored.UpsertTrendingScores(ctx, trending))
+
+	for _, key := range []string{"leaderboard:global:all_time", "leaderboard:global:daily:2025-06-01", "leaderboard:global:trending"} {
+		query := &leaderboardscoring.LeaderboardQuery{Key: key, Start: 0, Stop: -1, Trending: key == "leaderboard:global:trending", HalfLives: 71}
+		want, err := repo.GetLeaderboard(ctx, query)
+		require.NoError(t, err)
+		got, err := restored.GetLeaderboard(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, key)
+	}
+
+	// The daily key keeps its TTL, a later upsert must not extend it
+	require.NoError(t, restored.UpsertScores(ctx, &leaderboardscoring.UpsertScore{
+		Keys: []string{"leaderboard:global:daily:2025-06-01"}, UserID: "1", Score: 1, ExpireAt: time.Now().Add(-time.Minute),
+	}))
+	rows, err := restored.GetLeaderboard(ctx, &leaderboardscoring.LeaderboardQuery{Key: "leaderboard:global:daily:2025-06-01", Start: 0, Stop: -1})
+	require.NoError(t, err)
+	assert.Len(t, rows.LeaderboardRows, 3)
+}
+
+func TestMemoryLeaderboardRepository_LoadSnapshotMissingFile(t *testing.T) {
+	repo := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{
+		SnapshotPath: filepath.Join(t.TempDir(), "missing.json"),
+	})
+
+	assert.NoError(t, repo.LoadSnapshot())
+}
+
+func TestMemoryLeaderboardRepository_SweepRemovesExpiredKeys(t *testing.T) {
+	repo := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{})
+	ctx := context.Background()
+
+	require.NoError(t, repo.UpsertScores(ctx, &leaderboardscoring.UpsertScore{
+		Keys: []string{"short"}, UserID: "1", Score: 1, ExpireAt: time.Now().Add(20 * time.Millisecond),
+	}))
+	require.NoError(t, repo.UpsertScores(ctx, &leaderboardscoring.UpsertScore{
+		Keys: []string{"long"}, UserID: "1", Score: 1, ExpireAt: time.Now().Add(time.Hour),
+	}))
+
+	assert.Equal(t, 0, repo.Sweep())
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 1, repo.Sweep())
+
+	rows, err := repo.GetLeaderboard(ctx, &leaderboardscoring.LeaderboardQuery{Key: "long", Start: 0, Stop: 0})
+	require.NoError(t, err)
+	assert.Len(t, rows.LeaderboardRows, 1)
+}
+
+func TestMemoryLeaderboardRepository_StartSavesSnapshotOnShutdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboards.json")
+	repo := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{SnapshotPath: path})
+	require.NoError(t, repo.UpsertScores(context.Background(), &leaderboardscoring.UpsertScore{
+		Keys: []string{"board"}, UserID: "1", Score: 7,
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- repo.Start(ctx) }()
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	restored := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{SnapshotPath: path})
+	require.NoError(t, restored.LoadSnapshot())
+
+	ranks, err := restored.GetUserRanks(context.Background(), []string{"board"}, "1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), ranks[0].Score)
+}
@@ -0,0 +1,284 @@
+package memoryrepository
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	skiplistMaxLevel = 32
+	skiplistP        = 0.25
+)
+
+type skiplistLevel struct {
+	forward *skiplistNode
+	// span is the number of nodes the forward link skips, it turns rank lookups into O(log n)
+	span int
+}
+
+type skiplistNode struct {
+	member string
+	score  float64
+	levels []skiplistLevel
+}
+
+// skiplist keeps members in the order of a Redis ZSET read with ZREVRANGE: highest score
+// first, equal scores ordered by member, also descending.
+type skiplist struct {
+	head   *skiplistNode
+	level  int
+	length int
+}
+
+func newSkiplist() *skiplist {
+	return &skiplist{
+		head:  &skiplistNode{levels: make([]skiplistLevel, skiplistMaxLevel)},
+		level: 1,
+	}
+}
+
+// precedes reports whether node is ordered before (score, member)
+func precedes(node *skiplistNode, score float64, member string) bool {
+	return node.score > score || (node.score == score && node.member > member)
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skiplistMaxLevel && rand.Float64() < skiplistP {
+		level++
+	}
+
+	return level
+}
+
+func (sl *skiplist) insert(score float64, member string) {
+	var (
+		update [skiplistMaxLevel]*skiplistNode
+		rank   [skiplistMaxLevel]int
+	)
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.levels[i].forward != nil && precedes(x.levels[i].forward, score, member) {
+			rank[i] += x.levels[i].span
+			x = x.levels[i].forward
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > sl.level {
+		for i := sl.level; i < level; i++ {
+			update[i] = sl.head
+			update[i].levels[i].span = sl.length
+		}
+		sl.level = level
+	}
+
+	node := &skiplistNode{member: member, score: score, levels: make([]skiplistLevel, level)}
+	for i := 0; i < level; i++ {
+		node.levels[i].forward = update[i].levels[i].forward
+		update[i].levels[i].forward = node
+
+		node.levels[i].span = update[i].levels[i].span - (rank[0] - rank[i])
+		update[i].levels[i].span = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < sl.level; i++ {
+		update[i].levels[i].span++
+	}
+
+	sl.length++
+}
+
+func (sl *skiplist) delete(score float64, member string) {
+	var update [skiplistMaxLevel]*skiplistNode
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].forward != nil && precedes(x.levels[i].forward, score, member) {
+			x = x.levels[i].forward
+		}
+		update[i] = x
+	}
+
+	x = x.levels[0].forward
+	if x == nil || x.score != score || x.member != member {
+		return
+	}
+
+	for i := 0; i < sl.level; i++ {
+		if update[i].levels[i].forward == x {
+			update[i].levels[i].span += x.levels[i].span - 1
+			update[i].levels[i].forward = x.levels[i].forward
+		} else {
+			update[i].levels[i].span--
+		}
+	}
+
+	for sl.level > 1 && sl.head.levels[sl.level-1].forward == nil {
+		sl.level--
+	}
+
+	sl.length--
+}
+
+// rank returns the number of members ordered before (score, member)
+func (sl *skiplist) rank(score float64, member string) int {
+	rank := 0
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].forward != nil && precedes(x.levels[i].forward, score, member) {
+			rank += x.levels[i].span
+			x = x.levels[i].forward
+		}
+	}
+
+	return rank
+}
+
+// countAtLeast returns the number of members scored min or higher
+func (sl *skiplist) countAtLeast(min float64) int {
+	count := 0
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].forward != nil && x.levels[i].forward.score >= min {
+			count += x.levels[i].span
+			x = x.levels[i].forward
+		}
+	}
+
+	return count
+}
+
+// at returns the node at the 0-based position pos, or nil when out of range
+func (sl *skiplist) at(pos int) *skiplistNode {
+	if pos < 0 || pos >= sl.length {
+		return nil
+	}
+
+	target := pos + 1
+	traversed := 0
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].forward != nil && traversed+x.levels[i].span <= target {
+			traversed += x.levels[i].span
+			x = x.levels[i].forward
+		}
+		if traversed == target {
+			return x
+		}
+	}
+
+	return nil
+}
+
+type member struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// sortedSet is the in-memory counterpart of a Redis ZSET
+type sortedSet struct {
+	scores map[string]float64
+	list   *skiplist
+}
+
+func newSortedSet() *sortedSet {
+	return &sortedSet{scores: make(map[string]float64), list: newSkiplist()}
+}
+
+func (z *sortedSet) len() int {
+	return z.list.length
+}
+
+// incrBy behaves like ZINCRBY, members start at zero
+func (z *sortedSet) incrBy(name string, delta float64) float64 {
+	score := z.scores[name] + delta
+	z.add(name, score)
+
+	return score
+}
+
+// add behaves like ZADD, it replaces the score of name
+func (z *sortedSet) add(name string, score float64) {
+	if old, ok := z.scores[name]; ok {
+		z.list.delete(old, name)
+	}
+
+	z.scores[name] = score
+	z.list.insert(score, name)
+}
+
+// score behaves like ZSCORE, unknown members score zero
+func (z *sortedSet) score(name string) float64 {
+	return z.scores[name]
+}
+
+// countAtLeast behaves like ZCOUNT min +inf
+func (z *sortedSet) countAtLeast(min float64) int64 {
+	return int64(z.list.countAtLeast(min))
+}
+
+// countDistinctFloorsAtLeast returns the number of distinct whole parts among the scores of
+// min or higher. It walks those members, the cost grows with the position of min.
+func (z *sortedSet) countDistinctFloorsAtLeast(min float64) int64 {
+	var (
+		count int64
+		prev  float64
+	)
+	for node := z.list.head.levels[0].forward; node != nil && node.score >= min; node = node.levels[0].forward {
+		floor := math.Floor(node.score)
+		if count == 0 || floor != prev {
+			count++
+			prev = floor
+		}
+	}
+
+	return count
+}
+
+// revRange behaves like ZREVRANGE, negative indexes count from the lowest score
+func (z *sortedSet) revRange(start, stop int64) []member {
+	length := int64(z.len())
+	if start < 0 {
+		start = max(length+start, 0)
+	}
+	if stop < 0 {
+		stop = length + stop
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	if start > stop || start >= length {
+		return nil
+	}
+
+	members := make([]member, 0, stop-start+1)
+	for node := z.list.at(int(start)); node != nil && int64(len(members)) <= stop-start; node = node.levels[0].forward {
+		members = append(members, member{Member: node.member, Score: node.score})
+	}
+
+	return members
+}
+
+// revRank behaves like ZREVRANK and ZSCORE, ok is false for unknown members
+func (z *sortedSet) revRank(name string) (rank int64, score float64, ok bool) {
+	score, ok = z.scores[name]
+	if !ok {
+		return 0, 0, false
+	}
+
+	return int64(z.list.rank(score, name)), score, true
+}
+
+// members returns all members, highest score first
+func (z *sortedSet) members() []member {
+	return z.revRange(0, -1)
+}
@@ -0,0 +1,121 @@
+package natsrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gocasters/rankr/adapter/natsadapter"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/nats-io/nats.go"
+)
+
+// Headers of a dead letter message, the body is the processed score event as published.
+// Letters published before these headers existed report an unknown reason.
+const (
+	HeaderReason        = "Rankr-Dlq-Reason"
+	HeaderDeliveryCount = "Rankr-Dlq-Delivery-Count"
+	HeaderFailedAt      = "Rankr-Dlq-Failed-At"
+)
+
+const unknownReason = "unknown"
+
+// DeadLetterQueue keeps dead letters as messages of one subject in the processed events
+// stream. Reading them does not consume them, they stay until deleted or until the stream
+// limits (max_age, max_messages) drop them.
+type DeadLetterQueue struct {
+	adapter *natsadapter.Adapter
+	subject string
+}
+
+func NewDeadLetterQueue(adapter *natsadapter.Adapter, subject string) *DeadLetterQueue {
+	return &DeadLetterQueue{adapter: adapter, subject: subject}
+}
+
+func (q *DeadLetterQueue) Add(ctx context.Context, letter leaderboardscoring.DeadLetter) error {
+	failedAt := letter.FailedAt
+	if failedAt.IsZero() {
+		failedAt = time.Now()
+	}
+
+	msg := nats.NewMsg(q.subject)
+	msg.Data = letter.Data
+	msg.Header.Set(HeaderReason, letter.Reason)
+	msg.Header.Set(HeaderDeliveryCount, strconv.FormatUint(letter.DeliveryCount, 10))
+	msg.Header.Set(HeaderFailedAt, failedAt.UTC().Format(time.RFC3339Nano))
+
+	return q.adapter.PublishMsg(ctx, msg)
+}
+
+func (q *DeadLetterQueue) List(ctx context.Context, after uint64, limit int) ([]leaderboardscoring.DeadLetter, error) {
+	msgs, err := q.adapter.ReadMsgs(ctx, q.subject, after+1, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]leaderboardscoring.DeadLetter, 0, len(msgs))
+	for _, msg := range msgs {
+		letters = append(letters, deadLetter(msg))
+	}
+
+	return letters, nil
+}
+
+func (q *DeadLetterQueue) Get(ctx context.Context, sequence uint64) (leaderboardscoring.DeadLetter, error) {
+	msg, err := q.adapter.GetMsg(ctx, sequence)
+	if errors.Is(err, natsadapter.ErrMsgNotFound) {
+		return leaderboardscoring.DeadLetter{}, leaderboardscoring.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return leaderboardscoring.DeadLetter{}, err
+	}
+
+	// The sequence may belong to a processed event waiting for the batch processor
+	if msg.Subject != q.subject {
+		return leaderboardscoring.DeadLetter{}, leaderboardscoring.ErrDeadLetterNotFound
+	}
+
+	return deadLetter(msg), nil
+}
+
+func (q *DeadLetterQueue) Delete(ctx context.Context, sequence uint64) error {
+	err := q.adapter.DeleteMsg(ctx, sequence)
+	if errors.Is(err, natsadapter.ErrMsgNotFound) {
+		return leaderboardscoring.ErrDeadLetterNotFound
+	}
+
+	return err
+}
+
+func (q *DeadLetterQueue) Depth(ctx context.Context) (int64, error) {
+	count, err := q.adapter.CountMsgs(ctx, q.subject)
+	if err != nil {
+		return 0, fmt.Errorf("count dead letters: %w", err)
+	}
+
+	return int64(count), nil
+}
+
+func deadLetter(msg natsadapter.StoredMsg) leaderboardscoring.DeadLetter {
+	letter := leaderboardscoring.DeadLetter{
+		Sequence: msg.Sequence,
+		Data:     msg.Data,
+		Reason:   msg.Header.Get(HeaderReason),
+		FailedAt: msg.Time,
+	}
+	if letter.Reason == "" {
+		letter.Reason = unknownReason
+	}
+	if count, err := strconv.ParseUint(msg.Header.Get(HeaderDeliveryCount), 10, 64); err == nil {
+		letter.DeliveryCount = count
+	}
+	if failedAt, err := time.Parse(time.RFC3339Nano, msg.Header.Get(HeaderFailedAt)); err == nil {
+		letter.FailedAt = failedAt
+	}
+
+	leaderboardscoring.DecodeDeadLetter(&letter)
+
+	return letter
+}
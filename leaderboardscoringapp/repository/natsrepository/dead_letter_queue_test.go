@@ -0,0 +1,89 @@
+package natsrepository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/adapter/natsadapter"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/natsrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+func newAdapter(t *testing.T) *natsadapter.Adapter {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, JetStream: true, StoreDir: t.TempDir()})
+	require.NoError(t, err)
+	go ns.Start()
+	require.True(t, ns.ReadyForConnections(4*time.Second))
+	t.Cleanup(ns.Shutdown)
+
+	adapter, err := natsadapter.New(natsadapter.Config{
+		URL:             ns.ClientURL(),
+		StreamName:      "PROCESSED",
+		StreamSubjects:  []string{"processed", "processed.dlq"},
+		StorageType:     natsadapter.StorageMemory,
+		RetentionPolicy: natsadapter.RetentionWorkQueue,
+	}, nopLogger{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = adapter.Close() })
+
+	return adapter
+}
+
+func TestDeadLetterQueue(t *testing.T) {
+	ctx := context.Background()
+	adapter := newAdapter(t)
+	queue := natsrepository.NewDeadLetterQueue(adapter, "processed.dlq")
+
+	require.NoError(t, adapter.Publish(ctx, "processed", []byte(`{"event_id":"pending"}`)))
+	failedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, queue.Add(ctx, leaderboardscoring.DeadLetter{
+		Data:          []byte(`{"event_id":"e-1","user_id":"7","project_id":"1001","score":3}`),
+		Reason:        "persist events: connection refused",
+		DeliveryCount: 5,
+		FailedAt:      failedAt,
+	}))
+	// Published before the headers existed
+	require.NoError(t, adapter.Publish(ctx, "processed.dlq", []byte("not json")))
+
+	depth, err := queue.Depth(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), depth)
+
+	letters, err := queue.List(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+
+	assert.Equal(t, "persist events: connection refused", letters[0].Reason)
+	assert.Equal(t, uint64(5), letters[0].DeliveryCount)
+	assert.True(t, failedAt.Equal(letters[0].FailedAt))
+	require.NotNil(t, letters[0].Event)
+	assert.Equal(t, "e-1", letters[0].Event.EventID)
+	assert.Equal(t, "1001", letters[0].Event.ProjectID)
+
+	assert.Equal(t, "unknown", letters[1].Reason)
+	assert.Nil(t, letters[1].Event)
+
+	rest, err := queue.List(ctx, letters[0].Sequence, 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, letters[1].Sequence, rest[0].Sequence)
+
+	_, err = queue.Get(ctx, 1)
+	assert.ErrorIs(t, err, leaderboardscoring.ErrDeadLetterNotFound, "sequence 1 is a pending processed event")
+
+	require.NoError(t, queue.Delete(ctx, letters[0].Sequence))
+	_, err = queue.Get(ctx, letters[0].Sequence)
+	assert.ErrorIs(t, err, leaderboardscoring.ErrDeadLetterNotFound)
+	assert.ErrorIs(t, queue.Delete(ctx, letters[0].Sequence), leaderboardscoring.ErrDeadLetterNotFound)
+}
@@ -0,0 +1,217 @@
+package redisrepository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	legacyKeyPattern   = "leaderboard:*"
+	migrateScanCount   = 500
+	migrateCopyBatch   = 1_000
+	migrateStagingPart = ":migrating"
+	migrateMarkerPart  = ":migrated"
+	migrateMarkerTTL   = 7 * 24 * time.Hour
+)
+
+// MigratedKey maps a leaderboard key of the legacy layout ("leaderboard:1001:daily:2025-06-01")
+// to the hash-tagged layout ("leaderboard:{1001}:daily:2025-06-01"). ok is false for keys
+// that are already migrated or are not leaderboard keys.
+func MigratedKey(legacy string) (key string, ok bool) {
+	rest, found := strings.CutPrefix(legacy, "leaderboard:")
+	if !found || strings.ContainsAny(rest, "{}") {
+		return "", false
+	}
+
+	scope, board, found := strings.Cut(rest, ":")
+	if !found || scope == "" || board == "" {
+		return "", false
+	}
+
+	return "leaderboard:{" + scope + "}:" + board, true
+}
+
+// KeyMigrationResult summarizes a key layout migration.
+type KeyMigrationResult struct {
+	Scanned  int
+	Migrated int
+	Members  int64
+}
+
+// KeyMigrator moves leaderboards from the legacy key layout to the hash-tagged one. It
+// must run after the new version is deployed, when nothing writes the legacy keys anymore.
+//
+// Keys are copied rather than renamed, because the two names can be in different hash slots
+// on Redis Cluster. A legacy key is first copied into a staging key next to its new name,
+// then merged into the new key, which may already hold scores written since the deployment,
+// and finally deleted. The merge also sets a marker key, so running the migration again
+// after a failure never merges the same legacy key twice.
+type KeyMigrator struct {
+	client redis.UniversalClient
+}
+
+func NewKeyMigrator(client redis.UniversalClient) *KeyMigrator {
+	return &KeyMigrator{client: client}
+}
+
+// Migrate migrates all legacy leaderboard keys. With dryRun it only counts them.
+func (m *KeyMigrator) Migrate(ctx context.Context, dryRun bool) (KeyMigrationResult, error) {
+	var result KeyMigrationResult
+
+	migrateNode := func(ctx context.Context, node *redis.Client) error {
+		iter := node.Scan(ctx, 0, legacyKeyPattern, migrateScanCount).Iterator()
+		for iter.Next(ctx) {
+			legacy := iter.Val()
+			key, ok := MigratedKey(legacy)
+			if !ok {
+				continue
+			}
+			result.Scanned++
+
+			if dryRun {
+				continue
+			}
+
+			members, err := m.migrateKey(ctx, legacy, key)
+			if err != nil {
+				return fmt.Errorf("migrate %s: %w", legacy, err)
+			}
+			result.Migrated++
+			result.Members += members
+		}
+
+		return iter.Err()
+	}
+
+	var err error
+	switch client := m.client.(type) {
+	case *redis.ClusterClient:
+		err = forEachMaster(ctx, client, migrateNode)
+	case *redis.Client:
+		err = migrateNode(ctx, client)
+	default:
+		err = fmt.Errorf("unsupported redis client %T", m.client)
+	}
+
+	return result, err
+}
+
+// forEachMaster calls fn for one master after another, unlike ClusterClient.ForEachMaster
+// which runs them concurrently.
+func forEachMaster(ctx context.Context, client *redis.ClusterClient, fn func(context.Context, *redis.Client) error) error {
+	var masters []*redis.Client
+	if err := client.ForEachMaster(ctx, func(_ context.Context, node *redis.Client) error {
+		masters = append(masters, node)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, node := range masters {
+		if err := fn(ctx, node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *KeyMigrator) migrateKey(ctx context.Context, legacy, key string) (int64, error) {
+	log := logger.L()
+
+	keyType, err := m.client.Type(ctx, legacy).Result()
+	if err != nil {
+		return 0, fmt.Errorf("type: %w", err)
+	}
+	if keyType != "zset" {
+		log.Warn("skipping legacy leaderboard key that is not a sorted set",
+			slog.String("key", legacy),
+			slog.String("type", keyType))
+		return 0, nil
+	}
+
+	// A marker means the merge already happened, only the legacy key is left to delete
+	marker := key + migrateMarkerPart
+	merged, err := m.client.Exists(ctx, marker).Result()
+	if err != nil {
+		return 0, fmt.Errorf("exists: %w", err)
+	}
+	if merged > 0 {
+		if err := m.client.Del(ctx, legacy).Err(); err != nil {
+			return 0, fmt.Errorf("del: %w", err)
+		}
+		return 0, nil
+	}
+
+	legacyTTL, err := m.client.PTTL(ctx, legacy).Result()
+	if err != nil {
+		return 0, fmt.Errorf("pttl: %w", err)
+	}
+
+	// Step 1: copy into the staging key; ZADD overwrites, so a retry copies the same scores
+	staging := key + migrateStagingPart
+	var copied int64
+	for start := int64(0); ; start += migrateCopyBatch {
+		members, err := m.client.ZRangeWithScores(ctx, legacy, start, start+migrateCopyBatch-1).Result()
+		if err != nil {
+			return 0, fmt.Errorf("zrange: %w", err)
+		}
+		if len(members) == 0 {
+			break
+		}
+
+		if err := m.client.ZAdd(ctx, staging, members...).Err(); err != nil {
+			return 0, fmt.Errorf("zadd staging: %w", err)
+		}
+		copied += int64(len(members))
+
+		if len(members) < migrateCopyBatch {
+			break
+		}
+	}
+
+	// Step 2: merge into the new key, keeping its expiry or taking over the legacy one
+	newTTL, err := m.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("pttl: %w", err)
+	}
+
+	var expireAt time.Time
+	switch {
+	case newTTL > 0:
+		expireAt = time.Now().Add(newTTL)
+	case legacyTTL > 0:
+		expireAt = time.Now().Add(legacyTTL)
+	}
+
+	_, err = m.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(ctx, key, &redis.ZStore{Keys: []string{key, staging}, Aggregate: "SUM"})
+		if !expireAt.IsZero() {
+			pipe.PExpireAt(ctx, key, expireAt)
+		}
+		pipe.Del(ctx, staging)
+		pipe.Set(ctx, marker, legacy, migrateMarkerTTL)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("merge: %w", err)
+	}
+
+	// Step 3: drop the legacy key
+	if err := m.client.Del(ctx, legacy).Err(); err != nil {
+		return copied, fmt.Errorf("del: %w", err)
+	}
+
+	log.Info("migrated leaderboard key",
+		slog.String("from", legacy),
+		slog.String("to", key),
+		slog.Int64("members", copied))
+
+	return copied, nil
+}
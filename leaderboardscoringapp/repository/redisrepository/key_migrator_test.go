@@ -0,0 +1,38 @@
+package redisrepository_test
+
+import (
+	"testing"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/redisrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigratedKey(t *testing.T) {
+	tests := []struct {
+		legacy string
+		want   string
+		ok     bool
+	}{
+		{"leaderboard:global:all_time", "leaderboard:{global}:all_time", true},
+		{"leaderboard:1001:daily:2025-06-01", "leaderboard:{1001}:daily:2025-06-01", true},
+		{"leaderboard:1001:weekly:2025-W23", "leaderboard:{1001}:weekly:2025-W23", true},
+		{"leaderboard:{1001}:all_time", "", false},
+		{"leaderboard:{1001}:all_time:migrated", "", false},
+		{"leaderboard", "", false},
+		{"leaderboard:global", "", false},
+		{"public_leaderboard:project:1", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := redisrepository.MigratedKey(tt.legacy)
+		assert.Equal(t, tt.ok, ok, tt.legacy)
+		assert.Equal(t, tt.want, got, tt.legacy)
+	}
+}
+
+func TestMigratedKey_MatchesServiceLayout(t *testing.T) {
+	got, ok := redisrepository.MigratedKey("leaderboard:1001:monthly:2025-06")
+	assert.True(t, ok)
+	assert.Equal(t, leaderboardscoring.LeaderboardKey("1001", "monthly", "2025-06"), got)
+}
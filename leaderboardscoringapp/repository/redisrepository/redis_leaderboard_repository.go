@@ -2,27 +2,135 @@ package redisrepository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	redisadapter "github.com/gocasters/rankr/adapter/redis"
 	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 	"github.com/gocasters/rankr/pkg/logger"
-	"github.com/gocasters/rankr/pkg/timettl"
 	"github.com/redis/go-redis/v9"
 	"log/slog"
+	"math"
+	"strconv"
 	"time"
 )
 
-// RedisLeaderboardRepository manages leaderboard using Redis Sorted Sets (ZSET)
+// upsertFirstReachedLua sets the member of a first_reached board to its new total plus the
+// reach time fraction, and sets the expiry when the key has none, like upsertWithExpiration.
+// Scores are formatted with %.17g, Lua's default formatting would round the fraction away.
+// ARGV[5] = "1" clamps the total at zero and skips a missing member, see UpsertScore.ClampAtZero.
+var upsertFirstReachedLua = redis.NewScript(`
+local total = tonumber(ARGV[2])
+local current = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if current then
+  total = total + math.floor(tonumber(current))
+elseif ARGV[5] == "1" then
+  return 0
+end
+if ARGV[5] == "1" and total < 0 then
+  total = 0
+end
+redis.call("ZADD", KEYS[1], string.format("%.17g", total + tonumber(ARGV[3])), ARGV[1])
+
+local expire_at = tonumber(ARGV[4])
+if expire_at > 0 and redis.call("TTL", KEYS[1]) < 0 then
+  redis.call("EXPIREAT", KEYS[1], expire_at)
+end
+return total
+`)
+
+// upsertClampedLua adds ARGV[2] to the member ARGV[1] without going below zero, and sets
+// the expiry ARGV[3] when the key has none, like upsertWithExpiration. A missing member is
+// left out, there is nothing to take away from it.
+var upsertClampedLua = redis.NewScript(`
+local current = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if not current then
+  return 0
+end
+
+local total = tonumber(current) + tonumber(ARGV[2])
+if total < 0 then
+  total = 0
+end
+redis.call("ZADD", KEYS[1], string.format("%.17g", total), ARGV[1])
+
+local expire_at = tonumber(ARGV[3])
+if expire_at > 0 and redis.call("TTL", KEYS[1]) < 0 then
+  redis.call("EXPIREAT", KEYS[1], expire_at)
+end
+return 1
+`)
+
+// countDistinctHigherLua counts the distinct whole scores of at least ARGV[1], jumping
+// from one score to the next lower one, so it costs one lookup per distinct score.
+var countDistinctHigherLua = redis.NewScript(`
+local count = 0
+local max = "+inf"
+while true do
+  local top = redis.call("ZREVRANGEBYSCORE", KEYS[1], max, ARGV[1], "WITHSCORES", "LIMIT", 0, 1)
+  if #top == 0 then
+    return count
+  end
+  count = count + 1
+  max = "(" .. string.format("%.17g", math.floor(tonumber(top[2])))
+end
+`)
+
+// upsertTrendingLua adds a contribution to a trending board once per event, see
+// leaderboardscoring.TrendingWeight and TrendingRebase. KEYS are the board, its base and
+// the event marker, ARGV the member, score, half-lives, marker TTL in milliseconds (0
+// counts every call), the rebase threshold and "1" to clamp at zero like upsertClampedLua.
+// It returns 0 for an event already counted.
+var upsertTrendingLua = redis.NewScript(`
+if tonumber(ARGV[4]) > 0 and not redis.call("SET", KEYS[3], 1, "NX", "PX", ARGV[4]) then
+  return 0
+end
+
+local half_lives = tonumber(ARGV[3])
+local base = tonumber(redis.call("GET", KEYS[2]) or "0")
+if half_lives - base > tonumber(ARGV[5]) then
+  local rebased = math.floor(half_lives)
+  local scale = 2 ^ (base - rebased)
+  local members = redis.call("ZRANGE", KEYS[1], 0, -1, "WITHSCORES")
+  for i = 1, #members, 2 do
+    redis.call("ZADD", KEYS[1], string.format("%.17g", tonumber(members[i + 1]) * scale), members[i])
+  end
+  base = rebased
+  redis.call("SET", KEYS[2], string.format("%.17g", base))
+end
+
+local weight = tonumber(ARGV[2]) * 2 ^ (half_lives - base)
+if ARGV[6] ~= "1" then
+  redis.call("ZINCRBY", KEYS[1], string.format("%.17g", weight), ARGV[1])
+  return 1
+end
+
+local current = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if current then
+  local total = tonumber(current) + weight
+  if total < 0 then
+    total = 0
+  end
+  redis.call("ZADD", KEYS[1], string.format("%.17g", total), ARGV[1])
+end
+return 1
+`)
+
+// RedisLeaderboardRepository manages leaderboard using Redis Sorted Sets (ZSET).
+//
+// It works on standalone Redis and on Redis Cluster. Commands are grouped by hash slot,
+// each group runs as one MULTI/EXEC transaction, which Redis Cluster only allows within
+// a single slot.
 type RedisLeaderboardRepository struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
-func NewRedisLeaderboardRepository(client *redis.Client) leaderboardscoring.LeaderboardCache {
+func NewRedisLeaderboardRepository(client redis.UniversalClient) leaderboardscoring.LeaderboardCache {
 	return &RedisLeaderboardRepository{
 		client: client,
 	}
 }
 
-func (r *RedisLeaderboardRepository) UpsertScores(ctx context.Context, score *leaderboardscoring.UpsertScore, timeframe leaderboardscoring.Timeframe) error {
+func (r *RedisLeaderboardRepository) UpsertScores(ctx context.Context, score *leaderboardscoring.UpsertScore) error {
 	log := logger.L()
 
 	if score == nil {
@@ -37,33 +145,46 @@ func (r *RedisLeaderboardRepository) UpsertScores(ctx context.Context, score *le
 		return nil
 	}
 
+	if !score.ReachedAt.IsZero() {
+		return r.upsertFirstReached(ctx, score)
+	}
+
+	if score.ClampAtZero {
+		return r.upsertClamped(ctx, score)
+	}
+
 	// For all_time, no expiration needed
-	if timeframe == leaderboardscoring.AllTime {
+	if score.ExpireAt.IsZero() {
 		return r.upsertWithoutExpiration(ctx, score)
 	}
 
-	// Calculate expiration time for the end of the period
-	expirationTime, err := timettl.CalculateEndOfPeriod(timeframe.String())
-	if err != nil {
-		log.Error("failed to calculate expiration time",
-			slog.String("timeframe", timeframe.String()),
-			slog.String("error", err.Error()))
-		return fmt.Errorf("calculate expiration: %w", err)
+	return r.upsertWithExpiration(ctx, score, score.ExpireAt)
+}
+
+// forEachSlot calls fn once per group of keys that share a hash slot
+func forEachSlot(keys []string, fn func(keys []string) error) error {
+	for _, group := range redisadapter.GroupBySlot(keys) {
+		if err := fn(group); err != nil {
+			return err
+		}
 	}
 
-	return r.upsertWithExpiration(ctx, score, expirationTime)
+	return nil
 }
 
 // upsertWithoutExpiration for all_time leaderboards (no TTL)
 func (r *RedisLeaderboardRepository) upsertWithoutExpiration(ctx context.Context, score *leaderboardscoring.UpsertScore) error {
 	log := logger.L()
-	pipe := r.client.Pipeline()
 
-	for _, key := range score.Keys {
-		pipe.ZIncrBy(ctx, key, float64(score.Score), score.UserID)
-	}
-
-	_, err := pipe.Exec(ctx)
+	err := forEachSlot(score.Keys, func(keys []string) error {
+		_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.ZIncrBy(ctx, key, float64(score.Score), score.UserID)
+			}
+			return nil
+		})
+		return err
+	})
 	if err != nil {
 		log.Error("failed to update all_time scores",
 			slog.String("user_id", score.UserID),
@@ -83,45 +204,106 @@ func (r *RedisLeaderboardRepository) upsertWithoutExpiration(ctx context.Context
 func (r *RedisLeaderboardRepository) upsertWithExpiration(ctx context.Context, score *leaderboardscoring.UpsertScore, expirationTime time.Time) error {
 	log := logger.L()
 
-	// Step 1: Get TTL for all keys in batch
-	pipe := r.client.Pipeline()
-	ttlCmds := make([]*redis.DurationCmd, len(score.Keys))
+	err := forEachSlot(score.Keys, func(keys []string) error {
+		// Step 1: Get TTL for all keys of the slot
+		pipe := r.client.Pipeline()
+		ttlCmds := make([]*redis.DurationCmd, len(keys))
 
-	for i, key := range score.Keys {
-		ttlCmds[i] = pipe.TTL(ctx, key)
-	}
+		for i, key := range keys {
+			ttlCmds[i] = pipe.TTL(ctx, key)
+		}
+
+		if _, err := pipe.Exec(ctx); err != nil {
+			return fmt.Errorf("ttl pipeline: %w", err)
+		}
+
+		// Step 2: Increment scores and set expiration where needed
+		_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range keys {
+				// Always increment score
+				pipe.ZIncrBy(ctx, key, float64(score.Score), score.UserID)
 
-	_, err := pipe.Exec(ctx)
+				// Set expiration only if key doesn't have TTL
+				ttl := ttlCmds[i].Val()
+				if ttl == -2*time.Second || ttl == -1*time.Second {
+					pipe.ExpireAt(ctx, key, expirationTime)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("update pipeline: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		log.Error("failed to get TTLs",
+		log.Error("failed to update scores with expiration",
 			slog.String("user_id", score.UserID),
 			slog.String("error", err.Error()))
-		return fmt.Errorf("ttl pipeline: %w", err)
+		return err
 	}
 
-	// Step 2: Increment scores and set expiration where needed
-	pipe = r.client.Pipeline()
+	log.Debug("successfully updated scores with expiration",
+		slog.String("user_id", score.UserID),
+		slog.Int64("score", score.Score),
+		slog.Int("keys_count", len(score.Keys)))
+
+	return nil
+}
+
+// upsertFirstReached updates the keys of first_reached boards one at a time, each with a
+// single-key script, so it also works across the slots of a Redis Cluster.
+func (r *RedisLeaderboardRepository) upsertFirstReached(ctx context.Context, score *leaderboardscoring.UpsertScore) error {
+	log := logger.L()
 
-	for i, key := range score.Keys {
-		// Always increment score
-		pipe.ZIncrBy(ctx, key, float64(score.Score), score.UserID)
+	var expireAt int64
+	if !score.ExpireAt.IsZero() {
+		expireAt = score.ExpireAt.Unix()
+	}
+	fraction := leaderboardscoring.FirstReachedFraction(score.ReachedAt)
 
-		// Set expiration only if key doesn't have TTL
-		ttl := ttlCmds[i].Val()
-		if ttl == -2*time.Second || ttl == -1*time.Second {
-			pipe.ExpireAt(ctx, key, expirationTime)
+	for _, key := range score.Keys {
+		err := upsertFirstReachedLua.Run(ctx, r.client, []string{key},
+			score.UserID, score.Score, strconv.FormatFloat(fraction, 'g', -1, 64), expireAt, luaFlag(score.ClampAtZero)).Err()
+		if err != nil {
+			log.Error("failed to update first_reached score",
+				slog.String("key", key),
+				slog.String("user_id", score.UserID),
+				slog.String("error", err.Error()))
+			return fmt.Errorf("upsert first_reached: %w", err)
 		}
 	}
 
-	_, err = pipe.Exec(ctx)
-	if err != nil {
-		log.Error("failed to update scores with expiration",
-			slog.String("user_id", score.UserID),
-			slog.String("error", err.Error()))
-		return fmt.Errorf("update pipeline: %w", err)
+	log.Debug("successfully updated first_reached scores",
+		slog.String("user_id", score.UserID),
+		slog.Int64("score", score.Score),
+		slog.Int("keys_count", len(score.Keys)))
+
+	return nil
+}
+
+// upsertClamped updates the keys one at a time with a single-key script, like
+// upsertFirstReached, so the zero floor holds against concurrent updates.
+func (r *RedisLeaderboardRepository) upsertClamped(ctx context.Context, score *leaderboardscoring.UpsertScore) error {
+	log := logger.L()
+
+	var expireAt int64
+	if !score.ExpireAt.IsZero() {
+		expireAt = score.ExpireAt.Unix()
 	}
 
-	log.Debug("successfully updated scores with expiration",
+	for _, key := range score.Keys {
+		if err := upsertClampedLua.Run(ctx, r.client, []string{key}, score.UserID, score.Score, expireAt).Err(); err != nil {
+			log.Error("failed to update clamped score",
+				slog.String("key", key),
+				slog.String("user_id", score.UserID),
+				slog.String("error", err.Error()))
+			return fmt.Errorf("upsert clamped: %w", err)
+		}
+	}
+
+	log.Debug("successfully updated clamped scores",
 		slog.String("user_id", score.UserID),
 		slog.Int64("score", score.Score),
 		slog.Int("keys_count", len(score.Keys)))
@@ -129,21 +311,180 @@ func (r *RedisLeaderboardRepository) upsertWithExpiration(ctx context.Context, s
 	return nil
 }
 
+// luaFlag passes a bool to a script
+func luaFlag(set bool) string {
+	if set {
+		return "1"
+	}
+
+	return "0"
+}
+
+// UpsertTrendingScores adds a boosted contribution to the trending leaderboards (no TTL).
+// Each board is updated by one script, which keeps its base and event markers in its slot.
+func (r *RedisLeaderboardRepository) UpsertTrendingScores(ctx context.Context, score *leaderboardscoring.TrendingScore) error {
+	log := logger.L()
+
+	if score == nil || len(score.Keys) == 0 || score.UserID == "" {
+		log.Debug("invalid TrendingScore; skipping upsert")
+		return nil
+	}
+
+	var dedupeTTL int64
+	if score.EventID != "" && score.DedupeTTL > 0 {
+		dedupeTTL = score.DedupeTTL.Milliseconds()
+	}
+
+	counted := 0
+	for _, key := range score.Keys {
+		keys := []string{key, leaderboardscoring.TrendingBaseKey(key), leaderboardscoring.TrendingEventKey(key, score.EventID)}
+		added, err := upsertTrendingLua.Run(ctx, r.client, keys,
+			score.UserID, score.Score, score.HalfLives, dedupeTTL, leaderboardscoring.TrendingRebaseHalfLives, luaFlag(score.ClampAtZero)).Int()
+		if err != nil {
+			log.Error("failed to update trending scores",
+				slog.String("user_id", score.UserID),
+				slog.String("key", key),
+				slog.String("error", err.Error()))
+			return fmt.Errorf("upsert trending %s: %w", key, err)
+		}
+		counted += added
+	}
+
+	log.Debug("successfully updated trending scores",
+		slog.String("user_id", score.UserID),
+		slog.String("event_id", score.EventID),
+		slog.Int("keys_count", len(score.Keys)),
+		slog.Int("counted", counted))
+
+	return nil
+}
+
 func (r *RedisLeaderboardRepository) GetLeaderboard(ctx context.Context, leaderboard *leaderboardscoring.LeaderboardQuery) (leaderboardscoring.LeaderboardQueryResult, error) {
+	if leaderboard.Trending {
+		return r.getTrendingLeaderboard(ctx, leaderboard)
+	}
+
 	data, err := r.client.ZRevRangeWithScores(ctx, leaderboard.Key, leaderboard.Start, leaderboard.Stop).Result()
 	if err != nil {
 		return leaderboardscoring.LeaderboardQueryResult{}, fmt.Errorf("zrevrange: %w", err)
 	}
 
+	return leaderboardRows(leaderboard, data, func(stored float64) int64 {
+		return int64(math.Floor(stored))
+	}), nil
+}
+
+// getTrendingLeaderboard reads a trending board together with its base in one transaction,
+// so a concurrent rebase never mixes with the decay of the page
+func (r *RedisLeaderboardRepository) getTrendingLeaderboard(ctx context.Context, leaderboard *leaderboardscoring.LeaderboardQuery) (leaderboardscoring.LeaderboardQueryResult, error) {
+	var (
+		baseCmd  *redis.StringCmd
+		rangeCmd *redis.ZSliceCmd
+	)
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		baseCmd = pipe.Get(ctx, leaderboardscoring.TrendingBaseKey(leaderboard.Key))
+		rangeCmd = pipe.ZRevRangeWithScores(ctx, leaderboard.Key, leaderboard.Start, leaderboard.Stop)
+		return nil
+	})
+	// redis.Nil is returned for a board that was never rebased
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return leaderboardscoring.LeaderboardQueryResult{}, fmt.Errorf("trending pipeline: %w", err)
+	}
+
+	var base float64
+	if err := baseCmd.Err(); err == nil {
+		if base, err = baseCmd.Float64(); err != nil {
+			return leaderboardscoring.LeaderboardQueryResult{}, fmt.Errorf("trending base: %w", err)
+		}
+	}
+
+	data, err := rangeCmd.Result()
+	if err != nil {
+		return leaderboardscoring.LeaderboardQueryResult{}, fmt.Errorf("zrevrange: %w", err)
+	}
+
+	return leaderboardRows(leaderboard, data, func(stored float64) int64 {
+		return int64(math.Round(leaderboardscoring.TrendingDecay(stored, leaderboard.HalfLives, base)))
+	}), nil
+}
+
+func leaderboardRows(leaderboard *leaderboardscoring.LeaderboardQuery, data []redis.Z, score func(stored float64) int64) leaderboardscoring.LeaderboardQueryResult {
 	rows := make([]leaderboardscoring.LeaderboardEntry, 0, len(data))
 	for i, entry := range data {
 		row := leaderboardscoring.LeaderboardEntry{
 			Rank:   leaderboard.Start + int64(i) + 1,
 			UserID: fmt.Sprintf("%v", entry.Member),
-			Score:  int64(entry.Score),
+			Score:  score(entry.Score),
 		}
 		rows = append(rows, row)
 	}
 
-	return leaderboardscoring.LeaderboardQueryResult{LeaderboardRows: rows}, nil
+	return leaderboardscoring.LeaderboardQueryResult{LeaderboardRows: rows}
+}
+
+// GetUserRanks returns the user's 1-based rank and score on each key, with one round trip
+// per hash slot. Keys the user is not a member of get rank 0.
+func (r *RedisLeaderboardRepository) GetUserRanks(ctx context.Context, keys []string, userID string) ([]leaderboardscoring.UserRank, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	rankCmds := make(map[string]*redis.IntCmd, len(keys))
+	scoreCmds := make(map[string]*redis.FloatCmd, len(keys))
+
+	err := forEachSlot(keys, func(slotKeys []string) error {
+		pipe := r.client.Pipeline()
+		for _, key := range slotKeys {
+			rankCmds[key] = pipe.ZRevRank(ctx, key, userID)
+			scoreCmds[key] = pipe.ZScore(ctx, key, userID)
+		}
+
+		// redis.Nil is returned for keys the user is not a member of
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rank pipeline: %w", err)
+	}
+
+	ranks := make([]leaderboardscoring.UserRank, len(keys))
+	for i, key := range keys {
+		ranks[i] = leaderboardscoring.UserRank{Key: key}
+
+		rank, err := rankCmds[key].Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("zrevrank %s: %w", key, err)
+		}
+
+		ranks[i].Rank = rank + 1
+		ranks[i].Score = int64(math.Floor(scoreCmds[key].Val()))
+	}
+
+	return ranks, nil
+}
+
+// CountHigherScores counts the members, or distinct whole scores, of at least score+1.
+// The stored fraction of first_reached boards never lifts a member to the next score.
+func (r *RedisLeaderboardRepository) CountHigherScores(ctx context.Context, key string, score int64, distinct bool) (int64, error) {
+	minScore := strconv.FormatInt(score+1, 10)
+
+	if distinct {
+		count, err := countDistinctHigherLua.Run(ctx, r.client, []string{key}, minScore).Int64()
+		if err != nil {
+			return 0, fmt.Errorf("count distinct scores: %w", err)
+		}
+		return count, nil
+	}
+
+	count, err := r.client.ZCount(ctx, key, minScore, "+inf").Result()
+	if err != nil {
+		return 0, fmt.Errorf("zcount: %w", err)
+	}
+
+	return count, nil
 }
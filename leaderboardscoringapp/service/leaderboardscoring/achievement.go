@@ -0,0 +1,423 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// badgePublishBatchSize is the number of pending awards published per retry run
+const badgePublishBatchSize = 500
+
+// BadgeKind is how a badge rule measures progress
+type BadgeKind string
+
+const (
+	// BadgeCount awards the badge once Threshold matching events were counted
+	BadgeCount BadgeKind = "count"
+	// BadgeStreak awards the badge once matching events happened in Threshold
+	// consecutive days or weeks
+	BadgeStreak BadgeKind = "streak"
+)
+
+// BadgeScope is what a badge rule counts over
+type BadgeScope string
+
+const (
+	BadgeScopeGlobal BadgeScope = "global"
+	// BadgeScopeProject counts the events of each project on their own, the badge is
+	// still awarded once and records the project it was earned in
+	BadgeScopeProject BadgeScope = "project"
+)
+
+// StreakUnit is the period of a streak rule. Periods follow the UTC calendar, weeks
+// start on Monday.
+type StreakUnit string
+
+const (
+	StreakDay  StreakUnit = "day"
+	StreakWeek StreakUnit = "week"
+)
+
+// BadgeRule declares a badge and the events that earn it. An event matches when its type
+// is one of Events and it passes every filter that is set: CloseReason ("merged",
+// "completed", ...) for closed pull requests and issues, Labels (any of them, case
+// insensitive) and Projects.
+type BadgeRule struct {
+	ID          string      `koanf:"id"`
+	Name        string      `koanf:"name"`
+	Description string      `koanf:"description"`
+	Events      []EventName `koanf:"events"`
+	CloseReason string      `koanf:"close_reason"`
+	Labels      []string    `koanf:"labels"`
+	Projects    []string    `koanf:"projects"`
+	Kind        BadgeKind   `koanf:"kind"`
+	Threshold   int64       `koanf:"threshold"`
+	StreakUnit  StreakUnit  `koanf:"streak_unit"`
+	Scope       BadgeScope  `koanf:"scope"`
+}
+
+func (r BadgeRule) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ID, validation.Required, validation.Length(1, 64)),
+		validation.Field(&r.Name, validation.Required),
+		validation.Field(&r.Events, validation.Required, validation.Each(validation.By(func(value interface{}) error {
+			return value.(EventName).Validate()
+		}))),
+		validation.Field(&r.Kind, validation.Required, validation.In(BadgeCount, BadgeStreak)),
+		validation.Field(&r.Threshold, validation.Required, validation.Min(int64(1))),
+		validation.Field(&r.StreakUnit,
+			validation.When(r.Kind == BadgeStreak, validation.Required, validation.In(StreakDay, StreakWeek)).
+				Else(validation.Empty)),
+		validation.Field(&r.Scope, validation.In(BadgeScopeGlobal, BadgeScopeProject)),
+	)
+}
+
+// matches reports whether an event of projectID counts towards the badge
+func (r BadgeRule) matches(req *EventRequest, projectID string) bool {
+	if !containsEvent(r.Events, EventName(req.EventName)) {
+		return false
+	}
+	if len(r.Projects) > 0 && !containsString(r.Projects, projectID) {
+		return false
+	}
+	if r.CloseReason != "" && eventCloseReason(req.Payload) != r.CloseReason {
+		return false
+	}
+	if len(r.Labels) > 0 && !hasAnyLabel(eventLabels(req.Payload), r.Labels) {
+		return false
+	}
+
+	return true
+}
+
+// scopeKey returns the key progress of an event of projectID is counted under
+func (r BadgeRule) scopeKey(projectID string) string {
+	if r.Scope == BadgeScopeProject {
+		return projectID
+	}
+
+	return globalScope
+}
+
+// period returns the streak period at falls into, as days or weeks since the Unix epoch
+func (r BadgeRule) period(at time.Time) int64 {
+	if r.Kind != BadgeStreak {
+		return 0
+	}
+
+	days := at.UTC().Unix() / int64(24*time.Hour/time.Second)
+	if r.StreakUnit == StreakWeek {
+		// 1970-01-01 was a Thursday, shift so that weeks start on Monday
+		return (days + 3) / 7
+	}
+
+	return days
+}
+
+// reached reports whether the progress earns the badge
+func (r BadgeRule) reached(progress BadgeProgress) bool {
+	if r.Kind == BadgeStreak {
+		return progress.Streak >= r.Threshold
+	}
+
+	return progress.Count >= r.Threshold
+}
+
+// AchievementConfig declares the badges contributors can earn
+type AchievementConfig struct {
+	Badges []BadgeRule `koanf:"badges"`
+}
+
+func (c AchievementConfig) Validate() error {
+	seen := make(map[string]bool, len(c.Badges))
+	for i, rule := range c.Badges {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("achievements.badges[%d]: %w", i, err)
+		}
+		if seen[rule.ID] {
+			return fmt.Errorf("achievements.badges[%d]: duplicate badge id %q", i, rule.ID)
+		}
+		seen[rule.ID] = true
+	}
+
+	return nil
+}
+
+// BadgeProgress is the state of a badge rule for one user and scope
+type BadgeProgress struct {
+	Count int64
+	// Streak is the number of consecutive periods up to the latest one with an event
+	Streak int64
+}
+
+// BadgeAward is a badge held by a user. Name and Description come from the current rule,
+// a badge whose rule was removed keeps its ID as name.
+type BadgeAward struct {
+	UserID      string
+	BadgeID     string
+	Name        string
+	Description string
+	// ProjectID is the project the badge was earned in, empty for global badges
+	ProjectID string
+	// EventID is the event that earned the badge
+	EventID   string
+	AwardedAt time.Time
+	// PublishedAt is zero until the badge-awarded event was published
+	PublishedAt time.Time
+}
+
+// BadgeAwarded is published once for every awarded badge
+type BadgeAwarded struct {
+	UserID      string    `json:"user_id"`
+	BadgeID     string    `json:"badge_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	EventID     string    `json:"event_id"`
+	AwardedAt   time.Time `json:"awarded_at"`
+}
+
+// AchievementStore keeps the progress of badge rules and the awarded badges.
+type AchievementStore interface {
+	// RecordBadgeProgress counts one matching event in period and returns the new progress.
+	// The streak grows when period follows the latest recorded one and restarts after a gap,
+	// an event of an earlier period only adds to the count.
+	RecordBadgeProgress(ctx context.Context, userID, badgeID, scopeKey string, period int64) (BadgeProgress, error)
+	// AwardBadge stores the award, it returns false when the user already holds the badge
+	AwardBadge(ctx context.Context, award BadgeAward) (bool, error)
+	// ListUserBadges returns the badges of a user, earliest first
+	ListUserBadges(ctx context.Context, userID string) ([]BadgeAward, error)
+	// ListUnpublishedBadgeAwards returns up to limit awards whose event is not published yet
+	ListUnpublishedBadgeAwards(ctx context.Context, limit int) ([]BadgeAward, error)
+	MarkBadgeAwardPublished(ctx context.Context, userID, badgeID string, at time.Time) error
+}
+
+// BadgeAwardedPublisher publishes the badge-awarded event
+type BadgeAwardedPublisher interface {
+	PublishBadgeAwarded(ctx context.Context, event BadgeAwarded) error
+}
+
+// AchievementService evaluates the badge rules against the raw event stream and awards
+// every badge at most once per user.
+type AchievementService struct {
+	rules     []BadgeRule
+	byID      map[string]BadgeRule
+	store     AchievementStore
+	publisher BadgeAwardedPublisher
+	validator Validator
+}
+
+func NewAchievementService(
+	config AchievementConfig,
+	store AchievementStore,
+	publisher BadgeAwardedPublisher,
+	validator Validator,
+) *AchievementService {
+	byID := make(map[string]BadgeRule, len(config.Badges))
+	for _, rule := range config.Badges {
+		byID[rule.ID] = rule
+	}
+
+	return &AchievementService{
+		rules:     config.Badges,
+		byID:      byID,
+		store:     store,
+		publisher: publisher,
+		validator: validator,
+	}
+}
+
+// ListBadges returns the badges contributors can earn
+func (s *AchievementService) ListBadges() []BadgeRule {
+	return s.rules
+}
+
+// ListUserBadges returns the badges a user holds, earliest first
+func (s *AchievementService) ListUserBadges(ctx context.Context, req ListUserBadgesRequest) ([]BadgeAward, error) {
+	if err := s.validator.ValidateListUserBadges(req); err != nil {
+		return nil, errors.Join(ErrInvalidArguments, err)
+	}
+
+	awards, err := s.store.ListUserBadges(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("list user badges: %w", err)
+	}
+
+	for i := range awards {
+		awards[i] = s.describe(awards[i])
+	}
+
+	return awards, nil
+}
+
+// EvaluateEvent records the progress of every rule the event matches and awards the
+// badges it completes. An award whose event fails to publish is stored anyway, the
+// event is retried by PublishPendingAwards.
+func (s *AchievementService) EvaluateEvent(ctx context.Context, req *EventRequest) ([]BadgeAward, error) {
+	projectID := strconv.FormatUint(req.RepositoryID, 10)
+
+	var (
+		awarded []BadgeAward
+		errs    []error
+	)
+	for _, rule := range s.rules {
+		if !rule.matches(req, projectID) {
+			continue
+		}
+
+		award, ok, err := s.evaluate(ctx, rule, req, projectID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("badge %s: %w", rule.ID, err))
+		}
+		if ok {
+			awarded = append(awarded, award)
+		}
+	}
+
+	return awarded, errors.Join(errs...)
+}
+
+// PublishPendingAwards publishes the badge-awarded events that failed to go out, it
+// returns the number of events published
+func (s *AchievementService) PublishPendingAwards(ctx context.Context, now time.Time) (int, error) {
+	awards, err := s.store.ListUnpublishedBadgeAwards(ctx, badgePublishBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list unpublished badge awards: %w", err)
+	}
+
+	published := 0
+	for _, award := range awards {
+		if err := s.publish(ctx, s.describe(award), now); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func (s *AchievementService) evaluate(ctx context.Context, rule BadgeRule, req *EventRequest, projectID string) (BadgeAward, bool, error) {
+	progress, err := s.store.RecordBadgeProgress(ctx, req.UserID, rule.ID, rule.scopeKey(projectID), rule.period(req.Timestamp))
+	if err != nil {
+		return BadgeAward{}, false, fmt.Errorf("record progress: %w", err)
+	}
+	if !rule.reached(progress) {
+		return BadgeAward{}, false, nil
+	}
+
+	award := BadgeAward{
+		UserID:      req.UserID,
+		BadgeID:     rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		EventID:     req.ID,
+		AwardedAt:   time.Now().UTC(),
+	}
+	if rule.Scope == BadgeScopeProject {
+		award.ProjectID = projectID
+	}
+
+	created, err := s.store.AwardBadge(ctx, award)
+	if err != nil {
+		return BadgeAward{}, false, fmt.Errorf("award: %w", err)
+	}
+	if !created {
+		// Already held
+		return BadgeAward{}, false, nil
+	}
+
+	return award, true, s.publish(ctx, award, award.AwardedAt)
+}
+
+func (s *AchievementService) publish(ctx context.Context, award BadgeAward, now time.Time) error {
+	event := BadgeAwarded{
+		UserID:      award.UserID,
+		BadgeID:     award.BadgeID,
+		Name:        award.Name,
+		Description: award.Description,
+		ProjectID:   award.ProjectID,
+		EventID:     award.EventID,
+		AwardedAt:   award.AwardedAt,
+	}
+	if err := s.publisher.PublishBadgeAwarded(ctx, event); err != nil {
+		return fmt.Errorf("publish badge awarded: %w", err)
+	}
+
+	return s.store.MarkBadgeAwardPublished(ctx, award.UserID, award.BadgeID, now.UTC())
+}
+
+// describe fills in the name and description of the rule of an award
+func (s *AchievementService) describe(award BadgeAward) BadgeAward {
+	rule, ok := s.byID[award.BadgeID]
+	if !ok {
+		award.Name = award.BadgeID
+		return award
+	}
+
+	award.Name, award.Description = rule.Name, rule.Description
+	return award
+}
+
+func eventCloseReason(payload EventPayload) string {
+	switch p := payload.(type) {
+	case PullRequestClosedPayload:
+		return p.CloseReason.String()
+	case IssueClosedPayload:
+		return p.CloseReason.String()
+	default:
+		return ""
+	}
+}
+
+func eventLabels(payload EventPayload) []string {
+	switch p := payload.(type) {
+	case PullRequestOpenedPayload:
+		return p.Labels
+	case PullRequestClosedPayload:
+		return p.Labels
+	case IssueOpenedPayload:
+		return p.Labels
+	case IssueClosedPayload:
+		return p.Labels
+	default:
+		return nil
+	}
+}
+
+func hasAnyLabel(labels, wanted []string) bool {
+	for _, label := range labels {
+		for _, w := range wanted {
+			if strings.EqualFold(label, w) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func containsEvent(events []EventName, event EventName) bool {
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
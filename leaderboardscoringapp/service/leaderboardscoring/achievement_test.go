@@ -0,0 +1,278 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type progressKey struct {
+	userID, badgeID, scopeKey string
+}
+
+type progressRow struct {
+	count, period, streak int64
+}
+
+// fakeAchievementStore mirrors the upserts of the achievement tables in memory
+type fakeAchievementStore struct {
+	progress map[progressKey]progressRow
+	awards   []leaderboardscoring.BadgeAward
+}
+
+func newFakeAchievementStore() *fakeAchievementStore {
+	return &fakeAchievementStore{progress: make(map[progressKey]progressRow)}
+}
+
+func (f *fakeAchievementStore) RecordBadgeProgress(_ context.Context, userID, badgeID, scopeKey string, period int64) (leaderboardscoring.BadgeProgress, error) {
+	key := progressKey{userID, badgeID, scopeKey}
+
+	row, ok := f.progress[key]
+	switch {
+	case !ok || period > row.period+1:
+		row.streak = 1
+	case period == row.period+1:
+		row.streak++
+	}
+	row.count++
+	if period > row.period {
+		row.period = period
+	}
+	f.progress[key] = row
+
+	return leaderboardscoring.BadgeProgress{Count: row.count, Streak: row.streak}, nil
+}
+
+func (f *fakeAchievementStore) AwardBadge(_ context.Context, award leaderboardscoring.BadgeAward) (bool, error) {
+	for _, a := range f.awards {
+		if a.UserID == award.UserID && a.BadgeID == award.BadgeID {
+			return false, nil
+		}
+	}
+	award.Name, award.Description = "", ""
+	f.awards = append(f.awards, award)
+
+	return true, nil
+}
+
+func (f *fakeAchievementStore) ListUserBadges(_ context.Context, userID string) ([]leaderboardscoring.BadgeAward, error) {
+	var awards []leaderboardscoring.BadgeAward
+	for _, a := range f.awards {
+		if a.UserID == userID {
+			awards = append(awards, a)
+		}
+	}
+
+	return awards, nil
+}
+
+func (f *fakeAchievementStore) ListUnpublishedBadgeAwards(_ context.Context, limit int) ([]leaderboardscoring.BadgeAward, error) {
+	var awards []leaderboardscoring.BadgeAward
+	for _, a := range f.awards {
+		if a.PublishedAt.IsZero() && len(awards) < limit {
+			awards = append(awards, a)
+		}
+	}
+
+	return awards, nil
+}
+
+func (f *fakeAchievementStore) MarkBadgeAwardPublished(_ context.Context, userID, badgeID string, at time.Time) error {
+	for i, a := range f.awards {
+		if a.UserID == userID && a.BadgeID == badgeID {
+			f.awards[i].PublishedAt = at
+		}
+	}
+
+	return nil
+}
+
+type fakeBadgePublisher struct {
+	events []leaderboardscoring.BadgeAwarded
+	err    error
+}
+
+func (f *fakeBadgePublisher) PublishBadgeAwarded(_ context.Context, event leaderboardscoring.BadgeAwarded) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, event)
+
+	return nil
+}
+
+var testBadges = leaderboardscoring.AchievementConfig{Badges: []leaderboardscoring.BadgeRule{
+	{
+		ID:          "first-merged-pr",
+		Name:        "First merged PR",
+		Events:      []leaderboardscoring.EventName{leaderboardscoring.PullRequestClosed},
+		CloseReason: "merged",
+		Kind:        leaderboardscoring.BadgeCount,
+		Threshold:   1,
+	},
+	{
+		ID:        "bug-hunter",
+		Name:      "Bug hunter",
+		Events:    []leaderboardscoring.EventName{leaderboardscoring.IssueClosed},
+		Labels:    []string{"bug"},
+		Kind:      leaderboardscoring.BadgeCount,
+		Threshold: 2,
+		Scope:     leaderboardscoring.BadgeScopeProject,
+	},
+	{
+		ID:         "daily-reviewer",
+		Name:       "Daily reviewer",
+		Events:     []leaderboardscoring.EventName{leaderboardscoring.PullRequestReview},
+		Kind:       leaderboardscoring.BadgeStreak,
+		Threshold:  3,
+		StreakUnit: leaderboardscoring.StreakDay,
+	},
+}}
+
+var badgeDay = time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+
+var badgeEventSeq int
+
+func badgeEvent(userID string, repositoryID uint64, at time.Time, payload leaderboardscoring.EventPayload) *leaderboardscoring.EventRequest {
+	badgeEventSeq++
+
+	return &leaderboardscoring.EventRequest{
+		ID:           "evt-" + strconv.Itoa(badgeEventSeq),
+		UserID:       userID,
+		EventName:    payload.EventType(),
+		RepositoryID: repositoryID,
+		Timestamp:    at,
+		Payload:      payload,
+	}
+}
+
+func newAchievementService(t *testing.T) (*leaderboardscoring.AchievementService, *fakeAchievementStore, *fakeBadgePublisher) {
+	t.Helper()
+	require.NoError(t, testBadges.Validate())
+
+	store, publisher := newFakeAchievementStore(), &fakeBadgePublisher{}
+	svc := leaderboardscoring.NewAchievementService(testBadges, store, publisher, leaderboardscoring.NewValidator())
+
+	return svc, store, publisher
+}
+
+func TestAchievementService_AwardsOncePerUser(t *testing.T) {
+	svc, _, publisher := newAchievementService(t)
+	ctx := context.Background()
+
+	closed := badgeEvent("7", 1001, badgeDay, leaderboardscoring.PullRequestClosedPayload{CloseReason: leaderboardscoring.PrCloseReasonClosedWithoutMerge})
+	awarded, err := svc.EvaluateEvent(ctx, closed)
+	require.NoError(t, err)
+	assert.Empty(t, awarded, "a PR closed without merge does not count")
+
+	merged := badgeEvent("7", 1001, badgeDay, leaderboardscoring.PullRequestClosedPayload{CloseReason: leaderboardscoring.PrCloseReasonMerged})
+	awarded, err = svc.EvaluateEvent(ctx, merged)
+	require.NoError(t, err)
+	require.Len(t, awarded, 1)
+	assert.Equal(t, "first-merged-pr", awarded[0].BadgeID)
+	assert.Equal(t, merged.ID, awarded[0].EventID)
+	assert.Empty(t, awarded[0].ProjectID)
+
+	awarded, err = svc.EvaluateEvent(ctx, badgeEvent("7", 1001, badgeDay, leaderboardscoring.PullRequestClosedPayload{CloseReason: leaderboardscoring.PrCloseReasonMerged}))
+	require.NoError(t, err)
+	assert.Empty(t, awarded)
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, "First merged PR", publisher.events[0].Name)
+}
+
+func TestAchievementService_ProjectScope(t *testing.T) {
+	svc, _, _ := newAchievementService(t)
+	ctx := context.Background()
+
+	bug := leaderboardscoring.IssueClosedPayload{Labels: []string{"Bug"}}
+
+	// one bug in each of two projects does not reach the per-project threshold
+	for _, repo := range []uint64{1001, 1002} {
+		awarded, err := svc.EvaluateEvent(ctx, badgeEvent("7", repo, badgeDay, bug))
+		require.NoError(t, err)
+		assert.Empty(t, awarded)
+	}
+
+	awarded, err := svc.EvaluateEvent(ctx, badgeEvent("7", 1002, badgeDay, leaderboardscoring.IssueClosedPayload{Labels: []string{"docs"}}))
+	require.NoError(t, err)
+	assert.Empty(t, awarded, "events without the label do not count")
+
+	awarded, err = svc.EvaluateEvent(ctx, badgeEvent("7", 1002, badgeDay, bug))
+	require.NoError(t, err)
+	require.Len(t, awarded, 1)
+	assert.Equal(t, "bug-hunter", awarded[0].BadgeID)
+	assert.Equal(t, "1002", awarded[0].ProjectID)
+}
+
+func TestAchievementService_Streak(t *testing.T) {
+	svc, _, _ := newAchievementService(t)
+	ctx := context.Background()
+	review := leaderboardscoring.PullRequestReviewPayload{}
+
+	for _, at := range []time.Time{
+		badgeDay,
+		badgeDay.Add(2 * time.Hour), // same day
+		badgeDay.AddDate(0, 0, 1),
+		badgeDay.AddDate(0, 0, 3), // gap, the streak restarts
+		badgeDay.AddDate(0, 0, 4),
+	} {
+		awarded, err := svc.EvaluateEvent(ctx, badgeEvent("7", 1001, at, review))
+		require.NoError(t, err)
+		assert.Empty(t, awarded, "no three consecutive days at %s", at)
+	}
+
+	awarded, err := svc.EvaluateEvent(ctx, badgeEvent("7", 1001, badgeDay.AddDate(0, 0, 5), review))
+	require.NoError(t, err)
+	require.Len(t, awarded, 1)
+	assert.Equal(t, "daily-reviewer", awarded[0].BadgeID)
+}
+
+func TestAchievementService_PublishRetry(t *testing.T) {
+	svc, store, publisher := newAchievementService(t)
+	ctx := context.Background()
+
+	publisher.err = errors.New("nats down")
+	awarded, err := svc.EvaluateEvent(ctx, badgeEvent("7", 1001, badgeDay, leaderboardscoring.PullRequestClosedPayload{CloseReason: leaderboardscoring.PrCloseReasonMerged}))
+	require.Error(t, err)
+	require.Len(t, awarded, 1, "the award is stored although its event failed")
+	assert.True(t, store.awards[0].PublishedAt.IsZero())
+
+	publisher.err = nil
+	published, err := svc.PublishPendingAwards(ctx, badgeDay)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, "First merged PR", publisher.events[0].Name)
+
+	published, err = svc.PublishPendingAwards(ctx, badgeDay)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	badges, err := svc.ListUserBadges(ctx, leaderboardscoring.ListUserBadgesRequest{UserID: "7"})
+	require.NoError(t, err)
+	require.Len(t, badges, 1)
+	assert.Equal(t, "First merged PR", badges[0].Name)
+
+	_, err = svc.ListUserBadges(ctx, leaderboardscoring.ListUserBadgesRequest{})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+}
+
+func TestAchievementConfig_Validate(t *testing.T) {
+	duplicate := leaderboardscoring.AchievementConfig{Badges: []leaderboardscoring.BadgeRule{testBadges.Badges[0], testBadges.Badges[0]}}
+	assert.Error(t, duplicate.Validate())
+
+	streak := testBadges.Badges[2]
+	streak.StreakUnit = ""
+	assert.Error(t, leaderboardscoring.AchievementConfig{Badges: []leaderboardscoring.BadgeRule{streak}}.Validate())
+
+	unknown := testBadges.Badges[0]
+	unknown.Events = []leaderboardscoring.EventName{"pull_request_merged"}
+	assert.Error(t, leaderboardscoring.AchievementConfig{Badges: []leaderboardscoring.BadgeRule{unknown}}.Validate())
+}
@@ -0,0 +1,248 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/timettl"
+)
+
+// AdjustmentScopeAll scopes an adjustment to every board, like a regular event
+const AdjustmentScopeAll = "all"
+
+const (
+	// RuleAdjustment is the rule of the points an admin granted or removed by hand
+	RuleAdjustment = "manual_adjustment"
+	// RuleAdjustmentReversal is the rule of the points that undo an adjustment
+	RuleAdjustmentReversal = "adjustment_reversal"
+)
+
+// Adjustment grants or removes points by hand, e.g. for a conference talk or after abuse.
+// It is scored as a score_adjustment event and kept as an audit record.
+type Adjustment struct {
+	ID        int64
+	UserID    string
+	ProjectID string
+	// Timeframe is the scope: AdjustmentScopeAll, or the timeframe whose boards change
+	Timeframe string
+	// Period is the key of the period the boards of a period timeframe belong to, in the
+	// timezone of the project. It is empty for all and all_time.
+	Period string
+	Points int64
+	Reason string
+	Actor  string
+	// EffectiveAt is the event time of the adjustment, it places the points in Period
+	EffectiveAt time.Time
+	// ReversalOf is the ID of the adjustment this one reverses, zero otherwise
+	ReversalOf int64
+	// ReversedBy is the ID of the reversal of this adjustment, zero while it stands
+	ReversedBy int64
+	CreatedAt  time.Time
+}
+
+// EventID is the ID of the score event of the adjustment
+func (a Adjustment) EventID() string {
+	return "adjustment-" + strconv.FormatInt(a.ID, 10)
+}
+
+// scope returns the timeframe the score event is limited to, empty for every board
+func (a Adjustment) scope() string {
+	if a.Timeframe == AdjustmentScopeAll {
+		return ""
+	}
+
+	return a.Timeframe
+}
+
+// AdjustmentFilter narrows the adjustments listed, empty fields match everything
+type AdjustmentFilter struct {
+	UserID    string
+	ProjectID string
+}
+
+// AdjustmentStore keeps the audit trail of the adjustments
+type AdjustmentStore interface {
+	// CreateAdjustment stores an adjustment and assigns its ID. Storing a second reversal
+	// of the same adjustment fails with ErrAdjustmentReversed.
+	CreateAdjustment(ctx context.Context, adjustment Adjustment) (Adjustment, error)
+	// GetAdjustment fails with ErrAdjustmentNotFound for an unknown ID
+	GetAdjustment(ctx context.Context, id int64) (Adjustment, error)
+	// ListAdjustments returns the adjustments matching filter, latest first
+	ListAdjustments(ctx context.Context, filter AdjustmentFilter, offset, limit int) ([]Adjustment, error)
+}
+
+// AdjustmentScorer scores the points of an adjustment
+type AdjustmentScorer interface {
+	ApplyAdjustment(ctx context.Context, adjustment Adjustment) error
+}
+
+// AdjustmentService applies manual score adjustments through the regular scoring pipeline
+// and keeps an audit record of each.
+type AdjustmentService struct {
+	locations projectLocations
+	store     AdjustmentStore
+	scorer    AdjustmentScorer
+	validator Validator
+}
+
+func NewAdjustmentService(
+	cfg Config,
+	store AdjustmentStore,
+	scorer AdjustmentScorer,
+	validator Validator,
+) *AdjustmentService {
+	return &AdjustmentService{
+		locations: newProjectLocations(cfg.ProjectTimezones),
+		store:     store,
+		scorer:    scorer,
+		validator: validator,
+	}
+}
+
+// CreateAdjustment records an adjustment and scores its points
+func (s *AdjustmentService) CreateAdjustment(ctx context.Context, req CreateAdjustmentRequest) (Adjustment, error) {
+	if err := s.validator.ValidateCreateAdjustment(req); err != nil {
+		return Adjustment{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	effectiveAt, period, err := s.effectiveAt(req, time.Now())
+	if err != nil {
+		return Adjustment{}, err
+	}
+
+	return s.apply(ctx, Adjustment{
+		UserID:      req.UserID,
+		ProjectID:   req.ProjectID,
+		Timeframe:   req.Timeframe,
+		Period:      period,
+		Points:      req.Points,
+		Reason:      req.Reason,
+		Actor:       req.Actor,
+		EffectiveAt: effectiveAt,
+	})
+}
+
+// ReverseAdjustment takes the points of an adjustment back with a reversal of the same
+// scope and period. An adjustment is reversed at most once, a reversal cannot be reversed.
+func (s *AdjustmentService) ReverseAdjustment(ctx context.Context, req ReverseAdjustmentRequest) (Adjustment, error) {
+	if err := s.validator.ValidateReverseAdjustment(req); err != nil {
+		return Adjustment{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	original, err := s.store.GetAdjustment(ctx, req.ID)
+	if err != nil {
+		return Adjustment{}, err
+	}
+	if original.ReversalOf != 0 {
+		return Adjustment{}, errors.Join(ErrInvalidArguments, fmt.Errorf("adjustment %d is a reversal itself", original.ID))
+	}
+	if original.ReversedBy != 0 {
+		return Adjustment{}, ErrAdjustmentReversed
+	}
+
+	return s.apply(ctx, Adjustment{
+		UserID:      original.UserID,
+		ProjectID:   original.ProjectID,
+		Timeframe:   original.Timeframe,
+		Period:      original.Period,
+		Points:      -original.Points,
+		Reason:      req.Reason,
+		Actor:       req.Actor,
+		EffectiveAt: original.EffectiveAt,
+		ReversalOf:  original.ID,
+	})
+}
+
+func (s *AdjustmentService) GetAdjustment(ctx context.Context, id int64) (Adjustment, error) {
+	return s.store.GetAdjustment(ctx, id)
+}
+
+func (s *AdjustmentService) ListAdjustments(ctx context.Context, req ListAdjustmentsRequest) ([]Adjustment, error) {
+	if err := s.validator.ValidateListAdjustments(req); err != nil {
+		return nil, errors.Join(ErrInvalidArguments, err)
+	}
+
+	filter := AdjustmentFilter{UserID: req.UserID, ProjectID: req.ProjectID}
+
+	return s.store.ListAdjustments(ctx, filter, int(req.Offset), int(req.PageSize))
+}
+
+// apply stores the audit record first, its ID makes the score event. A record whose score
+// failed is kept: the boards may hold part of the points, a reversal takes them back.
+func (s *AdjustmentService) apply(ctx context.Context, adjustment Adjustment) (Adjustment, error) {
+	created, err := s.store.CreateAdjustment(ctx, adjustment)
+	if err != nil {
+		return Adjustment{}, err
+	}
+
+	if err := s.scorer.ApplyAdjustment(ctx, created); err != nil {
+		return created, fmt.Errorf("adjustment %d was recorded but not applied: %w", created.ID, err)
+	}
+
+	return created, nil
+}
+
+// effectiveAt returns the event time of a new adjustment and the period it lands in. A
+// past period starts at different times for the project board and the UTC global board,
+// the later start lies within both.
+func (s *AdjustmentService) effectiveAt(req CreateAdjustmentRequest, now time.Time) (time.Time, string, error) {
+	switch req.Timeframe {
+	case AdjustmentScopeAll, AllTime.String():
+		return now, "", nil
+	}
+
+	local := now.In(s.locations.of(req.ProjectID))
+	if req.Period == "" {
+		period, err := timettl.PeriodKeyAt(req.Timeframe, local)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		return now, period, nil
+	}
+
+	start, err := boardTime(req.Timeframe, req.Period, local)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	utcStart, err := timettl.StartOfPeriod(req.Timeframe, req.Period, time.UTC)
+	if err != nil {
+		return time.Time{}, "", errors.Join(ErrInvalidArguments, err)
+	}
+	if utcStart.After(start) {
+		start = utcStart
+	}
+	if start.After(now) {
+		start = now
+	}
+
+	return start, req.Period, nil
+}
+
+// ApplyAdjustment scores an adjustment like an event of its project, limited to the boards
+// of its scope. The reason goes along as the source reference of the score event. Points
+// taken away leave each board at zero at least, see UpsertScore.ClampAtZero.
+func (s *Service) ApplyAdjustment(ctx context.Context, adjustment Adjustment) error {
+	repositoryID, err := strconv.ParseUint(adjustment.ProjectID, 10, 64)
+	if err != nil {
+		return errors.Join(ErrInvalidArguments, fmt.Errorf("project_id must be a repository ID: %w", err))
+	}
+
+	req := &EventRequest{
+		ID:           adjustment.EventID(),
+		UserID:       adjustment.UserID,
+		EventName:    ScoreAdjustment.String(),
+		RepositoryID: repositoryID,
+		Timestamp:    adjustment.EffectiveAt,
+	}
+
+	source := ScoreSource{Ref: adjustment.Reason, Rule: RuleAdjustment}
+	if adjustment.ReversalOf != 0 {
+		source.Rule = RuleAdjustmentReversal
+	}
+
+	return s.applyScore(ctx, req, adjustment.Points, source, adjustment.scope())
+}
@@ -0,0 +1,221 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/memoryrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAdjustmentStore keeps the audit trail in memory, one reversal per adjustment
+type fakeAdjustmentStore struct {
+	adjustments []leaderboardscoring.Adjustment
+}
+
+func (f *fakeAdjustmentStore) CreateAdjustment(_ context.Context, adjustment leaderboardscoring.Adjustment) (leaderboardscoring.Adjustment, error) {
+	for i, a := range f.adjustments {
+		if adjustment.ReversalOf != 0 && a.ID == adjustment.ReversalOf {
+			if a.ReversedBy != 0 {
+				return leaderboardscoring.Adjustment{}, leaderboardscoring.ErrAdjustmentReversed
+			}
+			f.adjustments[i].ReversedBy = int64(len(f.adjustments) + 1)
+		}
+	}
+
+	adjustment.ID = int64(len(f.adjustments) + 1)
+	f.adjustments = append(f.adjustments, adjustment)
+
+	return adjustment, nil
+}
+
+func (f *fakeAdjustmentStore) GetAdjustment(_ context.Context, id int64) (leaderboardscoring.Adjustment, error) {
+	for _, a := range f.adjustments {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+
+	return leaderboardscoring.Adjustment{}, leaderboardscoring.ErrAdjustmentNotFound
+}
+
+func (f *fakeAdjustmentStore) ListAdjustments(context.Context, leaderboardscoring.AdjustmentFilter, int, int) ([]leaderboardscoring.Adjustment, error) {
+	return f.adjustments, nil
+}
+
+type fakeAdjustmentScorer struct {
+	applied []leaderboardscoring.Adjustment
+	err     error
+}
+
+func (f *fakeAdjustmentScorer) ApplyAdjustment(_ context.Context, adjustment leaderboardscoring.Adjustment) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.applied = append(f.applied, adjustment)
+
+	return nil
+}
+
+func newAdjustmentService() (*leaderboardscoring.AdjustmentService, *fakeAdjustmentStore, *fakeAdjustmentScorer) {
+	cfg := leaderboardscoring.Config{ProjectTimezones: map[string]string{
+		"1001": "Asia/Tokyo",
+		"1002": "America/New_York",
+	}}
+	store, scorer := &fakeAdjustmentStore{}, &fakeAdjustmentScorer{}
+
+	return leaderboardscoring.NewAdjustmentService(cfg, store, scorer, leaderboardscoring.NewValidator()), store, scorer
+}
+
+func adjustmentRequest(timeframe, period string, points int64) leaderboardscoring.CreateAdjustmentRequest {
+	return leaderboardscoring.CreateAdjustmentRequest{
+		UserID:    "7",
+		ProjectID: "1001",
+		Timeframe: timeframe,
+		Period:    period,
+		Points:    points,
+		Reason:    "Talk at GopherCon 2025",
+		Actor:     "admin-1",
+	}
+}
+
+func TestAdjustmentService_CreateAndReverse(t *testing.T) {
+	svc, store, scorer := newAdjustmentService()
+	ctx := context.Background()
+
+	adjustment, err := svc.CreateAdjustment(ctx, adjustmentRequest(leaderboardscoring.AdjustmentScopeAll, "", 50))
+	require.NoError(t, err)
+	assert.Equal(t, "adjustment-1", adjustment.EventID())
+	assert.Empty(t, adjustment.Period)
+	assert.WithinDuration(t, time.Now(), adjustment.EffectiveAt, time.Minute)
+	require.Len(t, scorer.applied, 1)
+
+	reversal, err := svc.ReverseAdjustment(ctx, leaderboardscoring.ReverseAdjustmentRequest{ID: adjustment.ID, Reason: "wrong user", Actor: "admin-2"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-50), reversal.Points)
+	assert.Equal(t, adjustment.ID, reversal.ReversalOf)
+	assert.Equal(t, adjustment.EffectiveAt, reversal.EffectiveAt)
+	assert.Equal(t, leaderboardscoring.AdjustmentScopeAll, reversal.Timeframe)
+	assert.Equal(t, "admin-2", reversal.Actor)
+	require.Len(t, scorer.applied, 2)
+	assert.Equal(t, reversal, scorer.applied[1])
+
+	_, err = svc.ReverseAdjustment(ctx, leaderboardscoring.ReverseAdjustmentRequest{ID: adjustment.ID, Reason: "again", Actor: "admin-2"})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrAdjustmentReversed)
+
+	_, err = svc.ReverseAdjustment(ctx, leaderboardscoring.ReverseAdjustmentRequest{ID: reversal.ID, Reason: "undo the undo", Actor: "admin-2"})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+
+	_, err = svc.ReverseAdjustment(ctx, leaderboardscoring.ReverseAdjustmentRequest{ID: 99, Reason: "unknown", Actor: "admin-2"})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrAdjustmentNotFound)
+
+	assert.Len(t, store.adjustments, 2)
+}
+
+func TestAdjustmentService_PastPeriod(t *testing.T) {
+	svc, _, _ := newAdjustmentService()
+	ctx := context.Background()
+
+	// June starts in Tokyo nine hours before it starts in UTC, the UTC start lies in both
+	adjustment, err := svc.CreateAdjustment(ctx, adjustmentRequest("monthly", "2025-06", -20))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", adjustment.Period)
+	assert.True(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC).Equal(adjustment.EffectiveAt), "effective at %s", adjustment.EffectiveAt)
+
+	// In New York it starts four hours after UTC
+	req := adjustmentRequest("daily", "2025-06-02", 5)
+	req.ProjectID = "1002"
+	adjustment, err = svc.CreateAdjustment(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2025, 6, 2, 4, 0, 0, 0, time.UTC).Equal(adjustment.EffectiveAt), "effective at %s", adjustment.EffectiveAt)
+
+	adjustment, err = svc.CreateAdjustment(ctx, adjustmentRequest("weekly", "", 5))
+	require.NoError(t, err)
+	assert.NotEmpty(t, adjustment.Period, "the current period is recorded")
+}
+
+func TestAdjustmentService_RecordedWhenScoringFails(t *testing.T) {
+	svc, store, scorer := newAdjustmentService()
+	scorer.err = errors.New("redis down")
+
+	adjustment, err := svc.CreateAdjustment(context.Background(), adjustmentRequest(leaderboardscoring.AdjustmentScopeAll, "", 50))
+	require.Error(t, err)
+	assert.Equal(t, int64(1), adjustment.ID)
+	assert.Len(t, store.adjustments, 1)
+}
+
+func TestAdjustmentService_Invalid(t *testing.T) {
+	svc, store, _ := newAdjustmentService()
+	ctx := context.Background()
+
+	invalid := []func(*leaderboardscoring.CreateAdjustmentRequest){
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.UserID = "" },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.ProjectID = "" },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.ProjectID = "rankr" },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.Timeframe = "trending" },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.Timeframe, r.Period = "all_time", "2025" },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.Timeframe, r.Period = "monthly", "2999-01" },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.Points = 0 },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.Points = 1_000_000 },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.Reason = "" },
+		func(r *leaderboardscoring.CreateAdjustmentRequest) { r.Actor = "" },
+	}
+	for i, mutate := range invalid {
+		req := adjustmentRequest(leaderboardscoring.AdjustmentScopeAll, "", 50)
+		mutate(&req)
+
+		_, err := svc.CreateAdjustment(ctx, req)
+		assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments, "case %d", i)
+	}
+	assert.Empty(t, store.adjustments)
+}
+
+// discardPublisher accepts the processed score events
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(context.Context, string, []byte) error {
+	return nil
+}
+
+func TestService_ApplyAdjustmentClampsAtZero(t *testing.T) {
+	cache := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{})
+	svc := leaderboardscoring.NewService(leaderboardscoring.Config{}, nil, cache, discardPublisher{}, "",
+		leaderboardscoring.NewValidator(), nil, nil, nil)
+	ctx := context.Background()
+	projectID := "1001"
+
+	// Points earned last month, the boards of the current periods are empty
+	require.NoError(t, cache.UpsertScores(ctx, &leaderboardscoring.UpsertScore{
+		Keys: []string{"leaderboard:{1001}:all_time"}, UserID: "7", Score: 30,
+	}))
+	require.NoError(t, cache.UpsertScores(ctx, &leaderboardscoring.UpsertScore{
+		Keys: []string{"leaderboard:{global}:all_time"}, UserID: "7", Score: 120,
+	}))
+
+	err := svc.ApplyAdjustment(ctx, leaderboardscoring.Adjustment{
+		ID:          1,
+		UserID:      "7",
+		ProjectID:   projectID,
+		Timeframe:   leaderboardscoring.AdjustmentScopeAll,
+		Points:      -50,
+		EffectiveAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	board := func(timeframe string, projectID *string) []leaderboardscoring.LeaderboardRow {
+		res, err := svc.GetLeaderboard(ctx, &leaderboardscoring.GetLeaderboardRequest{Timeframe: timeframe, ProjectID: projectID, PageSize: 10})
+		require.NoError(t, err)
+		return res.LeaderboardRows
+	}
+
+	assert.Equal(t, []leaderboardscoring.LeaderboardRow{{Rank: 1, UserID: "7", Score: 0}}, board("all_time", &projectID))
+	assert.Equal(t, []leaderboardscoring.LeaderboardRow{{Rank: 1, UserID: "7", Score: 70}}, board("all_time", nil))
+	for _, timeframe := range []string{"daily", "weekly", "monthly", "yearly", "trending"} {
+		assert.Empty(t, board(timeframe, &projectID), timeframe)
+		assert.Empty(t, board(timeframe, nil), timeframe)
+	}
+}
@@ -0,0 +1,304 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeadLetter is a processed score event the batch processor gave up on after its last
+// delivery attempt. Data holds the message as published, Event is nil when Data is not a
+// processed score event, such letters can only be discarded.
+type DeadLetter struct {
+	Sequence      uint64
+	Data          []byte
+	Event         *ProcessedScoreEvent
+	Reason        string
+	DeliveryCount uint64
+	FailedAt      time.Time
+}
+
+// DeadLetterAction is what an operator did with a dead letter
+type DeadLetterAction string
+
+const (
+	DeadLetterReprocessed DeadLetterAction = "reprocessed"
+	DeadLetterDiscarded   DeadLetterAction = "discarded"
+)
+
+// DeadLetterAuditRecord documents a dead letter that left the queue.
+type DeadLetterAuditRecord struct {
+	Sequence      uint64
+	EventID       string
+	Action        DeadLetterAction
+	FailureReason string
+	DeliveryCount uint64
+	Note          string
+	Actor         string
+	Payload       []byte
+	CreatedAt     time.Time
+}
+
+// DeadLetterQueue holds the dead letters of processed score events, oldest first.
+type DeadLetterQueue interface {
+	Add(ctx context.Context, letter DeadLetter) error
+	// List returns up to limit letters with a sequence above after
+	List(ctx context.Context, after uint64, limit int) ([]DeadLetter, error)
+	// Get returns ErrDeadLetterNotFound for unknown sequences
+	Get(ctx context.Context, sequence uint64) (DeadLetter, error)
+	Delete(ctx context.Context, sequence uint64) error
+	Depth(ctx context.Context) (int64, error)
+}
+
+// DeadLetterAudit keeps the audit trail of reprocessed and discarded dead letters
+type DeadLetterAudit interface {
+	AddDeadLetterAudits(ctx context.Context, records []DeadLetterAuditRecord) error
+}
+
+// deadLetterScanSize is the number of letters read per page while scanning the queue
+const deadLetterScanSize = 100
+
+// DLQService lets operators inspect the dead letter queue of processed score events and
+// move its letters back into AddProcessedScoreEvents or discard them.
+type DLQService struct {
+	queue       DeadLetterQueue
+	persistence EventPersistence
+	audit       DeadLetterAudit
+	validator   Validator
+}
+
+func NewDLQService(queue DeadLetterQueue, persistence EventPersistence, audit DeadLetterAudit, validator Validator) *DLQService {
+	return &DLQService{
+		queue:       queue,
+		persistence: persistence,
+		audit:       audit,
+		validator:   validator,
+	}
+}
+
+// DecodeDeadLetter sets letter.Event from letter.Data when it holds a processed score event
+// with an event ID, the idempotency key that makes reprocessing safe.
+func DecodeDeadLetter(letter *DeadLetter) {
+	var event ProcessedScoreEvent
+	if err := json.Unmarshal(letter.Data, &event); err != nil || event.EventID == "" {
+		letter.Event = nil
+		return
+	}
+
+	letter.Event = &event
+}
+
+// Matches reports whether letter passes all set fields of f
+func (f DeadLetterFilter) Matches(letter DeadLetter) bool {
+	if f.Reason != "" && !strings.Contains(strings.ToLower(letter.Reason), strings.ToLower(f.Reason)) {
+		return false
+	}
+	if f.EventID == "" && f.UserID == "" && f.ProjectID == "" {
+		return true
+	}
+	if letter.Event == nil {
+		return false
+	}
+
+	return (f.EventID == "" || letter.Event.EventID == f.EventID) &&
+		(f.UserID == "" || letter.Event.UserID == f.UserID) &&
+		(f.ProjectID == "" || letter.Event.ProjectID == f.ProjectID)
+}
+
+// ListDeadLetters returns a page of the letters matching req.Filter, oldest first.
+func (s *DLQService) ListDeadLetters(ctx context.Context, req ListDeadLettersRequest) (ListDeadLettersResponse, error) {
+	if err := s.validator.ValidateListDeadLettersRequest(req); err != nil {
+		return ListDeadLettersResponse{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultDeadLetterPageSize
+	}
+
+	res := ListDeadLettersResponse{DeadLetters: make([]DeadLetter, 0, limit)}
+	after := req.After
+	for len(res.DeadLetters) < limit {
+		letters, err := s.queue.List(ctx, after, deadLetterScanSize)
+		if err != nil {
+			return ListDeadLettersResponse{}, fmt.Errorf("list dead letters: %w", err)
+		}
+
+		for _, letter := range letters {
+			after = letter.Sequence
+			if !req.Filter.Matches(letter) {
+				continue
+			}
+
+			res.DeadLetters = append(res.DeadLetters, letter)
+			if len(res.DeadLetters) == limit {
+				res.NextAfter = letter.Sequence
+				break
+			}
+		}
+
+		if len(letters) < deadLetterScanSize {
+			break
+		}
+	}
+
+	depth, err := s.queue.Depth(ctx)
+	if err != nil {
+		return ListDeadLettersResponse{}, fmt.Errorf("dead letter depth: %w", err)
+	}
+	res.Depth = depth
+
+	return res, nil
+}
+
+// GetDeadLetter returns a single letter
+func (s *DLQService) GetDeadLetter(ctx context.Context, sequence uint64) (DeadLetter, error) {
+	if sequence == 0 {
+		return DeadLetter{}, errors.Join(ErrInvalidArguments, errors.New("sequence is required"))
+	}
+
+	return s.queue.Get(ctx, sequence)
+}
+
+// Depth returns the number of letters waiting in the queue
+func (s *DLQService) Depth(ctx context.Context) (int64, error) {
+	return s.queue.Depth(ctx)
+}
+
+// ReprocessDeadLetters persists the events of the selected letters again and removes them
+// from the queue. The event ID keeps already persisted events from being counted twice.
+// Letters without a processed score event are skipped and stay in the queue.
+func (s *DLQService) ReprocessDeadLetters(ctx context.Context, req DeadLetterActionRequest) (DeadLetterActionResponse, error) {
+	if err := s.validator.ValidateDeadLetterActionRequest(req, DeadLetterReprocessed); err != nil {
+		return DeadLetterActionResponse{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	var res DeadLetterActionResponse
+	err := s.eachSelected(ctx, req.Selection, func(letters []DeadLetter) error {
+		events := make([]ProcessedScoreEvent, 0, len(letters))
+		reprocessed := make([]DeadLetter, 0, len(letters))
+		for _, letter := range letters {
+			if letter.Event == nil {
+				res.Skipped = append(res.Skipped, DeadLetterSkip{
+					Sequence: letter.Sequence,
+					Reason:   "payload is not a processed score event, it can only be discarded",
+				})
+				continue
+			}
+
+			events = append(events, *letter.Event)
+			reprocessed = append(reprocessed, letter)
+		}
+
+		if err := s.persistence.AddProcessedScoreEvents(ctx, events); err != nil {
+			return fmt.Errorf("persist dead letter events: %w", err)
+		}
+
+		done, err := s.remove(ctx, reprocessed, DeadLetterReprocessed, req)
+		res.Affected += done
+		return err
+	})
+
+	return res, err
+}
+
+// DiscardDeadLetters removes the selected letters from the queue, the audit record keeps
+// their payload.
+func (s *DLQService) DiscardDeadLetters(ctx context.Context, req DeadLetterActionRequest) (DeadLetterActionResponse, error) {
+	if err := s.validator.ValidateDeadLetterActionRequest(req, DeadLetterDiscarded); err != nil {
+		return DeadLetterActionResponse{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	var res DeadLetterActionResponse
+	err := s.eachSelected(ctx, req.Selection, func(letters []DeadLetter) error {
+		done, err := s.remove(ctx, letters, DeadLetterDiscarded, req)
+		res.Affected += done
+		return err
+	})
+
+	return res, err
+}
+
+// eachSelected calls fn with the selected letters, a page at a time. fn may delete the
+// letters it is given, the scan continues after the last sequence it has seen.
+func (s *DLQService) eachSelected(ctx context.Context, sel DeadLetterSelection, fn func([]DeadLetter) error) error {
+	if sel.Sequence != 0 {
+		letter, err := s.queue.Get(ctx, sel.Sequence)
+		if err != nil {
+			return err
+		}
+
+		return fn([]DeadLetter{letter})
+	}
+
+	var after uint64
+	for {
+		letters, err := s.queue.List(ctx, after, deadLetterScanSize)
+		if err != nil {
+			return fmt.Errorf("list dead letters: %w", err)
+		}
+		if len(letters) == 0 {
+			return nil
+		}
+		after = letters[len(letters)-1].Sequence
+
+		selected := make([]DeadLetter, 0, len(letters))
+		for _, letter := range letters {
+			if sel.All || sel.Filter.Matches(letter) {
+				selected = append(selected, letter)
+			}
+		}
+
+		if len(selected) > 0 {
+			if err := fn(selected); err != nil {
+				return err
+			}
+		}
+
+		if len(letters) < deadLetterScanSize {
+			return nil
+		}
+	}
+}
+
+// remove records the audit trail of letters and deletes them from the queue. The audit
+// comes first, a letter that fails to be deleted is reported again by the next run.
+func (s *DLQService) remove(ctx context.Context, letters []DeadLetter, action DeadLetterAction, req DeadLetterActionRequest) (int, error) {
+	if len(letters) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	records := make([]DeadLetterAuditRecord, 0, len(letters))
+	for _, letter := range letters {
+		record := DeadLetterAuditRecord{
+			Sequence:      letter.Sequence,
+			Action:        action,
+			FailureReason: letter.Reason,
+			DeliveryCount: letter.DeliveryCount,
+			Note:          req.Note,
+			Actor:         req.Actor,
+			Payload:       letter.Data,
+			CreatedAt:     now,
+		}
+		if letter.Event != nil {
+			record.EventID = letter.Event.EventID
+		}
+		records = append(records, record)
+	}
+
+	if err := s.audit.AddDeadLetterAudits(ctx, records); err != nil {
+		return 0, fmt.Errorf("audit dead letters: %w", err)
+	}
+
+	for i, letter := range letters {
+		if err := s.queue.Delete(ctx, letter.Sequence); err != nil {
+			return i, fmt.Errorf("delete dead letter %d: %w", letter.Sequence, err)
+		}
+	}
+
+	return len(letters), nil
+}
@@ -0,0 +1,234 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeadLetterQueue keeps letters by sequence
+type fakeDeadLetterQueue struct {
+	letters map[uint64]leaderboardscoring.DeadLetter
+	next    uint64
+}
+
+func newFakeDeadLetterQueue(payloads ...string) *fakeDeadLetterQueue {
+	q := &fakeDeadLetterQueue{letters: make(map[uint64]leaderboardscoring.DeadLetter)}
+	for _, payload := range payloads {
+		_ = q.Add(context.Background(), leaderboardscoring.DeadLetter{Data: []byte(payload), Reason: "persist events: timeout", DeliveryCount: 5})
+	}
+
+	return q
+}
+
+func (q *fakeDeadLetterQueue) Add(_ context.Context, letter leaderboardscoring.DeadLetter) error {
+	q.next++
+	letter.Sequence = q.next
+	leaderboardscoring.DecodeDeadLetter(&letter)
+	q.letters[letter.Sequence] = letter
+	return nil
+}
+
+func (q *fakeDeadLetterQueue) List(_ context.Context, after uint64, limit int) ([]leaderboardscoring.DeadLetter, error) {
+	letters := make([]leaderboardscoring.DeadLetter, 0)
+	for _, letter := range q.letters {
+		if letter.Sequence > after {
+			letters = append(letters, letter)
+		}
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i].Sequence < letters[j].Sequence })
+
+	return letters[:min(limit, len(letters))], nil
+}
+
+func (q *fakeDeadLetterQueue) Get(_ context.Context, sequence uint64) (leaderboardscoring.DeadLetter, error) {
+	letter, ok := q.letters[sequence]
+	if !ok {
+		return leaderboardscoring.DeadLetter{}, leaderboardscoring.ErrDeadLetterNotFound
+	}
+	return letter, nil
+}
+
+func (q *fakeDeadLetterQueue) Delete(_ context.Context, sequence uint64) error {
+	delete(q.letters, sequence)
+	return nil
+}
+
+func (q *fakeDeadLetterQueue) Depth(context.Context) (int64, error) {
+	return int64(len(q.letters)), nil
+}
+
+// fakeEventStore records persisted events
+type fakeEventStore struct {
+	fakeScoreStore
+	events []leaderboardscoring.ProcessedScoreEvent
+	err    error
+}
+
+func (f *fakeEventStore) AddProcessedScoreEvents(_ context.Context, events []leaderboardscoring.ProcessedScoreEvent) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, events...)
+	return nil
+}
+
+type fakeDeadLetterAudit struct {
+	records []leaderboardscoring.DeadLetterAuditRecord
+}
+
+func (f *fakeDeadLetterAudit) AddDeadLetterAudits(_ context.Context, records []leaderboardscoring.DeadLetterAuditRecord) error {
+	f.records = append(f.records, records...)
+	return nil
+}
+
+func event(id, userID, projectID string) string {
+	return fmt.Sprintf(`{"event_id":%q,"user_id":%q,"project_id":%q,"score":1}`, id, userID, projectID)
+}
+
+func TestDLQService_ListDeadLetters(t *testing.T) {
+	queue := newFakeDeadLetterQueue(event("e1", "7", "1001"), "not json", event("e3", "8", "1001"), event("e4", "7", "2002"))
+	svc := leaderboardscoring.NewDLQService(queue, &fakeEventStore{}, &fakeDeadLetterAudit{}, leaderboardscoring.NewValidator())
+	ctx := context.Background()
+
+	res, err := svc.ListDeadLetters(ctx, leaderboardscoring.ListDeadLettersRequest{
+		Filter: leaderboardscoring.DeadLetterFilter{UserID: "7"},
+		Limit:  1,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.DeadLetters, 1)
+	assert.Equal(t, "e1", res.DeadLetters[0].Event.EventID)
+	assert.Equal(t, int64(4), res.Depth)
+
+	next, err := svc.ListDeadLetters(ctx, leaderboardscoring.ListDeadLettersRequest{
+		Filter: leaderboardscoring.DeadLetterFilter{UserID: "7"},
+		After:  res.NextAfter,
+		Limit:  1,
+	})
+	require.NoError(t, err)
+	require.Len(t, next.DeadLetters, 1)
+	assert.Equal(t, "e4", next.DeadLetters[0].Event.EventID)
+
+	byReason, err := svc.ListDeadLetters(ctx, leaderboardscoring.ListDeadLettersRequest{
+		Filter: leaderboardscoring.DeadLetterFilter{Reason: "TIMEOUT"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, byReason.DeadLetters, 4)
+	assert.Zero(t, byReason.NextAfter)
+
+	_, err = svc.ListDeadLetters(ctx, leaderboardscoring.ListDeadLettersRequest{Limit: -1})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+}
+
+func TestDLQService_ReprocessDeadLetters(t *testing.T) {
+	queue := newFakeDeadLetterQueue(event("e1", "7", "1001"), "not json", event("e3", "8", "1001"), event("e4", "7", "2002"))
+	store := &fakeEventStore{}
+	audit := &fakeDeadLetterAudit{}
+	svc := leaderboardscoring.NewDLQService(queue, store, audit, leaderboardscoring.NewValidator())
+	ctx := context.Background()
+
+	res, err := svc.ReprocessDeadLetters(ctx, leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{Filter: leaderboardscoring.DeadLetterFilter{ProjectID: "1001"}},
+		Actor:     "42",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Affected)
+	require.Len(t, store.events, 2)
+	assert.Equal(t, "e1", store.events[0].EventID)
+	assert.Equal(t, "e3", store.events[1].EventID)
+
+	require.Len(t, audit.records, 2)
+	assert.Equal(t, leaderboardscoring.DeadLetterReprocessed, audit.records[0].Action)
+	assert.Equal(t, "42", audit.records[0].Actor)
+	assert.Equal(t, "persist events: timeout", audit.records[0].FailureReason)
+	assert.Equal(t, uint64(5), audit.records[0].DeliveryCount)
+
+	res, err = svc.ReprocessDeadLetters(ctx, leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{All: true},
+		Actor:     "42",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Affected)
+	require.Len(t, res.Skipped, 1)
+	assert.Equal(t, uint64(2), res.Skipped[0].Sequence)
+
+	depth, err := svc.Depth(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), depth, "the letter that isn't an event stays")
+}
+
+func TestDLQService_ReprocessKeepsLettersWhenPersistFails(t *testing.T) {
+	queue := newFakeDeadLetterQueue(event("e1", "7", "1001"))
+	audit := &fakeDeadLetterAudit{}
+	svc := leaderboardscoring.NewDLQService(queue, &fakeEventStore{err: errors.New("connection refused")}, audit, leaderboardscoring.NewValidator())
+
+	res, err := svc.ReprocessDeadLetters(context.Background(), leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{Sequence: 1},
+		Actor:     "42",
+	})
+	require.Error(t, err)
+	assert.Zero(t, res.Affected)
+	assert.Empty(t, audit.records)
+	assert.Len(t, queue.letters, 1)
+}
+
+func TestDLQService_DiscardDeadLetters(t *testing.T) {
+	queue := newFakeDeadLetterQueue(event("e1", "7", "1001"), "not json")
+	store := &fakeEventStore{}
+	audit := &fakeDeadLetterAudit{}
+	svc := leaderboardscoring.NewDLQService(queue, store, audit, leaderboardscoring.NewValidator())
+	ctx := context.Background()
+
+	_, err := svc.DiscardDeadLetters(ctx, leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{Sequence: 2},
+		Actor:     "42",
+	})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments, "a note is required")
+
+	res, err := svc.DiscardDeadLetters(ctx, leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{Sequence: 2},
+		Actor:     "42",
+		Note:      "garbage from a broken producer",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Affected)
+	assert.Empty(t, store.events)
+
+	require.Len(t, audit.records, 1)
+	assert.Equal(t, leaderboardscoring.DeadLetterDiscarded, audit.records[0].Action)
+	assert.Equal(t, []byte("not json"), audit.records[0].Payload)
+	assert.Empty(t, audit.records[0].EventID)
+	assert.Equal(t, "garbage from a broken producer", audit.records[0].Note)
+
+	_, err = svc.DiscardDeadLetters(ctx, leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{Sequence: 2},
+		Actor:     "42",
+		Note:      "again",
+	})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrDeadLetterNotFound)
+}
+
+func TestValidator_ValidateDeadLetterActionRequest(t *testing.T) {
+	validator := leaderboardscoring.NewValidator()
+
+	invalid := []leaderboardscoring.DeadLetterSelection{
+		{},
+		{Sequence: 1, All: true},
+		{All: true, Filter: leaderboardscoring.DeadLetterFilter{UserID: "7"}},
+	}
+	for _, sel := range invalid {
+		assert.Error(t, validator.ValidateDeadLetterActionRequest(leaderboardscoring.DeadLetterActionRequest{
+			Selection: sel, Actor: "42",
+		}, leaderboardscoring.DeadLetterReprocessed), "%+v", sel)
+	}
+
+	assert.Error(t, validator.ValidateDeadLetterActionRequest(leaderboardscoring.DeadLetterActionRequest{
+		Selection: leaderboardscoring.DeadLetterSelection{All: true},
+	}, leaderboardscoring.DeadLetterReprocessed), "actor is required")
+}
@@ -17,6 +17,13 @@ const (
 	IssueComment EventName = "issue_comment"
 
 	CommitPush EventName = "commit_push"
+
+	// StreakBonus is the bonus of a streak milestone. It is scored by the service itself
+	// and is not a valid raw event.
+	StreakBonus EventName = "streak_bonus"
+	// ScoreAdjustment is a manual adjustment by an admin, or its reversal. Like a streak
+	// bonus it is not a valid raw event.
+	ScoreAdjustment EventName = "score_adjustment"
 )
 
 func (e EventName) Validate() error {
@@ -50,6 +57,10 @@ func (e EventName) String() string {
 		return "issue_comment"
 	case CommitPush:
 		return "commit_push"
+	case StreakBonus:
+		return "streak_bonus"
+	case ScoreAdjustment:
+		return "score_adjustment"
 	default:
 		return "unknown"
 	}
@@ -74,6 +85,7 @@ const (
 	Monthly
 	Weekly
 	Daily
+	Trending
 )
 
 var Timeframes = []Timeframe{
@@ -96,6 +108,8 @@ func (tf Timeframe) String() string {
 		return "weekly"
 	case Daily:
 		return "daily"
+	case Trending:
+		return "trending"
 	default:
 		return "unknown"
 	}
@@ -105,12 +119,38 @@ type UpsertScore struct {
 	Keys   []string
 	Score  int64
 	UserID string
+	// ExpireAt is the end of the keys' period; zero means the keys never expire (all_time)
+	ExpireAt time.Time
+	// ReachedAt is set for first_reached boards. The cached score then becomes the new
+	// total plus FirstReachedFraction(ReachedAt), so earlier users win ties.
+	ReachedAt time.Time
+	// ClampAtZero makes a negative Score take a member at most down to zero, and leaves
+	// the keys a member is missing from untouched. Each key is updated atomically.
+	ClampAtZero bool
+}
+
+// TrendingScore holds a contribution for the trending leaderboards. The cache boosts Score
+// by HalfLives, the half-lives elapsed between the trending epoch and the event.
+type TrendingScore struct {
+	Keys      []string
+	UserID    string
+	Score     int64
+	HalfLives float64
+	// EventID is counted once per board within DedupeTTL, an empty ID or TTL always counts
+	EventID   string
+	DedupeTTL time.Duration
+	// ClampAtZero works like UpsertScore.ClampAtZero
+	ClampAtZero bool
 }
 
 type LeaderboardQuery struct {
 	Key   string
 	Start int64
 	Stop  int64
+	// Trending boards store boosted values, they are decayed to HalfLives, the half-lives
+	// elapsed since the trending epoch, before being rounded into LeaderboardEntry.Score
+	Trending  bool
+	HalfLives float64
 }
 
 type LeaderboardEntry struct {
@@ -123,12 +163,74 @@ type LeaderboardQueryResult struct {
 	LeaderboardRows []LeaderboardEntry
 }
 
+// UserRank is the position of a user on one leaderboard.
+// Rank is 1-based and 0 when the user is not on the leaderboard.
+type UserRank struct {
+	Key   string
+	Rank  int64
+	Score int64
+}
+
+// RankChange describes how one user moved on one leaderboard after a score update.
+// OldRank is 0 when the user just entered the leaderboard.
+type RankChange struct {
+	LeaderboardKey string   `json:"leaderboard_key"`
+	UserID         string   `json:"user_id"`
+	OldRank        int64    `json:"old_rank"`
+	NewRank        int64    `json:"new_rank"`
+	Score          int64    `json:"score"`
+	Overtaken      []string `json:"overtaken,omitempty"`
+}
+
+// ProcessedScoreEvent is the persisted record of a scored event.
+// EventID is the ID of the source event and identifies the record, so
+// redelivered events are stored only once.
 type ProcessedScoreEvent struct {
-	ID        int64     `json:"id"`
-	UserID    string    `json:"user_id"`
-	EventName EventName `json:"event_name"`
-	Score     int64     `json:"score"`
-	Timestamp time.Time `json:"timestamp"`
+	ID             int64     `json:"id"`
+	EventID        string    `json:"event_id"`
+	UserID         string    `json:"user_id"`
+	EventName      EventName `json:"event_name"`
+	Score          int64     `json:"score"`
+	ProjectID      string    `json:"project_id"`
+	RepositoryID   uint64    `json:"repository_id"`
+	RepositoryName string    `json:"repository_name"`
+	// SourceNumber is the pull request or issue number of the source event, SourceRef the
+	// pushed branch, or the event that extended the streak for a streak bonus
+	SourceNumber int32  `json:"source_number,omitempty"`
+	SourceRef    string `json:"source_ref,omitempty"`
+	// Rule is the scoring rule that awarded Score, empty for events scored before rules
+	// were recorded
+	Rule string `json:"rule,omitempty"`
+	// ScopeTimeframe limits the event to the boards of one timeframe, empty means every
+	// board. Only adjustments are scoped.
+	ScopeTimeframe string `json:"scope_timeframe,omitempty"`
+	// Timestamp is the original event time, ProcessedAt the time it was scored
+	Timestamp   time.Time `json:"timestamp"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+// ScoreEventFilter selects the processed score events that make up one leaderboard.
+// An empty ProjectID matches all projects, zero times leave the range open.
+type ScoreEventFilter struct {
+	ProjectID string
+	// Timeframe drops the events scoped to the boards of another timeframe
+	Timeframe string
+	// From and To bound the original event time as [From, To)
+	From time.Time
+	To   time.Time
+	// OrderByReachTime orders equal totals by the time of the user's last event, earliest
+	// first, like a first_reached board. Otherwise they are ordered by user ID descending.
+	OrderByReachTime bool
+}
+
+// ContributorProfile is the public identity of a leaderboard user.
+type ContributorProfile struct {
+	UserID      string
+	Username    string
+	DisplayName string
+	Anonymous   bool
+	// Timezone is an IANA name, empty when unknown
+	Timezone string
 }
 
 type SnapshotRow struct {
@@ -0,0 +1,289 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/timettl"
+)
+
+// RuleBasePoints is the rule of the fixed points every event type scores
+const RuleBasePoints = "base_points"
+
+// streakMilestoneRule names the rule of a streak milestone bonus, e.g. "streak_milestone_7d"
+func streakMilestoneRule(days int64) string {
+	return "streak_milestone_" + strconv.FormatInt(days, 10) + "d"
+}
+
+// ScoreSource references what a score event was scored for
+type ScoreSource struct {
+	Number int32
+	Ref    string
+	Rule   string
+}
+
+// payloadSource returns the pull request or issue number of a raw event, or the branch of a push
+func payloadSource(payload EventPayload) ScoreSource {
+	switch p := payload.(type) {
+	case PullRequestOpenedPayload:
+		return ScoreSource{Number: p.PrNumber}
+	case PullRequestClosedPayload:
+		return ScoreSource{Number: p.PrNumber}
+	case PullRequestReviewPayload:
+		return ScoreSource{Number: p.PrNumber}
+	case IssueOpenedPayload:
+		return ScoreSource{Number: p.IssueNumber}
+	case IssueClosedPayload:
+		return ScoreSource{Number: p.IssueNumber}
+	case IssueCommentedPayload:
+		return ScoreSource{Number: p.IssueNumber}
+	case PushPayload:
+		return ScoreSource{Ref: p.BranchName}
+	default:
+		return ScoreSource{}
+	}
+}
+
+// ScoreSum is the points of one user's events of one type, project and local day
+type ScoreSum struct {
+	EventName EventName
+	ProjectID string
+	// Day is the local date at midnight UTC
+	Day    time.Time
+	Points int64
+	Events int64
+}
+
+// ScoreExplanationStore reads the persisted score events of one user.
+type ScoreExplanationStore interface {
+	// SumUserScore sums the points of the user's events matching filter per event type,
+	// project and day, days follow loc
+	SumUserScore(ctx context.Context, userID string, filter ScoreEventFilter, loc *time.Location) ([]ScoreSum, error)
+	// ListUserScoreEvents returns the user's events matching filter, latest first. An
+	// empty eventName matches every type.
+	ListUserScoreEvents(ctx context.Context, userID string, filter ScoreEventFilter, eventName EventName, offset, limit int) ([]ProcessedScoreEvent, error)
+}
+
+// ScoreGroup is the points and number of events behind one part of a score
+type ScoreGroup struct {
+	Key    string
+	Points int64
+	Events int64
+}
+
+// ScoreExplanation splits the score of a user on one board into the persisted events
+// behind it.
+type ScoreExplanation struct {
+	UserID         string
+	LeaderboardKey string
+	Timeframe      string
+	ProjectID      string
+	// From and To bound the board, both are zero for all_time
+	From     time.Time
+	To       time.Time
+	Timezone string
+	Total    int64
+	Events   int64
+	// BoardScore is the score on the cached board, nil once the board expired or when the
+	// user is not on it. It is ahead of Total while events wait to be persisted.
+	BoardScore  *int64
+	ByEventType []ScoreGroup
+	ByProject   []ScoreGroup
+	ByDay       []ScoreGroup
+}
+
+// ExplainService answers why a contributor has the score they have. It reads the
+// persisted score events, the cache only keeps the sums.
+type ExplainService struct {
+	locations projectLocations
+	store     ScoreExplanationStore
+	cache     LeaderboardCache
+	validator Validator
+}
+
+func NewExplainService(
+	cfg Config,
+	store ScoreExplanationStore,
+	cache LeaderboardCache,
+	validator Validator,
+) *ExplainService {
+	return &ExplainService{
+		locations: newProjectLocations(cfg.ProjectTimezones),
+		store:     store,
+		cache:     cache,
+		validator: validator,
+	}
+}
+
+// ExplainScore groups the score of a user on a board by event type, project and day
+func (s *ExplainService) ExplainScore(ctx context.Context, req ExplainScoreRequest) (ScoreExplanation, error) {
+	if err := s.validator.ValidateExplainScore(req); err != nil {
+		return ScoreExplanation{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	board, err := s.board(req)
+	if err != nil {
+		return ScoreExplanation{}, err
+	}
+
+	sums, err := s.store.SumUserScore(ctx, req.UserID, board.filter, board.loc)
+	if err != nil {
+		return ScoreExplanation{}, fmt.Errorf("sum user score: %w", err)
+	}
+
+	explanation := ScoreExplanation{
+		UserID:         req.UserID,
+		LeaderboardKey: board.key,
+		Timeframe:      req.Timeframe,
+		From:           board.filter.From,
+		To:             board.filter.To,
+		Timezone:       board.loc.String(),
+	}
+	if req.ProjectID != nil {
+		explanation.ProjectID = *req.ProjectID
+	}
+
+	byEventType := make(map[string]*ScoreGroup)
+	byProject := make(map[string]*ScoreGroup)
+	byDay := make(map[string]*ScoreGroup)
+	for _, sum := range sums {
+		explanation.Total += sum.Points
+		explanation.Events += sum.Events
+
+		addToGroup(byEventType, sum.EventName.String(), sum)
+		addToGroup(byProject, sum.ProjectID, sum)
+		addToGroup(byDay, sum.Day.Format(time.DateOnly), sum)
+	}
+
+	explanation.ByEventType = sortedGroups(byEventType, false)
+	explanation.ByProject = sortedGroups(byProject, false)
+	explanation.ByDay = sortedGroups(byDay, true)
+
+	ranks, err := s.cache.GetUserRanks(ctx, []string{board.key}, req.UserID)
+	if err != nil {
+		return ScoreExplanation{}, fmt.Errorf("get board score: %w", err)
+	}
+	if len(ranks) == 1 && ranks[0].Rank > 0 {
+		score := ranks[0].Score
+		explanation.BoardScore = &score
+	}
+
+	return explanation, nil
+}
+
+// ListScoreEvents drills down to the events behind a score, latest first. EventName,
+// Project and Day narrow the events of the board.
+func (s *ExplainService) ListScoreEvents(ctx context.Context, req ListScoreEventsRequest) ([]ProcessedScoreEvent, error) {
+	if err := s.validator.ValidateListScoreEvents(req); err != nil {
+		return nil, errors.Join(ErrInvalidArguments, err)
+	}
+
+	board, err := s.board(req.ExplainScoreRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := board.filter
+	if req.Project != "" {
+		if filter.ProjectID != "" && filter.ProjectID != req.Project {
+			return nil, nil
+		}
+		filter.ProjectID = req.Project
+	}
+
+	if req.Day != "" {
+		day, err := time.ParseInLocation(time.DateOnly, req.Day, board.loc)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidArguments, fmt.Errorf("day must be a date like 2025-06-01: %w", err))
+		}
+
+		from, to := day.UTC(), day.AddDate(0, 0, 1).UTC()
+		if filter.From.IsZero() || from.After(filter.From) {
+			filter.From = from
+		}
+		if filter.To.IsZero() || to.Before(filter.To) {
+			filter.To = to
+		}
+		if !filter.From.Before(filter.To) {
+			return nil, nil
+		}
+	}
+
+	return s.store.ListUserScoreEvents(ctx, req.UserID, filter, req.EventName, int(req.Offset), int(req.PageSize))
+}
+
+type explainBoard struct {
+	key    string
+	filter ScoreEventFilter
+	loc    *time.Location
+}
+
+// board resolves the key and event window of the board a request names
+func (s *ExplainService) board(req ExplainScoreRequest) (explainBoard, error) {
+	getReq := &GetLeaderboardRequest{Timeframe: req.Timeframe, ProjectID: req.ProjectID}
+
+	loc := time.UTC
+	if req.ProjectID != nil {
+		loc = s.locations.of(*req.ProjectID)
+	}
+
+	at, err := boardTime(req.Timeframe, req.Period, time.Now().In(loc))
+	if err != nil {
+		return explainBoard{}, err
+	}
+
+	filter, err := exportFilter(getReq, at)
+	if err != nil {
+		return explainBoard{}, err
+	}
+
+	return explainBoard{key: getReq.BuildKey(at), filter: filter, loc: loc}, nil
+}
+
+// boardTime returns a time within period, now for the current period
+func boardTime(timeframe, period string, now time.Time) (time.Time, error) {
+	if period == "" {
+		return now, nil
+	}
+
+	start, err := timettl.StartOfPeriod(timeframe, period, now.Location())
+	if err != nil {
+		return time.Time{}, errors.Join(ErrInvalidArguments, err)
+	}
+	if start.After(now) {
+		return time.Time{}, errors.Join(ErrInvalidArguments, fmt.Errorf("period %s has not started yet", period))
+	}
+
+	return start, nil
+}
+
+func addToGroup(groups map[string]*ScoreGroup, key string, sum ScoreSum) {
+	group, ok := groups[key]
+	if !ok {
+		group = &ScoreGroup{Key: key}
+		groups[key] = group
+	}
+	group.Points += sum.Points
+	group.Events += sum.Events
+}
+
+// sortedGroups orders groups by points, highest first, or by key when byKey is set
+func sortedGroups(groups map[string]*ScoreGroup, byKey bool) []ScoreGroup {
+	result := make([]ScoreGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !byKey && result[i].Points != result[j].Points {
+			return result[i].Points > result[j].Points
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
@@ -0,0 +1,235 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExplanationStore sums and lists processed score events in memory
+type fakeExplanationStore struct {
+	events     []leaderboardscoring.ProcessedScoreEvent
+	lastFilter leaderboardscoring.ScoreEventFilter
+}
+
+func (f *fakeExplanationStore) match(userID string, filter leaderboardscoring.ScoreEventFilter, event leaderboardscoring.ProcessedScoreEvent) bool {
+	return event.UserID == userID &&
+		(filter.ProjectID == "" || event.ProjectID == filter.ProjectID) &&
+		(filter.From.IsZero() || !event.Timestamp.Before(filter.From)) &&
+		(filter.To.IsZero() || event.Timestamp.Before(filter.To))
+}
+
+func (f *fakeExplanationStore) SumUserScore(_ context.Context, userID string, filter leaderboardscoring.ScoreEventFilter, loc *time.Location) ([]leaderboardscoring.ScoreSum, error) {
+	f.lastFilter = filter
+
+	var sums []leaderboardscoring.ScoreSum
+	for _, event := range f.events {
+		if !f.match(userID, filter, event) {
+			continue
+		}
+		year, month, day := event.Timestamp.In(loc).Date()
+		sums = append(sums, leaderboardscoring.ScoreSum{
+			EventName: event.EventName,
+			ProjectID: event.ProjectID,
+			Day:       time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+			Points:    event.Score,
+			Events:    1,
+		})
+	}
+
+	return sums, nil
+}
+
+func (f *fakeExplanationStore) ListUserScoreEvents(_ context.Context, userID string, filter leaderboardscoring.ScoreEventFilter, eventName leaderboardscoring.EventName, offset, limit int) ([]leaderboardscoring.ProcessedScoreEvent, error) {
+	f.lastFilter = filter
+
+	var events []leaderboardscoring.ProcessedScoreEvent
+	for _, event := range f.events {
+		if f.match(userID, filter, event) && (eventName == "" || event.EventName == eventName) {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Timestamp.After(events[j].Timestamp) })
+
+	if offset >= len(events) {
+		return nil, nil
+	}
+	events = events[offset:]
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+func scoredEvent(id, userID, projectID string, name leaderboardscoring.EventName, score int64, at time.Time) leaderboardscoring.ProcessedScoreEvent {
+	return leaderboardscoring.ProcessedScoreEvent{
+		EventID:   id,
+		UserID:    userID,
+		ProjectID: projectID,
+		EventName: name,
+		Score:     score,
+		Timestamp: at,
+		Rule:      leaderboardscoring.RuleBasePoints,
+	}
+}
+
+func newExplainService(store *fakeExplanationStore, cache leaderboardscoring.LeaderboardCache) *leaderboardscoring.ExplainService {
+	cfg := leaderboardscoring.Config{ProjectTimezones: map[string]string{"1001": "Asia/Tokyo"}}
+
+	return leaderboardscoring.NewExplainService(cfg, store, cache, leaderboardscoring.NewValidator())
+}
+
+func TestExplainScore_Groups(t *testing.T) {
+	store := &fakeExplanationStore{events: []leaderboardscoring.ProcessedScoreEvent{
+		scoredEvent("e1", "7", "1001", leaderboardscoring.PullRequestClosed, 10, badgeDay),
+		scoredEvent("e2", "7", "1001", leaderboardscoring.IssueClosed, 4, badgeDay.Add(time.Hour)),
+		scoredEvent("e3", "7", "1002", leaderboardscoring.PullRequestClosed, 10, badgeDay.AddDate(0, 0, 1)),
+		scoredEvent("e4", "8", "1001", leaderboardscoring.IssueClosed, 4, badgeDay),
+	}}
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey,
+		leaderboardscoring.LeaderboardEntry{UserID: "7", Score: 30},
+		leaderboardscoring.LeaderboardEntry{UserID: "8", Score: 4},
+	)
+
+	res, err := newExplainService(store, cache).ExplainScore(context.Background(), leaderboardscoring.ExplainScoreRequest{
+		UserID:    "7",
+		Timeframe: "all_time",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, watchedKey, res.LeaderboardKey)
+	assert.Equal(t, int64(24), res.Total)
+	assert.Equal(t, int64(3), res.Events)
+	require.NotNil(t, res.BoardScore)
+	assert.Equal(t, int64(30), *res.BoardScore, "the board is ahead while events wait to be persisted")
+	assert.True(t, res.From.IsZero())
+
+	assert.Equal(t, []leaderboardscoring.ScoreGroup{
+		{Key: "pull_request_closed", Points: 20, Events: 2},
+		{Key: "issue_closed", Points: 4, Events: 1},
+	}, res.ByEventType)
+	assert.Equal(t, []leaderboardscoring.ScoreGroup{
+		{Key: "1001", Points: 14, Events: 2},
+		{Key: "1002", Points: 10, Events: 1},
+	}, res.ByProject)
+	assert.Equal(t, []leaderboardscoring.ScoreGroup{
+		{Key: "2025-06-02", Points: 14, Events: 2},
+		{Key: "2025-06-03", Points: 10, Events: 1},
+	}, res.ByDay)
+
+	res, err = newExplainService(store, cache).ExplainScore(context.Background(), leaderboardscoring.ExplainScoreRequest{
+		UserID:    "9",
+		Timeframe: "all_time",
+	})
+	require.NoError(t, err)
+	assert.Zero(t, res.Total)
+	assert.Nil(t, res.BoardScore)
+}
+
+func TestExplainScore_ProjectPeriod(t *testing.T) {
+	store := &fakeExplanationStore{}
+	projectID := "1001"
+
+	res, err := newExplainService(store, newFakeLeaderboardCache()).ExplainScore(context.Background(), leaderboardscoring.ExplainScoreRequest{
+		UserID:    "7",
+		Timeframe: "monthly",
+		ProjectID: &projectID,
+		Period:    "2025-06",
+	})
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", res.Timezone)
+	assert.True(t, time.Date(2025, 6, 1, 0, 0, 0, 0, tokyo).Equal(res.From), "from %s", res.From)
+	assert.True(t, time.Date(2025, 7, 1, 0, 0, 0, 0, tokyo).Equal(res.To), "to %s", res.To)
+	assert.Equal(t, "1001", store.lastFilter.ProjectID)
+}
+
+func TestListScoreEvents_DrillDown(t *testing.T) {
+	store := &fakeExplanationStore{events: []leaderboardscoring.ProcessedScoreEvent{
+		scoredEvent("e1", "7", "1001", leaderboardscoring.PullRequestClosed, 10, badgeDay),
+		scoredEvent("e2", "7", "1001", leaderboardscoring.IssueClosed, 4, badgeDay.Add(time.Hour)),
+		scoredEvent("e3", "7", "1002", leaderboardscoring.PullRequestClosed, 10, badgeDay.AddDate(0, 0, 1)),
+	}}
+	svc := newExplainService(store, newFakeLeaderboardCache())
+	ctx := context.Background()
+	board := leaderboardscoring.ExplainScoreRequest{UserID: "7", Timeframe: "all_time"}
+
+	events, err := svc.ListScoreEvents(ctx, leaderboardscoring.ListScoreEventsRequest{ExplainScoreRequest: board, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, "e3", events[0].EventID, "latest first")
+
+	events, err = svc.ListScoreEvents(ctx, leaderboardscoring.ListScoreEventsRequest{
+		ExplainScoreRequest: board,
+		EventName:           leaderboardscoring.PullRequestClosed,
+		Day:                 "2025-06-02",
+		PageSize:            10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "e1", events[0].EventID)
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), store.lastFilter.From)
+	assert.Equal(t, time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), store.lastFilter.To)
+
+	events, err = svc.ListScoreEvents(ctx, leaderboardscoring.ListScoreEventsRequest{ExplainScoreRequest: board, EventName: leaderboardscoring.StreakBonus, PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = svc.ListScoreEvents(ctx, leaderboardscoring.ListScoreEventsRequest{ExplainScoreRequest: board, Project: "1002", PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "e3", events[0].EventID)
+
+	projectID := "1001"
+	events, err = svc.ListScoreEvents(ctx, leaderboardscoring.ListScoreEventsRequest{
+		ExplainScoreRequest: leaderboardscoring.ExplainScoreRequest{UserID: "7", Timeframe: "all_time", ProjectID: &projectID},
+		Project:             "1002",
+		PageSize:            10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, events, "a project outside the board has no events")
+
+	events, err = svc.ListScoreEvents(ctx, leaderboardscoring.ListScoreEventsRequest{
+		ExplainScoreRequest: leaderboardscoring.ExplainScoreRequest{UserID: "7", Timeframe: "monthly", Period: "2025-05"},
+		Day:                 "2025-06-02",
+		PageSize:            10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, events, "a day outside the board has no events")
+}
+
+func TestExplainScore_Invalid(t *testing.T) {
+	svc := newExplainService(&fakeExplanationStore{}, newFakeLeaderboardCache())
+	ctx := context.Background()
+
+	invalid := []leaderboardscoring.ExplainScoreRequest{
+		{Timeframe: "all_time"},
+		{UserID: "7", Timeframe: "trending"},
+		{UserID: "7", Timeframe: "all_time", Period: "2025"},
+		{UserID: "7", Timeframe: "monthly", Period: "June"},
+		{UserID: "7", Timeframe: "monthly", Period: "2999-01"},
+	}
+	for _, req := range invalid {
+		_, err := svc.ExplainScore(ctx, req)
+		assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments, "%+v", req)
+	}
+
+	board := leaderboardscoring.ExplainScoreRequest{UserID: "7", Timeframe: "all_time"}
+	for _, req := range []leaderboardscoring.ListScoreEventsRequest{
+		{ExplainScoreRequest: board, EventName: "pull_request_merged", PageSize: 10},
+		{ExplainScoreRequest: board, Day: "02/06/2025", PageSize: 10},
+		{ExplainScoreRequest: board, PageSize: 0},
+	} {
+		_, err := svc.ListScoreEvents(ctx, req)
+		assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments, "%+v", req)
+	}
+}
@@ -0,0 +1,253 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
+	"github.com/gocasters/rankr/pkg/timettl"
+	types "github.com/gocasters/rankr/type"
+)
+
+// exportPageSize is the number of rows read, enriched and written at a time
+const exportPageSize = 1_000
+
+// ContributorDirectory resolves leaderboard user IDs to public contributor profiles.
+// Unknown users are missing from the result.
+type ContributorDirectory interface {
+	GetProfiles(ctx context.Context, userIDs []string) (map[string]ContributorProfile, error)
+}
+
+// exportSource returns the ranked rows of one board, a page at a time.
+type exportSource func(ctx context.Context, offset, limit int) ([]LeaderboardEntry, error)
+
+// ExportLeaderboard writes a whole leaderboard to w in the requested format.
+//
+// The current period and all_time/trending boards are read from the cache. Past periods
+// have expired there, so they are rebuilt from the persisted score events. Rows are joined
+// with contributor display names and per-event-type points one page at a time, the board
+// is never held in memory as a whole.
+func (s *Service) ExportLeaderboard(ctx context.Context, req *ExportLeaderboardRequest, w io.Writer) error {
+	if err := s.validator.ValidateExportLeaderboard(req); err != nil {
+		return errors.Join(ErrInvalidArguments, err)
+	}
+
+	getReq := req.getLeaderboardRequest()
+	loc := s.requestLocation(getReq)
+	now := time.Now().In(loc)
+
+	at, err := boardTime(req.Timeframe, req.Period, now)
+	if err != nil {
+		return err
+	}
+
+	filter, err := exportFilter(getReq, at)
+	if err != nil {
+		return err
+	}
+
+	mode := s.config.Ranking.modeOf(getReq)
+	filter.OrderByReachTime = mode == RankingFirstReached
+
+	var source exportSource
+	if !filter.To.IsZero() && !filter.To.After(now) {
+		source = func(ctx context.Context, offset, limit int) ([]LeaderboardEntry, error) {
+			return s.eventPersistence.GetUserScores(ctx, filter, offset, limit)
+		}
+	} else {
+		source = s.cacheExportSource(getReq, at)
+	}
+
+	withBreakdown := req.Timeframe != Trending.String()
+
+	writer, err := newExportWriter(req.Format, w, withBreakdown)
+	if err != nil {
+		return err
+	}
+
+	// Pages are read from the top, so one ranker carries tied ranks across page boundaries
+	rank := &ranker{mode: mode}
+	if err := s.writeExportRows(ctx, writer, source, rank, filter, withBreakdown, req.Viewer); err != nil {
+		writer.Abort()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (s *Service) writeExportRows(ctx context.Context, writer exportWriter, source exportSource, rank *ranker, filter ScoreEventFilter, withBreakdown bool, viewer privacy.Viewer) error {
+	for offset := 0; ; offset += exportPageSize {
+		entries, err := source(ctx, offset, exportPageSize)
+		if err != nil {
+			return fmt.Errorf("read leaderboard page: %w", err)
+		}
+		rank.rankRows(entries)
+
+		rows, err := s.exportRows(ctx, entries, filter, withBreakdown, viewer)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if err := writer.WriteRow(row); err != nil {
+				return fmt.Errorf("write export row: %w", err)
+			}
+		}
+
+		if len(entries) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// exportFilter selects the score events of the board that contains "at".
+func exportFilter(req *GetLeaderboardRequest, at time.Time) (ScoreEventFilter, error) {
+	filter := ScoreEventFilter{Timeframe: req.Timeframe}
+	if req.ProjectID != nil {
+		filter.ProjectID = *req.ProjectID
+	}
+
+	switch req.Timeframe {
+	case AllTime.String(), Trending.String():
+		return filter, nil
+	}
+
+	period, err := timettl.PeriodKeyAt(req.Timeframe, at)
+	if err != nil {
+		return ScoreEventFilter{}, err
+	}
+
+	start, err := timettl.StartOfPeriod(req.Timeframe, period, at.Location())
+	if err != nil {
+		return ScoreEventFilter{}, err
+	}
+
+	end, err := timettl.EndOfPeriodAt(req.Timeframe, start)
+	if err != nil {
+		return ScoreEventFilter{}, err
+	}
+
+	filter.From, filter.To = start.UTC(), end.UTC()
+	return filter, nil
+}
+
+func (s *Service) cacheExportSource(req *GetLeaderboardRequest, at time.Time) exportSource {
+	return func(ctx context.Context, offset, limit int) ([]LeaderboardEntry, error) {
+		pageReq := *req
+		pageReq.Offset = int32(offset)
+		pageReq.PageSize = int32(limit)
+
+		result, err := s.leaderboard.GetLeaderboard(ctx, s.leaderboardQuery(&pageReq, at))
+		if err != nil {
+			return nil, err
+		}
+
+		return result.LeaderboardRows, nil
+	}
+}
+
+// exportRows joins a page of entries with contributor profiles and event breakdowns.
+// A missing contributor directory or a failed lookup only leaves the names empty, the
+// anonymous contributors the viewer may not see are masked by the identity resolver.
+func (s *Service) exportRows(ctx context.Context, entries []LeaderboardEntry, filter ScoreEventFilter, withBreakdown bool, viewer privacy.Viewer) ([]ExportRow, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	userIDs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		userIDs = append(userIDs, e.UserID)
+	}
+
+	var profiles map[string]ContributorProfile
+	if s.contributors != nil {
+		var err error
+		profiles, err = s.contributors.GetProfiles(ctx, userIDs)
+		if err != nil {
+			logger.L().Warn("failed to resolve contributor profiles for export; exporting without names",
+				slog.Int("count", len(userIDs)),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	identities := s.userIdentities(ctx, userIDs, viewer)
+
+	var breakdowns map[string]map[EventName]int64
+	if withBreakdown {
+		var err error
+		breakdowns, err = s.eventPersistence.GetScoreBreakdown(ctx, filter, userIDs)
+		if err != nil {
+			return nil, fmt.Errorf("read score breakdown: %w", err)
+		}
+	}
+
+	rows := make([]ExportRow, 0, len(entries))
+	for _, e := range entries {
+		row := ExportRow{
+			Rank:   e.Rank,
+			UserID: e.UserID,
+			Score:  e.Score,
+		}
+
+		if withBreakdown {
+			row.Breakdown = breakdowns[e.UserID]
+			if row.Breakdown == nil {
+				row.Breakdown = map[EventName]int64{}
+			}
+		}
+
+		if identity, ok := identities[e.UserID]; ok && identity.Masked() {
+			row.UserID = ""
+			row.DisplayName = identity.DisplayName
+		} else if profile, ok := profiles[e.UserID]; ok {
+			row.Username = profile.Username
+			row.DisplayName = profile.DisplayName
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// userIdentities returns the identities of leaderboard users as the viewer may see them.
+// Users without an identity, e.g. without a resolver, are shown as is.
+func (s *Service) userIdentities(ctx context.Context, userIDs []string, viewer privacy.Viewer) map[string]privacy.Identity {
+	if s.identities == nil {
+		return nil
+	}
+
+	return UserIdentities(ctx, s.identities, userIDs, viewer)
+}
+
+// UserIdentities resolves leaderboard user IDs, which are GitHub user IDs, to identities.
+// IDs that are not numeric cannot belong to a contributor and are left out.
+func UserIdentities(ctx context.Context, resolver *privacy.IdentityResolver, userIDs []string, viewer privacy.Viewer) map[string]privacy.Identity {
+	ids := make([]types.ID, 0, len(userIDs))
+	keys := make(map[types.ID]string, len(userIDs))
+	for _, userID := range userIDs {
+		id, err := strconv.ParseUint(userID, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, types.ID(id))
+		keys[types.ID(id)] = userID
+	}
+
+	identities := make(map[string]privacy.Identity, len(ids))
+	if len(ids) == 0 {
+		return identities
+	}
+
+	for id, identity := range resolver.Identities(ctx, ids, viewer) {
+		identities[keys[id]] = identity
+	}
+
+	return identities
+}
@@ -0,0 +1,289 @@
+package leaderboardscoring_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/adapter/contributor"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/privacy"
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// fakeScoreStore answers score queries from memory and records the filters it received.
+type fakeScoreStore struct {
+	scores     []leaderboardscoring.LeaderboardEntry
+	breakdowns map[string]map[leaderboardscoring.EventName]int64
+	filters    []leaderboardscoring.ScoreEventFilter
+}
+
+func (f *fakeScoreStore) AddProcessedScoreEvents(context.Context, []leaderboardscoring.ProcessedScoreEvent) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeScoreStore) AddSnapshot(context.Context, []leaderboardscoring.SnapshotRow) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeScoreStore) GetUserScores(_ context.Context, filter leaderboardscoring.ScoreEventFilter, offset, limit int) ([]leaderboardscoring.LeaderboardEntry, error) {
+	f.filters = append(f.filters, filter)
+	if offset >= len(f.scores) {
+		return nil, nil
+	}
+
+	return f.scores[offset:min(offset+limit, len(f.scores))], nil
+}
+
+func (f *fakeScoreStore) GetScoreBreakdown(_ context.Context, filter leaderboardscoring.ScoreEventFilter, userIDs []string) (map[string]map[leaderboardscoring.EventName]int64, error) {
+	f.filters = append(f.filters, filter)
+
+	result := make(map[string]map[leaderboardscoring.EventName]int64)
+	for _, id := range userIDs {
+		if b, ok := f.breakdowns[id]; ok {
+			result[id] = b
+		}
+	}
+
+	return result, nil
+}
+
+type fakeDirectory map[string]leaderboardscoring.ContributorProfile
+
+func (f fakeDirectory) GetProfiles(_ context.Context, userIDs []string) (map[string]leaderboardscoring.ContributorProfile, error) {
+	result := make(map[string]leaderboardscoring.ContributorProfile)
+	for _, id := range userIDs {
+		if p, ok := f[id]; ok {
+			result[id] = p
+		}
+	}
+
+	return result, nil
+}
+
+// fakeProfiles is the contributor service as the identity resolver sees it
+type fakeProfiles []contributor.Profile
+
+func (f fakeProfiles) GetProfilesByVCS(_ context.Context, _ string, userIDs []int64) ([]contributor.Profile, error) {
+	var result []contributor.Profile
+	for _, id := range userIDs {
+		for _, p := range f {
+			if p.VcsUserID == id {
+				result = append(result, p)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+var exportIdentities = privacy.NewIdentityResolver(privacy.Config{PseudonymSecret: "test-secret"}, fakeProfiles{
+	{ContributorID: 11, VcsUserID: 1, VcsUsername: "alice", DisplayName: "Alice"},
+	{ContributorID: 12, VcsUserID: 2, VcsUsername: "bob", DisplayName: "Bob", PrivacyMode: "anonymous"},
+})
+
+func newExportService(store *fakeScoreStore, cache leaderboardscoring.LeaderboardCache) *leaderboardscoring.Service {
+	directory := fakeDirectory{
+		"1": {UserID: "1", Username: "alice", DisplayName: "Alice"},
+		"2": {UserID: "2", Username: "bob", DisplayName: "Bob", Anonymous: true},
+	}
+
+	return leaderboardscoring.NewService(leaderboardscoring.Config{}, store, cache, nil, "",
+		leaderboardscoring.NewValidator(), nil, directory, exportIdentities)
+}
+
+func TestExportLeaderboard_CurrentBoardAsCSV(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey,
+		leaderboardscoring.LeaderboardEntry{Rank: 1, UserID: "1", Score: 30},
+		leaderboardscoring.LeaderboardEntry{Rank: 2, UserID: "2", Score: 20},
+		leaderboardscoring.LeaderboardEntry{Rank: 3, UserID: "3", Score: 10},
+	)
+	store := &fakeScoreStore{breakdowns: map[string]map[leaderboardscoring.EventName]int64{
+		"1": {leaderboardscoring.PullRequestOpened: 20, leaderboardscoring.CommitPush: 10},
+	}}
+
+	var buf bytes.Buffer
+	err := newExportService(store, cache).ExportLeaderboard(context.Background(), &leaderboardscoring.ExportLeaderboardRequest{
+		Timeframe: "all_time",
+		Format:    leaderboardscoring.ExportFormatCSV,
+	}, &buf)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	assert.Equal(t, []string{"rank", "user_id", "username", "display_name", "score"}, records[0][:5])
+	assert.Len(t, records[0], 14)
+	assert.Equal(t, []string{"1", "1", "alice", "Alice", "30", "20", "0", "0", "0", "0", "0", "10", "0", "0"}, records[1])
+	assert.Equal(t, []string{"2", "", "", exportIdentities.Pseudonym(2), "20"}, records[2][:5])
+	assert.Equal(t, []string{"3", "3", "", "", "10"}, records[3][:5])
+}
+
+func TestExportLeaderboard_RevealsAnonymousToAllowedViewers(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey, leaderboardscoring.LeaderboardEntry{Rank: 1, UserID: "2", Score: 20})
+
+	tests := []struct {
+		name   string
+		viewer privacy.Viewer
+	}{
+		{name: "contributor:read", viewer: privacy.Viewer{RevealIdentities: true}},
+		{name: "the contributor itself", viewer: privacy.Viewer{ContributorID: 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := newExportService(&fakeScoreStore{}, cache).ExportLeaderboard(context.Background(), &leaderboardscoring.ExportLeaderboardRequest{
+				Timeframe: "all_time",
+				Format:    leaderboardscoring.ExportFormatCSV,
+				Viewer:    tt.viewer,
+			}, &buf)
+			require.NoError(t, err)
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+			assert.Equal(t, []string{"1", "2", "bob", "Bob", "20"}, records[1][:5])
+		})
+	}
+}
+
+func TestExportLeaderboard_PastPeriodFromPersistedEvents(t *testing.T) {
+	store := &fakeScoreStore{scores: []leaderboardscoring.LeaderboardEntry{
+		{Rank: 1, UserID: "1", Score: 42},
+	}}
+	projectID := "1001"
+
+	var buf bytes.Buffer
+	err := newExportService(store, newFakeLeaderboardCache()).ExportLeaderboard(context.Background(), &leaderboardscoring.ExportLeaderboardRequest{
+		Timeframe: "monthly",
+		ProjectID: &projectID,
+		Period:    "2025-06",
+		Format:    leaderboardscoring.ExportFormatXLSX,
+	}, &buf)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, store.filters)
+	assert.Equal(t, leaderboardscoring.ScoreEventFilter{
+		ProjectID: "1001",
+		Timeframe: "monthly",
+		From:      time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}, store.filters[0])
+
+	file, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows("Leaderboard")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"1", "1", "alice", "Alice", "42"}, rows[1][:5])
+}
+
+func TestExportLeaderboard_JSONLines(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey, leaderboardscoring.LeaderboardEntry{Rank: 1, UserID: "1", Score: 5})
+	store := &fakeScoreStore{breakdowns: map[string]map[leaderboardscoring.EventName]int64{
+		"1": {leaderboardscoring.IssueComment: 5},
+	}}
+
+	var buf bytes.Buffer
+	err := newExportService(store, cache).ExportLeaderboard(context.Background(), &leaderboardscoring.ExportLeaderboardRequest{
+		Timeframe: "all_time",
+		Format:    leaderboardscoring.ExportFormatJSONL,
+	}, &buf)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var row map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+	assert.Equal(t, "alice", row["username"])
+	assert.Equal(t, map[string]any{leaderboardscoring.IssueComment.String(): float64(5)}, row["breakdown"])
+}
+
+func TestExportLeaderboard_InvalidRequest(t *testing.T) {
+	svc := newExportService(&fakeScoreStore{}, newFakeLeaderboardCache())
+
+	tests := []*leaderboardscoring.ExportLeaderboardRequest{
+		{Timeframe: "all_time", Period: "2025", Format: leaderboardscoring.ExportFormatCSV},
+		{Timeframe: "monthly", Period: "2025-13", Format: leaderboardscoring.ExportFormatCSV},
+		{Timeframe: "monthly", Format: "pdf"},
+	}
+
+	for _, req := range tests {
+		err := svc.ExportLeaderboard(context.Background(), req, &bytes.Buffer{})
+		assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments, "%+v", req)
+	}
+}
+
+func TestExportLeaderboard_EscapesFormulas(t *testing.T) {
+	names := []string{`=HYPERLINK("http://evil","x")`, "+1+1", "-2+3", "@SUM(A1)", "\tname", "Plain - name"}
+	want := []string{`'=HYPERLINK("http://evil","x")`, "'+1+1", "'-2+3", "'@SUM(A1)", "'\tname", "Plain - name"}
+
+	cache := newFakeLeaderboardCache()
+	directory := fakeDirectory{}
+	var profiles fakeProfiles
+	var entries []leaderboardscoring.LeaderboardEntry
+	for i, name := range names {
+		id := int64(i + 1)
+		userID := strconv.FormatInt(id, 10)
+		directory[userID] = leaderboardscoring.ContributorProfile{UserID: userID, Username: name, DisplayName: name}
+		profiles = append(profiles, contributor.Profile{ContributorID: types.ID(100 + id), VcsUserID: id, VcsUsername: name, DisplayName: name})
+		entries = append(entries, leaderboardscoring.LeaderboardEntry{Rank: id, UserID: userID, Score: 10 - id})
+	}
+	cache.set(watchedKey, entries...)
+
+	identities := privacy.NewIdentityResolver(privacy.Config{PseudonymSecret: "test-secret"}, profiles)
+	svc := leaderboardscoring.NewService(leaderboardscoring.Config{}, &fakeScoreStore{}, cache, nil, "",
+		leaderboardscoring.NewValidator(), nil, directory, identities)
+
+	read := map[leaderboardscoring.ExportFormat]func(t *testing.T, buf *bytes.Buffer) [][]string{
+		leaderboardscoring.ExportFormatCSV: func(t *testing.T, buf *bytes.Buffer) [][]string {
+			records, err := csv.NewReader(buf).ReadAll()
+			require.NoError(t, err)
+			return records
+		},
+		leaderboardscoring.ExportFormatXLSX: func(t *testing.T, buf *bytes.Buffer) [][]string {
+			file, err := excelize.OpenReader(buf)
+			require.NoError(t, err)
+			defer file.Close()
+
+			rows, err := file.GetRows("Leaderboard")
+			require.NoError(t, err)
+			return rows
+		},
+	}
+
+	for format, readRows := range read {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := svc.ExportLeaderboard(context.Background(), &leaderboardscoring.ExportLeaderboardRequest{
+				Timeframe: "all_time",
+				Format:    format,
+			}, &buf)
+			require.NoError(t, err)
+
+			rows := readRows(t, &buf)
+			require.Len(t, rows, len(names)+1)
+			for i, w := range want {
+				assert.Equal(t, w, rows[i+1][2], "username %q", names[i])
+				assert.Equal(t, w, rows[i+1][3], "display name %q", names[i])
+			}
+		})
+	}
+}
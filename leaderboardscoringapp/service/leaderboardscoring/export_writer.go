@@ -0,0 +1,218 @@
+package leaderboardscoring
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportEventNames fixes the order of the breakdown columns
+var exportEventNames = []EventName{
+	PullRequestOpened,
+	PullRequestClosed,
+	PullRequestReview,
+	IssueOpened,
+	IssueClosed,
+	IssueComment,
+	CommitPush,
+	StreakBonus,
+	ScoreAdjustment,
+}
+
+// exportWriter writes export rows in one output format. Close must be called once
+// after the last row to flush buffered output, Abort releases resources on failure.
+type exportWriter interface {
+	WriteRow(row ExportRow) error
+	Close() error
+	Abort()
+}
+
+func newExportWriter(format ExportFormat, w io.Writer, withBreakdown bool) (exportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVExportWriter(w, withBreakdown)
+	case ExportFormatJSONL:
+		return newJSONLExportWriter(w), nil
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(w, withBreakdown)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func exportHeader(withBreakdown bool) []string {
+	header := []string{"rank", "user_id", "username", "display_name", "score"}
+	if withBreakdown {
+		for _, name := range exportEventNames {
+			header = append(header, name.String())
+		}
+	}
+
+	return header
+}
+
+// spreadsheetText escapes a text cell that a spreadsheet would read as a formula, such as a
+// display name starting with "=", by prefixing it with a quote
+func spreadsheetText(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+
+	return value
+}
+
+type csvExportWriter struct {
+	w             *csv.Writer
+	withBreakdown bool
+}
+
+func newCSVExportWriter(w io.Writer, withBreakdown bool) (*csvExportWriter, error) {
+	cw := &csvExportWriter{w: csv.NewWriter(w), withBreakdown: withBreakdown}
+	if err := cw.w.Write(exportHeader(withBreakdown)); err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+func (cw *csvExportWriter) WriteRow(row ExportRow) error {
+	record := []string{
+		strconv.FormatInt(row.Rank, 10),
+		spreadsheetText(row.UserID),
+		spreadsheetText(row.Username),
+		spreadsheetText(row.DisplayName),
+		strconv.FormatInt(row.Score, 10),
+	}
+	if cw.withBreakdown {
+		for _, name := range exportEventNames {
+			record = append(record, strconv.FormatInt(row.Breakdown[name], 10))
+		}
+	}
+
+	return cw.w.Write(record)
+}
+
+func (cw *csvExportWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvExportWriter) Abort() {}
+
+type jsonlExportWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+type jsonlExportRow struct {
+	Rank        int64               `json:"rank"`
+	UserID      string              `json:"user_id"`
+	Username    string              `json:"username,omitempty"`
+	DisplayName string              `json:"display_name,omitempty"`
+	Score       int64               `json:"score"`
+	Breakdown   map[EventName]int64 `json:"breakdown,omitempty"`
+}
+
+func newJSONLExportWriter(w io.Writer) *jsonlExportWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonlExportWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (jw *jsonlExportWriter) WriteRow(row ExportRow) error {
+	return jw.enc.Encode(jsonlExportRow(row))
+}
+
+func (jw *jsonlExportWriter) Close() error {
+	return jw.buf.Flush()
+}
+
+func (jw *jsonlExportWriter) Abort() {}
+
+const xlsxSheetName = "Leaderboard"
+
+// xlsxExportWriter uses the excelize stream writer, which keeps rows in a temporary
+// file instead of memory. The workbook is written to w on Close.
+type xlsxExportWriter struct {
+	w             io.Writer
+	file          *excelize.File
+	stream        *excelize.StreamWriter
+	withBreakdown bool
+	nextRow       int
+}
+
+func newXLSXExportWriter(w io.Writer, withBreakdown bool) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", xlsxSheetName); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(xlsxSheetName)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	xw := &xlsxExportWriter{w: w, file: file, stream: stream, withBreakdown: withBreakdown, nextRow: 1}
+
+	header := exportHeader(withBreakdown)
+	cells := make([]interface{}, len(header))
+	for i, h := range header {
+		cells[i] = h
+	}
+	if err := xw.writeCells(cells); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+func (xw *xlsxExportWriter) WriteRow(row ExportRow) error {
+	cells := []interface{}{row.Rank, spreadsheetText(row.UserID), spreadsheetText(row.Username), spreadsheetText(row.DisplayName), row.Score}
+	if xw.withBreakdown {
+		for _, name := range exportEventNames {
+			cells = append(cells, row.Breakdown[name])
+		}
+	}
+
+	return xw.writeCells(cells)
+}
+
+func (xw *xlsxExportWriter) writeCells(cells []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, xw.nextRow)
+	if err != nil {
+		return err
+	}
+
+	if err := xw.stream.SetRow(cell, cells); err != nil {
+		return err
+	}
+
+	xw.nextRow++
+	return nil
+}
+
+func (xw *xlsxExportWriter) Close() error {
+	defer func() { _ = xw.file.Close() }()
+
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+
+	return xw.file.Write(xw.w)
+}
+
+func (xw *xlsxExportWriter) Abort() {
+	_ = xw.file.Close()
+}
@@ -0,0 +1,191 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultSnapshotDailyRetention = 90 * 24 * time.Hour
+	defaultRankHistoryRange       = 90 * 24 * time.Hour
+)
+
+// SnapshotBucket is the granularity snapshots are thinned out to
+type SnapshotBucket string
+
+const (
+	SnapshotBucketDay  SnapshotBucket = "day"
+	SnapshotBucketWeek SnapshotBucket = "week"
+)
+
+// SnapshotRetentionConfig thins out the snapshot table. Snapshots of past days are reduced
+// to the last one of each day, those older than DailyFor to the last one of each ISO week,
+// and those older than WeeklyFor are deleted. Zero WeeklyFor keeps weekly snapshots forever.
+type SnapshotRetentionConfig struct {
+	DailyFor  time.Duration `koanf:"daily_for"`
+	WeeklyFor time.Duration `koanf:"weekly_for"`
+}
+
+// SnapshotStore reads and prunes the leaderboard snapshots written by LeaderboardSnapshot.
+// All times are UTC.
+type SnapshotStore interface {
+	// LatestSnapshotAt returns the time of the latest snapshot of key taken at or before asOf,
+	// ok is false when there is none
+	LatestSnapshotAt(ctx context.Context, key string, asOf time.Time) (at time.Time, ok bool, err error)
+	// GetSnapshotRows returns a page of the snapshot of key taken at, in rank order
+	GetSnapshotRows(ctx context.Context, key string, at time.Time, offset, limit int) ([]LeaderboardEntry, error)
+	// GetUserSnapshots returns the rows of userID in the snapshots of key taken in [from, to), oldest first
+	GetUserSnapshots(ctx context.Context, key, userID string, from, to time.Time) ([]SnapshotRow, error)
+	// ThinSnapshots keeps only the latest snapshot of each key per bucket among those taken before before
+	ThinSnapshots(ctx context.Context, before time.Time, bucket SnapshotBucket) (int64, error)
+	DeleteSnapshotsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// HistoryService answers questions about past leaderboards from the snapshot table. Only
+// all-time boards are snapshotted, so history is available for those only.
+type HistoryService struct {
+	config    SnapshotRetentionConfig
+	snapshots SnapshotStore
+	validator Validator
+}
+
+func NewHistoryService(config SnapshotRetentionConfig, snapshots SnapshotStore, validator Validator) *HistoryService {
+	if config.DailyFor <= 0 {
+		config.DailyFor = defaultSnapshotDailyRetention
+	}
+
+	return &HistoryService{
+		config:    config,
+		snapshots: snapshots,
+		validator: validator,
+	}
+}
+
+// historyKey is the hash-tagged key of the all-time board. Snapshots taken under the
+// legacy key layout are rewritten to it by migration 00011_snapshot_hash_tagged_keys.
+func historyKey(projectID *string) string {
+	scope := globalScope
+	if projectID != nil {
+		scope = *projectID
+	}
+
+	return LeaderboardKey(scope, AllTime.String(), "")
+}
+
+// GetLeaderboardAsOf returns a page of the all-time board as captured by the latest snapshot
+// taken at or before req.AsOf.
+func (s *HistoryService) GetLeaderboardAsOf(ctx context.Context, req *GetLeaderboardAsOfRequest) (GetLeaderboardAsOfResponse, error) {
+	if err := s.validator.ValidateGetLeaderboardAsOf(req); err != nil {
+		return GetLeaderboardAsOfResponse{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	key := historyKey(req.ProjectID)
+	at, ok, err := s.snapshots.LatestSnapshotAt(ctx, key, req.AsOf.UTC())
+	if err != nil {
+		return GetLeaderboardAsOfResponse{}, fmt.Errorf("find snapshot: %w", err)
+	}
+	if !ok {
+		return GetLeaderboardAsOfResponse{}, ErrLeaderboardNotFound
+	}
+
+	entries, err := s.snapshots.GetSnapshotRows(ctx, key, at, int(req.Offset), int(req.PageSize))
+	if err != nil {
+		return GetLeaderboardAsOfResponse{}, fmt.Errorf("read snapshot: %w", err)
+	}
+
+	rows := make([]LeaderboardRow, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, LeaderboardRow{Rank: entry.Rank, UserID: entry.UserID, Score: entry.Score})
+	}
+
+	return GetLeaderboardAsOfResponse{
+		ProjectID:       req.ProjectID,
+		SnapshotAt:      at,
+		LeaderboardRows: rows,
+	}, nil
+}
+
+// GetUserRankHistory returns the rank and score of a user in every snapshot of the all-time
+// board taken in [req.From, req.To). Snapshots the user is missing from have no point.
+func (s *HistoryService) GetUserRankHistory(ctx context.Context, req *GetUserRankHistoryRequest) (GetUserRankHistoryResponse, error) {
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if req.From.IsZero() {
+		req.From = req.To.Add(-defaultRankHistoryRange)
+	}
+
+	if err := s.validator.ValidateGetUserRankHistory(req); err != nil {
+		return GetUserRankHistoryResponse{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	rows, err := s.snapshots.GetUserSnapshots(ctx, historyKey(req.ProjectID), req.UserID, req.From.UTC(), req.To.UTC())
+	if err != nil {
+		return GetUserRankHistoryResponse{}, fmt.Errorf("read user snapshots: %w", err)
+	}
+
+	points := make([]RankHistoryPoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, RankHistoryPoint{
+			SnapshotAt: row.SnapshotTimestamp,
+			Rank:       row.Rank,
+			Score:      row.TotalScore,
+		})
+	}
+
+	return GetUserRankHistoryResponse{
+		UserID:    req.UserID,
+		ProjectID: req.ProjectID,
+		Points:    points,
+	}, nil
+}
+
+// SnapshotPruneResult counts the snapshot rows removed by each step of PruneSnapshots
+type SnapshotPruneResult struct {
+	ThinnedDaily  int64
+	ThinnedWeekly int64
+	Expired       int64
+}
+
+// PruneSnapshots applies the retention policy: snapshots of days before today are thinned
+// to one per day, those older than DailyFor to one per week, and weekly ones older than
+// WeeklyFor are deleted.
+func (s *HistoryService) PruneSnapshots(ctx context.Context, now time.Time) (SnapshotPruneResult, error) {
+	var (
+		res SnapshotPruneResult
+		err error
+	)
+
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	res.ThinnedDaily, err = s.snapshots.ThinSnapshots(ctx, today, SnapshotBucketDay)
+	if err != nil {
+		return res, fmt.Errorf("thin snapshots to daily: %w", err)
+	}
+
+	// Only whole weeks are thinned, the last snapshot of a week is the one kept
+	res.ThinnedWeekly, err = s.snapshots.ThinSnapshots(ctx, weekStart(today.Add(-s.config.DailyFor)), SnapshotBucketWeek)
+	if err != nil {
+		return res, fmt.Errorf("thin snapshots to weekly: %w", err)
+	}
+
+	if s.config.WeeklyFor > 0 {
+		res.Expired, err = s.snapshots.DeleteSnapshotsBefore(ctx, today.Add(-s.config.DailyFor-s.config.WeeklyFor))
+		if err != nil {
+			return res, fmt.Errorf("delete expired snapshots: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// weekStart returns the Monday 00:00 of the ISO week of t
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,199 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSnapshotStore keeps snapshot rows in memory and records the prune calls
+type fakeSnapshotStore struct {
+	rows []leaderboardscoring.SnapshotRow
+
+	thinned map[leaderboardscoring.SnapshotBucket]time.Time
+	expired time.Time
+}
+
+func (f *fakeSnapshotStore) LatestSnapshotAt(_ context.Context, key string, asOf time.Time) (time.Time, bool, error) {
+	var (
+		latest time.Time
+		ok     bool
+	)
+	for _, row := range f.rows {
+		if row.LeaderboardKey == key && !row.SnapshotTimestamp.After(asOf) && row.SnapshotTimestamp.After(latest) {
+			latest, ok = row.SnapshotTimestamp, true
+		}
+	}
+
+	return latest, ok, nil
+}
+
+func (f *fakeSnapshotStore) GetSnapshotRows(_ context.Context, key string, at time.Time, offset, limit int) ([]leaderboardscoring.LeaderboardEntry, error) {
+	var entries []leaderboardscoring.LeaderboardEntry
+	for _, row := range f.rows {
+		if row.LeaderboardKey == key && row.SnapshotTimestamp.Equal(at) {
+			entries = append(entries, leaderboardscoring.LeaderboardEntry{Rank: row.Rank, UserID: row.UserID, Score: row.TotalScore})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Rank < entries[j].Rank })
+
+	if offset >= len(entries) {
+		return nil, nil
+	}
+
+	return entries[offset:min(offset+limit, len(entries))], nil
+}
+
+func (f *fakeSnapshotStore) GetUserSnapshots(_ context.Context, key, userID string, from, to time.Time) ([]leaderboardscoring.SnapshotRow, error) {
+	var rows []leaderboardscoring.SnapshotRow
+	for _, row := range f.rows {
+		if row.LeaderboardKey == key && row.UserID == userID &&
+			!row.SnapshotTimestamp.Before(from) && row.SnapshotTimestamp.Before(to) {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func (f *fakeSnapshotStore) ThinSnapshots(_ context.Context, before time.Time, bucket leaderboardscoring.SnapshotBucket) (int64, error) {
+	if f.thinned == nil {
+		f.thinned = make(map[leaderboardscoring.SnapshotBucket]time.Time)
+	}
+	f.thinned[bucket] = before
+
+	return 1, nil
+}
+
+func (f *fakeSnapshotStore) DeleteSnapshotsBefore(_ context.Context, before time.Time) (int64, error) {
+	f.expired = before
+
+	return 2, nil
+}
+
+func snapshotRow(key, userID string, rank, score int64, at time.Time) leaderboardscoring.SnapshotRow {
+	return leaderboardscoring.SnapshotRow{
+		Rank:              rank,
+		UserID:            userID,
+		TotalScore:        score,
+		LeaderboardKey:    key,
+		SnapshotTimestamp: at,
+	}
+}
+
+func TestHistoryService_GetLeaderboardAsOf(t *testing.T) {
+	project := "1001"
+	key := leaderboardscoring.LeaderboardKey(project, leaderboardscoring.AllTime.String(), "")
+	may31 := time.Date(2025, 5, 31, 21, 0, 0, 0, time.UTC)
+	june2 := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	store := &fakeSnapshotStore{rows: []leaderboardscoring.SnapshotRow{
+		snapshotRow(key, "7", 2, 40, may31),
+		snapshotRow(key, "8", 1, 50, may31),
+		snapshotRow(key, "7", 1, 90, june2),
+	}}
+	svc := leaderboardscoring.NewHistoryService(leaderboardscoring.SnapshotRetentionConfig{}, store, leaderboardscoring.NewValidator())
+	ctx := context.Background()
+
+	res, err := svc.GetLeaderboardAsOf(ctx, &leaderboardscoring.GetLeaderboardAsOfRequest{
+		ProjectID: &project,
+		AsOf:      time.Date(2025, 6, 1, 23, 59, 59, 0, time.UTC),
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, may31, res.SnapshotAt)
+	assert.Equal(t, []leaderboardscoring.LeaderboardRow{
+		{Rank: 1, UserID: "8", Score: 50},
+		{Rank: 2, UserID: "7", Score: 40},
+	}, res.LeaderboardRows)
+
+	_, err = svc.GetLeaderboardAsOf(ctx, &leaderboardscoring.GetLeaderboardAsOfRequest{
+		ProjectID: &project,
+		AsOf:      time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		PageSize:  10,
+	})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrLeaderboardNotFound)
+
+	// The global board has no snapshot at all
+	_, err = svc.GetLeaderboardAsOf(ctx, &leaderboardscoring.GetLeaderboardAsOfRequest{AsOf: june2, PageSize: 10})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrLeaderboardNotFound)
+
+	_, err = svc.GetLeaderboardAsOf(ctx, &leaderboardscoring.GetLeaderboardAsOfRequest{PageSize: 10})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+}
+
+func TestHistoryService_GetUserRankHistory(t *testing.T) {
+	key := leaderboardscoring.LeaderboardKey("global", leaderboardscoring.AllTime.String(), "")
+	now := time.Now().UTC()
+
+	store := &fakeSnapshotStore{rows: []leaderboardscoring.SnapshotRow{
+		snapshotRow(key, "7", 5, 10, now.AddDate(0, 0, -120)),
+		snapshotRow(key, "7", 3, 30, now.AddDate(0, 0, -30)),
+		snapshotRow(key, "7", 1, 80, now.AddDate(0, 0, -1)),
+		snapshotRow(key, "8", 2, 60, now.AddDate(0, 0, -1)),
+	}}
+	svc := leaderboardscoring.NewHistoryService(leaderboardscoring.SnapshotRetentionConfig{}, store, leaderboardscoring.NewValidator())
+	ctx := context.Background()
+
+	// Defaults to the last 90 days
+	res, err := svc.GetUserRankHistory(ctx, &leaderboardscoring.GetUserRankHistoryRequest{UserID: "7"})
+	require.NoError(t, err)
+	require.Len(t, res.Points, 2)
+	assert.Equal(t, int64(3), res.Points[0].Rank)
+	assert.Equal(t, int64(1), res.Points[1].Rank)
+	assert.Equal(t, int64(80), res.Points[1].Score)
+
+	res, err = svc.GetUserRankHistory(ctx, &leaderboardscoring.GetUserRankHistoryRequest{
+		UserID: "7",
+		From:   now.AddDate(0, 0, -200),
+		To:     now.AddDate(0, 0, -30),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Points, 1)
+	assert.Equal(t, int64(5), res.Points[0].Rank)
+
+	_, err = svc.GetUserRankHistory(ctx, &leaderboardscoring.GetUserRankHistoryRequest{
+		UserID: "7",
+		From:   now,
+		To:     now.AddDate(0, 0, -1),
+	})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+
+	_, err = svc.GetUserRankHistory(ctx, &leaderboardscoring.GetUserRankHistoryRequest{})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+}
+
+func TestHistoryService_PruneSnapshots(t *testing.T) {
+	store := &fakeSnapshotStore{}
+	svc := leaderboardscoring.NewHistoryService(
+		leaderboardscoring.SnapshotRetentionConfig{WeeklyFor: 365 * 24 * time.Hour},
+		store,
+		leaderboardscoring.NewValidator(),
+	)
+
+	// Wednesday afternoon
+	res, err := svc.PruneSnapshots(context.Background(), time.Date(2025, 9, 10, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, leaderboardscoring.SnapshotPruneResult{ThinnedDaily: 1, ThinnedWeekly: 1, Expired: 2}, res)
+
+	// Today's snapshots stay untouched
+	assert.Equal(t, time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC), store.thinned[leaderboardscoring.SnapshotBucketDay])
+	// 90 days back is Thursday 2025-06-12, only the whole weeks before its Monday are thinned
+	assert.Equal(t, time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), store.thinned[leaderboardscoring.SnapshotBucketWeek])
+	assert.Equal(t, time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC), store.expired)
+}
+
+func TestHistoryService_PruneSnapshots_KeepsWeeklyForever(t *testing.T) {
+	store := &fakeSnapshotStore{}
+	svc := leaderboardscoring.NewHistoryService(leaderboardscoring.SnapshotRetentionConfig{}, store, leaderboardscoring.NewValidator())
+
+	res, err := svc.PruneSnapshots(context.Background(), time.Date(2025, 9, 10, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, res.Expired)
+	assert.True(t, store.expired.IsZero())
+}
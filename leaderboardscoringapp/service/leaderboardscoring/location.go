@@ -0,0 +1,47 @@
+package leaderboardscoring
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+)
+
+// projectLocations resolves the timezone used for the period boundaries of per-project leaderboards
+type projectLocations map[string]*time.Location
+
+func newProjectLocations(timezones map[string]string) projectLocations {
+	locations := make(projectLocations, len(timezones))
+
+	for projectID, tz := range timezones {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			logger.L().Warn("invalid project timezone; falling back to UTC",
+				slog.String("project_id", projectID),
+				slog.String("timezone", tz),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		locations[projectID] = loc
+	}
+
+	return locations
+}
+
+func (pl projectLocations) of(projectID string) *time.Location {
+	if loc, ok := pl[projectID]; ok {
+		return loc
+	}
+
+	return time.UTC
+}
+
+// requestLocation returns UTC for global leaderboards and the project's timezone otherwise
+func (s *Service) requestLocation(req *GetLeaderboardRequest) *time.Location {
+	if req.ProjectID == nil {
+		return time.UTC
+	}
+
+	return s.locations.of(*req.ProjectID)
+}
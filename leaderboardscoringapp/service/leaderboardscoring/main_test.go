@@ -0,0 +1,26 @@
+package leaderboardscoring_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gocasters/rankr/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	// the logger only accepts relative paths, so it writes below a scratch working directory
+	dir, err := os.MkdirTemp("", "leaderboardscoring-test")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	if err := logger.Init(logger.Config{Level: "error", FilePath: "logs/test.log"}); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -8,6 +8,15 @@ var (
 	ErrInvalidArguments     = errors.New("invalid arguments provided for the request")
 	ErrNotImplemented       = errors.New("repository method not implemented")
 	ErrLeaderboardNotFound  = errors.New("leaderboard data not found for the given criteria")
+
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+	ErrSeasonNotFound  = errors.New("season not found")
+	ErrSeasonClosed    = errors.New("season is closed, its standings are final")
+	ErrSeasonNameTaken = errors.New("a season with this name already exists")
+
+	ErrAdjustmentNotFound = errors.New("adjustment not found")
+	ErrAdjustmentReversed = errors.New("adjustment is already reversed")
 )
 
 const MsgSuccessfullyProcessedEvent = "successfully processed score event"
@@ -3,6 +3,7 @@ package leaderboardscoring
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/gocasters/rankr/pkg/privacy"
 	"github.com/gocasters/rankr/pkg/timettl"
 	eventpb "github.com/gocasters/rankr/protobuf/golang/event/v1"
 	leaderboardscoringpb "github.com/gocasters/rankr/protobuf/golang/leaderboardscoring/v1"
@@ -385,6 +386,7 @@ type GetLeaderboardResponse struct {
 	Timeframe       string
 	ProjectID       *string
 	LeaderboardRows []LeaderboardRow
+	RankingMode     RankingMode
 }
 
 func ToProtoTimeframe(tf string) leaderboardscoringpb.Timeframe {
@@ -399,11 +401,41 @@ func ToProtoTimeframe(tf string) leaderboardscoringpb.Timeframe {
 		return leaderboardscoringpb.Timeframe_TIMEFRAME_WEEKLY
 	case Daily.String():
 		return leaderboardscoringpb.Timeframe_TIMEFRAME_DAILY
+	case Trending.String():
+		return leaderboardscoringpb.Timeframe_TIMEFRAME_TRENDING
 	default:
 		return leaderboardscoringpb.Timeframe_TIMEFRAME_UNSPECIFIED
 	}
 }
 
+func ToProtoRankingMode(mode RankingMode) leaderboardscoringpb.RankingMode {
+	switch mode {
+	case RankingOrdinal:
+		return leaderboardscoringpb.RankingMode_RANKING_MODE_ORDINAL
+	case RankingCompetition:
+		return leaderboardscoringpb.RankingMode_RANKING_MODE_COMPETITION
+	case RankingDense:
+		return leaderboardscoringpb.RankingMode_RANKING_MODE_DENSE
+	case RankingFirstReached:
+		return leaderboardscoringpb.RankingMode_RANKING_MODE_FIRST_REACHED
+	default:
+		return leaderboardscoringpb.RankingMode_RANKING_MODE_UNSPECIFIED
+	}
+}
+
+func FromProtoRankingMode(mode leaderboardscoringpb.RankingMode) RankingMode {
+	switch mode {
+	case leaderboardscoringpb.RankingMode_RANKING_MODE_COMPETITION:
+		return RankingCompetition
+	case leaderboardscoringpb.RankingMode_RANKING_MODE_DENSE:
+		return RankingDense
+	case leaderboardscoringpb.RankingMode_RANKING_MODE_FIRST_REACHED:
+		return RankingFirstReached
+	default:
+		return RankingOrdinal
+	}
+}
+
 func FromProtoTimeframe(tf leaderboardscoringpb.Timeframe) string {
 	switch tf {
 	case leaderboardscoringpb.Timeframe_TIMEFRAME_ALL_TIME:
@@ -416,6 +448,8 @@ func FromProtoTimeframe(tf leaderboardscoringpb.Timeframe) string {
 		return Weekly.String()
 	case leaderboardscoringpb.Timeframe_TIMEFRAME_DAILY:
 		return Daily.String()
+	case leaderboardscoringpb.Timeframe_TIMEFRAME_TRENDING:
+		return Trending.String()
 	default:
 		return TimeframeUnspecified.String()
 	}
@@ -428,37 +462,340 @@ type GetLeaderboardRequest struct {
 	Offset    int32
 }
 
-// leaderboard:global:all_time , leaderboard:global:daily
-// leaderboard:1001:all_time , leaderboard:1001:daily
-func (q *GetLeaderboardRequest) BuildKey() string {
+type WatchLeaderboardRequest struct {
+	Timeframe string
+	ProjectID *string
+	PageSize  int32
+	// MinInterval is the minimum time between two updates, raised to the server minimum
+	MinInterval time.Duration
+	// ResumeVersion is the version of the last update the client received, zero for none
+	ResumeVersion uint64
+}
+
+func (q *WatchLeaderboardRequest) getLeaderboardRequest() *GetLeaderboardRequest {
+	return &GetLeaderboardRequest{
+		Timeframe: q.Timeframe,
+		ProjectID: q.ProjectID,
+		PageSize:  q.PageSize,
+	}
+}
+
+// LeaderboardUpdate is one page sent on a WatchLeaderboard stream.
+// Version identifies the content of the page and is used to resume a stream.
+type LeaderboardUpdate struct {
+	Timeframe       string
+	ProjectID       *string
+	LeaderboardRows []LeaderboardRow
+	RankingMode     RankingMode
+	Version         uint64
+	Initial         bool
+}
+
+type ExportFormat string
 
-	key := "leaderboard"
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+	ExportFormatXLSX  ExportFormat = "xlsx"
+)
 
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatJSONL:
+		return "application/x-ndjson"
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+type ExportLeaderboardRequest struct {
+	Timeframe string
+	ProjectID *string
+	// Period selects a past period of a daily, weekly, monthly or yearly board
+	// (e.g., "2025-06-01", "2025-W23", "2025-06", "2025"); empty means the current one
+	Period string
+	Format ExportFormat
+	// Viewer is the caller, anonymous contributors it may not see are exported by pseudonym
+	Viewer privacy.Viewer
+}
+
+// FileName returns a file name for the export, e.g. "leaderboard_1001_monthly_2025-06.csv"
+func (q *ExportLeaderboardRequest) FileName() string {
+	scope := globalScope
 	if q.ProjectID != nil {
-		key += fmt.Sprintf(":%s", *q.ProjectID)
-	} else {
-		key += ":global"
+		scope = *q.ProjectID
+	}
+
+	name := fmt.Sprintf("leaderboard_%s_%s", scope, q.Timeframe)
+	if q.Period != "" {
+		name += "_" + q.Period
 	}
 
-	key += fmt.Sprintf(":%s", q.Timeframe)
+	return name + "." + string(q.Format)
+}
+
+func (q *ExportLeaderboardRequest) getLeaderboardRequest() *GetLeaderboardRequest {
+	return &GetLeaderboardRequest{
+		Timeframe: q.Timeframe,
+		ProjectID: q.ProjectID,
+		PageSize:  exportPageSize,
+	}
+}
+
+// ExportRow is one contributor in a leaderboard export.
+// Breakdown holds the points per event type and is nil for trending boards.
+type ExportRow struct {
+	Rank        int64
+	UserID      string
+	Username    string
+	DisplayName string
+	Score       int64
+	Breakdown   map[EventName]int64
+}
+
+// leaderboard:{global}:all_time , leaderboard:{global}:daily:2025-06-01
+// leaderboard:{1001}:all_time , leaderboard:{1001}:daily:2025-06-01
+// leaderboard:{global}:trending , leaderboard:{1001}:trending
+//
+// The period is taken from "at", which must already be in the leaderboard's timezone.
+func (q *GetLeaderboardRequest) BuildKey(at time.Time) string {
+	scope := globalScope
+	if q.ProjectID != nil {
+		scope = *q.ProjectID
+	}
 
 	var period string
 	switch q.Timeframe {
 	case Yearly.String():
-		period = timettl.GetYear()
+		period = timettl.YearOf(at)
 	case Monthly.String():
-		period = timettl.GetMonth()
+		period = timettl.MonthOf(at)
 	case Weekly.String():
-		period = timettl.GetWeek()
+		period = timettl.WeekOf(at)
 	case Daily.String():
-		period = timettl.GetDay()
-	case AllTime.String():
-		return key
+		period = timettl.DayOf(at)
+	case AllTime.String(), Trending.String():
 	default:
 		period = "unknown"
 	}
 
-	key += fmt.Sprintf(":%s", period)
+	return LeaderboardKey(scope, q.Timeframe, period)
+}
+
+// DefaultDeadLetterPageSize is the page size of ListDeadLetters when none is given
+const DefaultDeadLetterPageSize = 50
+
+// DeadLetterFilter selects dead letters, unset fields match any letter. Reason matches a
+// case-insensitive part of the failure reason.
+type DeadLetterFilter struct {
+	EventID   string
+	UserID    string
+	ProjectID string
+	Reason    string
+}
+
+func (f DeadLetterFilter) IsEmpty() bool {
+	return f == DeadLetterFilter{}
+}
+
+type ListDeadLettersRequest struct {
+	Filter DeadLetterFilter
+	// After is the NextAfter of the previous page, zero for the first one
+	After uint64
+	Limit int
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters []DeadLetter
+	// NextAfter is zero on the last page
+	NextAfter uint64
+	// Depth is the number of letters in the queue, matching the filter or not
+	Depth int64
+}
+
+// DeadLetterSelection picks the letters of a reprocess or discard: a single sequence, all
+// letters, or the letters matching a filter. Exactly one of them must be set.
+type DeadLetterSelection struct {
+	Sequence uint64
+	All      bool
+	Filter   DeadLetterFilter
+}
+
+type DeadLetterActionRequest struct {
+	Selection DeadLetterSelection
+	// Actor is who asked for the action, it is kept in the audit record with Note
+	Actor string
+	Note  string
+}
+
+// DeadLetterSkip is a selected letter that was left in the queue
+type DeadLetterSkip struct {
+	Sequence uint64
+	Reason   string
+}
+
+type DeadLetterActionResponse struct {
+	// Affected is the number of letters reprocessed or discarded
+	Affected int
+	Skipped  []DeadLetterSkip
+}
+
+// GetLeaderboardAsOfRequest reads an all-time board as it was at AsOf
+// DefaultHistoryPageSize is the page size of GetLeaderboardAsOf over HTTP when none is given
+const DefaultHistoryPageSize = 50
+
+type GetLeaderboardAsOfRequest struct {
+	ProjectID *string
+	AsOf      time.Time
+	PageSize  int32
+	Offset    int32
+}
+
+type GetLeaderboardAsOfResponse struct {
+	ProjectID *string
+	// SnapshotAt is the time of the snapshot the rows were read from, at or before AsOf
+	SnapshotAt      time.Time
+	LeaderboardRows []LeaderboardRow
+}
+
+// GetUserRankHistoryRequest reads the snapshots of one user taken in [From, To). Zero To
+// means now, zero From 90 days before To.
+type GetUserRankHistoryRequest struct {
+	UserID    string
+	ProjectID *string
+	From      time.Time
+	To        time.Time
+}
+
+type RankHistoryPoint struct {
+	SnapshotAt time.Time
+	Rank       int64
+	Score      int64
+}
+
+type GetUserRankHistoryResponse struct {
+	UserID    string
+	ProjectID *string
+	Points    []RankHistoryPoint
+}
+
+// SeasonRequest defines a season to create or update. Nil WinnerCount means
+// DefaultSeasonWinnerCount, an empty RankingMode ordinal.
+type SeasonRequest struct {
+	Name         string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	ProjectIDs   []string
+	ScoringRules map[EventName]int64
+	RankingMode  RankingMode
+	WinnerCount  *int
+}
+
+func (r SeasonRequest) season() Season {
+	season := Season{
+		Name:         r.Name,
+		StartsAt:     r.StartsAt.UTC(),
+		EndsAt:       r.EndsAt.UTC(),
+		ProjectIDs:   r.ProjectIDs,
+		ScoringRules: r.ScoringRules,
+		RankingMode:  r.RankingMode,
+		WinnerCount:  DefaultSeasonWinnerCount,
+	}
+	if season.RankingMode == "" {
+		season.RankingMode = RankingOrdinal
+	}
+	if r.WinnerCount != nil {
+		season.WinnerCount = *r.WinnerCount
+	}
+
+	return season
+}
+
+// ListSeasonsRequest lists seasons in Status, all seasons when it is empty
+type ListSeasonsRequest struct {
+	Status   SeasonStatus
+	PageSize int32
+	Offset   int32
+}
 
-	return key
+type GetSeasonStandingsRequest struct {
+	SeasonID int64
+	PageSize int32
+	Offset   int32
 }
+
+type GetSeasonStandingsResponse struct {
+	Season Season
+	// Final is set once the season is closed, the standings of open seasons are live
+	Final     bool
+	Standings []SeasonStanding
+}
+
+type ListUserBadgesRequest struct {
+	UserID string
+}
+
+// ExplainScoreRequest names the board of a user's score like an export: Period selects a
+// past period, empty means the current one
+type ExplainScoreRequest struct {
+	UserID    string
+	Timeframe string
+	ProjectID *string
+	Period    string
+}
+
+// ListScoreEventsRequest lists the events behind a score. EventName, Project and Day (a
+// date in the board's timezone) are optional filters.
+type ListScoreEventsRequest struct {
+	ExplainScoreRequest
+	EventName EventName
+	Project   string
+	Day       string
+	PageSize  int32
+	Offset    int32
+}
+
+// DefaultScoreEventsPageSize is the page size of ListScoreEvents over HTTP when none is given
+const DefaultScoreEventsPageSize = 50
+
+type GetUserStreakRequest struct {
+	UserID string
+}
+
+type ListStreaksRequest struct {
+	PageSize int32
+	Offset   int32
+}
+
+// CreateAdjustmentRequest grants positive or removes negative Points. Timeframe is the
+// scope, AdjustmentScopeAll for every board. Period dates the adjustment into a past
+// period of a period timeframe, empty means the current one.
+type CreateAdjustmentRequest struct {
+	UserID    string
+	ProjectID string
+	Timeframe string
+	Period    string
+	Points    int64
+	Reason    string
+	Actor     string
+}
+
+type ReverseAdjustmentRequest struct {
+	ID     int64
+	Reason string
+	Actor  string
+}
+
+type ListAdjustmentsRequest struct {
+	UserID    string
+	ProjectID string
+	PageSize  int32
+	Offset    int32
+}
+
+// DefaultAdjustmentPageSize is the page size of ListAdjustments when none is given
+const DefaultAdjustmentPageSize = 50
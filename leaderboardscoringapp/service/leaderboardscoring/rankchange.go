@@ -0,0 +1,108 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gocasters/rankr/pkg/logger"
+)
+
+// MaxOvertakenPerChange bounds the overtaken list of one change, a jump from
+// the bottom of a large board must not produce a huge event.
+const MaxOvertakenPerChange = 10
+
+// userRanksForNotification returns the user's ranks on the keys about to be updated,
+// or nil when no notifier is configured or the ranks cannot be read.
+func (s *Service) userRanksForNotification(ctx context.Context, upsertScores []UpsertScore, userID string) []UserRank {
+	if s.rankNotifier == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(upsertScores)*2)
+	for _, us := range upsertScores {
+		keys = append(keys, us.Keys...)
+	}
+
+	ranks, err := s.leaderboard.GetUserRanks(ctx, keys, userID)
+	if err != nil {
+		logger.L().Warn("failed to get user ranks before update; skipping rank change notification",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	return ranks
+}
+
+// notifyRankChanges compares the ranks after the update with ranksBefore and hands the
+// movements to the notifier. Failures are logged only, scoring must not fail because of them.
+func (s *Service) notifyRankChanges(ctx context.Context, userID string, ranksBefore []UserRank) {
+	log := logger.L()
+
+	keys := make([]string, 0, len(ranksBefore))
+	for _, r := range ranksBefore {
+		keys = append(keys, r.Key)
+	}
+
+	ranksAfter, err := s.leaderboard.GetUserRanks(ctx, keys, userID)
+	if err != nil {
+		log.Warn("failed to get user ranks after update; skipping rank change notification",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	changes := make([]RankChange, 0, len(ranksAfter))
+	for i, after := range ranksAfter {
+		if after.Rank == 0 {
+			continue
+		}
+
+		before := ranksBefore[i]
+		change := RankChange{
+			LeaderboardKey: after.Key,
+			UserID:         userID,
+			OldRank:        before.Rank,
+			NewRank:        after.Rank,
+			Score:          after.Score,
+		}
+
+		// Users now ranked between the new and the old position were passed by this update
+		if before.Rank > after.Rank {
+			change.Overtaken = s.overtakenUsers(ctx, after.Key, after.Rank, before.Rank)
+		}
+
+		changes = append(changes, change)
+	}
+
+	if len(changes) > 0 {
+		s.rankNotifier.Notify(changes)
+	}
+}
+
+func (s *Service) overtakenUsers(ctx context.Context, key string, newRank, oldRank int64) []string {
+	stop := oldRank - 1
+	if stop-newRank+1 > MaxOvertakenPerChange {
+		stop = newRank + MaxOvertakenPerChange - 1
+	}
+
+	// Ranks are 1-based, the query is 0-based: rank newRank+1 is index newRank
+	res, err := s.leaderboard.GetLeaderboard(ctx, &LeaderboardQuery{
+		Key:   key,
+		Start: newRank,
+		Stop:  stop,
+	})
+	if err != nil {
+		logger.L().Warn("failed to get overtaken users",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	overtaken := make([]string, 0, len(res.LeaderboardRows))
+	for _, row := range res.LeaderboardRows {
+		overtaken = append(overtaken, row.UserID)
+	}
+
+	return overtaken
+}
@@ -0,0 +1,204 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// RankingMode decides which ranks users with equal scores get.
+type RankingMode string
+
+const (
+	// RankingOrdinal gives every user its own rank, equal scores are ordered by user ID (1,2,3,4)
+	RankingOrdinal RankingMode = "ordinal"
+	// RankingCompetition gives equal scores the same rank and skips the following ranks (1,2,2,4)
+	RankingCompetition RankingMode = "competition"
+	// RankingDense gives equal scores the same rank without gaps (1,2,2,3)
+	RankingDense RankingMode = "dense"
+	// RankingFirstReached orders equal scores by who reached the score first (1,2,3,4)
+	RankingFirstReached RankingMode = "first_reached"
+)
+
+// rankingWildcard matches any scope or timeframe in RankingConfig.Boards
+const rankingWildcard = "*"
+
+func (m RankingMode) Validate() error {
+	return validation.Validate(string(m),
+		validation.In(
+			string(RankingOrdinal),
+			string(RankingCompetition),
+			string(RankingDense),
+			string(RankingFirstReached),
+		),
+	)
+}
+
+// RankingConfig selects the ranking mode of each leaderboard.
+//
+// Boards maps "<scope>:<timeframe>" to a mode, where scope is "global" or a project ID
+// and either part may be "*". The most specific entry wins: "1001:monthly", then
+// "1001:*", then "*:monthly", then Default. Trending boards are always ordinal.
+//
+// The first_reached mode stores the time a user reached its score in the fraction of the
+// cached score, it must therefore be set before a board receives its first event.
+type RankingConfig struct {
+	Default RankingMode            `koanf:"default"`
+	Boards  map[string]RankingMode `koanf:"boards"`
+}
+
+func (c RankingConfig) Validate() error {
+	if err := c.Default.Validate(); err != nil {
+		return fmt.Errorf("ranking.default: %w", err)
+	}
+
+	for board, mode := range c.Boards {
+		scope, timeframe, ok := strings.Cut(board, ":")
+		if !ok || scope == "" || timeframe == "" {
+			return fmt.Errorf("ranking.boards: %q is not <scope>:<timeframe>", board)
+		}
+		if timeframe == Trending.String() {
+			return fmt.Errorf("ranking.boards: %q: trending boards are always ordinal", board)
+		}
+		if timeframe != rankingWildcard && !isRankedTimeframe(timeframe) {
+			return fmt.Errorf("ranking.boards: %q: unknown timeframe %q", board, timeframe)
+		}
+		if err := mode.Validate(); err != nil {
+			return fmt.Errorf("ranking.boards: %q: %w", board, err)
+		}
+	}
+
+	return nil
+}
+
+func isRankedTimeframe(timeframe string) bool {
+	for _, tf := range Timeframes {
+		if tf.String() == timeframe {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Mode returns the ranking mode of the board of scope and timeframe.
+func (c RankingConfig) Mode(scope, timeframe string) RankingMode {
+	if timeframe == Trending.String() {
+		return RankingOrdinal
+	}
+
+	for _, board := range []string{
+		scope + ":" + timeframe,
+		scope + ":" + rankingWildcard,
+		rankingWildcard + ":" + timeframe,
+	} {
+		if mode, ok := c.Boards[board]; ok && mode != "" {
+			return mode
+		}
+	}
+
+	if c.Default == "" {
+		return RankingOrdinal
+	}
+
+	return c.Default
+}
+
+func (c RankingConfig) modeOf(req *GetLeaderboardRequest) RankingMode {
+	scope := globalScope
+	if req.ProjectID != nil {
+		scope = *req.ProjectID
+	}
+
+	return c.Mode(scope, req.Timeframe)
+}
+
+// firstReachedEpoch is the earliest time first_reached can tell apart
+var firstReachedEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// FirstReachedFraction returns the tiebreaker a first_reached board adds to a score reached
+// at time t. It lies in [0, 1) and is higher for earlier times, so ZREVRANGE orders equal
+// scores by who reached them first, with one second resolution. The integer part of the
+// cached score keeps 21 bits of float64 precision, enough for scores up to about 2 million.
+func FirstReachedFraction(t time.Time) float64 {
+	const steps = 1 << 32
+
+	elapsed := int64(t.Sub(firstReachedEpoch) / time.Second)
+	elapsed = max(0, min(elapsed, steps-1))
+
+	return float64(steps-1-elapsed) / steps
+}
+
+// cachedScore converts a score read from a cache key to the score shown to users. The
+// fraction of first_reached boards is dropped, other boards only hold whole numbers.
+func cachedScore(stored float64) int64 {
+	return int64(math.Floor(stored))
+}
+
+// ranker assigns the ranks of consecutive rows read from a board in ZREVRANGE order.
+type ranker struct {
+	mode RankingMode
+	// higher is the number of users (competition) or distinct scores (dense) above the first row
+	higher    int64
+	started   bool
+	prevScore int64
+	rank      int64
+}
+
+// next returns the rank of the row at the 1-based position with the given score
+func (r *ranker) next(position, score int64) int64 {
+	switch r.mode {
+	case RankingCompetition:
+		switch {
+		case !r.started:
+			r.rank = r.higher + 1
+		case score != r.prevScore:
+			r.rank = position
+		}
+	case RankingDense:
+		switch {
+		case !r.started:
+			r.rank = r.higher + 1
+		case score != r.prevScore:
+			r.rank++
+		}
+	default:
+		r.rank = position
+	}
+
+	r.started, r.prevScore = true, score
+	return r.rank
+}
+
+// newRanker returns a ranker for rows read from key starting with the given first row.
+// Only competition and dense pages that don't start at the top need to ask the cache how
+// many users are ranked above.
+func (s *Service) newRanker(ctx context.Context, mode RankingMode, key string, offset int64, first LeaderboardEntry) (*ranker, error) {
+	return newCacheRanker(ctx, s.leaderboard, mode, key, offset, first)
+}
+
+func newCacheRanker(ctx context.Context, cache LeaderboardCache, mode RankingMode, key string, offset int64, first LeaderboardEntry) (*ranker, error) {
+	r := &ranker{mode: mode}
+	if offset == 0 || (mode != RankingCompetition && mode != RankingDense) {
+		return r, nil
+	}
+
+	higher, err := cache.CountHigherScores(ctx, key, first.Score, mode == RankingDense)
+	if err != nil {
+		return nil, fmt.Errorf("count higher scores: %w", err)
+	}
+	r.higher = higher
+
+	return r, nil
+}
+
+// rankRows replaces the positional ranks of rows with the ranks of r
+func (r *ranker) rankRows(rows []LeaderboardEntry) {
+	for i := range rows {
+		rows[i].Rank = r.next(rows[i].Rank, rows[i].Score)
+	}
+}
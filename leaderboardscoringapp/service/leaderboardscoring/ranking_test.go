@@ -0,0 +1,159 @@
+package leaderboardscoring_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/memoryrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankingConfig_Mode(t *testing.T) {
+	cfg := leaderboardscoring.RankingConfig{
+		Default: leaderboardscoring.RankingCompetition,
+		Boards: map[string]leaderboardscoring.RankingMode{
+			"1001:monthly": leaderboardscoring.RankingFirstReached,
+			"1001:*":       leaderboardscoring.RankingDense,
+			"*:daily":      leaderboardscoring.RankingOrdinal,
+		},
+	}
+
+	tests := []struct {
+		scope, timeframe string
+		want             leaderboardscoring.RankingMode
+	}{
+		{"1001", "monthly", leaderboardscoring.RankingFirstReached},
+		{"1001", "daily", leaderboardscoring.RankingDense},
+		{"2002", "daily", leaderboardscoring.RankingOrdinal},
+		{"global", "all_time", leaderboardscoring.RankingCompetition},
+		{"1001", "trending", leaderboardscoring.RankingOrdinal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, cfg.Mode(tt.scope, tt.timeframe), "%s:%s", tt.scope, tt.timeframe)
+	}
+
+	assert.Equal(t, leaderboardscoring.RankingOrdinal, leaderboardscoring.RankingConfig{}.Mode("global", "all_time"))
+}
+
+func TestRankingConfig_Validate(t *testing.T) {
+	valid := leaderboardscoring.RankingConfig{
+		Default: leaderboardscoring.RankingDense,
+		Boards:  map[string]leaderboardscoring.RankingMode{"*:weekly": leaderboardscoring.RankingFirstReached},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, leaderboardscoring.RankingConfig{}.Validate())
+
+	invalid := []leaderboardscoring.RankingConfig{
+		{Default: "olympic"},
+		{Boards: map[string]leaderboardscoring.RankingMode{"1001": leaderboardscoring.RankingDense}},
+		{Boards: map[string]leaderboardscoring.RankingMode{"1001:hourly": leaderboardscoring.RankingDense}},
+		{Boards: map[string]leaderboardscoring.RankingMode{"1001:trending": leaderboardscoring.RankingDense}},
+		{Boards: map[string]leaderboardscoring.RankingMode{"1001:daily": "olympic"}},
+	}
+	for _, cfg := range invalid {
+		assert.Error(t, cfg.Validate(), "%+v", cfg)
+	}
+}
+
+func TestFirstReachedFraction(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	earlier := leaderboardscoring.FirstReachedFraction(at)
+	later := leaderboardscoring.FirstReachedFraction(at.Add(time.Second))
+
+	assert.Greater(t, earlier, later)
+	assert.Less(t, leaderboardscoring.FirstReachedFraction(time.Time{}), 1.0)
+	assert.GreaterOrEqual(t, leaderboardscoring.FirstReachedFraction(at.AddDate(500, 0, 0)), 0.0)
+}
+
+func TestWatchLeaderboard_RanksByMode(t *testing.T) {
+	tests := []struct {
+		mode  leaderboardscoring.RankingMode
+		ranks []int64
+	}{
+		{leaderboardscoring.RankingOrdinal, []int64{1, 2, 3, 4}},
+		{leaderboardscoring.RankingCompetition, []int64{1, 2, 2, 4}},
+		{leaderboardscoring.RankingDense, []int64{1, 2, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			cache := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{})
+			for id, score := range map[string]int64{"1": 30, "2": 20, "3": 20, "4": 10} {
+				require.NoError(t, cache.UpsertScores(context.Background(), &leaderboardscoring.UpsertScore{
+					Keys: []string{watchedKey}, UserID: id, Score: score,
+				}))
+			}
+
+			svc := leaderboardscoring.NewService(leaderboardscoring.Config{
+				Ranking: leaderboardscoring.RankingConfig{Default: tt.mode},
+			}, nil, cache, nil, "", leaderboardscoring.NewValidator(), nil, nil, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			updates, _ := watch(ctx, svc, &leaderboardscoring.WatchLeaderboardRequest{Timeframe: "all_time", PageSize: 10})
+			update := nextUpdate(t, updates)
+
+			assert.Equal(t, tt.mode, update.RankingMode)
+			ranks := make([]int64, 0, len(update.LeaderboardRows))
+			for _, row := range update.LeaderboardRows {
+				ranks = append(ranks, row.Rank)
+			}
+			assert.Equal(t, tt.ranks, ranks)
+		})
+	}
+}
+
+func TestExportLeaderboard_DenseRanks(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey,
+		leaderboardscoring.LeaderboardEntry{Rank: 1, UserID: "3", Score: 20},
+		leaderboardscoring.LeaderboardEntry{Rank: 2, UserID: "2", Score: 20},
+		leaderboardscoring.LeaderboardEntry{Rank: 3, UserID: "1", Score: 10},
+	)
+
+	svc := leaderboardscoring.NewService(leaderboardscoring.Config{
+		Ranking: leaderboardscoring.RankingConfig{Default: leaderboardscoring.RankingDense},
+	}, &fakeScoreStore{}, cache, nil, "", leaderboardscoring.NewValidator(), nil, nil, nil)
+
+	var buf bytes.Buffer
+	err := svc.ExportLeaderboard(context.Background(), &leaderboardscoring.ExportLeaderboardRequest{
+		Timeframe: "all_time",
+		Format:    leaderboardscoring.ExportFormatCSV,
+	}, &buf)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, "1", records[1][0])
+	assert.Equal(t, "1", records[2][0])
+	assert.Equal(t, "2", records[3][0])
+}
+
+func TestExportLeaderboard_FirstReachedPastPeriodOrdersByReachTime(t *testing.T) {
+	store := &fakeScoreStore{scores: []leaderboardscoring.LeaderboardEntry{{Rank: 1, UserID: "1", Score: 42}}}
+
+	svc := leaderboardscoring.NewService(leaderboardscoring.Config{
+		Ranking: leaderboardscoring.RankingConfig{
+			Boards: map[string]leaderboardscoring.RankingMode{"global:monthly": leaderboardscoring.RankingFirstReached},
+		},
+	}, store, newFakeLeaderboardCache(), nil, "", leaderboardscoring.NewValidator(), nil, nil, nil)
+
+	err := svc.ExportLeaderboard(context.Background(), &leaderboardscoring.ExportLeaderboardRequest{
+		Timeframe: "monthly",
+		Period:    "2025-06",
+		Format:    leaderboardscoring.ExportFormatCSV,
+	}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, store.filters)
+	assert.True(t, store.filters[0].OrderByReachTime)
+}
@@ -0,0 +1,487 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSeasonRefreshInterval = time.Minute
+	defaultSeasonCloseDelay      = 10 * time.Minute
+	defaultSeasonBoardRetention  = 30 * 24 * time.Hour
+	// DefaultSeasonWinnerCount is the number of winners of a season created without one
+	DefaultSeasonWinnerCount = 3
+	// seasonFreezeBatchSize is the number of rows read from a season board at a time
+	seasonFreezeBatchSize = 1_000
+)
+
+// SeasonStatus is where a season is in its lifecycle
+type SeasonStatus string
+
+const (
+	SeasonUpcoming SeasonStatus = "upcoming"
+	SeasonActive   SeasonStatus = "active"
+	// SeasonClosing seasons have ended and wait for their standings to be frozen
+	SeasonClosing SeasonStatus = "closing"
+	SeasonClosed  SeasonStatus = "closed"
+)
+
+// Season is a named, time-boxed competition. Events of its projects with a timestamp in
+// [StartsAt, EndsAt) score on the season board, an empty ProjectIDs covers all projects.
+// ScoringRules overrides the points of an event type, zero excludes it.
+type Season struct {
+	ID           int64
+	Name         string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	ProjectIDs   []string
+	ScoringRules map[EventName]int64
+	RankingMode  RankingMode
+	WinnerCount  int
+	// Participants is the number of users in the final standings, set once closed
+	Participants int64
+	// ClosedAt is zero until the standings are frozen, AnnouncedAt until the season-ended
+	// event was published
+	ClosedAt    time.Time
+	AnnouncedAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Status returns the status of the season at now
+func (s Season) Status(now time.Time) SeasonStatus {
+	switch {
+	case !s.ClosedAt.IsZero():
+		return SeasonClosed
+	case now.Before(s.StartsAt):
+		return SeasonUpcoming
+	case now.Before(s.EndsAt):
+		return SeasonActive
+	default:
+		return SeasonClosing
+	}
+}
+
+// Covers reports whether an event of projectID at time at belongs to the season
+func (s Season) Covers(projectID string, at time.Time) bool {
+	if !s.ClosedAt.IsZero() || at.Before(s.StartsAt) || !at.Before(s.EndsAt) {
+		return false
+	}
+	if len(s.ProjectIDs) == 0 {
+		return true
+	}
+
+	for _, id := range s.ProjectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Points returns the points an event type is worth in the season
+func (s Season) Points(event EventName) int64 {
+	if points, ok := s.ScoringRules[event]; ok {
+		return points
+	}
+
+	return calculateScore(event.String())
+}
+
+// SeasonKey returns the cache key of the live board of a season
+func SeasonKey(seasonID int64) string {
+	return LeaderboardKey("season:"+strconv.FormatInt(seasonID, 10), AllTime.String(), "")
+}
+
+// SeasonStanding is one row of a season board. Winner is provisional until the season is closed.
+type SeasonStanding struct {
+	Rank   int64  `json:"rank"`
+	UserID string `json:"user_id"`
+	Score  int64  `json:"score"`
+	Winner bool   `json:"winner"`
+}
+
+// SeasonEnded is published once the standings of a season are frozen
+type SeasonEnded struct {
+	SeasonID     int64            `json:"season_id"`
+	Name         string           `json:"name"`
+	StartsAt     time.Time        `json:"starts_at"`
+	EndsAt       time.Time        `json:"ends_at"`
+	ProjectIDs   []string         `json:"project_ids"`
+	Participants int64            `json:"participants"`
+	Winners      []SeasonStanding `json:"winners"`
+	ClosedAt     time.Time        `json:"closed_at"`
+}
+
+// SeasonStore keeps seasons and their frozen standings.
+type SeasonStore interface {
+	// CreateSeason returns ErrSeasonNameTaken when the name is in use
+	CreateSeason(ctx context.Context, season Season) (Season, error)
+	// UpdateSeason updates a season that is not closed, it returns ErrSeasonNotFound
+	// for unknown or closed seasons and ErrSeasonNameTaken when the name is in use
+	UpdateSeason(ctx context.Context, season Season) (Season, error)
+	DeleteSeason(ctx context.Context, id int64) error
+	// GetSeason returns ErrSeasonNotFound for unknown seasons
+	GetSeason(ctx context.Context, id int64) (Season, error)
+	// ListSeasons returns the seasons in status at now, latest start first. An empty
+	// status lists all seasons.
+	ListSeasons(ctx context.Context, status SeasonStatus, now time.Time, offset, limit int) ([]Season, error)
+	// ListOpenSeasons returns the seasons that are not closed
+	ListOpenSeasons(ctx context.Context) ([]Season, error)
+	// CloseSeason stores the final standings and marks the season closed, it returns
+	// ErrSeasonClosed when it already is
+	CloseSeason(ctx context.Context, id int64, standings []SeasonStanding, closedAt time.Time) error
+	GetSeasonStandings(ctx context.Context, id int64, offset, limit int) ([]SeasonStanding, error)
+	GetSeasonWinners(ctx context.Context, id int64) ([]SeasonStanding, error)
+	// ListUnannouncedSeasons returns the closed seasons whose season-ended event is not published yet
+	ListUnannouncedSeasons(ctx context.Context) ([]Season, error)
+	MarkSeasonAnnounced(ctx context.Context, id int64, at time.Time) error
+}
+
+// SeasonEndedPublisher publishes the season-ended event
+type SeasonEndedPublisher interface {
+	PublishSeasonEnded(ctx context.Context, event SeasonEnded) error
+}
+
+// SeasonConfig tunes how seasons are scored and closed
+type SeasonConfig struct {
+	// RefreshInterval is how long the open seasons are cached for scoring events
+	RefreshInterval time.Duration `koanf:"refresh_interval"`
+	// CloseDelay is how long after its end a season is closed, so late events still count
+	CloseDelay time.Duration `koanf:"close_delay"`
+	// BoardRetention is how long the live board of a season stays in the cache after its end
+	BoardRetention time.Duration `koanf:"board_retention"`
+}
+
+// SeasonService manages seasons: their live boards while they run, and the frozen final
+// standings once they are closed.
+type SeasonService struct {
+	config    SeasonConfig
+	store     SeasonStore
+	cache     LeaderboardCache
+	publisher SeasonEndedPublisher
+	validator Validator
+
+	mu       sync.Mutex
+	open     []Season
+	loadedAt time.Time
+}
+
+func NewSeasonService(
+	config SeasonConfig,
+	store SeasonStore,
+	cache LeaderboardCache,
+	publisher SeasonEndedPublisher,
+	validator Validator,
+) *SeasonService {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaultSeasonRefreshInterval
+	}
+	if config.CloseDelay <= 0 {
+		config.CloseDelay = defaultSeasonCloseDelay
+	}
+	if config.BoardRetention <= 0 {
+		config.BoardRetention = defaultSeasonBoardRetention
+	}
+
+	return &SeasonService{
+		config:    config,
+		store:     store,
+		cache:     cache,
+		publisher: publisher,
+		validator: validator,
+	}
+}
+
+func (s *SeasonService) CreateSeason(ctx context.Context, req SeasonRequest) (Season, error) {
+	if err := s.validator.ValidateSeasonRequest(req); err != nil {
+		return Season{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	season, err := s.store.CreateSeason(ctx, req.season())
+	if err != nil {
+		return Season{}, err
+	}
+	s.invalidate()
+
+	return season, nil
+}
+
+// UpdateSeason replaces the definition of a season that is not closed. Points already on
+// the season board are kept, changed rules and windows only apply to later events.
+func (s *SeasonService) UpdateSeason(ctx context.Context, id int64, req SeasonRequest) (Season, error) {
+	if err := s.validator.ValidateSeasonRequest(req); err != nil {
+		return Season{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	current, err := s.store.GetSeason(ctx, id)
+	if err != nil {
+		return Season{}, err
+	}
+	if !current.ClosedAt.IsZero() {
+		return Season{}, ErrSeasonClosed
+	}
+
+	season := req.season()
+	season.ID = id
+	season, err = s.store.UpdateSeason(ctx, season)
+	if err != nil {
+		return Season{}, err
+	}
+	s.invalidate()
+
+	return season, nil
+}
+
+// DeleteSeason deletes a season that is not closed, closed seasons stay browsable.
+func (s *SeasonService) DeleteSeason(ctx context.Context, id int64) error {
+	season, err := s.store.GetSeason(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !season.ClosedAt.IsZero() {
+		return ErrSeasonClosed
+	}
+
+	if err := s.store.DeleteSeason(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	return nil
+}
+
+func (s *SeasonService) GetSeason(ctx context.Context, id int64) (Season, error) {
+	return s.store.GetSeason(ctx, id)
+}
+
+func (s *SeasonService) ListSeasons(ctx context.Context, req ListSeasonsRequest) ([]Season, error) {
+	if err := s.validator.ValidateListSeasons(req); err != nil {
+		return nil, errors.Join(ErrInvalidArguments, err)
+	}
+
+	return s.store.ListSeasons(ctx, req.Status, time.Now(), int(req.Offset), int(req.PageSize))
+}
+
+// GetSeasonStandings returns a page of the final standings of a closed season, or of the
+// live board of an open one.
+func (s *SeasonService) GetSeasonStandings(ctx context.Context, req GetSeasonStandingsRequest) (GetSeasonStandingsResponse, error) {
+	if err := s.validator.ValidateGetSeasonStandings(req); err != nil {
+		return GetSeasonStandingsResponse{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	season, err := s.store.GetSeason(ctx, req.SeasonID)
+	if err != nil {
+		return GetSeasonStandingsResponse{}, err
+	}
+
+	res := GetSeasonStandingsResponse{Season: season, Final: !season.ClosedAt.IsZero()}
+	if res.Final {
+		res.Standings, err = s.store.GetSeasonStandings(ctx, season.ID, int(req.Offset), int(req.PageSize))
+		if err != nil {
+			return GetSeasonStandingsResponse{}, fmt.Errorf("read season standings: %w", err)
+		}
+
+		return res, nil
+	}
+
+	rows, err := s.cache.GetLeaderboard(ctx, &LeaderboardQuery{
+		Key:   SeasonKey(season.ID),
+		Start: int64(req.Offset),
+		Stop:  int64(req.Offset) + int64(req.PageSize) - 1,
+	})
+	if err != nil {
+		return GetSeasonStandingsResponse{}, fmt.Errorf("read season board: %w", err)
+	}
+	if len(rows.LeaderboardRows) == 0 {
+		return res, nil
+	}
+
+	r, err := newCacheRanker(ctx, s.cache, season.RankingMode, SeasonKey(season.ID), int64(req.Offset), rows.LeaderboardRows[0])
+	if err != nil {
+		return GetSeasonStandingsResponse{}, err
+	}
+	r.rankRows(rows.LeaderboardRows)
+	res.Standings = seasonStandings(season, rows.LeaderboardRows)
+
+	return res, nil
+}
+
+// ScoreEvent adds the points of an event to the boards of the open seasons covering it.
+func (s *SeasonService) ScoreEvent(ctx context.Context, req *EventRequest) error {
+	seasons, err := s.openSeasons(ctx)
+	if err != nil {
+		return err
+	}
+
+	projectID := strconv.FormatUint(req.RepositoryID, 10)
+	for _, season := range seasons {
+		if !season.Covers(projectID, req.Timestamp) {
+			continue
+		}
+
+		points := season.Points(EventName(req.EventName))
+		if points <= 0 {
+			continue
+		}
+
+		upsert := UpsertScore{
+			Keys:     []string{SeasonKey(season.ID)},
+			Score:    points,
+			UserID:   req.UserID,
+			ExpireAt: season.EndsAt.Add(s.config.BoardRetention),
+		}
+		if season.RankingMode == RankingFirstReached {
+			upsert.ReachedAt = req.Timestamp
+		}
+
+		if err := s.cache.UpsertScores(ctx, &upsert); err != nil {
+			return fmt.Errorf("score season %d: %w", season.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// SeasonCloseResult lists the seasons a CloseEndedSeasons run closed and announced
+type SeasonCloseResult struct {
+	Closed    []int64
+	Announced []int64
+}
+
+// CloseEndedSeasons freezes the standings of the seasons that ended CloseDelay before now
+// into the store and publishes their season-ended events. An event that fails to publish
+// is retried by the next run.
+func (s *SeasonService) CloseEndedSeasons(ctx context.Context, now time.Time) (SeasonCloseResult, error) {
+	var res SeasonCloseResult
+
+	seasons, err := s.store.ListOpenSeasons(ctx)
+	if err != nil {
+		return res, fmt.Errorf("list open seasons: %w", err)
+	}
+
+	for _, season := range seasons {
+		if now.Before(season.EndsAt.Add(s.config.CloseDelay)) {
+			continue
+		}
+
+		if err := s.closeSeason(ctx, season, now); err != nil {
+			return res, fmt.Errorf("close season %d: %w", season.ID, err)
+		}
+		res.Closed = append(res.Closed, season.ID)
+	}
+	if len(res.Closed) > 0 {
+		s.invalidate()
+	}
+
+	unannounced, err := s.store.ListUnannouncedSeasons(ctx)
+	if err != nil {
+		return res, fmt.Errorf("list unannounced seasons: %w", err)
+	}
+
+	for _, season := range unannounced {
+		if err := s.announce(ctx, season, now); err != nil {
+			return res, fmt.Errorf("announce season %d: %w", season.ID, err)
+		}
+		res.Announced = append(res.Announced, season.ID)
+	}
+
+	return res, nil
+}
+
+// closeSeason reads the whole season board, ranks it and stores it as the final standings
+func (s *SeasonService) closeSeason(ctx context.Context, season Season, now time.Time) error {
+	key := SeasonKey(season.ID)
+	r := &ranker{mode: season.RankingMode}
+
+	var standings []SeasonStanding
+	for start := int64(0); ; start += seasonFreezeBatchSize {
+		rows, err := s.cache.GetLeaderboard(ctx, &LeaderboardQuery{Key: key, Start: start, Stop: start + seasonFreezeBatchSize - 1})
+		if err != nil {
+			return fmt.Errorf("read season board: %w", err)
+		}
+
+		r.rankRows(rows.LeaderboardRows)
+		standings = append(standings, seasonStandings(season, rows.LeaderboardRows)...)
+
+		if len(rows.LeaderboardRows) < seasonFreezeBatchSize {
+			break
+		}
+	}
+
+	err := s.store.CloseSeason(ctx, season.ID, standings, now.UTC())
+	if errors.Is(err, ErrSeasonClosed) {
+		// Another instance closed it first
+		return nil
+	}
+
+	return err
+}
+
+func (s *SeasonService) announce(ctx context.Context, season Season, now time.Time) error {
+	winners, err := s.store.GetSeasonWinners(ctx, season.ID)
+	if err != nil {
+		return fmt.Errorf("read winners: %w", err)
+	}
+
+	event := SeasonEnded{
+		SeasonID:     season.ID,
+		Name:         season.Name,
+		StartsAt:     season.StartsAt,
+		EndsAt:       season.EndsAt,
+		ProjectIDs:   season.ProjectIDs,
+		Participants: season.Participants,
+		Winners:      winners,
+		ClosedAt:     season.ClosedAt,
+	}
+	if err := s.publisher.PublishSeasonEnded(ctx, event); err != nil {
+		return fmt.Errorf("publish season ended: %w", err)
+	}
+
+	return s.store.MarkSeasonAnnounced(ctx, season.ID, now.UTC())
+}
+
+// openSeasons returns the open seasons, read from the store at most once per RefreshInterval
+func (s *SeasonService) openSeasons(ctx context.Context) ([]Season, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loadedAt.IsZero() && time.Since(s.loadedAt) < s.config.RefreshInterval {
+		return s.open, nil
+	}
+
+	seasons, err := s.store.ListOpenSeasons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list open seasons: %w", err)
+	}
+	s.open, s.loadedAt = seasons, time.Now()
+
+	return s.open, nil
+}
+
+// invalidate makes the next event reload the open seasons. Other instances pick up the
+// change after RefreshInterval.
+func (s *SeasonService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func seasonStandings(season Season, rows []LeaderboardEntry) []SeasonStanding {
+	standings := make([]SeasonStanding, 0, len(rows))
+	for _, row := range rows {
+		standings = append(standings, SeasonStanding{
+			Rank:   row.Rank,
+			UserID: row.UserID,
+			Score:  row.Score,
+			Winner: row.Rank <= int64(season.WinnerCount),
+		})
+	}
+
+	return standings
+}
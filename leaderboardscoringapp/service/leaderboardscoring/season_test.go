@@ -0,0 +1,307 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/memoryrepository"
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSeasonStore keeps seasons and their frozen standings in memory
+type fakeSeasonStore struct {
+	seasons   map[int64]leaderboardscoring.Season
+	standings map[int64][]leaderboardscoring.SeasonStanding
+	nextID    int64
+}
+
+func newFakeSeasonStore() *fakeSeasonStore {
+	return &fakeSeasonStore{
+		seasons:   make(map[int64]leaderboardscoring.Season),
+		standings: make(map[int64][]leaderboardscoring.SeasonStanding),
+	}
+}
+
+func (f *fakeSeasonStore) CreateSeason(_ context.Context, season leaderboardscoring.Season) (leaderboardscoring.Season, error) {
+	for _, s := range f.seasons {
+		if s.Name == season.Name {
+			return leaderboardscoring.Season{}, leaderboardscoring.ErrSeasonNameTaken
+		}
+	}
+
+	f.nextID++
+	season.ID = f.nextID
+	f.seasons[season.ID] = season
+
+	return season, nil
+}
+
+func (f *fakeSeasonStore) UpdateSeason(_ context.Context, season leaderboardscoring.Season) (leaderboardscoring.Season, error) {
+	if current, ok := f.seasons[season.ID]; !ok || !current.ClosedAt.IsZero() {
+		return leaderboardscoring.Season{}, leaderboardscoring.ErrSeasonNotFound
+	}
+	f.seasons[season.ID] = season
+
+	return season, nil
+}
+
+func (f *fakeSeasonStore) DeleteSeason(_ context.Context, id int64) error {
+	delete(f.seasons, id)
+
+	return nil
+}
+
+func (f *fakeSeasonStore) GetSeason(_ context.Context, id int64) (leaderboardscoring.Season, error) {
+	season, ok := f.seasons[id]
+	if !ok {
+		return leaderboardscoring.Season{}, leaderboardscoring.ErrSeasonNotFound
+	}
+
+	return season, nil
+}
+
+func (f *fakeSeasonStore) ListSeasons(_ context.Context, status leaderboardscoring.SeasonStatus, now time.Time, offset, limit int) ([]leaderboardscoring.Season, error) {
+	return f.list(func(s leaderboardscoring.Season) bool { return status == "" || s.Status(now) == status }), nil
+}
+
+func (f *fakeSeasonStore) ListOpenSeasons(context.Context) ([]leaderboardscoring.Season, error) {
+	return f.list(func(s leaderboardscoring.Season) bool { return s.ClosedAt.IsZero() }), nil
+}
+
+func (f *fakeSeasonStore) ListUnannouncedSeasons(context.Context) ([]leaderboardscoring.Season, error) {
+	return f.list(func(s leaderboardscoring.Season) bool { return !s.ClosedAt.IsZero() && s.AnnouncedAt.IsZero() }), nil
+}
+
+func (f *fakeSeasonStore) CloseSeason(_ context.Context, id int64, standings []leaderboardscoring.SeasonStanding, closedAt time.Time) error {
+	season := f.seasons[id]
+	if !season.ClosedAt.IsZero() {
+		return leaderboardscoring.ErrSeasonClosed
+	}
+
+	season.ClosedAt = closedAt
+	season.Participants = int64(len(standings))
+	f.seasons[id] = season
+	f.standings[id] = standings
+
+	return nil
+}
+
+func (f *fakeSeasonStore) GetSeasonStandings(_ context.Context, id int64, offset, limit int) ([]leaderboardscoring.SeasonStanding, error) {
+	standings := f.standings[id]
+	if offset >= len(standings) {
+		return nil, nil
+	}
+
+	return standings[offset:min(offset+limit, len(standings))], nil
+}
+
+func (f *fakeSeasonStore) GetSeasonWinners(_ context.Context, id int64) ([]leaderboardscoring.SeasonStanding, error) {
+	var winners []leaderboardscoring.SeasonStanding
+	for _, standing := range f.standings[id] {
+		if standing.Winner {
+			winners = append(winners, standing)
+		}
+	}
+
+	return winners, nil
+}
+
+func (f *fakeSeasonStore) MarkSeasonAnnounced(_ context.Context, id int64, at time.Time) error {
+	season := f.seasons[id]
+	season.AnnouncedAt = at
+	f.seasons[id] = season
+
+	return nil
+}
+
+func (f *fakeSeasonStore) list(match func(leaderboardscoring.Season) bool) []leaderboardscoring.Season {
+	var seasons []leaderboardscoring.Season
+	for _, season := range f.seasons {
+		if match(season) {
+			seasons = append(seasons, season)
+		}
+	}
+	sort.Slice(seasons, func(i, j int) bool { return seasons[i].ID < seasons[j].ID })
+
+	return seasons
+}
+
+// fakeSeasonPublisher records published events and fails while err is set
+type fakeSeasonPublisher struct {
+	events []leaderboardscoring.SeasonEnded
+	err    error
+}
+
+func (f *fakeSeasonPublisher) PublishSeasonEnded(_ context.Context, event leaderboardscoring.SeasonEnded) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, event)
+
+	return nil
+}
+
+// Season boards expire BoardRetention after the end, the window is therefore kept
+// around now
+var (
+	seasonStart = time.Now().UTC().Truncate(time.Hour).AddDate(0, 0, -7)
+	seasonEnd   = seasonStart.AddDate(0, 0, 14)
+)
+
+func newSeasonService(t *testing.T) (*leaderboardscoring.SeasonService, *fakeSeasonStore, *fakeSeasonPublisher) {
+	t.Helper()
+
+	store := newFakeSeasonStore()
+	publisher := &fakeSeasonPublisher{}
+	cache := memoryrepository.NewMemoryLeaderboardRepository(memoryrepository.Config{})
+	svc := leaderboardscoring.NewSeasonService(leaderboardscoring.SeasonConfig{}, store, cache, publisher, leaderboardscoring.NewValidator())
+
+	return svc, store, publisher
+}
+
+func seasonEvent(id, userID string, event leaderboardscoring.EventName, repositoryID uint64, at time.Time) *leaderboardscoring.EventRequest {
+	return &leaderboardscoring.EventRequest{
+		ID:           id,
+		UserID:       userID,
+		EventName:    event.String(),
+		RepositoryID: repositoryID,
+		Timestamp:    at,
+	}
+}
+
+func TestSeason_Status(t *testing.T) {
+	season := leaderboardscoring.Season{StartsAt: seasonStart, EndsAt: seasonEnd}
+
+	assert.Equal(t, leaderboardscoring.SeasonUpcoming, season.Status(seasonStart.Add(-time.Second)))
+	assert.Equal(t, leaderboardscoring.SeasonActive, season.Status(seasonStart))
+	assert.Equal(t, leaderboardscoring.SeasonClosing, season.Status(seasonEnd))
+
+	season.ClosedAt = seasonEnd.Add(time.Hour)
+	assert.Equal(t, leaderboardscoring.SeasonClosed, season.Status(seasonEnd))
+}
+
+func TestSeasonService_ScoreEvent(t *testing.T) {
+	svc, _, _ := newSeasonService(t)
+	ctx := context.Background()
+
+	season, err := svc.CreateSeason(ctx, leaderboardscoring.SeasonRequest{
+		Name:       "Hacktoberfest 2025",
+		StartsAt:   seasonStart,
+		EndsAt:     seasonEnd,
+		ProjectIDs: []string{"1001"},
+		ScoringRules: map[leaderboardscoring.EventName]int64{
+			leaderboardscoring.PullRequestClosed: 10,
+			leaderboardscoring.IssueComment:      0,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, leaderboardscoring.DefaultSeasonWinnerCount, season.WinnerCount)
+	assert.Equal(t, leaderboardscoring.RankingOrdinal, season.RankingMode)
+
+	inside := seasonStart.Add(48 * time.Hour)
+	for _, event := range []*leaderboardscoring.EventRequest{
+		seasonEvent("e1", "7", leaderboardscoring.PullRequestClosed, 1001, inside),
+		seasonEvent("e2", "7", leaderboardscoring.CommitPush, 1001, inside),
+		seasonEvent("e3", "8", leaderboardscoring.PullRequestClosed, 1001, inside),
+		// Excluded by the rules, another project, before and after the window
+		seasonEvent("e4", "8", leaderboardscoring.IssueComment, 1001, inside),
+		seasonEvent("e5", "9", leaderboardscoring.PullRequestClosed, 2002, inside),
+		seasonEvent("e6", "9", leaderboardscoring.PullRequestClosed, 1001, seasonStart.Add(-time.Second)),
+		seasonEvent("e7", "9", leaderboardscoring.PullRequestClosed, 1001, seasonEnd),
+	} {
+		require.NoError(t, svc.ScoreEvent(ctx, event))
+	}
+
+	res, err := svc.GetSeasonStandings(ctx, leaderboardscoring.GetSeasonStandingsRequest{SeasonID: season.ID, PageSize: 10})
+	require.NoError(t, err)
+	assert.False(t, res.Final)
+	assert.Equal(t, []leaderboardscoring.SeasonStanding{
+		{Rank: 1, UserID: "7", Score: 17, Winner: true},
+		{Rank: 2, UserID: "8", Score: 10, Winner: true},
+	}, res.Standings)
+}
+
+func TestSeasonService_CloseEndedSeasons(t *testing.T) {
+	svc, store, publisher := newSeasonService(t)
+	ctx := context.Background()
+
+	winners := 2
+	season, err := svc.CreateSeason(ctx, leaderboardscoring.SeasonRequest{
+		Name:        "Autumn",
+		StartsAt:    seasonStart,
+		EndsAt:      seasonEnd,
+		RankingMode: leaderboardscoring.RankingCompetition,
+		WinnerCount: &winners,
+	})
+	require.NoError(t, err)
+
+	inside := seasonStart.Add(time.Hour)
+	for i, userID := range []string{"7", "8", "9"} {
+		require.NoError(t, svc.ScoreEvent(ctx, seasonEvent("a"+userID, userID, leaderboardscoring.CommitPush, 1001, inside)))
+		if i == 0 {
+			require.NoError(t, svc.ScoreEvent(ctx, seasonEvent("b"+userID, userID, leaderboardscoring.CommitPush, 1001, inside)))
+		}
+	}
+
+	// Late events may still arrive during the close delay
+	res, err := svc.CloseEndedSeasons(ctx, seasonEnd.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, res.Closed)
+
+	publisher.err = errors.New("nats unavailable")
+	_, err = svc.CloseEndedSeasons(ctx, seasonEnd.Add(time.Hour))
+	require.Error(t, err)
+
+	closed, err := svc.GetSeasonStandings(ctx, leaderboardscoring.GetSeasonStandingsRequest{SeasonID: season.ID, PageSize: 10})
+	require.NoError(t, err)
+	assert.True(t, closed.Final)
+	assert.Equal(t, int64(3), closed.Season.Participants)
+	// Users 8 and 9 tie for second, both are winners
+	assert.Equal(t, []leaderboardscoring.SeasonStanding{
+		{Rank: 1, UserID: "7", Score: 14, Winner: true},
+		{Rank: 2, UserID: "9", Score: 7, Winner: true},
+		{Rank: 2, UserID: "8", Score: 7, Winner: true},
+	}, closed.Standings)
+	assert.True(t, store.seasons[season.ID].AnnouncedAt.IsZero())
+
+	// The next run publishes the event that failed
+	publisher.err = nil
+	res, err = svc.CloseEndedSeasons(ctx, seasonEnd.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, res.Closed)
+	assert.Equal(t, []int64{season.ID}, res.Announced)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, "Autumn", publisher.events[0].Name)
+	assert.Len(t, publisher.events[0].Winners, 3)
+
+	// Closed seasons are final
+	_, err = svc.UpdateSeason(ctx, season.ID, leaderboardscoring.SeasonRequest{Name: "Autumn", StartsAt: seasonStart, EndsAt: seasonEnd})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrSeasonClosed)
+	assert.ErrorIs(t, svc.DeleteSeason(ctx, season.ID), leaderboardscoring.ErrSeasonClosed)
+}
+
+func TestSeasonService_CreateSeason_Invalid(t *testing.T) {
+	svc, _, _ := newSeasonService(t)
+	ctx := context.Background()
+
+	for name, req := range map[string]leaderboardscoring.SeasonRequest{
+		"no name":        {StartsAt: seasonStart, EndsAt: seasonEnd},
+		"ends too early": {Name: "s", StartsAt: seasonEnd, EndsAt: seasonStart},
+		"unknown event":  {Name: "s", StartsAt: seasonStart, EndsAt: seasonEnd, ScoringRules: map[leaderboardscoring.EventName]int64{"star": 1}},
+		"negative rule":  {Name: "s", StartsAt: seasonStart, EndsAt: seasonEnd, ScoringRules: map[leaderboardscoring.EventName]int64{leaderboardscoring.CommitPush: -1}},
+		"bad mode":       {Name: "s", StartsAt: seasonStart, EndsAt: seasonEnd, RankingMode: "random"},
+	} {
+		_, err := svc.CreateSeason(ctx, req)
+		assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments, name)
+	}
+
+	_, err := svc.CreateSeason(ctx, leaderboardscoring.SeasonRequest{Name: "s", StartsAt: seasonStart, EndsAt: seasonEnd})
+	require.NoError(t, err)
+	_, err = svc.CreateSeason(ctx, leaderboardscoring.SeasonRequest{Name: "s", StartsAt: seasonStart, EndsAt: seasonEnd})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrSeasonNameTaken)
+}
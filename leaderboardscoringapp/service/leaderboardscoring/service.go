@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
 	"github.com/gocasters/rankr/pkg/timettl"
 )
 
@@ -24,12 +25,21 @@ const (
 type EventPersistence interface {
 	AddProcessedScoreEvents(ctx context.Context, events []ProcessedScoreEvent) error
 	AddSnapshot(ctx context.Context, snapshots []SnapshotRow) error
+	// GetUserScores ranks users by the sum of their matching events, highest first
+	GetUserScores(ctx context.Context, filter ScoreEventFilter, offset, limit int) ([]LeaderboardEntry, error)
+	// GetScoreBreakdown sums the points of the given users per event type
+	GetScoreBreakdown(ctx context.Context, filter ScoreEventFilter, userIDs []string) (map[string]map[EventName]int64, error)
 }
 
 // LeaderboardCache = redis layer
 type LeaderboardCache interface {
-	UpsertScores(ctx context.Context, score *UpsertScore, timeframe Timeframe) error
+	UpsertScores(ctx context.Context, score *UpsertScore) error
+	UpsertTrendingScores(ctx context.Context, score *TrendingScore) error
 	GetLeaderboard(ctx context.Context, leaderboard *LeaderboardQuery) (LeaderboardQueryResult, error)
+	GetUserRanks(ctx context.Context, keys []string, userID string) ([]UserRank, error)
+	// CountHigherScores returns the number of members scored above score, or the number of
+	// distinct scores above it when distinct is set. Fractions of stored scores are dropped.
+	CountHigherScores(ctx context.Context, key string, score int64, distinct bool) (int64, error)
 }
 
 // Publisher interface for publishing processed events
@@ -37,27 +47,62 @@ type Publisher interface {
 	Publish(ctx context.Context, subject string, data []byte) error
 }
 
+// RankChangeNotifier receives rank movements after scores were updated
+type RankChangeNotifier interface {
+	Notify(changes []RankChange)
+}
+
+type Config struct {
+	Trending TrendingConfig `koanf:"trending"`
+	Ranking  RankingConfig  `koanf:"ranking"`
+	// ProjectTimezones maps a project ID to an IANA timezone (e.g., "Asia/Tokyo").
+	// Per-project period boards of unlisted projects use UTC.
+	ProjectTimezones map[string]string `koanf:"project_timezones"`
+	Watch            WatchConfig       `koanf:"watch"`
+	// SnapshotRetention thins out old rows of the snapshot table
+	SnapshotRetention SnapshotRetentionConfig `koanf:"snapshot_retention"`
+	Seasons           SeasonConfig            `koanf:"seasons"`
+	Achievements      AchievementConfig       `koanf:"achievements"`
+	Streaks           StreakConfig            `koanf:"streaks"`
+}
+
 type Service struct {
+	config              Config
+	locations           projectLocations
 	eventPersistence    EventPersistence
 	leaderboard         LeaderboardCache
 	publisher           Publisher
 	processedEventTopic string
 	validator           Validator
+	rankNotifier        RankChangeNotifier
+	contributors        ContributorDirectory
+	identities          *privacy.IdentityResolver
+	watchers            *watchHub
 }
 
 func NewService(
+	cfg Config,
 	persistence EventPersistence,
 	leaderboard LeaderboardCache,
 	publisher Publisher,
 	processedEventTopic string,
 	validator Validator,
+	rankNotifier RankChangeNotifier,
+	contributors ContributorDirectory,
+	identities *privacy.IdentityResolver,
 ) *Service {
 	return &Service{
+		config:              cfg,
+		locations:           newProjectLocations(cfg.ProjectTimezones),
 		eventPersistence:    persistence,
 		leaderboard:         leaderboard,
 		publisher:           publisher,
 		processedEventTopic: processedEventTopic,
 		validator:           validator,
+		rankNotifier:        rankNotifier,
+		contributors:        contributors,
+		identities:          identities,
+		watchers:            newWatchHub(),
 	}
 }
 
@@ -74,16 +119,76 @@ func (s *Service) ProcessScoreEvent(ctx context.Context, req *EventRequest) erro
 		return nil
 	}
 
+	source := payloadSource(req.Payload)
+	source.Rule = RuleBasePoints
+
+	return s.applyScore(ctx, req, score, source, "")
+}
+
+// AwardStreakBonus scores the bonus of a streak milestone like an event of the project the
+// streak was extended in. The bonus event ID is derived from the user and the local day,
+// a user reaches at most one milestone a day.
+func (s *Service) AwardStreakBonus(ctx context.Context, trigger *EventRequest, day time.Time, milestone StreakMilestone) error {
+	req := &EventRequest{
+		ID:             fmt.Sprintf("streak-bonus-%s-%s", trigger.UserID, timettl.DayOf(day)),
+		UserID:         trigger.UserID,
+		EventName:      StreakBonus.String(),
+		RepositoryID:   trigger.RepositoryID,
+		RepositoryName: trigger.RepositoryName,
+		Timestamp:      trigger.Timestamp,
+	}
+
+	return s.applyScore(ctx, req, milestone.Bonus, ScoreSource{
+		Ref:  trigger.ID,
+		Rule: streakMilestoneRule(milestone.Days),
+	}, "")
+}
+
+// applyScore adds score to the boards of the event and publishes it for persistence. A
+// scope limits the score to the boards of that timeframe, empty means every board.
+func (s *Service) applyScore(ctx context.Context, req *EventRequest, score int64, source ScoreSource, scope string) error {
+	log := logger.L()
+
+	// Points taken away by hand never take a board below zero, snapshots only hold scores
+	// of zero and more
+	clamp := score < 0 && (source.Rule == RuleAdjustment || source.Rule == RuleAdjustmentReversal)
+
 	// Update Redis leaderboard (real-time) for all timeframes
+	projectID := strconv.FormatUint(req.RepositoryID, 10)
+	var upsertScores []UpsertScore
 	for _, tf := range Timeframes {
-		keys := s.generateKeys(strconv.FormatUint(req.RepositoryID, 10), tf)
+		if scope != "" && tf.String() != scope {
+			continue
+		}
 
-		upsertScore := UpsertScore{
-			Keys:   keys,
-			Score:  score,
-			UserID: req.UserID,
+		tfUpsertScores, err := s.generateUpsertScores(projectID, tf, req.Timestamp, score, req.UserID)
+		if err != nil {
+			log.Error(ErrFailedToUpdateScores.Error(), slog.String("error", err.Error()))
+			return errors.Join(ErrFailedToUpdateScores, err)
+		}
+
+		for i := range tfUpsertScores {
+			tfUpsertScores[i].ClampAtZero = clamp
 		}
-		if err := s.leaderboard.UpsertScores(ctx, &upsertScore, tf); err != nil {
+		upsertScores = append(upsertScores, tfUpsertScores...)
+	}
+
+	ranksBefore := s.userRanksForNotification(ctx, upsertScores, req.UserID)
+
+	for i := range upsertScores {
+		if err := s.leaderboard.UpsertScores(ctx, &upsertScores[i]); err != nil {
+			log.Error(ErrFailedToUpdateScores.Error(), slog.String("error", err.Error()))
+			return errors.Join(ErrFailedToUpdateScores, err)
+		}
+	}
+
+	if ranksBefore != nil {
+		s.notifyRankChanges(ctx, req.UserID, ranksBefore)
+	}
+
+	// Update trending leaderboards with a weight boosted by the event time
+	if scope == "" {
+		if err := s.upsertTrendingScores(ctx, req, score, clamp); err != nil {
 			log.Error(ErrFailedToUpdateScores.Error(), slog.String("error", err.Error()))
 			return errors.Join(ErrFailedToUpdateScores, err)
 		}
@@ -91,10 +196,19 @@ func (s *Service) ProcessScoreEvent(ctx context.Context, req *EventRequest) erro
 
 	// Publish to NATS JetStream for batch persistence (once per event)
 	pse := ProcessedScoreEvent{
-		UserID:    req.UserID,
-		EventName: EventName(req.EventName),
-		Score:     score,
-		Timestamp: time.Now().UTC(),
+		EventID:        req.ID,
+		UserID:         req.UserID,
+		EventName:      EventName(req.EventName),
+		Score:          score,
+		ProjectID:      projectID,
+		RepositoryID:   req.RepositoryID,
+		RepositoryName: req.RepositoryName,
+		SourceNumber:   source.Number,
+		SourceRef:      source.Ref,
+		Rule:           source.Rule,
+		ScopeTimeframe: scope,
+		Timestamp:      req.Timestamp.UTC(),
+		ProcessedAt:    time.Now().UTC(),
 	}
 
 	dataMsg, mErr := json.Marshal(pse)
@@ -129,49 +243,60 @@ func (s *Service) GetLeaderboard(ctx context.Context, req *GetLeaderboardRequest
 		return GetLeaderboardResponse{}, errors.Join(ErrInvalidArguments, err)
 	}
 
-	key := req.BuildKey()
-
-	stop := int64(req.Offset) + int64(req.PageSize) - 1
-
-	lbQuery := &LeaderboardQuery{
-		Key:   key,
-		Start: int64(req.Offset),
-		Stop:  stop,
-	}
-
-	leaderboardScoring, err := s.leaderboard.GetLeaderboard(ctx, lbQuery)
+	page, err := s.readLeaderboard(ctx, req, time.Now())
 	if err != nil {
 		log.Error("Failed to get leaderboard from repository", slog.String("error", err.Error()))
 		return GetLeaderboardResponse{}, err
 	}
 
-	if len(leaderboardScoring.LeaderboardRows) == 0 {
-		log.Debug("No leaderboard data found for the given criteria", slog.String("key", key))
+	if len(page.rows) == 0 {
+		log.Debug("No leaderboard data found for the given criteria", slog.String("key", page.key))
 		return GetLeaderboardResponse{}, nil
 	}
 
-	leaderboardRes := mapLeaderboardScoringToParam(leaderboardScoring)
+	leaderboardRes := mapLeaderboardScoringToParam(LeaderboardQueryResult{LeaderboardRows: page.rows})
 	leaderboardRes.Timeframe = req.Timeframe
 	leaderboardRes.ProjectID = req.ProjectID
+	leaderboardRes.RankingMode = page.mode
 
 	log.Debug("Successfully retrieved leaderboard data", slog.Int("row_count", len(leaderboardRes.LeaderboardRows)))
 	return leaderboardRes, nil
 }
 
+func (s *Service) upsertTrendingScores(ctx context.Context, req *EventRequest, score int64, clamp bool) error {
+	// A timestamp from the future would boost the event and rebase the boards ahead of time
+	at := req.Timestamp
+	if now := time.Now(); at.After(now) {
+		at = now
+	}
+
+	trendingScore := TrendingScore{
+		Keys:        s.generateKeys(strconv.FormatUint(req.RepositoryID, 10), Trending),
+		UserID:      req.UserID,
+		Score:       score,
+		HalfLives:   s.config.Trending.HalfLives(at),
+		EventID:     req.ID,
+		DedupeTTL:   s.config.Trending.dedupeTTL(),
+		ClampAtZero: clamp,
+	}
+
+	return s.leaderboard.UpsertTrendingScores(ctx, &trendingScore)
+}
+
 // LeaderboardSnapshot creates snapshots for specified project leaderboards
 func (s *Service) LeaderboardSnapshot(ctx context.Context, projectIDs []string) error {
 	log := logger.L()
 
 	log.Info("starting leaderboard snapshot creation", slog.Int("project_count", len(projectIDs)))
 
-	keys := getSnapshotKeys(projectIDs)
-	if len(keys) == 0 {
+	scopes := getSnapshotScopes(projectIDs)
+	if len(scopes) == 0 {
 		log.Warn("no snapshot keys to process")
 		return nil
 	}
 
 	// Process keys
-	results := s.processKeys(ctx, keys)
+	results := s.processKeys(ctx, scopes)
 
 	var errs []error
 	for err := range results {
@@ -188,19 +313,20 @@ func (s *Service) LeaderboardSnapshot(ctx context.Context, projectIDs []string)
 	return nil
 }
 
-// processKeys processes multiple keys concurrently
-func (s *Service) processKeys(ctx context.Context, keys []string) <-chan error {
-	results := make(chan error, len(keys))
+// processKeys snapshots the all-time leaderboards of multiple scopes concurrently
+func (s *Service) processKeys(ctx context.Context, scopes []string) <-chan error {
+	results := make(chan error, len(scopes))
 	var wg sync.WaitGroup
 
-	for _, key := range keys {
+	for _, scope := range scopes {
 		wg.Add(1)
-		go func(k string) {
+		go func(scope string) {
 			defer wg.Done()
 
-			_, err := s.createSnapshotForKey(ctx, k)
+			key := LeaderboardKey(scope, AllTime.String(), "")
+			_, err := s.createSnapshotForKey(ctx, key, s.config.Ranking.Mode(scope, AllTime.String()))
 			results <- err
-		}(key)
+		}(scope)
 	}
 
 	go func() {
@@ -210,8 +336,9 @@ func (s *Service) processKeys(ctx context.Context, keys []string) <-chan error {
 	return results
 }
 
-// createSnapshotForKey creates snapshot for a single leaderboard key
-func (s *Service) createSnapshotForKey(ctx context.Context, key string) (int, error) {
+// createSnapshotForKey creates snapshot for a single leaderboard key, ranked with the
+// board's ranking mode
+func (s *Service) createSnapshotForKey(ctx context.Context, key string, mode RankingMode) (int, error) {
 	log := logger.L()
 	snapshotTime := time.Now().UTC()
 
@@ -221,6 +348,9 @@ func (s *Service) createSnapshotForKey(ctx context.Context, key string) (int, er
 	offset := int64(0)
 	batchSize := int64(snapshotBatchSize)
 
+	// The pages are read in order from the top, one ranker carries ties across them
+	rank := &ranker{mode: mode}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -245,6 +375,7 @@ func (s *Service) createSnapshotForKey(ctx context.Context, key string) (int, er
 		}
 
 		// Convert to snapshot rows
+		rank.rankRows(leaderboard.LeaderboardRows)
 		for _, row := range leaderboard.LeaderboardRows {
 			snapshot := SnapshotRow{
 				Rank:              row.Rank,
@@ -330,76 +461,132 @@ func (s *Service) RestoreLeaderboardFromSnapshot(ctx context.Context, projectIDs
 
 // Helper: get snapshot keys for projects
 func getSnapshotKeys(projectIDs []string) []string {
-	keys := make([]string, 0, len(projectIDs)+1)
+	scopes := getSnapshotScopes(projectIDs)
+	keys := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		keys = append(keys, LeaderboardKey(scope, AllTime.String(), ""))
+	}
+
+	return keys
+}
+
+// getSnapshotScopes returns the scopes whose all-time leaderboards are snapshotted
+func getSnapshotScopes(projectIDs []string) []string {
+	scopes := make([]string, 0, len(projectIDs)+1)
 
 	// Global all-time leaderboard
-	keys = append(keys, getGlobalLeaderboardKey(AllTime, ""))
+	scopes = append(scopes, globalScope)
 
 	// Per-project all-time leaderboards
 	for _, pID := range projectIDs {
 		if pID != "" {
-			keys = append(keys, getPerProjectLeaderboardKey(pID, AllTime, ""))
+			scopes = append(scopes, pID)
 		}
 	}
 
-	return keys
+	return scopes
 }
 
 // All Keys
+//
+// The scope ("global" or the project ID) is a Redis Cluster hash tag, so all boards of one
+// scope live in the same hash slot and an event touches at most two slots.
 
 // Global Leaderboards
-// leaderboard:global:all_time	members(user_ids)
-// leaderboard:global:yearly:{year}
-// leaderboard:global:monthly:{year}-{month}
-// leaderboard:global:weekly:{year}-W{week_number}
-// leaderboard:global:daily:{year}-{week_number}-{day_number}
+// leaderboard:{global}:all_time	members(user_ids)
+// leaderboard:{global}:yearly:{year}
+// leaderboard:{global}:monthly:{year}-{month}
+// leaderboard:{global}:weekly:{year}-W{week_number}
+// leaderboard:{global}:daily:{year}-{month}-{day}
+// leaderboard:{global}:trending
 
 // Per-Project Leaderboards
 // leaderboard:{project_id}:all_time
 // leaderboard:{project_id}:yearly:{year}
 // leaderboard:{project_id}:monthly:{year}-{month}
 // leaderboard:{project_id}:weekly:{year}-W{week_number}
-// leaderboard:{project_id}:daily:{year}-{week_number}-{day_number}
-func (s *Service) generateKeys(projectID string, timeframe Timeframe) []string {
-	keyMap := make(map[string]struct{})
-	addKey := func(key string) {
-		if _, exists := keyMap[key]; !exists {
-			keyMap[key] = struct{}{}
+// leaderboard:{project_id}:daily:{year}-{month}-{day}
+// leaderboard:{project_id}:trending
+//
+// Period keys are derived from the event's own timestamp. Global keys follow the
+// UTC calendar while per-project keys follow the project's timezone, so one event
+// can produce different periods (and expiries) for the two scopes.
+func (s *Service) generateUpsertScores(projectID string, timeframe Timeframe, at time.Time, score int64, userID string) ([]UpsertScore, error) {
+	if timeframe == AllTime {
+		// Keys of first_reached boards get their own upsert, the others share one
+		shared := UpsertScore{Score: score, UserID: userID}
+		var firstReached []UpsertScore
+		for _, scope := range []string{globalScope, projectID} {
+			key := LeaderboardKey(scope, timeframe.String(), "")
+			if s.config.Ranking.Mode(scope, timeframe.String()) == RankingFirstReached {
+				firstReached = append(firstReached, UpsertScore{Keys: []string{key}, Score: score, UserID: userID, ReachedAt: at})
+				continue
+			}
+			shared.Keys = append(shared.Keys, key)
+		}
+
+		if len(shared.Keys) == 0 {
+			return firstReached, nil
 		}
+		return append([]UpsertScore{shared}, firstReached...), nil
 	}
 
-	var period string
-	var tf Timeframe
-	switch timeframe {
-	case AllTime:
-		addKey(getGlobalLeaderboardKey(AllTime, ""))
-		addKey(getPerProjectLeaderboardKey(projectID, AllTime, ""))
-	case Yearly:
-		period = timettl.GetYear()
-		tf = Yearly
-	case Monthly:
-		period = timettl.GetMonth()
-		tf = Monthly
-	case Weekly:
-		period = timettl.GetWeek()
-		tf = Weekly
-	case Daily:
-		period = timettl.GetDay()
-		tf = Daily
+	now := time.Now()
+	scopes := []struct {
+		name     string
+		location *time.Location
+	}{
+		{name: globalScope, location: time.UTC},
+		{name: projectID, location: s.locations.of(projectID)},
 	}
 
-	if timeframe != AllTime {
-		addKey(getGlobalLeaderboardKey(tf, period))
-		addKey(getPerProjectLeaderboardKey(projectID, tf, period))
+	upsertScores := make([]UpsertScore, 0, len(scopes))
+	for _, scope := range scopes {
+		local := at.In(scope.location)
+
+		period, err := timettl.PeriodKeyAt(timeframe.String(), local)
+		if err != nil {
+			return nil, err
+		}
+
+		expireAt, err := timettl.EndOfPeriodAt(timeframe.String(), local)
+		if err != nil {
+			return nil, err
+		}
+
+		// The period of a late event is already closed and its key has expired
+		if !expireAt.After(now) {
+			continue
+		}
+
+		upsertScore := UpsertScore{
+			Keys:     []string{LeaderboardKey(scope.name, timeframe.String(), period)},
+			Score:    score,
+			UserID:   userID,
+			ExpireAt: expireAt,
+		}
+		if s.config.Ranking.Mode(scope.name, timeframe.String()) == RankingFirstReached {
+			upsertScore.ReachedAt = at
+		}
+
+		upsertScores = append(upsertScores, upsertScore)
 	}
 
-	// Convert map keys to slice
-	keys := make([]string, 0, len(keyMap))
-	for k := range keyMap {
-		keys = append(keys, k)
+	return upsertScores, nil
+}
+
+// generateKeys returns the keys of timeframes without a period (all_time, trending)
+func (s *Service) generateKeys(projectID string, timeframe Timeframe) []string {
+	return []string{
+		getGlobalLeaderboardKey(timeframe, ""),
+		getPerProjectLeaderboardKey(projectID, timeframe, ""),
 	}
+}
 
-	return keys
+// BasePoints returns the points an event earns on the boards by its name, zero for an
+// event that is not scored. Streak bonuses and adjustments come on top.
+func BasePoints(eventName string) int64 {
+	return calculateScore(eventName)
 }
 
 func calculateScore(eventType string) int64 {
@@ -424,20 +611,26 @@ func calculateScore(eventType string) int64 {
 	}
 }
 
-func getGlobalLeaderboardKey(timeframe Timeframe, period string) string {
-	if timeframe == AllTime {
-		return fmt.Sprintf("leaderboard:global:%s", timeframe.String())
+// globalScope is the scope of the leaderboards across all projects
+const globalScope = "global"
+
+// LeaderboardKey returns the cache key of a board. Scope is "global" or a project ID,
+// period is ignored for all_time and trending.
+func LeaderboardKey(scope, timeframe, period string) string {
+	key := fmt.Sprintf("leaderboard:{%s}:%s", scope, timeframe)
+	if timeframe == AllTime.String() || timeframe == Trending.String() {
+		return key
 	}
 
-	return fmt.Sprintf("leaderboard:global:%s:%s", timeframe.String(), period)
+	return key + ":" + period
 }
 
-func getPerProjectLeaderboardKey(project string, timeframe Timeframe, period string) string {
-	if timeframe == AllTime {
-		return fmt.Sprintf("leaderboard:%s:%s", project, timeframe.String())
-	}
+func getGlobalLeaderboardKey(timeframe Timeframe, period string) string {
+	return LeaderboardKey(globalScope, timeframe.String(), period)
+}
 
-	return fmt.Sprintf("leaderboard:%s:%s:%s", project, timeframe.String(), period)
+func getPerProjectLeaderboardKey(project string, timeframe Timeframe, period string) string {
+	return LeaderboardKey(project, timeframe.String(), period)
 }
 
 func mapLeaderboardScoringToParam(scoring LeaderboardQueryResult) GetLeaderboardResponse {
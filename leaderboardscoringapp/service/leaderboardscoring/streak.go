@@ -0,0 +1,266 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	defaultStreakTimezoneCacheTTL  = time.Hour
+	defaultStreakTimezoneCacheSize = 10000
+)
+
+// StreakMilestone awards Bonus points once a streak reaches Days
+type StreakMilestone struct {
+	Days  int64 `koanf:"days"`
+	Bonus int64 `koanf:"bonus"`
+}
+
+// StreakConfig tunes the contribution streaks
+type StreakConfig struct {
+	// Milestones are optional, without them streaks do not score
+	Milestones []StreakMilestone `koanf:"milestones"`
+	// TimezoneCacheTTL is how long the timezone of a contributor is cached
+	TimezoneCacheTTL time.Duration `koanf:"timezone_cache_ttl"`
+	// TimezoneCacheSize is the most timezones kept in the cache
+	TimezoneCacheSize int `koanf:"timezone_cache_size"`
+}
+
+func (c StreakConfig) Validate() error {
+	days := make(map[int64]bool, len(c.Milestones))
+	for i, m := range c.Milestones {
+		err := validation.ValidateStruct(&m,
+			validation.Field(&m.Days, validation.Required, validation.Min(int64(2))),
+			validation.Field(&m.Bonus, validation.Required, validation.Min(int64(1))),
+		)
+		if err != nil {
+			return fmt.Errorf("streak milestone %d: %w", i, err)
+		}
+		if days[m.Days] {
+			return fmt.Errorf("duplicate streak milestone of %d days", m.Days)
+		}
+		days[m.Days] = true
+	}
+
+	return nil
+}
+
+// Streak counts the consecutive days, in the timezone of the contributor, with at least
+// one scored event. LastActiveDay is the local date at midnight UTC.
+type Streak struct {
+	UserID        string
+	Current       int64
+	Longest       int64
+	LastActiveDay time.Time
+	Timezone      string
+	UpdatedAt     time.Time
+}
+
+// at returns the streak as seen at now: a streak whose last active day is before
+// yesterday is broken, even when the reset job did not run yet.
+func (s Streak) at(now time.Time) Streak {
+	if s.Current == 0 || s.LastActiveDay.IsZero() {
+		return s
+	}
+
+	if s.LastActiveDay.Before(localDay(now, loadLocation(s.Timezone)).AddDate(0, 0, -1)) {
+		s.Current = 0
+	}
+
+	return s
+}
+
+// StreakStore keeps the streak of every contributor
+type StreakStore interface {
+	// RecordActiveDay marks day active for the user. The streak grows when day follows the
+	// last active day and restarts after a gap, an earlier or the same day changes nothing.
+	// advanced reports whether day became the last active day.
+	RecordActiveDay(ctx context.Context, userID string, day time.Time, timezone string) (streak Streak, advanced bool, err error)
+	// GetStreak returns the streak of the user, ok is false when it has none
+	GetStreak(ctx context.Context, userID string) (streak Streak, ok bool, err error)
+	// ListStreaks returns the streaks not broken at now, longest current streak first
+	ListStreaks(ctx context.Context, now time.Time, offset, limit int) ([]Streak, error)
+	// ResetBrokenStreaks sets the current streak of the users who missed a day to zero
+	ResetBrokenStreaks(ctx context.Context, now time.Time) (int64, error)
+}
+
+// StreakBonusScorer scores the bonus points of a streak milestone
+type StreakBonusScorer interface {
+	AwardStreakBonus(ctx context.Context, trigger *EventRequest, day time.Time, milestone StreakMilestone) error
+}
+
+// StreakService keeps the contribution streaks up to date from the scored events.
+type StreakService struct {
+	config       StreakConfig
+	store        StreakStore
+	scorer       StreakBonusScorer
+	contributors ContributorDirectory
+	validator    Validator
+
+	mu        sync.Mutex
+	timezones map[string]cachedTimezone
+}
+
+type cachedTimezone struct {
+	name      string
+	expiresAt time.Time
+}
+
+// NewStreakService returns the streak service. Without a contributor directory every
+// streak counts days in UTC.
+func NewStreakService(
+	config StreakConfig,
+	store StreakStore,
+	scorer StreakBonusScorer,
+	contributors ContributorDirectory,
+	validator Validator,
+) *StreakService {
+	if config.TimezoneCacheTTL <= 0 {
+		config.TimezoneCacheTTL = defaultStreakTimezoneCacheTTL
+	}
+	if config.TimezoneCacheSize <= 0 {
+		config.TimezoneCacheSize = defaultStreakTimezoneCacheSize
+	}
+
+	return &StreakService{
+		config:       config,
+		store:        store,
+		scorer:       scorer,
+		contributors: contributors,
+		validator:    validator,
+		timezones:    make(map[string]cachedTimezone),
+	}
+}
+
+// RecordEvent extends the streak of the event's user by the local day of the event and
+// scores the bonus of a milestone the streak reaches.
+func (s *StreakService) RecordEvent(ctx context.Context, req *EventRequest) (Streak, error) {
+	timezone := s.timezone(ctx, req.UserID)
+	day := localDay(req.Timestamp, loadLocation(timezone))
+
+	streak, advanced, err := s.store.RecordActiveDay(ctx, req.UserID, day, timezone)
+	if err != nil {
+		return Streak{}, fmt.Errorf("record active day: %w", err)
+	}
+	if !advanced {
+		return streak, nil
+	}
+
+	for _, m := range s.config.Milestones {
+		if m.Days != streak.Current {
+			continue
+		}
+		if err := s.scorer.AwardStreakBonus(ctx, req, day, m); err != nil {
+			return streak, fmt.Errorf("award %d day streak bonus: %w", m.Days, err)
+		}
+	}
+
+	return streak, nil
+}
+
+// GetUserStreak returns the streak of a user, a user without one has an empty streak
+func (s *StreakService) GetUserStreak(ctx context.Context, req GetUserStreakRequest) (Streak, error) {
+	if err := s.validator.ValidateGetUserStreak(req); err != nil {
+		return Streak{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	streak, ok, err := s.store.GetStreak(ctx, req.UserID)
+	if err != nil {
+		return Streak{}, fmt.Errorf("get streak: %w", err)
+	}
+	if !ok {
+		return Streak{UserID: req.UserID}, nil
+	}
+
+	return streak.at(time.Now()), nil
+}
+
+// ListStreaks returns the streak leaderboard, longest current streak first
+func (s *StreakService) ListStreaks(ctx context.Context, req ListStreaksRequest) ([]Streak, error) {
+	if err := s.validator.ValidateListStreaks(req); err != nil {
+		return nil, errors.Join(ErrInvalidArguments, err)
+	}
+
+	return s.store.ListStreaks(ctx, time.Now(), int(req.Offset), int(req.PageSize))
+}
+
+// ResetBrokenStreaks resets the streaks that were not extended yesterday, it returns the
+// number of streaks reset
+func (s *StreakService) ResetBrokenStreaks(ctx context.Context, now time.Time) (int64, error) {
+	return s.store.ResetBrokenStreaks(ctx, now)
+}
+
+// timezone returns the timezone of the user, UTC when it is unknown or the contributor
+// service cannot be reached
+func (s *StreakService) timezone(ctx context.Context, userID string) string {
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.timezones[userID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.name
+	}
+
+	if s.contributors == nil {
+		return time.UTC.String()
+	}
+
+	name := time.UTC.String()
+	profiles, err := s.contributors.GetProfiles(ctx, []string{userID})
+	if err != nil {
+		// Not cached, the next event tries again
+		return name
+	}
+	if p, ok := profiles[userID]; ok && p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err == nil {
+			name = p.Timezone
+		}
+	}
+
+	s.mu.Lock()
+	if _, ok := s.timezones[userID]; !ok && len(s.timezones) >= s.config.TimezoneCacheSize {
+		s.evictTimezones(now)
+	}
+	s.timezones[userID] = cachedTimezone{name: name, expiresAt: now.Add(s.config.TimezoneCacheTTL)}
+	s.mu.Unlock()
+
+	return name
+}
+
+// evictTimezones makes room for one more timezone in the full cache. It drops the expired
+// timezones and, when they are not enough, arbitrary ones. The caller holds s.mu.
+func (s *StreakService) evictTimezones(now time.Time) {
+	for userID, cached := range s.timezones {
+		if !now.Before(cached.expiresAt) {
+			delete(s.timezones, userID)
+		}
+	}
+	for userID := range s.timezones {
+		if len(s.timezones) < s.config.TimezoneCacheSize {
+			return
+		}
+		delete(s.timezones, userID)
+	}
+}
+
+// localDay returns the calendar date of t in loc at midnight UTC
+func localDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func loadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
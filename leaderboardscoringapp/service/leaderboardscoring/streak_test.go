@@ -0,0 +1,228 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStreakStore mirrors the upsert of contributor_streak in memory
+type fakeStreakStore struct {
+	streaks map[string]leaderboardscoring.Streak
+}
+
+func (f *fakeStreakStore) RecordActiveDay(_ context.Context, userID string, day time.Time, timezone string) (leaderboardscoring.Streak, bool, error) {
+	streak, ok := f.streaks[userID]
+	advanced := !ok || day.After(streak.LastActiveDay)
+
+	switch {
+	case !ok || day.After(streak.LastActiveDay.AddDate(0, 0, 1)):
+		streak.Current = 1
+	case day.Equal(streak.LastActiveDay.AddDate(0, 0, 1)):
+		streak.Current++
+	}
+	if advanced {
+		streak.LastActiveDay = day
+	}
+	streak.UserID, streak.Timezone = userID, timezone
+	streak.Longest = max(streak.Longest, streak.Current)
+	f.streaks[userID] = streak
+
+	return streak, advanced, nil
+}
+
+func (f *fakeStreakStore) GetStreak(_ context.Context, userID string) (leaderboardscoring.Streak, bool, error) {
+	streak, ok := f.streaks[userID]
+
+	return streak, ok, nil
+}
+
+func (f *fakeStreakStore) ListStreaks(context.Context, time.Time, int, int) ([]leaderboardscoring.Streak, error) {
+	return nil, nil
+}
+
+func (f *fakeStreakStore) ResetBrokenStreaks(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+type streakBonus struct {
+	eventID string
+	day     time.Time
+	bonus   int64
+}
+
+type fakeBonusScorer struct {
+	bonuses []streakBonus
+}
+
+func (f *fakeBonusScorer) AwardStreakBonus(_ context.Context, trigger *leaderboardscoring.EventRequest, day time.Time, milestone leaderboardscoring.StreakMilestone) error {
+	f.bonuses = append(f.bonuses, streakBonus{eventID: trigger.ID, day: day, bonus: milestone.Bonus})
+
+	return nil
+}
+
+type failingDirectory struct {
+	calls int
+}
+
+func (f *failingDirectory) GetProfiles(context.Context, []string) (map[string]leaderboardscoring.ContributorProfile, error) {
+	f.calls++
+
+	return nil, errors.New("contributor service unavailable")
+}
+
+var testStreaks = leaderboardscoring.StreakConfig{Milestones: []leaderboardscoring.StreakMilestone{
+	{Days: 3, Bonus: 10},
+	{Days: 5, Bonus: 25},
+}}
+
+func newStreakService(t *testing.T, directory leaderboardscoring.ContributorDirectory) (*leaderboardscoring.StreakService, *fakeStreakStore, *fakeBonusScorer) {
+	t.Helper()
+	require.NoError(t, testStreaks.Validate())
+
+	store := &fakeStreakStore{streaks: make(map[string]leaderboardscoring.Streak)}
+	scorer := &fakeBonusScorer{}
+	svc := leaderboardscoring.NewStreakService(testStreaks, store, scorer, directory, leaderboardscoring.NewValidator())
+
+	return svc, store, scorer
+}
+
+func TestStreakService_CountsLocalDays(t *testing.T) {
+	directory := fakeDirectory{"7": {UserID: "7", Timezone: "Asia/Tokyo"}}
+	svc, _, _ := newStreakService(t, directory)
+	ctx := context.Background()
+	commit := leaderboardscoring.PushPayload{}
+
+	// 2025-06-01 20:00 UTC is already 2025-06-02 in Tokyo
+	streak, err := svc.RecordEvent(ctx, badgeEvent("7", 1001, time.Date(2025, 6, 1, 20, 0, 0, 0, time.UTC), commit))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), streak.LastActiveDay)
+	assert.Equal(t, "Asia/Tokyo", streak.Timezone)
+
+	// 2025-06-02 10:00 UTC is the same Tokyo day, 16:00 UTC the next one
+	streak, err = svc.RecordEvent(ctx, badgeEvent("7", 1001, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), commit))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), streak.Current)
+
+	streak, err = svc.RecordEvent(ctx, badgeEvent("7", 1001, time.Date(2025, 6, 2, 16, 0, 0, 0, time.UTC), commit))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), streak.Current)
+	assert.Equal(t, time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), streak.LastActiveDay)
+}
+
+func TestStreakService_MilestoneBonus(t *testing.T) {
+	svc, _, scorer := newStreakService(t, nil)
+	ctx := context.Background()
+	review := leaderboardscoring.PullRequestReviewPayload{}
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.RecordEvent(ctx, badgeEvent("7", 1001, badgeDay.AddDate(0, 0, i), review))
+		require.NoError(t, err)
+	}
+	require.Len(t, scorer.bonuses, 1)
+	assert.Equal(t, int64(10), scorer.bonuses[0].bonus)
+	assert.Equal(t, time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), scorer.bonuses[0].day)
+
+	// a second event on the milestone day does not score again
+	streak, err := svc.RecordEvent(ctx, badgeEvent("7", 1001, badgeDay.AddDate(0, 0, 2).Add(time.Hour), review))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), streak.Current)
+	assert.Len(t, scorer.bonuses, 1)
+
+	// a missed day restarts the streak, the milestone scores again once reached
+	for _, offset := range []int{4, 5, 6} {
+		_, err := svc.RecordEvent(ctx, badgeEvent("7", 1001, badgeDay.AddDate(0, 0, offset), review))
+		require.NoError(t, err)
+	}
+	require.Len(t, scorer.bonuses, 2)
+
+	streak, err = svc.RecordEvent(ctx, badgeEvent("7", 1001, badgeDay.AddDate(0, 0, 7), review))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), streak.Current)
+	assert.Equal(t, int64(4), streak.Longest)
+}
+
+func TestStreakService_DirectoryUnavailable(t *testing.T) {
+	directory := &failingDirectory{}
+	svc, _, _ := newStreakService(t, directory)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		streak, err := svc.RecordEvent(ctx, badgeEvent("7", 1001, badgeDay, leaderboardscoring.PushPayload{}))
+		require.NoError(t, err)
+		assert.Equal(t, "UTC", streak.Timezone)
+	}
+	assert.Equal(t, 2, directory.calls, "a failed lookup is not cached")
+}
+
+// countingDirectory counts the profile lookups of each user
+type countingDirectory struct {
+	fakeDirectory
+	calls map[string]int
+}
+
+func (f *countingDirectory) GetProfiles(ctx context.Context, userIDs []string) (map[string]leaderboardscoring.ContributorProfile, error) {
+	for _, id := range userIDs {
+		f.calls[id]++
+	}
+
+	return f.fakeDirectory.GetProfiles(ctx, userIDs)
+}
+
+func TestStreakService_TimezoneCacheSize(t *testing.T) {
+	directory := &countingDirectory{
+		fakeDirectory: fakeDirectory{"7": {UserID: "7", Timezone: "Asia/Tokyo"}, "8": {UserID: "8", Timezone: "Europe/Berlin"}},
+		calls:         make(map[string]int),
+	}
+	config := testStreaks
+	config.TimezoneCacheSize = 1
+	svc := leaderboardscoring.NewStreakService(config, &fakeStreakStore{streaks: make(map[string]leaderboardscoring.Streak)},
+		&fakeBonusScorer{}, directory, leaderboardscoring.NewValidator())
+	ctx := context.Background()
+
+	for _, userID := range []string{"7", "7", "8", "7"} {
+		_, err := svc.RecordEvent(ctx, badgeEvent(userID, 1001, badgeDay, leaderboardscoring.PushPayload{}))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, directory.calls["7"], "the timezone of 8 evicted the one of 7")
+	assert.Equal(t, 1, directory.calls["8"])
+}
+
+func TestStreakService_GetUserStreak(t *testing.T) {
+	svc, store, _ := newStreakService(t, nil)
+	ctx := context.Background()
+
+	streak, err := svc.GetUserStreak(ctx, leaderboardscoring.GetUserStreakRequest{UserID: "7"})
+	require.NoError(t, err)
+	assert.Equal(t, leaderboardscoring.Streak{UserID: "7"}, streak)
+
+	store.streaks["8"] = leaderboardscoring.Streak{
+		UserID:        "8",
+		Current:       4,
+		Longest:       9,
+		LastActiveDay: time.Now().UTC().AddDate(0, 0, -5).Truncate(24 * time.Hour),
+		Timezone:      "UTC",
+	}
+	streak, err = svc.GetUserStreak(ctx, leaderboardscoring.GetUserStreakRequest{UserID: "8"})
+	require.NoError(t, err)
+	assert.Zero(t, streak.Current, "a streak without an event yesterday is broken")
+	assert.Equal(t, int64(9), streak.Longest)
+
+	_, err = svc.GetUserStreak(ctx, leaderboardscoring.GetUserStreakRequest{})
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+}
+
+func TestStreakConfig_Validate(t *testing.T) {
+	duplicate := leaderboardscoring.StreakConfig{Milestones: []leaderboardscoring.StreakMilestone{{Days: 7, Bonus: 1}, {Days: 7, Bonus: 2}}}
+	assert.Error(t, duplicate.Validate())
+
+	single := leaderboardscoring.StreakConfig{Milestones: []leaderboardscoring.StreakMilestone{{Days: 1, Bonus: 1}}}
+	assert.Error(t, single.Validate())
+
+	assert.NoError(t, leaderboardscoring.StreakConfig{}.Validate())
+}
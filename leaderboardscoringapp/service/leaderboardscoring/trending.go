@@ -0,0 +1,100 @@
+package leaderboardscoring
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	defaultTrendingHalfLife  = 7 * 24 * time.Hour
+	defaultTrendingDedupeTTL = 24 * time.Hour
+
+	// TrendingRebaseHalfLives is how many half-lives a boost may exceed the base of its
+	// board by. A larger boost rebases the board first, so stored values stay far below
+	// the float64 limit (~2^1023) however long the boards live.
+	TrendingRebaseHalfLives = 64
+)
+
+var defaultTrendingEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// TrendingConfig controls the time-decayed "trending" leaderboard.
+//
+// Trending scores use the rising-base technique: instead of decaying every stored
+// score over time, each new contribution is boosted by 2^((t - epoch) / halfLife)
+// and added with ZINCRBY. Ordering between members is therefore always correct,
+// and the real decayed value is recovered at read time by dividing with the
+// boost of "now".
+//
+// Each board keeps a base, the half-lives already divided out of its stored values.
+// Once a boost outgrows the base by TrendingRebaseHalfLives the cache scales the board
+// down and moves its base forward, in the same atomic step as the write.
+type TrendingConfig struct {
+	HalfLife time.Duration `koanf:"half_life"`
+	// Epoch is the origin of the rising base, it must be the same on all instances
+	Epoch time.Time `koanf:"epoch"`
+	// DedupeTTL is how long a board remembers the events it counted, a redelivery within
+	// it is not counted again
+	DedupeTTL time.Duration `koanf:"dedupe_ttl"`
+}
+
+func (c TrendingConfig) halfLife() time.Duration {
+	if c.HalfLife <= 0 {
+		return defaultTrendingHalfLife
+	}
+
+	return c.HalfLife
+}
+
+func (c TrendingConfig) epoch() time.Time {
+	if c.Epoch.IsZero() {
+		return defaultTrendingEpoch
+	}
+
+	return c.Epoch
+}
+
+func (c TrendingConfig) dedupeTTL() time.Duration {
+	if c.DedupeTTL <= 0 {
+		return defaultTrendingDedupeTTL
+	}
+
+	return c.DedupeTTL
+}
+
+// HalfLives returns the number of half-lives elapsed between epoch and t.
+func (c TrendingConfig) HalfLives(t time.Time) float64 {
+	return float64(t.Sub(c.epoch())) / float64(c.halfLife())
+}
+
+// TrendingWeight returns the value added to a trending board with the given base for a
+// contribution of score made halfLives after the epoch.
+func TrendingWeight(score int64, halfLives, base float64) float64 {
+	return float64(score) * math.Exp2(halfLives-base)
+}
+
+// TrendingDecay converts a value stored on a trending board with the given base into
+// the decayed score as observed halfLives after the epoch.
+func TrendingDecay(stored, halfLives, base float64) float64 {
+	return stored * math.Exp2(base-halfLives)
+}
+
+// TrendingRebase returns the base a board has to move to before it stores a contribution
+// made halfLives after the epoch, base itself when the boost still fits. The stored values
+// of the board are then multiplied with 2^(base - rebased).
+func TrendingRebase(halfLives, base float64) float64 {
+	if halfLives-base <= TrendingRebaseHalfLives {
+		return base
+	}
+
+	return math.Floor(halfLives)
+}
+
+// TrendingBaseKey returns the key holding the base of a trending board
+func TrendingBaseKey(board string) string {
+	return board + ":base"
+}
+
+// TrendingEventKey returns the key marking an event as counted on a trending board
+func TrendingEventKey(board, eventID string) string {
+	return board + ":event:" + eventID
+}
@@ -0,0 +1,162 @@
+package leaderboardscoring_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type trendingContribution struct {
+	userID string
+	score  int64
+	at     time.Time
+}
+
+// referenceTrendingScores decays every contribution individually: score * 0.5^(age/halfLife).
+func referenceTrendingScores(contributions []trendingContribution, halfLife time.Duration, now time.Time) map[string]float64 {
+	scores := make(map[string]float64)
+	for _, c := range contributions {
+		age := float64(now.Sub(c.at)) / float64(halfLife)
+		scores[c.userID] += float64(c.score) * math.Pow(0.5, age)
+	}
+
+	return scores
+}
+
+// risingBaseTrendingScores mimics what the cache does on one trending ZSET, rebases
+// included, and decays on read.
+func risingBaseTrendingScores(cfg leaderboardscoring.TrendingConfig, contributions []trendingContribution, now time.Time) map[string]float64 {
+	stored := make(map[string]float64)
+	var base float64
+	for _, c := range contributions {
+		halfLives := cfg.HalfLives(c.at)
+		if rebased := leaderboardscoring.TrendingRebase(halfLives, base); rebased != base {
+			for userID := range stored {
+				stored[userID] *= math.Exp2(base - rebased)
+			}
+			base = rebased
+		}
+		stored[c.userID] += leaderboardscoring.TrendingWeight(c.score, halfLives, base)
+	}
+
+	scores := make(map[string]float64, len(stored))
+	for userID, value := range stored {
+		scores[userID] = leaderboardscoring.TrendingDecay(value, cfg.HalfLives(now), base)
+	}
+
+	return scores
+}
+
+func rankUsers(scores map[string]float64) []string {
+	users := make([]string, 0, len(scores))
+	for userID := range scores {
+		users = append(users, userID)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		if scores[users[i]] == scores[users[j]] {
+			return users[i] < users[j]
+		}
+		return scores[users[i]] > scores[users[j]]
+	})
+
+	return users
+}
+
+func TestTrending_MatchesReferenceImplementation(t *testing.T) {
+	cfg := leaderboardscoring.TrendingConfig{
+		HalfLife: 72 * time.Hour,
+		Epoch:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, time.UTC)
+
+	rnd := rand.New(rand.NewSource(42))
+	contributions := make([]trendingContribution, 0, 5_000)
+	for i := 0; i < cap(contributions); i++ {
+		contributions = append(contributions, trendingContribution{
+			userID: string(rune('a' + rnd.Intn(26))),
+			score:  int64(1 + rnd.Intn(7)),
+			at:     now.Add(-time.Duration(rnd.Int63n(int64(60 * 24 * time.Hour)))),
+		})
+	}
+
+	expected := referenceTrendingScores(contributions, cfg.HalfLife, now)
+	actual := risingBaseTrendingScores(cfg, contributions, now)
+
+	require.Len(t, actual, len(expected))
+	for userID, want := range expected {
+		assert.InDelta(t, want, actual[userID], want*1e-9, "user %s", userID)
+	}
+
+	assert.Equal(t, rankUsers(expected), rankUsers(actual))
+}
+
+func TestTrending_NewcomerOvertakesEarlyContributor(t *testing.T) {
+	cfg := leaderboardscoring.TrendingConfig{HalfLife: 7 * 24 * time.Hour}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	contributions := []trendingContribution{
+		{userID: "veteran", score: 100, at: now.Add(-70 * 24 * time.Hour)},
+		{userID: "newcomer", score: 10, at: now.Add(-24 * time.Hour)},
+	}
+
+	scores := risingBaseTrendingScores(cfg, contributions, now)
+
+	assert.Equal(t, []string{"newcomer", "veteran"}, rankUsers(scores))
+	assert.InDelta(t, 100.0/1024, scores["veteran"], 1e-9)
+}
+
+func TestTrending_ScoreHalvesAfterOneHalfLife(t *testing.T) {
+	cfg := leaderboardscoring.TrendingConfig{HalfLife: 24 * time.Hour}
+	at := time.Date(2025, 3, 10, 8, 30, 0, 0, time.UTC)
+
+	weight := leaderboardscoring.TrendingWeight(40, cfg.HalfLives(at), 0)
+
+	assert.InDelta(t, 40, leaderboardscoring.TrendingDecay(weight, cfg.HalfLives(at), 0), 1e-9)
+	assert.InDelta(t, 20, leaderboardscoring.TrendingDecay(weight, cfg.HalfLives(at.Add(24*time.Hour)), 0), 1e-9)
+	assert.InDelta(t, 10, leaderboardscoring.TrendingDecay(weight, cfg.HalfLives(at.Add(48*time.Hour)), 0), 1e-9)
+}
+
+func TestTrending_Rebase(t *testing.T) {
+	assert.Equal(t, float64(0), leaderboardscoring.TrendingRebase(64, 0))
+	assert.Equal(t, float64(64), leaderboardscoring.TrendingRebase(64.5, 0))
+	assert.Equal(t, float64(130), leaderboardscoring.TrendingRebase(130.2, 64))
+	assert.Equal(t, float64(64), leaderboardscoring.TrendingRebase(10, 64), "an old event never moves the base back")
+}
+
+func TestTrending_LongUptimeMatchesReference(t *testing.T) {
+	// ~15,000 half-lives since the epoch, 2^15000 overflows a float64 without rebasing
+	cfg := leaderboardscoring.TrendingConfig{
+		HalfLife: time.Hour,
+		Epoch:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	now := time.Date(2026, 9, 20, 12, 0, 0, 0, time.UTC)
+
+	rnd := rand.New(rand.NewSource(7))
+	contributions := make([]trendingContribution, 0, 2_000)
+	for i := 0; i < cap(contributions); i++ {
+		contributions = append(contributions, trendingContribution{
+			userID: string(rune('a' + rnd.Intn(26))),
+			score:  int64(1 + rnd.Intn(7)),
+			at:     now.Add(-time.Duration(rnd.Int63n(int64(400 * time.Hour)))),
+		})
+	}
+	sort.Slice(contributions, func(i, j int) bool { return contributions[i].at.Before(contributions[j].at) })
+
+	expected := referenceTrendingScores(contributions, cfg.HalfLife, now)
+	actual := risingBaseTrendingScores(cfg, contributions, now)
+
+	require.Len(t, actual, len(expected))
+	for userID, want := range expected {
+		assert.False(t, math.IsInf(actual[userID], 0) || math.IsNaN(actual[userID]), "user %s", userID)
+		assert.InDelta(t, want, actual[userID], want*1e-9, "user %s", userID)
+	}
+
+	assert.Equal(t, rankUsers(expected), rankUsers(actual))
+}
@@ -1,7 +1,9 @@
 package leaderboardscoring
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -54,14 +56,16 @@ func (v Validator) ValidateGetLeaderboard(request *GetLeaderboardRequest) error
 				Monthly.String(),
 				Weekly.String(),
 				Daily.String(),
+				Trending.String(),
 			).Error(
 				fmt.Sprintf(
-					"timeframe must be one of: %s, %s, %s, %s, %s",
+					"timeframe must be one of: %s, %s, %s, %s, %s, %s",
 					AllTime.String(),
 					Yearly.String(),
 					Monthly.String(),
 					Weekly.String(),
 					Daily.String(),
+					Trending.String(),
 				),
 			),
 		),
@@ -79,3 +83,311 @@ func (v Validator) ValidateGetLeaderboard(request *GetLeaderboardRequest) error
 		),
 	)
 }
+
+func (v Validator) ValidateExportLeaderboard(request *ExportLeaderboardRequest) error {
+	return validation.ValidateStruct(request,
+		validation.Field(&request.Timeframe,
+			validation.Required.Error("timeframe is required"),
+			validation.In(
+				AllTime.String(),
+				Yearly.String(),
+				Monthly.String(),
+				Weekly.String(),
+				Daily.String(),
+				Trending.String(),
+			).Error("timeframe must be one of: all_time, yearly, monthly, weekly, daily, trending"),
+		),
+
+		validation.Field(&request.Period,
+			validation.When(
+				request.Timeframe == AllTime.String() || request.Timeframe == Trending.String(),
+				validation.Empty.Error("period is not supported for all_time and trending leaderboards"),
+			),
+		),
+
+		validation.Field(&request.Format,
+			validation.Required.Error("format is required"),
+			validation.In(ExportFormatCSV, ExportFormatJSONL, ExportFormatXLSX).
+				Error("format must be one of: csv, jsonl, xlsx"),
+		),
+	)
+}
+
+func (v Validator) ValidateListDeadLettersRequest(request ListDeadLettersRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Limit,
+			validation.Min(0).Error("limit cannot be negative"),
+			validation.Max(maxPageSize).Error(fmt.Sprintf("limit cannot exceed %d", maxPageSize)),
+		),
+	)
+}
+
+func (v Validator) ValidateDeadLetterActionRequest(request DeadLetterActionRequest, action DeadLetterAction) error {
+	selected := 0
+	if request.Selection.Sequence != 0 {
+		selected++
+	}
+	if request.Selection.All {
+		selected++
+	}
+	if !request.Selection.Filter.IsEmpty() {
+		selected++
+	}
+	if selected != 1 {
+		return errors.New("select exactly one of a sequence, all letters or a filter")
+	}
+
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Actor, validation.Required.Error("actor is required")),
+		validation.Field(&request.Note,
+			validation.When(action == DeadLetterDiscarded, validation.Required.Error("a note is required to discard dead letters")),
+			validation.Length(0, 1000),
+		),
+	)
+}
+
+func (v Validator) ValidateGetLeaderboardAsOf(request *GetLeaderboardAsOfRequest) error {
+	return validation.ValidateStruct(request,
+		validation.Field(&request.AsOf, validation.Required.Error("as_of is required")),
+
+		validation.Field(&request.Offset,
+			validation.Min(int32(minOffset)).Error("offset cannot be negative"),
+			validation.Max(int32(maxOffset)).Error(fmt.Sprintf("offset cannot exceed %d", maxOffset)),
+		),
+
+		validation.Field(&request.PageSize,
+			validation.Required.Error("page_size is required"),
+			validation.Min(int32(minPageSize)).Error(fmt.Sprintf("page_size must be at least %d", minPageSize)),
+			validation.Max(int32(maxPageSize)).Error(fmt.Sprintf("page_size cannot exceed %d", maxPageSize)),
+		),
+	)
+}
+
+func (v Validator) ValidateGetUserRankHistory(request *GetUserRankHistoryRequest) error {
+	if !request.From.Before(request.To) {
+		return errors.New("from must be before to")
+	}
+
+	return validation.ValidateStruct(request,
+		validation.Field(&request.UserID, validation.Required.Error("user_id is required")),
+	)
+}
+
+const (
+	maxSeasonNameLength  = 100
+	maxSeasonWinnerCount = 100
+)
+
+func (v Validator) ValidateSeasonRequest(request SeasonRequest) error {
+	if !request.StartsAt.IsZero() && !request.EndsAt.After(request.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+
+	for event, points := range request.ScoringRules {
+		if err := event.Validate(); err != nil {
+			return fmt.Errorf("scoring_rules: unknown event type %q", event)
+		}
+		if points < 0 {
+			return fmt.Errorf("scoring_rules: points of %s cannot be negative", event)
+		}
+	}
+
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Name,
+			validation.Required.Error("name is required"),
+			validation.RuneLength(1, maxSeasonNameLength).Error(fmt.Sprintf("name cannot exceed %d characters", maxSeasonNameLength)),
+		),
+		validation.Field(&request.StartsAt, validation.Required.Error("starts_at is required")),
+		validation.Field(&request.EndsAt, validation.Required.Error("ends_at is required")),
+		validation.Field(&request.ProjectIDs, validation.Each(validation.Required.Error("project_ids cannot contain empty IDs"))),
+		validation.Field(&request.RankingMode),
+		validation.Field(&request.WinnerCount,
+			validation.Min(0).Error("winner_count cannot be negative"),
+			validation.Max(maxSeasonWinnerCount).Error(fmt.Sprintf("winner_count cannot exceed %d", maxSeasonWinnerCount)),
+		),
+	)
+}
+
+func (v Validator) ValidateListSeasons(request ListSeasonsRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Status, validation.In(SeasonUpcoming, SeasonActive, SeasonClosing, SeasonClosed).
+			Error("status must be one of: upcoming, active, closing, closed")),
+		validation.Field(&request.Offset,
+			validation.Min(int32(minOffset)).Error("offset cannot be negative"),
+			validation.Max(int32(maxOffset)).Error(fmt.Sprintf("offset cannot exceed %d", maxOffset)),
+		),
+		validation.Field(&request.PageSize,
+			validation.Required.Error("page_size is required"),
+			validation.Min(int32(minPageSize)).Error(fmt.Sprintf("page_size must be at least %d", minPageSize)),
+			validation.Max(int32(maxPageSize)).Error(fmt.Sprintf("page_size cannot exceed %d", maxPageSize)),
+		),
+	)
+}
+
+func (v Validator) ValidateGetSeasonStandings(request GetSeasonStandingsRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.SeasonID, validation.Required.Error("season_id is required")),
+		validation.Field(&request.Offset,
+			validation.Min(int32(minOffset)).Error("offset cannot be negative"),
+			validation.Max(int32(maxOffset)).Error(fmt.Sprintf("offset cannot exceed %d", maxOffset)),
+		),
+		validation.Field(&request.PageSize,
+			validation.Required.Error("page_size is required"),
+			validation.Min(int32(minPageSize)).Error(fmt.Sprintf("page_size must be at least %d", minPageSize)),
+			validation.Max(int32(maxPageSize)).Error(fmt.Sprintf("page_size cannot exceed %d", maxPageSize)),
+		),
+	)
+}
+
+func (v Validator) ValidateListUserBadges(request ListUserBadgesRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.UserID, validation.Required.Error("user_id is required")),
+	)
+}
+
+func (v Validator) ValidateGetUserStreak(request GetUserStreakRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.UserID, validation.Required.Error("user_id is required")),
+	)
+}
+
+func (v Validator) ValidateListStreaks(request ListStreaksRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Offset,
+			validation.Min(int32(minOffset)).Error("offset cannot be negative"),
+			validation.Max(int32(maxOffset)).Error(fmt.Sprintf("offset cannot exceed %d", maxOffset)),
+		),
+		validation.Field(&request.PageSize,
+			validation.Required.Error("page_size is required"),
+			validation.Min(int32(minPageSize)).Error(fmt.Sprintf("page_size must be at least %d", minPageSize)),
+			validation.Max(int32(maxPageSize)).Error(fmt.Sprintf("page_size cannot exceed %d", maxPageSize)),
+		),
+	)
+}
+
+func (v Validator) ValidateExplainScore(request ExplainScoreRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.UserID, validation.Required.Error("user_id is required")),
+		validation.Field(&request.Timeframe,
+			validation.Required.Error("timeframe is required"),
+			validation.In(
+				AllTime.String(),
+				Yearly.String(),
+				Monthly.String(),
+				Weekly.String(),
+				Daily.String(),
+			).Error("timeframe must be one of: all_time, yearly, monthly, weekly, daily"),
+		),
+		validation.Field(&request.Period,
+			validation.When(
+				request.Timeframe == AllTime.String(),
+				validation.Empty.Error("period is not supported for all_time leaderboards"),
+			),
+		),
+	)
+}
+
+func (v Validator) ValidateListScoreEvents(request ListScoreEventsRequest) error {
+	if err := v.ValidateExplainScore(request.ExplainScoreRequest); err != nil {
+		return err
+	}
+
+	// EventName validates itself against the raw event types, streak bonuses are scored too
+	if request.EventName != "" {
+		err := validation.Validate(string(request.EventName), validation.In(
+			string(PullRequestOpened),
+			string(PullRequestClosed),
+			string(PullRequestReview),
+			string(IssueOpened),
+			string(IssueClosed),
+			string(IssueComment),
+			string(CommitPush),
+			string(StreakBonus),
+			string(ScoreAdjustment),
+		).Error("event_type must be a scored event type"))
+		if err != nil {
+			return validation.Errors{"EventName": err}
+		}
+	}
+
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Offset,
+			validation.Min(int32(minOffset)).Error("offset cannot be negative"),
+			validation.Max(int32(maxOffset)).Error(fmt.Sprintf("offset cannot exceed %d", maxOffset)),
+		),
+		validation.Field(&request.PageSize,
+			validation.Required.Error("page_size is required"),
+			validation.Min(int32(minPageSize)).Error(fmt.Sprintf("page_size must be at least %d", minPageSize)),
+			validation.Max(int32(maxPageSize)).Error(fmt.Sprintf("page_size cannot exceed %d", maxPageSize)),
+		),
+	)
+}
+
+const (
+	maxAdjustmentPoints       = 100000
+	maxAdjustmentReasonLength = 255
+)
+
+var projectIDPattern = regexp.MustCompile(`^[0-9]+$`)
+
+func (v Validator) ValidateCreateAdjustment(request CreateAdjustmentRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.UserID, validation.Required.Error("user_id is required")),
+		validation.Field(&request.ProjectID,
+			validation.Required.Error("project_id is required"),
+			validation.Match(projectIDPattern).Error("project_id must be a repository ID"),
+		),
+		validation.Field(&request.Timeframe,
+			validation.Required.Error("timeframe is required"),
+			validation.In(
+				AdjustmentScopeAll,
+				AllTime.String(),
+				Yearly.String(),
+				Monthly.String(),
+				Weekly.String(),
+				Daily.String(),
+			).Error("timeframe must be one of: all, all_time, yearly, monthly, weekly, daily"),
+		),
+		validation.Field(&request.Period,
+			validation.When(
+				request.Timeframe == AdjustmentScopeAll || request.Timeframe == AllTime.String(),
+				validation.Empty.Error("period is only supported for yearly, monthly, weekly and daily adjustments"),
+			),
+		),
+		validation.Field(&request.Points,
+			validation.Required.Error("points cannot be zero"),
+			validation.Min(int64(-maxAdjustmentPoints)).Error(fmt.Sprintf("points cannot be below -%d", maxAdjustmentPoints)),
+			validation.Max(int64(maxAdjustmentPoints)).Error(fmt.Sprintf("points cannot exceed %d", maxAdjustmentPoints)),
+		),
+		validation.Field(&request.Reason,
+			validation.Required.Error("reason is required"),
+			validation.RuneLength(1, maxAdjustmentReasonLength).Error(fmt.Sprintf("reason cannot exceed %d characters", maxAdjustmentReasonLength)),
+		),
+		validation.Field(&request.Actor, validation.Required.Error("actor is required")),
+	)
+}
+
+func (v Validator) ValidateReverseAdjustment(request ReverseAdjustmentRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.ID, validation.Required.Error("id is required")),
+		validation.Field(&request.Reason,
+			validation.Required.Error("reason is required"),
+			validation.RuneLength(1, maxAdjustmentReasonLength).Error(fmt.Sprintf("reason cannot exceed %d characters", maxAdjustmentReasonLength)),
+		),
+		validation.Field(&request.Actor, validation.Required.Error("actor is required")),
+	)
+}
+
+func (v Validator) ValidateListAdjustments(request ListAdjustmentsRequest) error {
+	return validation.ValidateStruct(&request,
+		validation.Field(&request.Offset,
+			validation.Min(int32(minOffset)).Error("offset cannot be negative"),
+			validation.Max(int32(maxOffset)).Error(fmt.Sprintf("offset cannot exceed %d", maxOffset)),
+		),
+		validation.Field(&request.PageSize,
+			validation.Required.Error("page_size is required"),
+			validation.Min(int32(minPageSize)).Error(fmt.Sprintf("page_size must be at least %d", minPageSize)),
+			validation.Max(int32(maxPageSize)).Error(fmt.Sprintf("page_size cannot exceed %d", maxPageSize)),
+		),
+	)
+}
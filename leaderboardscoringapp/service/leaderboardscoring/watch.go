@@ -0,0 +1,350 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+)
+
+const (
+	defaultWatchPollInterval = 500 * time.Millisecond
+	defaultWatchMinInterval  = time.Second
+)
+
+// WatchConfig controls WatchLeaderboard streams.
+//
+// Every watched board (timeframe, project and page size) is read from the cache by a
+// single poller shared by all streams watching it; each stream then throttles the
+// updates it forwards to its client.
+type WatchConfig struct {
+	PollInterval time.Duration `koanf:"poll_interval"`
+	// MinInterval is the lower bound of the per-stream throttle requested by clients
+	MinInterval time.Duration `koanf:"min_interval"`
+}
+
+func (c WatchConfig) pollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return defaultWatchPollInterval
+	}
+
+	return c.PollInterval
+}
+
+func (c WatchConfig) minInterval() time.Duration {
+	if c.MinInterval <= 0 {
+		return defaultWatchMinInterval
+	}
+
+	return c.MinInterval
+}
+
+// WatchLeaderboard sends the current top rows of a leaderboard to send, then a new page
+// whenever they change, until ctx is cancelled or send fails.
+func (s *Service) WatchLeaderboard(ctx context.Context, req *WatchLeaderboardRequest, send func(LeaderboardUpdate) error) error {
+	getReq := req.getLeaderboardRequest()
+	if err := s.validator.ValidateGetLeaderboard(getReq); err != nil {
+		return errors.Join(ErrInvalidArguments, err)
+	}
+
+	interval := s.config.Watch.minInterval()
+	if req.MinInterval > interval {
+		interval = req.MinInterval
+	}
+
+	target := newWatchTarget(getReq)
+	sub := s.watchers.subscribe(s, target)
+	defer s.watchers.unsubscribe(target, sub)
+
+	var (
+		lastVersion = req.ResumeVersion
+		lastSent    time.Time
+		initial     = true
+		pending     *LeaderboardUpdate
+		throttle    <-chan time.Time
+	)
+
+	flush := func() error {
+		update := *pending
+		update.Initial = initial
+		pending, throttle = nil, nil
+
+		if err := send(update); err != nil {
+			return err
+		}
+
+		lastVersion, lastSent, initial = update.Version, time.Now(), false
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case update := <-sub.updates:
+			if update.Version == lastVersion {
+				// The client resumed with the current version, it already has this page
+				initial = false
+				pending, throttle = nil, nil
+				continue
+			}
+
+			pending = &update
+			if wait := interval - time.Since(lastSent); wait > 0 && !initial {
+				if throttle == nil {
+					throttle = time.After(wait)
+				}
+				continue
+			}
+
+			if err := flush(); err != nil {
+				return err
+			}
+
+		case <-throttle:
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// leaderboardQuery builds the cache query for req at time now.
+func (s *Service) leaderboardQuery(req *GetLeaderboardRequest, now time.Time) *LeaderboardQuery {
+	lbQuery := &LeaderboardQuery{
+		Key:   req.BuildKey(now.In(s.requestLocation(req))),
+		Start: int64(req.Offset),
+		Stop:  int64(req.Offset) + int64(req.PageSize) - 1,
+	}
+
+	// Trending ZSETs hold boosted values, the cache scales them down to the decayed score as of now
+	if req.Timeframe == Trending.String() {
+		lbQuery.Trending = true
+		lbQuery.HalfLives = s.config.Trending.HalfLives(now)
+	}
+
+	return lbQuery
+}
+
+// leaderboardPage is one page of a board, ranked by the board's ranking mode.
+type leaderboardPage struct {
+	key  string
+	mode RankingMode
+	rows []LeaderboardEntry
+}
+
+// readLeaderboard reads the page of req at time now and ranks it.
+func (s *Service) readLeaderboard(ctx context.Context, req *GetLeaderboardRequest, now time.Time) (leaderboardPage, error) {
+	lbQuery := s.leaderboardQuery(req, now)
+	page := leaderboardPage{key: lbQuery.Key, mode: s.config.Ranking.modeOf(req)}
+
+	result, err := s.leaderboard.GetLeaderboard(ctx, lbQuery)
+	if err != nil {
+		return leaderboardPage{}, err
+	}
+
+	page.rows = result.LeaderboardRows
+	if len(page.rows) == 0 {
+		return page, nil
+	}
+
+	r, err := s.newRanker(ctx, page.mode, page.key, lbQuery.Start, page.rows[0])
+	if err != nil {
+		return leaderboardPage{}, err
+	}
+	r.rankRows(page.rows)
+
+	return page, nil
+}
+
+// readWatchedPage reads the current page of a watched board.
+func (s *Service) readWatchedPage(ctx context.Context, target watchTarget) (LeaderboardUpdate, error) {
+	req := target.getLeaderboardRequest()
+
+	page, err := s.readLeaderboard(ctx, req, time.Now())
+	if err != nil {
+		return LeaderboardUpdate{}, err
+	}
+
+	rows := mapLeaderboardScoringToParam(LeaderboardQueryResult{LeaderboardRows: page.rows}).LeaderboardRows
+
+	return LeaderboardUpdate{
+		Timeframe:       req.Timeframe,
+		ProjectID:       req.ProjectID,
+		LeaderboardRows: rows,
+		RankingMode:     page.mode,
+		Version:         leaderboardVersion(page.key, rows),
+	}, nil
+}
+
+// leaderboardVersion hashes the key and rows of a page, so the version is the same on
+// every instance and changes when the period key rolls over. Zero is never returned.
+func leaderboardVersion(key string, rows []LeaderboardRow) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	buf := make([]byte, 8)
+	for _, r := range rows {
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(r.UserID))
+		binary.BigEndian.PutUint64(buf, uint64(r.Score))
+		_, _ = h.Write(buf)
+	}
+
+	if v := h.Sum64(); v != 0 {
+		return v
+	}
+
+	return 1
+}
+
+type watchTarget struct {
+	timeframe string
+	projectID string // empty for the global board
+	pageSize  int32
+}
+
+func newWatchTarget(req *GetLeaderboardRequest) watchTarget {
+	target := watchTarget{timeframe: req.Timeframe, pageSize: req.PageSize}
+	if req.ProjectID != nil {
+		target.projectID = *req.ProjectID
+	}
+
+	return target
+}
+
+func (t watchTarget) getLeaderboardRequest() *GetLeaderboardRequest {
+	req := &GetLeaderboardRequest{Timeframe: t.timeframe, PageSize: t.pageSize}
+	if t.projectID != "" {
+		projectID := t.projectID
+		req.ProjectID = &projectID
+	}
+
+	return req
+}
+
+type watchSubscriber struct {
+	// updates holds at most the latest unread page
+	updates chan LeaderboardUpdate
+}
+
+func (ws *watchSubscriber) deliver(update LeaderboardUpdate) {
+	for {
+		select {
+		case ws.updates <- update:
+			return
+		default:
+		}
+
+		// Drop the unread page, the subscriber only needs the latest one
+		select {
+		case <-ws.updates:
+		default:
+		}
+	}
+}
+
+type watchGroup struct {
+	subscribers map[*watchSubscriber]struct{}
+	latest      *LeaderboardUpdate
+	cancel      context.CancelFunc
+}
+
+// watchHub runs one poller per watched board while it has subscribers.
+type watchHub struct {
+	mu     sync.Mutex
+	groups map[watchTarget]*watchGroup
+}
+
+func newWatchHub() *watchHub {
+	return &watchHub{groups: make(map[watchTarget]*watchGroup)}
+}
+
+func (h *watchHub) subscribe(s *Service, target watchTarget) *watchSubscriber {
+	sub := &watchSubscriber{updates: make(chan LeaderboardUpdate, 1)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	group, ok := h.groups[target]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		group = &watchGroup{subscribers: make(map[*watchSubscriber]struct{}), cancel: cancel}
+		h.groups[target] = group
+
+		go h.poll(ctx, s, target, group)
+	}
+
+	group.subscribers[sub] = struct{}{}
+	if group.latest != nil {
+		sub.deliver(*group.latest)
+	}
+
+	return sub
+}
+
+func (h *watchHub) unsubscribe(target watchTarget, sub *watchSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	group, ok := h.groups[target]
+	if !ok {
+		return
+	}
+
+	delete(group.subscribers, sub)
+	if len(group.subscribers) == 0 {
+		group.cancel()
+		delete(h.groups, target)
+	}
+}
+
+// broadcast delivers the page polled for group. A poller whose group was cancelled may
+// still finish a read, its page is dropped once the target belongs to another group.
+func (h *watchHub) broadcast(target watchTarget, group *watchGroup, update LeaderboardUpdate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.groups[target] != group {
+		return
+	}
+
+	group.latest = &update
+	for sub := range group.subscribers {
+		sub.deliver(update)
+	}
+}
+
+func (h *watchHub) poll(ctx context.Context, s *Service, target watchTarget, group *watchGroup) {
+	ticker := time.NewTicker(s.config.Watch.pollInterval())
+	defer ticker.Stop()
+
+	var lastVersion uint64
+	for {
+		update, err := s.readWatchedPage(ctx, target)
+		switch {
+		case err != nil:
+			if ctx.Err() == nil {
+				logger.L().Warn("failed to read watched leaderboard",
+					slog.String("timeframe", target.timeframe),
+					slog.String("project_id", target.projectID),
+					slog.String("error", err.Error()))
+			}
+		case update.Version != lastVersion:
+			lastVersion = update.Version
+			h.broadcast(target, group, update)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package leaderboardscoring
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingCache holds the first read until release is closed and then returns a stale page,
+// later reads return the current page right away
+type blockingCache struct {
+	LeaderboardCache
+
+	reads   chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingCache) GetLeaderboard(context.Context, *LeaderboardQuery) (LeaderboardQueryResult, error) {
+	select {
+	case c.reads <- struct{}{}:
+		<-c.release
+		return LeaderboardQueryResult{LeaderboardRows: []LeaderboardEntry{{Rank: 1, UserID: "stale", Score: 1}}}, nil
+	default:
+		return LeaderboardQueryResult{LeaderboardRows: []LeaderboardEntry{{Rank: 1, UserID: "current", Score: 2}}}, nil
+	}
+}
+
+func TestWatchHub_ResubscribeDropsStalePoller(t *testing.T) {
+	cache := &blockingCache{reads: make(chan struct{}), release: make(chan struct{})}
+	svc := NewService(Config{Watch: WatchConfig{PollInterval: time.Hour}}, nil, cache, nil, "", NewValidator(), nil, nil, nil)
+	hub := newWatchHub()
+	target := watchTarget{timeframe: AllTime.String(), pageSize: 10}
+
+	// The first poller is stuck in its read when its only subscriber leaves
+	first := hub.subscribe(svc, target)
+	<-cache.reads
+	hub.unsubscribe(target, first)
+
+	second := hub.subscribe(svc, target)
+	defer hub.unsubscribe(target, second)
+
+	select {
+	case update := <-second.updates:
+		require.Len(t, update.LeaderboardRows, 1)
+		assert.Equal(t, "current", update.LeaderboardRows[0].UserID)
+	case <-time.After(time.Second):
+		t.Fatal("no page from the new poller")
+	}
+
+	// The first read completes after the new group started, its page must not reach it
+	close(cache.release)
+	assert.Never(t, func() bool {
+		select {
+		case update := <-second.updates:
+			return update.LeaderboardRows[0].UserID == "stale"
+		default:
+			return false
+		}
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	require.NotNil(t, hub.groups[target].latest)
+	assert.Equal(t, "current", hub.groups[target].latest.LeaderboardRows[0].UserID)
+}
@@ -0,0 +1,235 @@
+package leaderboardscoring_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeaderboardCache serves the rows of every key from memory and counts reads.
+type fakeLeaderboardCache struct {
+	mu    sync.Mutex
+	rows  map[string][]leaderboardscoring.LeaderboardEntry
+	reads int
+}
+
+func newFakeLeaderboardCache() *fakeLeaderboardCache {
+	return &fakeLeaderboardCache{rows: make(map[string][]leaderboardscoring.LeaderboardEntry)}
+}
+
+func (f *fakeLeaderboardCache) set(key string, rows ...leaderboardscoring.LeaderboardEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rows[key] = rows
+}
+
+func (f *fakeLeaderboardCache) readCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.reads
+}
+
+func (f *fakeLeaderboardCache) UpsertScores(context.Context, *leaderboardscoring.UpsertScore) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeLeaderboardCache) UpsertTrendingScores(context.Context, *leaderboardscoring.TrendingScore) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeLeaderboardCache) GetUserRanks(_ context.Context, keys []string, userID string) ([]leaderboardscoring.UserRank, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ranks := make([]leaderboardscoring.UserRank, 0, len(keys))
+	for _, key := range keys {
+		rank := leaderboardscoring.UserRank{Key: key}
+		for i, row := range f.rows[key] {
+			if row.UserID == userID {
+				rank.Rank, rank.Score = int64(i+1), row.Score
+				break
+			}
+		}
+		ranks = append(ranks, rank)
+	}
+
+	return ranks, nil
+}
+
+func (f *fakeLeaderboardCache) CountHigherScores(context.Context, string, int64, bool) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (f *fakeLeaderboardCache) GetLeaderboard(_ context.Context, q *leaderboardscoring.LeaderboardQuery) (leaderboardscoring.LeaderboardQueryResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reads++
+	rows := f.rows[q.Key]
+	if int(q.Stop) < len(rows) {
+		rows = rows[:q.Stop+1]
+	}
+
+	return leaderboardscoring.LeaderboardQueryResult{LeaderboardRows: rows}, nil
+}
+
+const watchedKey = "leaderboard:{global}:all_time"
+
+func newWatchService(cache leaderboardscoring.LeaderboardCache) *leaderboardscoring.Service {
+	cfg := leaderboardscoring.Config{
+		Watch: leaderboardscoring.WatchConfig{
+			PollInterval: 5 * time.Millisecond,
+			MinInterval:  10 * time.Millisecond,
+		},
+	}
+
+	return leaderboardscoring.NewService(cfg, nil, cache, nil, "", leaderboardscoring.NewValidator(), nil, nil, nil)
+}
+
+// watch runs WatchLeaderboard in the background and collects the sent updates.
+func watch(ctx context.Context, svc *leaderboardscoring.Service, req *leaderboardscoring.WatchLeaderboardRequest) (<-chan leaderboardscoring.LeaderboardUpdate, <-chan error) {
+	updates := make(chan leaderboardscoring.LeaderboardUpdate, 16)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- svc.WatchLeaderboard(ctx, req, func(update leaderboardscoring.LeaderboardUpdate) error {
+			updates <- update
+			return nil
+		})
+	}()
+
+	return updates, done
+}
+
+func nextUpdate(t *testing.T, updates <-chan leaderboardscoring.LeaderboardUpdate) leaderboardscoring.LeaderboardUpdate {
+	t.Helper()
+
+	select {
+	case update := <-updates:
+		return update
+	case <-time.After(2 * time.Second):
+		t.Fatal("no update received")
+		return leaderboardscoring.LeaderboardUpdate{}
+	}
+}
+
+func TestWatchLeaderboard_SendsInitialPageAndChanges(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey, leaderboardscoring.LeaderboardEntry{UserID: "1", Score: 10})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, _ := watch(ctx, newWatchService(cache), &leaderboardscoring.WatchLeaderboardRequest{Timeframe: "all_time", PageSize: 2})
+
+	initial := nextUpdate(t, updates)
+	assert.True(t, initial.Initial)
+	require.Len(t, initial.LeaderboardRows, 1)
+	assert.Equal(t, "1", initial.LeaderboardRows[0].UserID)
+
+	cache.set(watchedKey,
+		leaderboardscoring.LeaderboardEntry{UserID: "2", Score: 15},
+		leaderboardscoring.LeaderboardEntry{UserID: "1", Score: 10},
+	)
+
+	changed := nextUpdate(t, updates)
+	assert.False(t, changed.Initial)
+	assert.NotEqual(t, initial.Version, changed.Version)
+	require.Len(t, changed.LeaderboardRows, 2)
+	assert.Equal(t, "2", changed.LeaderboardRows[0].UserID)
+}
+
+func TestWatchLeaderboard_ResumeSkipsUnchangedPage(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey, leaderboardscoring.LeaderboardEntry{UserID: "1", Score: 10})
+	svc := newWatchService(cache)
+	req := &leaderboardscoring.WatchLeaderboardRequest{Timeframe: "all_time", PageSize: 2}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, _ := watch(ctx, svc, req)
+	first := nextUpdate(t, updates)
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	resumed := *req
+	resumed.ResumeVersion = first.Version
+	updates, _ = watch(ctx, svc, &resumed)
+
+	select {
+	case update := <-updates:
+		t.Fatalf("unexpected update after resume: %+v", update)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cache.set(watchedKey, leaderboardscoring.LeaderboardEntry{UserID: "1", Score: 11})
+
+	update := nextUpdate(t, updates)
+	assert.False(t, update.Initial)
+	assert.Equal(t, int64(11), update.LeaderboardRows[0].Score)
+}
+
+func TestWatchLeaderboard_ThrottlesUpdates(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+	cache.set(watchedKey, leaderboardscoring.LeaderboardEntry{UserID: "1", Score: 0})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, _ := watch(ctx, newWatchService(cache), &leaderboardscoring.WatchLeaderboardRequest{
+		Timeframe:   "all_time",
+		PageSize:    1,
+		MinInterval: 200 * time.Millisecond,
+	})
+	nextUpdate(t, updates)
+
+	// Change the board on every poll, only the latest page may be sent after the interval
+	deadline := time.Now().Add(150 * time.Millisecond)
+	for score := int64(1); time.Now().Before(deadline); score++ {
+		cache.set(watchedKey, leaderboardscoring.LeaderboardEntry{UserID: "1", Score: score})
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	select {
+	case update := <-updates:
+		t.Fatalf("update sent before the throttle interval: %+v", update)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	update := nextUpdate(t, updates)
+	assert.Greater(t, update.LeaderboardRows[0].Score, int64(1))
+}
+
+func TestWatchLeaderboard_StopsPollingAfterCancel(t *testing.T) {
+	cache := newFakeLeaderboardCache()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, done := watch(ctx, newWatchService(cache), &leaderboardscoring.WatchLeaderboardRequest{Timeframe: "all_time", PageSize: 1})
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	time.Sleep(20 * time.Millisecond)
+	reads := cache.readCount()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, reads, cache.readCount())
+}
+
+func TestWatchLeaderboard_InvalidTimeframe(t *testing.T) {
+	svc := newWatchService(newFakeLeaderboardCache())
+
+	err := svc.WatchLeaderboard(context.Background(), &leaderboardscoring.WatchLeaderboardRequest{Timeframe: "hourly", PageSize: 1},
+		func(leaderboardscoring.LeaderboardUpdate) error { return nil })
+
+	assert.ErrorIs(t, err, leaderboardscoring.ErrInvalidArguments)
+}
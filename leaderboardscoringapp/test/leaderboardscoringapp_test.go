@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	redisadapter "github.com/gocasters/rankr/adapter/redis"
+	"github.com/gocasters/rankr/leaderboardscoringapp/repository/cachetest"
 	postgrerepository "github.com/gocasters/rankr/leaderboardscoringapp/repository/database"
 	"github.com/gocasters/rankr/leaderboardscoringapp/repository/redisrepository"
 	"github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
@@ -183,7 +184,12 @@ func (suite *IntegrationTestSuite) runMigrations() {
 		event_type VARCHAR(50) NOT NULL,
 		event_timestamp TIMESTAMP NOT NULL,
 		score_delta BIGINT NOT NULL,
-		processed_at TIMESTAMP DEFAULT NOW()
+		processed_at TIMESTAMP DEFAULT NOW(),
+		event_id VARCHAR(255),
+		project_id VARCHAR(100),
+		repository_id BIGINT,
+		repository_name VARCHAR(255),
+		CONSTRAINT uniq_processed_score_events_event_id UNIQUE (event_id)
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_score_events_user_id ON processed_score_events (user_id);
@@ -249,11 +255,15 @@ func (suite *IntegrationTestSuite) TestProcessScoreEvent_Success() {
 	ctx := context.Background()
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -278,7 +288,7 @@ func (suite *IntegrationTestSuite) TestProcessScoreEvent_Success() {
 	err := service.ProcessScoreEvent(ctx, eventReq)
 	suite.NoError(err)
 
-	leaderboardKey := "leaderboard:1001:all_time"
+	leaderboardKey := "leaderboard:{1001}:all_time"
 	score, err := suite.redisClient.ZScore(ctx, leaderboardKey, strconv.FormatUint(userID, 10)).Result()
 	suite.NoError(err)
 	suite.Equal(float64(1), score, "Score should be 1 for PullRequestOpened")
@@ -291,11 +301,15 @@ func (suite *IntegrationTestSuite) TestProcessScoreEvent_InvalidEvent() {
 	ctx := context.Background()
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	// Missing required fields
@@ -322,11 +336,15 @@ func (suite *IntegrationTestSuite) TestMultipleEvents_IncreaseScore() {
 	require.Len(suite.T(), keys, 0, "Redis should be empty before test")
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(456)
@@ -371,7 +389,7 @@ func (suite *IntegrationTestSuite) TestMultipleEvents_IncreaseScore() {
 	}
 
 	// Verify total score in Redis : 1 + 2 + 4 = 7
-	leaderboardKey := "leaderboard:1001:all_time"
+	leaderboardKey := "leaderboard:{1001}:all_time"
 	score, err := suite.redisClient.ZScore(ctx, leaderboardKey, userIDStr).Result()
 
 	suite.NoError(err)
@@ -384,11 +402,15 @@ func (suite *IntegrationTestSuite) TestLeaderboardRanking_Real() {
 	ctx := context.Background()
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	// Create users with different scores
@@ -427,7 +449,7 @@ func (suite *IntegrationTestSuite) TestLeaderboardRanking_Real() {
 	}
 
 	// Get leaderboard from Redis
-	leaderboardKey := "leaderboard:1001:all_time"
+	leaderboardKey := "leaderboard:{1001}:all_time"
 	rankings, err := suite.redisClient.ZRevRangeWithScores(ctx, leaderboardKey, 0, -1).Result()
 	suite.NoError(err)
 
@@ -452,18 +474,26 @@ func (suite *IntegrationTestSuite) TestEventPersistence_Real() {
 	// Create events directly in PostgreSQL
 	events := []leaderboardscoring.ProcessedScoreEvent{
 		{
-			ID:        1,
-			UserID:    "user1",
-			EventName: leaderboardscoring.PullRequestOpened,
-			Score:     10,
-			Timestamp: time.Now().UTC(),
+			EventID:        "event-1",
+			UserID:         "user1",
+			EventName:      leaderboardscoring.PullRequestOpened,
+			Score:          10,
+			ProjectID:      "1001",
+			RepositoryID:   1001,
+			RepositoryName: "rankr",
+			Timestamp:      time.Now().UTC().Add(-time.Minute),
+			ProcessedAt:    time.Now().UTC(),
 		},
 		{
-			ID:        2,
-			UserID:    "user2",
-			EventName: leaderboardscoring.PullRequestClosed,
-			Score:     20,
-			Timestamp: time.Now().UTC(),
+			EventID:        "event-2",
+			UserID:         "user2",
+			EventName:      leaderboardscoring.PullRequestClosed,
+			Score:          20,
+			ProjectID:      "1001",
+			RepositoryID:   1001,
+			RepositoryName: "rankr",
+			Timestamp:      time.Now().UTC().Add(-time.Minute),
+			ProcessedAt:    time.Now().UTC(),
 		},
 	}
 
@@ -489,6 +519,27 @@ func (suite *IntegrationTestSuite) TestEventPersistence_Real() {
 	suite.NoError(err)
 	suite.Equal(leaderboardscoring.PullRequestOpened.String(), eventType)
 	suite.Equal(int64(10), score)
+
+	// Redelivered events must not create duplicate rows
+	err = suite.persistence.AddProcessedScoreEvents(ctx, append(events, events[0]))
+	suite.NoError(err)
+
+	err = suite.postgresConn.Pool.QueryRow(
+		ctx,
+		"SELECT COUNT(*) FROM processed_score_events WHERE event_id IN ('event-1', 'event-2')",
+	).Scan(&count)
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	var projectID string
+	var repositoryID int64
+	err = suite.postgresConn.Pool.QueryRow(
+		ctx,
+		"SELECT project_id, repository_id FROM processed_score_events WHERE event_id = 'event-1'",
+	).Scan(&projectID, &repositoryID)
+	suite.NoError(err)
+	suite.Equal("1001", projectID)
+	suite.Equal(int64(1001), repositoryID)
 }
 
 // Get leaderboard through service (reads from real Redis)
@@ -496,11 +547,15 @@ func (suite *IntegrationTestSuite) TestGetLeaderboard_Real() {
 	ctx := context.Background()
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	// Add users to Redis leaderboard
@@ -510,7 +565,7 @@ func (suite *IntegrationTestSuite) TestGetLeaderboard_Real() {
 		"mahdi":    90,
 	}
 
-	leaderboardKey := "leaderboard:1001:all_time"
+	leaderboardKey := "leaderboard:{1001}:all_time"
 	for user, score := range userScores {
 		err := suite.redisClient.ZAdd(ctx, leaderboardKey, redis.Z{Score: float64(score), Member: user}).Err()
 		suite.NoError(err)
@@ -539,11 +594,15 @@ func (suite *IntegrationTestSuite) TestConcurrentEvents() {
 	ctx := context.Background()
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	// Simulate concurrent requests from different users
@@ -580,7 +639,7 @@ func (suite *IntegrationTestSuite) TestConcurrentEvents() {
 	}
 
 	// Verify all users are in Redis
-	leaderboardKey := "leaderboard:1001:all_time"
+	leaderboardKey := "leaderboard:{1001}:all_time"
 	size, err := suite.redisClient.ZCard(ctx, leaderboardKey).Result()
 	suite.NoError(err)
 	suite.Equal(int64(10), size)
@@ -591,15 +650,19 @@ func (suite *IntegrationTestSuite) TestPagination_Real() {
 	ctx := context.Background()
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	// Add 25 users to Redis
-	leaderboardKey := "leaderboard:1001:all_time"
+	leaderboardKey := "leaderboard:{1001}:all_time"
 	for i := 0; i < 25; i++ {
 		user := fmt.Sprintf("user%d", i)
 		score := float64(100 - i)
@@ -638,11 +701,15 @@ func (suite *IntegrationTestSuite) TestGetLeaderboard_InvalidOffset() {
 	ctx := context.Background()
 
 	service := leaderboardscoring.NewService(
+		leaderboardscoring.Config{},
 		suite.persistence,
 		suite.leaderboard,
 		suite.mockPublisher,
 		"processed_events",
 		leaderboardscoring.NewValidator(),
+		nil,
+		nil,
+		nil,
 	)
 
 	var projectID = "1001"
@@ -657,6 +724,104 @@ func (suite *IntegrationTestSuite) TestGetLeaderboard_InvalidOffset() {
 	suite.Error(err)
 }
 
+// Tied ranks of competition and dense boards continue across pages
+func (suite *IntegrationTestSuite) TestGetLeaderboard_RankingModesAcrossPages() {
+	ctx := context.Background()
+
+	leaderboardKey := "leaderboard:{1001}:all_time"
+	for user, score := range map[string]int64{"u1": 50, "u2": 40, "u3": 40, "u4": 40, "u5": 30} {
+		suite.NoError(suite.redisClient.ZAdd(ctx, leaderboardKey, redis.Z{Score: float64(score), Member: user}).Err())
+	}
+
+	projectID := "1001"
+	req := &leaderboardscoring.GetLeaderboardRequest{
+		Timeframe: leaderboardscoring.AllTime.String(),
+		ProjectID: &projectID,
+		PageSize:  2,
+		Offset:    2,
+	}
+
+	for mode, want := range map[leaderboardscoring.RankingMode][]int64{
+		leaderboardscoring.RankingOrdinal:     {3, 4},
+		leaderboardscoring.RankingCompetition: {2, 2},
+		leaderboardscoring.RankingDense:       {2, 2},
+	} {
+		service := leaderboardscoring.NewService(
+			leaderboardscoring.Config{Ranking: leaderboardscoring.RankingConfig{Default: mode}},
+			suite.persistence,
+			suite.leaderboard,
+			suite.mockPublisher,
+			"processed_events",
+			leaderboardscoring.NewValidator(),
+			nil,
+			nil,
+			nil,
+		)
+
+		resp, err := service.GetLeaderboard(ctx, req)
+		suite.NoError(err)
+		suite.Equal(mode, resp.RankingMode)
+		suite.Require().Len(resp.LeaderboardRows, 2)
+		suite.Equal(want, []int64{resp.LeaderboardRows[0].Rank, resp.LeaderboardRows[1].Rank}, mode)
+
+		last, err := service.GetLeaderboard(ctx, &leaderboardscoring.GetLeaderboardRequest{
+			Timeframe: req.Timeframe, ProjectID: &projectID, PageSize: 2, Offset: 4,
+		})
+		suite.NoError(err)
+		suite.Require().Len(last.LeaderboardRows, 1)
+		if mode == leaderboardscoring.RankingDense {
+			suite.Equal(int64(3), last.LeaderboardRows[0].Rank)
+		} else {
+			suite.Equal(int64(5), last.LeaderboardRows[0].Rank)
+		}
+	}
+}
+
+// TestLeaderboardCacheConformance runs the shared LeaderboardCache suite against Redis
+func (suite *IntegrationTestSuite) TestLeaderboardCacheConformance() {
+	cachetest.Run(suite.T(), func(t *testing.T) leaderboardscoring.LeaderboardCache {
+		return suite.leaderboard
+	})
+}
+
+// TestKeyMigration moves legacy keys to the hash-tagged layout and merges them with
+// scores already written under the new names
+func (suite *IntegrationTestSuite) TestKeyMigration() {
+	ctx := suite.ctx
+	expireAt := time.Now().Add(time.Hour)
+
+	suite.Require().NoError(suite.redisClient.ZAdd(ctx, "leaderboard:1001:all_time",
+		redis.Z{Member: "1", Score: 10}, redis.Z{Member: "2", Score: 5}).Err())
+	suite.Require().NoError(suite.redisClient.ZAdd(ctx, "leaderboard:global:daily:2025-06-01",
+		redis.Z{Member: "1", Score: 3}).Err())
+	suite.Require().NoError(suite.redisClient.ExpireAt(ctx, "leaderboard:global:daily:2025-06-01", expireAt).Err())
+	suite.Require().NoError(suite.redisClient.ZIncrBy(ctx, "leaderboard:{1001}:all_time", 4, "1").Err())
+
+	migrator := redisrepository.NewKeyMigrator(suite.redisClient)
+
+	dryRun, err := migrator.Migrate(ctx, true)
+	suite.Require().NoError(err)
+	suite.Equal(2, dryRun.Scanned)
+	suite.Equal(0, dryRun.Migrated)
+
+	result, err := migrator.Migrate(ctx, false)
+	suite.Require().NoError(err)
+	suite.Equal(2, result.Migrated)
+	suite.Equal(int64(3), result.Members)
+
+	suite.Equal(int64(0), suite.redisClient.Exists(ctx, "leaderboard:1001:all_time", "leaderboard:global:daily:2025-06-01").Val())
+	suite.Equal(float64(14), suite.redisClient.ZScore(ctx, "leaderboard:{1001}:all_time", "1").Val())
+	suite.Equal(float64(5), suite.redisClient.ZScore(ctx, "leaderboard:{1001}:all_time", "2").Val())
+
+	ttl := suite.redisClient.TTL(ctx, "leaderboard:{global}:daily:2025-06-01").Val()
+	suite.Greater(ttl, 59*time.Minute)
+
+	// A second run finds nothing to migrate
+	again, err := migrator.Migrate(ctx, false)
+	suite.Require().NoError(err)
+	suite.Equal(0, again.Scanned)
+}
+
 // Mock implementations
 type MockNATSPublisher struct {
 	PublishCalled bool
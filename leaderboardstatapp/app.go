@@ -9,13 +9,20 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/gocasters/rankr/adapter/contributor"
 	"github.com/gocasters/rankr/adapter/leaderboardscoring"
+	"github.com/gocasters/rankr/adapter/nats"
 	"github.com/gocasters/rankr/adapter/project"
 	"github.com/gocasters/rankr/adapter/redis"
+	"github.com/gocasters/rankr/leaderboardstatapp/delivery/consumer"
 	"github.com/gocasters/rankr/leaderboardstatapp/delivery/scheduler"
 	"github.com/gocasters/rankr/leaderboardstatapp/repository"
 	"github.com/gocasters/rankr/pkg/cachemanager"
 	"github.com/gocasters/rankr/pkg/database"
+	"github.com/gocasters/rankr/pkg/privacy"
+	"github.com/gocasters/rankr/pkg/topicsname"
 
 	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
 	"github.com/gocasters/rankr/pkg/httpserver"
@@ -38,6 +45,10 @@ type Application struct {
 	Scheduler              scheduler.Scheduler
 	scoringRPCClient       *grpc.RPCClient
 	projectRPCClient       *grpc.RPCClient
+	contributorRPCClient   *grpc.RPCClient
+	WMRouter               *message.Router
+	natsAdapter            *nats.Adapter
+	boardRefresher         *leaderboardstat.PublicBoardRefresher
 }
 
 func Setup(
@@ -79,18 +90,50 @@ func Setup(
 		return Application{}, fmt.Errorf("failed to create project client: %w", err)
 	}
 
+	// The privacy_mode of the contributors decides what the public responses show
+	if err := config.Privacy.Validate(); err != nil {
+		scoringRPCClient.Close()
+		projectRPCClient.Close()
+		return Application{}, fmt.Errorf("invalid privacy config: %w", err)
+	}
+
+	contributorRPCClient, err := grpc.NewClient(config.ContributorRPC, statLogger)
+	if err != nil {
+		scoringRPCClient.Close()
+		projectRPCClient.Close()
+		return Application{}, fmt.Errorf("failed to create contributor RPC client: %w", err)
+	}
+
+	contributorClient, err := contributor.New(contributorRPCClient)
+	if err != nil {
+		scoringRPCClient.Close()
+		projectRPCClient.Close()
+		contributorRPCClient.Close()
+		return Application{}, fmt.Errorf("failed to create contributor client: %w", err)
+	}
+
 	statRepo := repository.NewLeaderboardstatRepo(config.Repository, postgresConn)
 	statValidator := leaderboardstat.NewValidator(statRepo)
 	redisLeaderboardRepo := repository.NewRedisLeaderboardRepository(redisAdapter.Client())
 
-	statSvc := leaderboardstat.NewService(statRepo, statValidator, *cache, redisLeaderboardRepo, lbScoringClient, projectClient)
-	statHandler := statHTTP.NewHandler(statSvc)
+	statSvc := leaderboardstat.NewService(
+		statRepo,
+		statValidator,
+		*cache,
+		redisLeaderboardRepo,
+		lbScoringClient,
+		projectClient,
+		privacy.NewIdentityResolver(config.Privacy, contributorClient),
+	)
+	insightsSvc := leaderboardstat.NewProjectInsightsService(repository.NewProjectInsightsRepo(postgresConn), statValidator)
+	statHandler := statHTTP.NewHandler(statSvc, insightsSvc)
 
 	httpServer, err := httpserver.New(config.HTTPServer)
 	if err != nil {
 		statLogger.Error("failed to initialize HTTP server", slog.Any("error", err))
 		scoringRPCClient.Close()
 		projectRPCClient.Close()
+		contributorRPCClient.Close()
 		return Application{}, err
 	}
 
@@ -99,6 +142,7 @@ func Setup(
 		statLogger.Error("Failed to initialize gRPC server", slog.String("error", gErr.Error()))
 		scoringRPCClient.Close()
 		projectRPCClient.Close()
+		contributorRPCClient.Close()
 		return Application{}, gErr
 	}
 	statGrpcHandler := statGRPC.NewHandler(statSvc)
@@ -107,6 +151,54 @@ func Setup(
 	// Initialize scheduler
 	statScheduler := scheduler.New(&statSvc, config.SchedulerCfg)
 
+	// The project insights grow with the raw event stream
+	if config.StreamNameRawEvents == "" {
+		config.StreamNameRawEvents = topicsname.StreamNameRawEvents
+	}
+
+	wmLogger := watermill.NewStdLogger(true, true)
+	natsAdapter, err := nats.New(ctx, config.WatermillNats, wmLogger)
+	if err != nil {
+		statLogger.Error("failed to initialize NATS Watermill adapter", slog.String("error", err.Error()))
+		scoringRPCClient.Close()
+		projectRPCClient.Close()
+		contributorRPCClient.Close()
+		return Application{}, err
+	}
+
+	router, err := message.NewRouter(message.RouterConfig{}, wmLogger)
+	if err != nil {
+		statLogger.Error("failed to initialize Watermill router", slog.String("error", err.Error()))
+		_ = natsAdapter.Close()
+		scoringRPCClient.Close()
+		projectRPCClient.Close()
+		contributorRPCClient.Close()
+		return Application{}, err
+	}
+
+	// The public boards follow the scoring updates between the scheduled refreshes
+	var boardRefresher *leaderboardstat.PublicBoardRefresher
+	if config.PublicBoardRefresh.Enabled {
+		boardRefresher = leaderboardstat.NewPublicBoardRefresher(config.PublicBoardRefresh, &statSvc)
+	}
+	eventHandler := consumer.NewHandler(insightsSvc, boardRefresher)
+
+	router.AddConsumerHandler(
+		"project_insights_consumer",
+		config.StreamNameRawEvents,
+		natsAdapter.Subscriber(),
+		eventHandler.HandleEvent,
+	)
+
+	if boardRefresher != nil {
+		router.AddConsumerHandler(
+			"public_board_refresh_consumer",
+			topicsname.TopicLeaderboardUpdated,
+			natsAdapter.Subscriber(),
+			eventHandler.HandleLeaderboardUpdated,
+		)
+	}
+
 	return Application{
 		LeaderboardstatRepo:    statRepo,
 		LeaderboardstatSrv:     statSvc,
@@ -115,13 +207,17 @@ func Setup(
 			*httpServer,
 			statHandler,
 		),
-		GRPCServer:       statGrpcServer,
-		Config:           config,
-		CacheManager:     *cache,
-		redis:            redisAdapter,
-		Scheduler:        statScheduler,
-		scoringRPCClient: scoringRPCClient,
-		projectRPCClient: projectRPCClient,
+		GRPCServer:           statGrpcServer,
+		Config:               config,
+		CacheManager:         *cache,
+		redis:                redisAdapter,
+		Scheduler:            statScheduler,
+		scoringRPCClient:     scoringRPCClient,
+		projectRPCClient:     projectRPCClient,
+		contributorRPCClient: contributorRPCClient,
+		WMRouter:             router,
+		natsAdapter:          natsAdapter,
+		boardRefresher:       boardRefresher,
 	}, nil
 }
 
@@ -133,6 +229,7 @@ func (app Application) Start() {
 
 	startServers(app, &wg)
 	app.startScheduler(ctx, &wg)
+	startBoardRefresher(ctx, app.boardRefresher, &wg)
 	<-ctx.Done()
 
 	statLogger := logger.L()
@@ -159,6 +256,19 @@ func (app Application) startScheduler(ctx context.Context, wg *sync.WaitGroup) {
 	}()
 }
 
+func startBoardRefresher(ctx context.Context, refresher *leaderboardstat.PublicBoardRefresher, wg *sync.WaitGroup) {
+	if refresher == nil {
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		logger.L().Info("Public board refresher started", slog.String("topic", topicsname.TopicLeaderboardUpdated))
+		_ = refresher.Start(ctx)
+	}()
+}
+
 func startServers(app Application, wg *sync.WaitGroup) {
 	statLogger := logger.L()
 
@@ -181,6 +291,15 @@ func startServers(app Application, wg *sync.WaitGroup) {
 			statLogger.Error("error in serving leaderboard-stat gRPC server", "error", err)
 		}
 	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		statLogger.Info("Project insights consumer started", slog.String("stream", app.Config.StreamNameRawEvents))
+		if err := app.WMRouter.Run(context.Background()); err != nil {
+			statLogger.Error("Project insights consumer stopped with error", slog.Any("error", err))
+		}
+	}()
 }
 
 func (app Application) shutdownServers(ctx context.Context) bool {
@@ -191,9 +310,10 @@ func (app Application) shutdownServers(ctx context.Context) bool {
 
 	go func() {
 		var shutdownWg sync.WaitGroup
-		shutdownWg.Add(2)
+		shutdownWg.Add(3)
 		go app.shutdownHTTPServer(ctx, &shutdownWg)
 		go app.shutdownGRPCServer(ctx, &shutdownWg)
+		go app.shutdownEventConsumer(&shutdownWg)
 
 		shutdownWg.Wait()
 
@@ -224,6 +344,11 @@ func (app Application) closeRPCClients() {
 		app.projectRPCClient.Close()
 		statLogger.Info("Project RPC client closed")
 	}
+
+	if app.contributorRPCClient != nil {
+		app.contributorRPCClient.Close()
+		statLogger.Info("Contributor RPC client closed")
+	}
 }
 
 func (app Application) shutdownHTTPServer(parentCtx context.Context, wg *sync.WaitGroup) {
@@ -250,3 +375,19 @@ func (app Application) shutdownGRPCServer(parentCtx context.Context, wg *sync.Wa
 
 	statLogger.Info("leaderboard-stat gRPC server shutdown successfully.")
 }
+
+func (app Application) shutdownEventConsumer(wg *sync.WaitGroup) {
+	statLogger := logger.L()
+	defer wg.Done()
+	statLogger.Info("starting gracefully shutdown project insights consumer")
+
+	if err := app.WMRouter.Close(); err != nil {
+		statLogger.Error("project insights consumer shutdown failed", slog.Any("error", err))
+	}
+
+	if err := app.natsAdapter.Close(); err != nil {
+		statLogger.Error("NATS adapter close failed", slog.Any("error", err))
+	}
+
+	statLogger.Info("project insights consumer shutdown successfully.")
+}
@@ -1,13 +1,16 @@
 package leaderboardstatapp
 
 import (
+	"github.com/gocasters/rankr/adapter/nats"
 	"github.com/gocasters/rankr/adapter/redis"
 	"github.com/gocasters/rankr/leaderboardstatapp/delivery/scheduler"
 	"github.com/gocasters/rankr/leaderboardstatapp/repository"
+	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
 	"github.com/gocasters/rankr/pkg/database"
 	"github.com/gocasters/rankr/pkg/grpc"
 	"github.com/gocasters/rankr/pkg/httpserver"
 	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
 	"time"
 )
 
@@ -23,4 +26,12 @@ type Config struct {
 	SchedulerCfg          scheduler.Config  `koanf:"scheduler_cfg"`
 	LeaderboardScoringRPC grpc.ClientConfig `koanf:"leaderboard_scoring_rpc"`
 	ProjectRPC            grpc.ClientConfig `koanf:"project_rpc"`
+	ContributorRPC        grpc.ClientConfig `koanf:"contributor_rpc"`
+	WatermillNats         nats.Config       `koanf:"watermill_nats"`
+	StreamNameRawEvents   string            `koanf:"stream_name_raw_events"`
+
+	// PublicBoardRefresh applies the scoring updates to the cached public boards
+	PublicBoardRefresh leaderboardstat.PublicBoardRefreshConfig `koanf:"public_board_refresh"`
+	// Privacy hides the contributors in privacy_mode "anonymous" on the public responses
+	Privacy privacy.Config `koanf:"privacy"`
 }
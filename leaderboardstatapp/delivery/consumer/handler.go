@@ -0,0 +1,161 @@
+package consumer
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/gocasters/rankr/leaderboardscoringapp/delivery/publisher/rankupdate"
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
+	"github.com/gocasters/rankr/pkg/logger"
+	eventpb "github.com/gocasters/rankr/protobuf/golang/event/v1"
+	types "github.com/gocasters/rankr/type"
+	"google.golang.org/protobuf/proto"
+)
+
+type Handler struct {
+	insightsSvc leaderboardstat.ProjectInsightsService
+	refresher   *leaderboardstat.PublicBoardRefresher
+}
+
+// NewHandler returns the event handler, refresher is nil when the public boards are only
+// refreshed by the scheduler
+func NewHandler(insightsSvc leaderboardstat.ProjectInsightsService, refresher *leaderboardstat.PublicBoardRefresher) Handler {
+	return Handler{
+		insightsSvc: insightsSvc,
+		refresher:   refresher,
+	}
+}
+
+// HandleEvent adds a raw event to the health series of its project
+func (h Handler) HandleEvent(msg *message.Message) error {
+	log := logger.L()
+
+	var event eventpb.Event
+	if err := proto.Unmarshal(msg.Payload, &event); err != nil {
+		log.Error(
+			"Failed to unmarshal event payload",
+			slog.String("msg_id", msg.UUID),
+			slog.String("error", err.Error()),
+		)
+		// A malformed message never succeeds - acknowledge it
+		return nil
+	}
+
+	req, err := lbscoring.NewEventRequest().MapProtoEventToEventRequest(&event)
+	if err != nil {
+		log.Warn(
+			"Skipping event without insights",
+			slog.String("event_id", event.Id),
+			slog.String("event_name", event.EventName.String()),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+
+	recorded, err := h.insightsSvc.RecordEvent(msg.Context(), req)
+	if err != nil {
+		log.Error(
+			"Failed to record project activity",
+			slog.String("event_id", req.ID),
+			slog.Uint64("repository_id", req.RepositoryID),
+			slog.String("error", err.Error()),
+		)
+		// Return error to trigger retry
+		return err
+	}
+
+	log.Debug(
+		"Project activity handled",
+		slog.String("event_id", req.ID),
+		slog.Uint64("repository_id", req.RepositoryID),
+		slog.Bool("recorded", recorded),
+	)
+
+	return nil
+}
+
+// HandleLeaderboardUpdated queues the score changes of a project's daily board for its
+// public board. Other boards are not cached publicly and are acknowledged.
+func (h Handler) HandleLeaderboardUpdated(msg *message.Message) error {
+	log := logger.L()
+
+	if h.refresher == nil {
+		return nil
+	}
+
+	var event rankupdate.LeaderboardUpdatedEvent
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		log.Error(
+			"Failed to unmarshal leaderboard update",
+			slog.String("msg_id", msg.UUID),
+			slog.String("error", err.Error()),
+		)
+		// A malformed message never succeeds - acknowledge it
+		return nil
+	}
+
+	projectID, period, ok := parseDailyProjectKey(event.LeaderboardKey)
+	if !ok {
+		return nil
+	}
+
+	scores := make(map[int]float64, len(event.Changes))
+	for _, change := range event.Changes {
+		contributorID, err := strconv.Atoi(change.UserID)
+		if err != nil {
+			log.Warn(
+				"Skipping rank change of a non-numeric user",
+				slog.String("leaderboard_key", event.LeaderboardKey),
+				slog.String("user_id", change.UserID),
+			)
+			continue
+		}
+		scores[contributorID] = float64(change.Score)
+	}
+
+	queued := h.refresher.Notify(leaderboardstat.BoardUpdate{
+		ProjectID: projectID,
+		Period:    period,
+		Scores:    scores,
+		At:        event.Timestamp,
+	}, time.Now())
+
+	log.Debug(
+		"Leaderboard update handled",
+		slog.String("leaderboard_key", event.LeaderboardKey),
+		slog.Int("changes", len(event.Changes)),
+		slog.Bool("queued", queued),
+	)
+
+	return nil
+}
+
+// parseDailyProjectKey returns the project and day of a key like leaderboard:{1001}:daily:2025-06-01
+func parseDailyProjectKey(key string) (types.ID, string, bool) {
+	rest, found := strings.CutPrefix(key, "leaderboard:{")
+	if !found {
+		return 0, "", false
+	}
+
+	scope, rest, found := strings.Cut(rest, "}:")
+	if !found {
+		return 0, "", false
+	}
+
+	period, found := strings.CutPrefix(rest, lbscoring.Daily.String()+":")
+	if !found || period == "" {
+		return 0, "", false
+	}
+
+	projectID, err := strconv.ParseUint(scope, 10, 64)
+	if err != nil || projectID == 0 {
+		return 0, "", false
+	}
+
+	return types.ID(projectID), period, true
+}
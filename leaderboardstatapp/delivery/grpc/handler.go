@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
 	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
 	"github.com/gocasters/rankr/pkg/slice"
 	leaderboardstatpb "github.com/gocasters/rankr/protobuf/golang/leaderboardstat"
 	types "github.com/gocasters/rankr/type"
@@ -11,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"log/slog"
+	"time"
 )
 
 type Handler struct {
@@ -49,6 +52,25 @@ func (h Handler) GetContributorStats(ctx context.Context, req *leaderboardstatpb
 		TotalScore:    statsRes.TotalScore,
 		ProjectsScore: slice.MapFromIDFloat64ToUint64Float64(statsRes.ProjectsScore),
 		ScoreHistory:  scoreHistory,
+		Streak: &leaderboardstatpb.ContributorStreak{
+			Current:  statsRes.Streak.Current,
+			Longest:  statsRes.Streak.Longest,
+			Timezone: statsRes.Streak.Timezone,
+		},
+	}
+	if analytics := statsRes.Analytics; analytics != nil {
+		contributorStatResponse.Analytics = &leaderboardstatpb.ContributorAnalytics{
+			GlobalPercentile:      analytics.GlobalPercentile,
+			ProjectPercentiles:    slice.MapFromIDFloat64ToUint64Float64(analytics.ProjectPercentiles),
+			WeeklyRankChange:      analytics.WeeklyRankChange,
+			MonthlyRankChange:     analytics.MonthlyRankChange,
+			WeeklyVelocity:        analytics.WeeklyVelocity,
+			MonthlyVelocity:       analytics.MonthlyVelocity,
+			ProjectedMonthEndRank: uint64(analytics.ProjectedMonthEndRank),
+		}
+	}
+	if !statsRes.Streak.LastActiveDay.IsZero() {
+		contributorStatResponse.Streak.LastActiveDay = statsRes.Streak.LastActiveDay.Format(time.DateOnly)
 	}
 
 	return contributorStatResponse, nil
@@ -80,7 +102,8 @@ func (h Handler) GetPublicLeaderboard(ctx context.Context, req *leaderboardstatp
 		slog.Uint64("project_id", projectId),
 		slog.Int64("page_size", int64(pageSize)),
 		slog.Int64("offset", int64(offset)))
-	scoreList, err := h.leaderboardStatSvc.GetPublicLeaderboard(ctx, types.ID(projectId), pageSize, offset)
+	// gRPC callers get the board as the public sees it
+	scoreList, err := h.leaderboardStatSvc.GetPublicLeaderboard(ctx, types.ID(projectId), pageSize, offset, privacy.Viewer{})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get public leaderboard: %v", err)
 	}
@@ -92,9 +115,12 @@ func (h Handler) GetPublicLeaderboard(ctx context.Context, req *leaderboardstatp
 	items := make([]*leaderboardstatpb.PublicLeaderboardRow, 0, len(scoreList.UsersScore))
 	for _, us := range scoreList.UsersScore {
 		item := &leaderboardstatpb.PublicLeaderboardRow{
-			UserId: uint64(us.ContributorID),
-			Rank:   us.Rank,
-			Score:  us.Score,
+			UserId:       uint64(us.ContributorID),
+			Rank:         us.Rank,
+			Score:        us.Score,
+			DisplayName:  us.Identity.DisplayName,
+			ProfileImage: us.Identity.ProfileImage,
+			Anonymous:    us.Identity.Anonymous,
 		}
 		items = append(items, item)
 	}
@@ -103,10 +129,35 @@ func (h Handler) GetPublicLeaderboard(ctx context.Context, req *leaderboardstatp
 		ProjectId: projectId,
 		Rows:      items,
 	}
+	if !scoreList.LastUpdated.IsZero() {
+		response.LastUpdated = timestamppb.New(scoreList.LastUpdated)
+	}
 
 	return response, nil
 }
 
+func (h Handler) GetContributorPeriodScores(ctx context.Context, req *leaderboardstatpb.ContributorPeriodScoresRequest) (*leaderboardstatpb.ContributorPeriodScoresResponse, error) {
+	scores, err := h.leaderboardStatSvc.GetContributorPeriodScores(ctx, leaderboardstat.ContributorPeriodScoresRequest{
+		ContributorID: types.ID(req.GetContributorId()),
+		Timeframe:     req.GetTimeframe(),
+		Period:        req.GetPeriod(),
+	})
+	if err != nil {
+		if errors.Is(err, leaderboardstat.ErrInvalidArguments) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get contributor period scores: %v", err)
+	}
+
+	return &leaderboardstatpb.ContributorPeriodScoresResponse{
+		ContributorId: req.GetContributorId(),
+		Timeframe:     scores.Timeframe,
+		Period:        scores.Period,
+		TotalScore:    scores.TotalScore,
+		ProjectsScore: slice.MapFromIDFloat64ToUint64Float64(scores.ProjectsScore),
+	}, nil
+}
+
 /*
 func (h Handler) GetContributorTotalStats(ctx context.Context, req *leaderboardstatpb.ContributorStatRequest) (*leaderboardstatpb.ContributorStatResponse, error) {
 	stats, err := h.leaderboardStatSvc.GetContributorTotalStats(ctx, types.ID(req.GetContributorId()))
@@ -1,20 +1,30 @@
 package http
 
 import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
+	"github.com/gocasters/rankr/pkg/privacy"
 	types "github.com/gocasters/rankr/type"
 	"github.com/labstack/echo/v4"
+	"hash/fnv"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Handler struct {
 	LeaderboardStatService leaderboardstat.Service
+	ProjectInsightsService leaderboardstat.ProjectInsightsService
 }
 
-func NewHandler(leaderboardStatService leaderboardstat.Service) Handler {
+func NewHandler(leaderboardStatService leaderboardstat.Service, projectInsightsService leaderboardstat.ProjectInsightsService) Handler {
 	return Handler{
 		LeaderboardStatService: leaderboardStatService,
+		ProjectInsightsService: projectInsightsService,
 	}
 }
 
@@ -28,25 +38,343 @@ func (h Handler) GetContributorStats(c echo.Context) error {
 	}
 
 	contributorID := types.ID(idInt)
+	identity, ok, err := h.contributorIdentity(c, contributorID)
+	if !ok {
+		return err
+	}
+
 	response, err := h.LeaderboardStatService.GetContributorStats(c.Request().Context(), contributorID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to get contributor stats",
 		})
 	}
+	response.Identity = identity
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetContributorPeriodScores returns the scores of a contributor in one daily, weekly,
+// monthly or yearly period, the current one when no period is given
+func (h Handler) GetContributorPeriodScores(c echo.Context) error {
+	idInt, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid contributor ID",
+		})
+	}
+
+	if _, ok, err := h.contributorIdentity(c, types.ID(idInt)); !ok {
+		return err
+	}
+
+	req := leaderboardstat.ContributorPeriodScoresRequest{
+		ContributorID: types.ID(idInt),
+		Timeframe:     c.QueryParam("timeframe"),
+		Period:        c.QueryParam("period"),
+	}
+	if req.Timeframe == "" {
+		req.Timeframe = leaderboardstat.TimeframeDaily
+	}
+
+	response, err := h.LeaderboardStatService.GetContributorPeriodScores(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, leaderboardstat.ErrInvalidArguments) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get contributor period scores",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// CompareContributors compares two to five contributors in one period, the current month
+// when no timeframe is given
+func (h Handler) CompareContributors(c echo.Context) error {
+	req := leaderboardstat.CompareContributorsRequest{
+		Timeframe: c.QueryParam("timeframe"),
+		Period:    c.QueryParam("period"),
+		Bucket:    c.QueryParam("bucket"),
+	}
+	if req.Timeframe == "" {
+		req.Timeframe = "monthly"
+	}
+
+	for _, value := range strings.Split(c.QueryParam("ids"), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid contributor ID",
+			})
+		}
+		req.ContributorIDs = append(req.ContributorIDs, types.ID(id))
+	}
+
+	response, err := h.LeaderboardStatService.CompareContributors(c.Request().Context(), req, privacy.ViewerOf(c))
+	if err != nil {
+		if errors.Is(err, leaderboardstat.ErrInvalidArguments) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, leaderboardstat.ErrContributorHidden) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": leaderboardstat.ErrContributorHidden.Error(),
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to compare contributors",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetProjectInsights returns the weekly health of a project, the last 12 weeks when no
+// weeks are given
+func (h Handler) GetProjectInsights(c echo.Context) error {
+	projectID, err := strconv.ParseUint(c.Param("project_id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid project_id",
+		})
+	}
+
+	req := leaderboardstat.ProjectInsightsRequest{ProjectID: types.ID(projectID)}
+	if weeks := c.QueryParam("weeks"); weeks != "" {
+		req.Weeks, err = strconv.Atoi(weeks)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid weeks",
+			})
+		}
+	}
+
+	response, err := h.ProjectInsightsService.GetProjectInsights(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, leaderboardstat.ErrInvalidArguments) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get project insights",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetCohortRetention returns the retention matrix of the weekly or monthly cohorts of a
+// project, of every contributor without project_id. format=csv returns it as a CSV file
+// with one column of retained contributors per period since the cohort's.
+func (h Handler) GetCohortRetention(c echo.Context) error {
+	req := leaderboardstat.CohortRetentionRequest{Granularity: c.QueryParam("granularity")}
+	if req.Granularity == "" {
+		req.Granularity = "monthly"
+	}
+
+	if projectID := c.QueryParam("project_id"); projectID != "" {
+		id, err := strconv.ParseUint(projectID, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid project_id",
+			})
+		}
+		req.ProjectID = types.ID(id)
+	}
+
+	if cohorts := c.QueryParam("cohorts"); cohorts != "" {
+		n, err := strconv.Atoi(cohorts)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid cohorts",
+			})
+		}
+		req.Cohorts = n
+	}
+
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "csv" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "format must be json or csv",
+		})
+	}
+
+	response, err := h.LeaderboardStatService.GetCohortRetention(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, leaderboardstat.ErrInvalidArguments) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get cohort retention",
+		})
+	}
+
+	if format != "csv" {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	data, err := cohortRetentionCSV(response)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to write cohort retention",
+		})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=cohort-retention-%d-%s.csv", response.ProjectID, response.Granularity))
+
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// cohortRetentionCSV writes a row per cohort: cohort, size and the retained contributors
+// of each period, the header numbers the periods from the cohort's
+func cohortRetentionCSV(retention leaderboardstat.CohortRetention) ([]byte, error) {
+	periods := 0
+	for _, row := range retention.Cohorts {
+		periods = max(periods, len(row.Retained))
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"cohort", "size"}
+	for i := 0; i < periods; i++ {
+		header = append(header, strconv.Itoa(i))
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, row := range retention.Cohorts {
+		record := make([]string, 0, len(header))
+		record = append(record, row.Cohort, strconv.FormatInt(row.Size, 10))
+		for _, retained := range row.Retained {
+			record = append(record, strconv.FormatInt(retained, 10))
+		}
+		// Later cohorts have not reached the last periods yet
+		for len(record) < len(header) {
+			record = append(record, "")
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GetContributorTimeSeries returns a chart series of a contributor's score, events or rank
+func (h Handler) GetContributorTimeSeries(c echo.Context) error {
+	return h.getTimeSeries(c, leaderboardstat.SeriesContributor, c.Param("id"))
+}
+
+// GetProjectTimeSeries returns a chart series of a project's score or events
+func (h Handler) GetProjectTimeSeries(c echo.Context) error {
+	return h.getTimeSeries(c, leaderboardstat.SeriesProject, c.Param("project_id"))
+}
+
+func (h Handler) getTimeSeries(c echo.Context, entity, entityID string) error {
+	id, err := strconv.ParseUint(entityID, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("Invalid %s ID", entity),
+		})
+	}
+
+	if entity == leaderboardstat.SeriesContributor {
+		if _, ok, err := h.contributorIdentity(c, types.ID(id)); !ok {
+			return err
+		}
+	}
+
+	req := leaderboardstat.TimeSeriesRequest{
+		Entity:   entity,
+		EntityID: types.ID(id),
+		Metric:   c.QueryParam("metric"),
+		Bucket:   c.QueryParam("bucket"),
+	}
+	if req.Metric == "" {
+		req.Metric = leaderboardstat.MetricScore
+	}
+	if req.Bucket == "" {
+		req.Bucket = leaderboardstat.BucketDay
+	}
+
+	if req.From, err = parseSeriesTime(c.QueryParam("from")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "from must be a date like 2025-06-01 or an RFC 3339 time",
+		})
+	}
+	if req.To, err = parseSeriesTime(c.QueryParam("to")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "to must be a date like 2025-06-01 or an RFC 3339 time",
+		})
+	}
+
+	response, err := h.LeaderboardStatService.GetTimeSeries(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, leaderboardstat.ErrInvalidArguments) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get time series",
+		})
+	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// parseSeriesTime parses a UTC date or an RFC 3339 time, zero when value is empty
+func parseSeriesTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// PublicLeaderboardRowResponse is a row of a public board, an anonymous contributor the
+// caller may not see has no user_id and a pseudonym as display_name
 type PublicLeaderboardRowResponse struct {
-	Rank   uint64  `json:"rank"`
-	UserID uint64  `json:"user_id"`
-	Score  float64 `json:"score"`
+	Rank         uint64  `json:"rank"`
+	UserID       uint64  `json:"user_id,omitempty"`
+	Score        float64 `json:"score"`
+	DisplayName  string  `json:"display_name,omitempty"`
+	ProfileImage string  `json:"profile_image,omitempty"`
+	Anonymous    bool    `json:"anonymous"`
 }
 
 type GetPublicLeaderboardResponse struct {
-	ProjectID string                         `json:"project_id"`
-	Rows      []PublicLeaderboardRowResponse `json:"rows"`
+	ProjectID   string                         `json:"project_id"`
+	Rows        []PublicLeaderboardRowResponse `json:"rows"`
+	LastUpdated *time.Time                     `json:"last_updated,omitempty"`
 }
 
 func (h Handler) GetPublicLeaderboard(c echo.Context) error {
@@ -82,7 +410,9 @@ func (h Handler) GetPublicLeaderboard(c echo.Context) error {
 		}
 	}
 
-	result, err := h.LeaderboardStatService.GetPublicLeaderboard(c.Request().Context(), types.ID(projectIDInt), pageSize, offset)
+	viewer := privacy.ViewerOf(c)
+
+	result, err := h.LeaderboardStatService.GetPublicLeaderboard(c.Request().Context(), types.ID(projectIDInt), pageSize, offset, viewer)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to get public leaderboard",
@@ -92,16 +422,77 @@ func (h Handler) GetPublicLeaderboard(c echo.Context) error {
 	rows := make([]PublicLeaderboardRowResponse, 0, len(result.UsersScore))
 	for _, us := range result.UsersScore {
 		rows = append(rows, PublicLeaderboardRowResponse{
-			Rank:   us.Rank,
-			UserID: uint64(us.ContributorID),
-			Score:  us.Score,
+			Rank:         us.Rank,
+			UserID:       uint64(us.ContributorID),
+			Score:        us.Score,
+			DisplayName:  us.Identity.DisplayName,
+			ProfileImage: us.Identity.ProfileImage,
+			Anonymous:    us.Identity.Anonymous,
 		})
 	}
 
+	// A poll of a cached board that did not change since the client last saw it is
+	// answered without a body. The validators follow the rows as resolved for the viewer,
+	// so a contributor turning anonymous changes them like a write of the board.
+	if !result.LastUpdated.IsZero() {
+		etag := publicLeaderboardETag(projectIDInt, result.LastUpdated, pageSize, offset, rows)
+		if publicLeaderboardNotModified(c, etag, result.ModifiedAt) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		setPublicLeaderboardCacheHeaders(c, etag, result.ModifiedAt)
+	}
+
 	response := GetPublicLeaderboardResponse{
 		ProjectID: projectID,
 		Rows:      rows,
 	}
+	if !result.LastUpdated.IsZero() {
+		response.LastUpdated = &result.LastUpdated
+	}
 
 	return c.JSON(http.StatusOK, response)
 }
+
+// publicLeaderboardETag changes whenever the board is written or a row shows its user
+// differently. The page is part of it so clients can poll several pages.
+func publicLeaderboardETag(projectID uint64, lastUpdated time.Time, pageSize, offset int32, rows []PublicLeaderboardRowResponse) string {
+	hash := fnv.New64a()
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(hash, "%d|%d|%g|%q|%q|%t\n",
+			row.Rank, row.UserID, row.Score, row.DisplayName, row.ProfileImage, row.Anonymous)
+	}
+
+	return fmt.Sprintf(`"%d-%d-%d-%d-%x"`, projectID, lastUpdated.UnixMilli(), pageSize, offset, hash.Sum64())
+}
+
+func setPublicLeaderboardCacheHeaders(c echo.Context, etag string, lastUpdated time.Time) {
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	header.Set("Last-Modified", lastUpdated.UTC().Format(http.TimeFormat))
+	header.Set("Cache-Control", "private, no-cache")
+}
+
+// publicLeaderboardNotModified reports whether the client already has the board written at
+// lastUpdated, If-None-Match takes precedence over If-Modified-Since
+func publicLeaderboardNotModified(c echo.Context, etag string, lastUpdated time.Time) bool {
+	req := c.Request()
+
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				setPublicLeaderboardCacheHeaders(c, etag, lastUpdated)
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil || since.Before(lastUpdated.Truncate(time.Second)) {
+		return false
+	}
+
+	setPublicLeaderboardCacheHeaders(c, etag, lastUpdated)
+	return true
+}
@@ -39,6 +39,17 @@ func (s Server) RegisterRoutes() {
 	// contributor group
 	contributorGroup := v1.Group("/contributors")
 	contributorGroup.GET("/:id/stats", s.Handler.GetContributorStats)
+	contributorGroup.GET("/:id/scores", s.Handler.GetContributorPeriodScores)
+	contributorGroup.GET("/:id/timeseries", s.Handler.GetContributorTimeSeries)
+	contributorGroup.GET("/compare", s.Handler.CompareContributors)
+
+	// project group
+	projectGroup := v1.Group("/projects")
+	projectGroup.GET("/:project_id/insights", s.Handler.GetProjectInsights)
+	projectGroup.GET("/:project_id/timeseries", s.Handler.GetProjectTimeSeries)
+
+	// cohort retention
+	v1.GET("/cohorts/retention", s.Handler.GetCohortRetention)
 
 	// public leaderboard
 	leaderboardGroup := v1.Group("/leaderboard")
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
+	"github.com/gocasters/rankr/pkg/privacy"
+	types "github.com/gocasters/rankr/type"
+	"github.com/labstack/echo/v4"
+)
+
+// contributorIdentity returns the identity of the contributor of a stats request, ok is
+// false once the error response is written
+func (h Handler) contributorIdentity(c echo.Context, contributorID types.ID) (privacy.Identity, bool, error) {
+	identity, err := h.LeaderboardStatService.GetContributorIdentity(c.Request().Context(), contributorID, privacy.ViewerOf(c))
+	if err != nil {
+		if errors.Is(err, leaderboardstat.ErrContributorHidden) {
+			return privacy.Identity{}, false, c.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		return privacy.Identity{}, false, c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get contributor",
+		})
+	}
+
+	return identity, true, nil
+}
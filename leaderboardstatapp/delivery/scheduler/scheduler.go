@@ -14,6 +14,9 @@ import (
 type Config struct {
 	DailyScoreCalculationCron string        `koanf:"daily_score_calculation_cron"`
 	PublicLeaderboardCron     string        `koanf:"public_leaderboard_cron"`
+	ScoreRollupCron           string        `koanf:"score_rollup_cron"`
+	CohortRetentionCron       string        `koanf:"cohort_retention_cron"`
+	ScoreSeriesCron           string        `koanf:"score_series_cron"`
 	JobContextTimeout         time.Duration `koanf:"job_context_timeout"`
 }
 
@@ -24,7 +27,7 @@ type Scheduler struct {
 }
 
 func New(leaderboardStatSvc *leaderboardstat.Service, schedulerCfg Config) Scheduler {
-	sch, err := gocron.NewScheduler(gocron.WithLocation(time.Local))
+	sch, err := gocron.NewScheduler(gocron.WithLocation(time.UTC))
 	if err != nil {
 		logger.L().Error("failed to create Scheduler", slog.String("error", err.Error()))
 		panic(err)
@@ -39,6 +42,15 @@ func New(leaderboardStatSvc *leaderboardstat.Service, schedulerCfg Config) Sched
 	if schedulerCfg.DailyScoreCalculationCron == "" {
 		schedulerCfg.DailyScoreCalculationCron = "0 2 * * *"
 	}
+	if schedulerCfg.ScoreRollupCron == "" {
+		schedulerCfg.ScoreRollupCron = "30 2 * * *"
+	}
+	if schedulerCfg.CohortRetentionCron == "" {
+		schedulerCfg.CohortRetentionCron = "0 3 * * *"
+	}
+	if schedulerCfg.ScoreSeriesCron == "" {
+		schedulerCfg.ScoreSeriesCron = "15 * * * *"
+	}
 
 	return Scheduler{
 		sch:                sch,
@@ -61,6 +73,18 @@ func (s *Scheduler) Start(ctx context.Context, wg *sync.WaitGroup) {
 		log.Error("failed to create public leaderboard job", slog.String("error", err.Error()))
 	}
 
+	if err := s.scoreRollupJob(ctx); err != nil {
+		log.Error("failed to create score rollup job", slog.String("error", err.Error()))
+	}
+
+	if err := s.cohortRetentionJob(ctx); err != nil {
+		log.Error("failed to create cohort retention job", slog.String("error", err.Error()))
+	}
+
+	if err := s.scoreSeriesJob(ctx); err != nil {
+		log.Error("failed to create score series job", slog.String("error", err.Error()))
+	}
+
 	s.sch.Start()
 
 	<-ctx.Done()
@@ -161,3 +185,124 @@ func (s *Scheduler) publicLeaderboardTask(parentCtx context.Context) {
 
 	log.Info("publicLeaderboardTask completed successfully")
 }
+
+func (s *Scheduler) scoreRollupJob(parentCtx context.Context) error {
+	log := logger.L()
+
+	rollupJob, err := s.sch.NewJob(
+		gocron.CronJob(s.cfg.ScoreRollupCron, false),
+		gocron.NewTask(func() { s.scoreRollupTask(parentCtx) }),
+		gocron.WithSingletonMode(gocron.LimitModeWait),
+		gocron.WithName("score-rollup"),
+		gocron.WithTags("leaderboardstat-service"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create score rollup job: %w", err)
+	}
+
+	log.Info("scoreRollup job created",
+		slog.String("name", rollupJob.Name()),
+		slog.String("uuid", rollupJob.ID().String()),
+		slog.Any("tags", rollupJob.Tags()),
+		slog.String("crontab", s.cfg.ScoreRollupCron),
+	)
+
+	return nil
+}
+
+func (s *Scheduler) scoreRollupTask(parentCtx context.Context) {
+	log := logger.L()
+
+	log.Info("scoreRollupTask started", slog.String("time", time.Now().Format(time.RFC3339)))
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.JobContextTimeout)
+	defer cancel()
+
+	if sErr := s.leaderboardStatSvc.RollupRecentScores(ctx, time.Now()); sErr != nil {
+		log.Error("failed to run scoreRollupTask", slog.String("error", sErr.Error()))
+		return
+	}
+
+	log.Info("scoreRollupTask completed successfully")
+}
+
+func (s *Scheduler) cohortRetentionJob(parentCtx context.Context) error {
+	log := logger.L()
+
+	cohortJob, err := s.sch.NewJob(
+		gocron.CronJob(s.cfg.CohortRetentionCron, false),
+		gocron.NewTask(func() { s.cohortRetentionTask(parentCtx) }),
+		gocron.WithSingletonMode(gocron.LimitModeWait),
+		gocron.WithName("cohort-retention"),
+		gocron.WithTags("leaderboardstat-service"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create cohort retention job: %w", err)
+	}
+
+	log.Info("cohortRetention job created",
+		slog.String("name", cohortJob.Name()),
+		slog.String("uuid", cohortJob.ID().String()),
+		slog.Any("tags", cohortJob.Tags()),
+		slog.String("crontab", s.cfg.CohortRetentionCron),
+	)
+
+	return nil
+}
+
+func (s *Scheduler) cohortRetentionTask(parentCtx context.Context) {
+	log := logger.L()
+
+	log.Info("cohortRetentionTask started", slog.String("time", time.Now().Format(time.RFC3339)))
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.JobContextTimeout)
+	defer cancel()
+
+	if sErr := s.leaderboardStatSvc.RefreshCohorts(ctx); sErr != nil {
+		log.Error("failed to run cohortRetentionTask", slog.String("error", sErr.Error()))
+		return
+	}
+
+	log.Info("cohortRetentionTask completed successfully")
+}
+
+func (s *Scheduler) scoreSeriesJob(parentCtx context.Context) error {
+	log := logger.L()
+
+	seriesJob, err := s.sch.NewJob(
+		gocron.CronJob(s.cfg.ScoreSeriesCron, false),
+		gocron.NewTask(func() { s.scoreSeriesTask(parentCtx) }),
+		gocron.WithSingletonMode(gocron.LimitModeWait),
+		gocron.WithName("score-series-refresh"),
+		gocron.WithTags("leaderboardstat-service"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create score series job: %w", err)
+	}
+
+	log.Info("scoreSeries job created",
+		slog.String("name", seriesJob.Name()),
+		slog.String("uuid", seriesJob.ID().String()),
+		slog.Any("tags", seriesJob.Tags()),
+		slog.String("crontab", s.cfg.ScoreSeriesCron),
+	)
+
+	return nil
+}
+
+func (s *Scheduler) scoreSeriesTask(parentCtx context.Context) {
+	log := logger.L()
+
+	log.Info("scoreSeriesTask started", slog.String("time", time.Now().Format(time.RFC3339)))
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.cfg.JobContextTimeout)
+	defer cancel()
+
+	buckets, sErr := s.leaderboardStatSvc.RefreshScoreSeries(ctx)
+	if sErr != nil {
+		log.Error("failed to run scoreSeriesTask", slog.String("error", sErr.Error()))
+		return
+	}
+
+	log.Info("scoreSeriesTask completed successfully", slog.Int64("buckets", buckets))
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
+	"github.com/gocasters/rankr/pkg/database"
+	types "github.com/gocasters/rankr/type"
+	"github.com/jackc/pgx/v5"
+)
+
+type ProjectInsightsRepo struct {
+	PostgreSQL *database.Database
+}
+
+func NewProjectInsightsRepo(db *database.Database) leaderboardstat.ProjectInsightsStore {
+	return ProjectInsightsRepo{PostgreSQL: db}
+}
+
+func (repo ProjectInsightsRepo) RecordProjectActivity(ctx context.Context, activity leaderboardstat.ProjectActivity) (bool, error) {
+	tx, err := repo.PostgreSQL.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx,
+		"INSERT INTO project_activity_events (event_id, project_id) VALUES ($1, $2) ON CONFLICT (event_id) DO NOTHING",
+		activity.EventID, activity.ProjectID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record activity event: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	// The event counts and points of the time series in the hour of the event, see
+	// score_series_hourly
+	countEvent := `
+		INSERT INTO score_series_hourly (entity_type, entity_id, bucket, score, events)
+		VALUES ($1, $2, DATE_TRUNC('hour', $3::timestamptz), $4, 1)
+		ON CONFLICT (entity_type, entity_id, bucket) DO UPDATE SET
+			score = score_series_hourly.score + EXCLUDED.score,
+			events = score_series_hourly.events + 1
+	`
+	if _, err := tx.Exec(ctx, countEvent, leaderboardstat.SeriesProject, activity.ProjectID, activity.OccurredAt, activity.Score); err != nil {
+		return false, fmt.Errorf("failed to count project event: %w", err)
+	}
+
+	if activity.ContributorID != 0 {
+		if _, err := tx.Exec(ctx, countEvent, leaderboardstat.SeriesContributor, activity.ContributorID, activity.OccurredAt, activity.Score); err != nil {
+			return false, fmt.Errorf("failed to count contributor event: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO project_contributor_weeks (project_id, contributor_id, week, events)
+			VALUES ($1, $2, $3, 1)
+			ON CONFLICT (project_id, week, contributor_id)
+			DO UPDATE SET events = project_contributor_weeks.events + 1
+		`, activity.ProjectID, activity.ContributorID, activity.Week); err != nil {
+			return false, fmt.Errorf("failed to record active contributor: %w", err)
+		}
+	}
+
+	if activity.MergedPR || activity.ClosedUnmergedPR || activity.IssueClosed {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO project_weekly_health (project_id, week, merged_prs, closed_unmerged_prs, closed_issues, updated_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())
+			ON CONFLICT (project_id, week)
+			DO UPDATE SET
+				merged_prs = project_weekly_health.merged_prs + EXCLUDED.merged_prs,
+				closed_unmerged_prs = project_weekly_health.closed_unmerged_prs + EXCLUDED.closed_unmerged_prs,
+				closed_issues = project_weekly_health.closed_issues + EXCLUDED.closed_issues,
+				updated_at = EXCLUDED.updated_at
+		`, activity.ProjectID, activity.Week,
+			boolToInt(activity.MergedPR), boolToInt(activity.ClosedUnmergedPR), boolToInt(activity.IssueClosed),
+		); err != nil {
+			return false, fmt.Errorf("failed to record weekly health: %w", err)
+		}
+	}
+
+	// A reopened issue counts in the week of its last close only
+	if c := activity.TimedIssueClose; c != nil {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO project_issue_closes (project_id, issue_id, week, opened_at, closed_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (project_id, issue_id)
+			DO UPDATE SET week = EXCLUDED.week, opened_at = EXCLUDED.opened_at, closed_at = EXCLUDED.closed_at
+			WHERE EXCLUDED.closed_at > project_issue_closes.closed_at
+		`, activity.ProjectID, c.IssueID, activity.Week, c.OpenedAt, c.ClosedAt); err != nil {
+			return false, fmt.Errorf("failed to record issue close: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit activity: %w", err)
+	}
+
+	return true, nil
+}
+
+func (repo ProjectInsightsRepo) GetProjectHealth(ctx context.Context, projectID types.ID, fromWeek, toWeek string) (map[string]leaderboardstat.WeeklyProjectHealth, error) {
+	health := make(map[string]leaderboardstat.WeeklyProjectHealth)
+	update := func(week string, fn func(h *leaderboardstat.WeeklyProjectHealth)) {
+		h, ok := health[week]
+		if !ok {
+			h.Week = week
+		}
+		fn(&h)
+		health[week] = h
+	}
+
+	// A contributor is new in the first week they were active in the project
+	rows, err := repo.PostgreSQL.Pool.Query(ctx, `
+		WITH first_weeks AS (
+			SELECT contributor_id, MIN(week) AS first_week
+			FROM project_contributor_weeks
+			WHERE project_id = $1
+			GROUP BY contributor_id
+		)
+		SELECT w.week, COUNT(*), COUNT(*) FILTER (WHERE f.first_week = w.week)
+		FROM project_contributor_weeks w
+		JOIN first_weeks f ON f.contributor_id = w.contributor_id
+		WHERE w.project_id = $1 AND w.week BETWEEN $2 AND $3
+		GROUP BY w.week
+	`, projectID, fromWeek, toWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active contributors: %w", err)
+	}
+	if err := scanRows(rows, func(rows pgx.Rows) error {
+		var week string
+		var active, newcomers int64
+		if err := rows.Scan(&week, &active, &newcomers); err != nil {
+			return err
+		}
+		update(week, func(h *leaderboardstat.WeeklyProjectHealth) {
+			h.ActiveContributors = active
+			h.NewContributors = newcomers
+			h.ReturningContributors = active - newcomers
+		})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read active contributors: %w", err)
+	}
+
+	rows, err = repo.PostgreSQL.Pool.Query(ctx, `
+		SELECT week, merged_prs, closed_unmerged_prs, closed_issues
+		FROM project_weekly_health
+		WHERE project_id = $1 AND week BETWEEN $2 AND $3
+	`, projectID, fromWeek, toWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weekly health: %w", err)
+	}
+	if err := scanRows(rows, func(rows pgx.Rows) error {
+		var week string
+		var merged, unmerged, issues int64
+		if err := rows.Scan(&week, &merged, &unmerged, &issues); err != nil {
+			return err
+		}
+		update(week, func(h *leaderboardstat.WeeklyProjectHealth) {
+			h.MergedPRs = merged
+			h.ClosedUnmergedPRs = unmerged
+			h.ClosedIssues = issues
+		})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read weekly health: %w", err)
+	}
+
+	rows, err = repo.PostgreSQL.Pool.Query(ctx, `
+		SELECT week, percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM closed_at - opened_at)::float8)
+		FROM project_issue_closes
+		WHERE project_id = $1 AND week BETWEEN $2 AND $3
+		GROUP BY week
+	`, projectID, fromWeek, toWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issue close times: %w", err)
+	}
+	if err := scanRows(rows, func(rows pgx.Rows) error {
+		var week string
+		var median float64
+		if err := rows.Scan(&week, &median); err != nil {
+			return err
+		}
+		update(week, func(h *leaderboardstat.WeeklyProjectHealth) {
+			h.MedianIssueCloseSeconds = &median
+		})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read issue close times: %w", err)
+	}
+
+	// The bus factor of a week comes from its weekly score rollup: the top contributors
+	// counted until their scores reach half of the week's total
+	rows, err = repo.PostgreSQL.Pool.Query(ctx, `
+		WITH ranked AS (
+			SELECT time_value AS week, score,
+				SUM(score) OVER (PARTITION BY time_value ORDER BY score DESC, contributor_id ROWS UNBOUNDED PRECEDING) AS running,
+				SUM(score) OVER (PARTITION BY time_value) AS total
+			FROM user_project_scores
+			WHERE project_id = $1 AND timeframe = 'weekly' AND time_value BETWEEN $2 AND $3 AND score > 0
+		)
+		SELECT week, COUNT(*) FILTER (WHERE running - score < total / 2)
+		FROM ranked
+		GROUP BY week
+	`, projectID, fromWeek, toWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weekly bus factor: %w", err)
+	}
+	if err := scanRows(rows, func(rows pgx.Rows) error {
+		var week string
+		var busFactor int64
+		if err := rows.Scan(&week, &busFactor); err != nil {
+			return err
+		}
+		update(week, func(h *leaderboardstat.WeeklyProjectHealth) {
+			h.BusFactor = busFactor
+		})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read weekly bus factor: %w", err)
+	}
+
+	return health, nil
+}
+
+func (repo ProjectInsightsRepo) GetProjectBusFactor(ctx context.Context, projectID types.ID, fromWeek, toWeek string) (int64, error) {
+	query := `
+		WITH totals AS (
+			SELECT contributor_id, SUM(score) AS score
+			FROM user_project_scores
+			WHERE project_id = $1 AND timeframe = 'weekly' AND time_value BETWEEN $2 AND $3
+			GROUP BY contributor_id
+			HAVING SUM(score) > 0
+		),
+		ranked AS (
+			SELECT score,
+				SUM(score) OVER (ORDER BY score DESC, contributor_id ROWS UNBOUNDED PRECEDING) AS running,
+				SUM(score) OVER () AS total
+			FROM totals
+		)
+		SELECT COUNT(*) FILTER (WHERE running - score < total / 2)
+		FROM ranked
+	`
+
+	var busFactor int64
+	if err := repo.PostgreSQL.Pool.QueryRow(ctx, query, projectID, fromWeek, toWeek).Scan(&busFactor); err != nil {
+		return 0, fmt.Errorf("error retrieving bus factor of project id %d: %w", projectID, err)
+	}
+
+	return busFactor, nil
+}
+
+// scanRows calls scan for every row and closes rows
+func scanRows(rows pgx.Rows, scan func(rows pgx.Rows) error) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
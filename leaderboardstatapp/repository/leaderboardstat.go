@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/gocasters/rankr/leaderboardstatapp/service/leaderboardstat"
 	"github.com/gocasters/rankr/pkg/database"
@@ -127,12 +128,17 @@ func (repo LeaderboardstatRepo) StoreDailyContributorScores(ctx context.Context,
 	`
 
 	for _, score := range scores {
+		earnedAt := score.EarnedAt
+		if earnedAt.IsZero() {
+			earnedAt = time.Now()
+		}
+
 		batch.Queue(query,
 			score.ContributorID,
 			score.ProjectID,
 			score.Score,
 			score.Rank,
-			time.Now(),
+			earnedAt.UTC(),
 		)
 	}
 
@@ -150,7 +156,7 @@ func (repo LeaderboardstatRepo) StoreDailyContributorScores(ctx context.Context,
 
 func (repo LeaderboardstatRepo) GetPendingDailyScores(ctx context.Context) ([]leaderboardstat.DailyContributorScore, error) {
 	query := `
-		SELECT id, contributor_id, project_id, score, rank
+		SELECT id, contributor_id, project_id, score, rank, COALESCE(earned_at, created_at, NOW())
 		FROM scores
 		WHERE status = 0
 	`
@@ -172,6 +178,7 @@ func (repo LeaderboardstatRepo) GetPendingDailyScores(ctx context.Context) ([]le
 			&s.ProjectID,
 			&s.Score,
 			&s.Rank,
+			&s.EarnedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan pending score: %w", err)
 		}
@@ -277,3 +284,481 @@ func (repo LeaderboardstatRepo) MarkDailyScoresAsProcessed(ctx context.Context,
 
 	return nil
 }
+
+func (repo LeaderboardstatRepo) GetUnattributedContributors(ctx context.Context) ([]types.ID, error) {
+	query := "SELECT DISTINCT contributor_id FROM scores WHERE project_id = 0 ORDER BY contributor_id"
+	rows, err := repo.PostgreSQL.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unattributed contributors: %w", err)
+	}
+	defer rows.Close()
+
+	var contributorIDs []types.ID
+	for rows.Next() {
+		var contributorID types.ID
+		if err := rows.Scan(&contributorID); err != nil {
+			return nil, fmt.Errorf("failed to scan unattributed contributor: %w", err)
+		}
+
+		contributorIDs = append(contributorIDs, contributorID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unattributed contributors: %w", err)
+	}
+
+	return contributorIDs, nil
+}
+
+func (repo LeaderboardstatRepo) AttributeContributorScores(ctx context.Context, contributorID, projectID types.ID) error {
+	tx, err := repo.PostgreSQL.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx,
+		"UPDATE scores SET project_id = $2 WHERE contributor_id = $1 AND project_id = 0",
+		contributorID, projectID,
+	); err != nil {
+		return fmt.Errorf("failed to attribute scores: %w", err)
+	}
+
+	// The global rows keep project 0, only the per-period rows belong to a project
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO user_project_scores (contributor_id, project_id, score, timeframe, time_value, updated_at)
+		SELECT contributor_id, $2, score, timeframe, time_value, NOW()
+		FROM user_project_scores
+		WHERE contributor_id = $1 AND project_id = 0 AND timeframe <> 'global'
+		ON CONFLICT (contributor_id, project_id, timeframe, time_value)
+		DO UPDATE SET
+			score = user_project_scores.score + EXCLUDED.score,
+			updated_at = EXCLUDED.updated_at
+	`, contributorID, projectID); err != nil {
+		return fmt.Errorf("failed to attribute user project scores: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx,
+		"DELETE FROM user_project_scores WHERE contributor_id = $1 AND project_id = 0 AND timeframe <> 'global'",
+		contributorID,
+	); err != nil {
+		return fmt.Errorf("failed to remove unattributed user project scores: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit attribution: %w", err)
+	}
+
+	return nil
+}
+
+func (repo LeaderboardstatRepo) RollupUserProjectScores(ctx context.Context, timeframe, period, fromDay, toDay string) (int64, error) {
+	tx, err := repo.PostgreSQL.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx,
+		"DELETE FROM user_project_scores WHERE timeframe = $1 AND time_value = $2",
+		timeframe, period,
+	); err != nil {
+		return 0, fmt.Errorf("failed to clear %s period %s: %w", timeframe, period, err)
+	}
+
+	// Daily time values are dates like 2025-06-01, a range of them compares as text
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO user_project_scores (contributor_id, project_id, score, timeframe, time_value, updated_at)
+		SELECT contributor_id, project_id, SUM(score), $1, $2, NOW()
+		FROM user_project_scores
+		WHERE timeframe = 'daily' AND time_value >= $3 AND time_value < $4
+		GROUP BY contributor_id, project_id
+	`, timeframe, period, fromDay, toDay)
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up %s period %s: %w", timeframe, period, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit rollup: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (repo LeaderboardstatRepo) GetContributorPeriodScores(ctx context.Context, contributorID types.ID, timeframe, period string) (map[types.ID]float64, error) {
+	query := `
+		SELECT project_id, score
+		FROM user_project_scores
+		WHERE contributor_id = $1 AND timeframe = $2 AND time_value = $3
+	`
+	rows, err := repo.PostgreSQL.Pool.Query(ctx, query, contributorID, timeframe, period)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s scores for contributor id %d: %w", timeframe, contributorID, err)
+	}
+	defer rows.Close()
+
+	projectScores := make(map[types.ID]float64)
+	for rows.Next() {
+		var projectID types.ID
+		var score float64
+
+		if err := rows.Scan(&projectID, &score); err != nil {
+			return nil, fmt.Errorf("error scanning %s scores for contributor id %d: %w", timeframe, contributorID, err)
+		}
+
+		projectScores[projectID] = score
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s scores for contributor id %d: %w", timeframe, contributorID, err)
+	}
+
+	return projectScores, nil
+}
+
+func (repo LeaderboardstatRepo) GetPeriodRanks(ctx context.Context, timeframe, period string, contributorIDs []types.ID) (map[types.ID]uint, error) {
+	query := `
+		SELECT contributor_id, rank FROM (
+			SELECT contributor_id, RANK() OVER (ORDER BY SUM(score) DESC) AS rank
+			FROM user_project_scores
+			WHERE timeframe = $1 AND time_value = $2
+			GROUP BY contributor_id
+		) ranked
+		WHERE contributor_id = ANY($3)
+	`
+	rows, err := repo.PostgreSQL.Pool.Query(ctx, query, timeframe, period, contributorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s ranks of period %s: %w", timeframe, period, err)
+	}
+	defer rows.Close()
+
+	ranks := make(map[types.ID]uint, len(contributorIDs))
+	for rows.Next() {
+		var contributorID types.ID
+		var rank int64
+
+		if err := rows.Scan(&contributorID, &rank); err != nil {
+			return nil, fmt.Errorf("error scanning %s ranks of period %s: %w", timeframe, period, err)
+		}
+
+		ranks[contributorID] = uint(rank)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s ranks of period %s: %w", timeframe, period, err)
+	}
+
+	return ranks, nil
+}
+
+func (repo LeaderboardstatRepo) GetContributorPercentiles(ctx context.Context, contributorID types.ID) (float64, map[types.ID]float64, error) {
+	globalQuery := `
+		WITH totals AS (
+			SELECT contributor_id, SUM(score) AS total
+			FROM user_project_scores
+			WHERE timeframe = 'daily'
+			GROUP BY contributor_id
+		)
+		SELECT percentile FROM (
+			SELECT contributor_id, 100 * CUME_DIST() OVER (ORDER BY total) AS percentile
+			FROM totals
+		) ranked
+		WHERE contributor_id = $1
+	`
+
+	var globalPercentile float64
+	err := repo.PostgreSQL.Pool.QueryRow(ctx, globalQuery, contributorID).Scan(&globalPercentile)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil, fmt.Errorf("error retrieving global percentile for contributor id %d: %w", contributorID, err)
+	}
+
+	// Project 0 holds the unattributed scores, it is no project board
+	projectQuery := `
+		WITH totals AS (
+			SELECT contributor_id, project_id, SUM(score) AS total
+			FROM user_project_scores
+			WHERE timeframe = 'daily' AND project_id <> 0 AND project_id IN (
+				SELECT project_id FROM user_project_scores WHERE contributor_id = $1
+			)
+			GROUP BY contributor_id, project_id
+		)
+		SELECT project_id, percentile FROM (
+			SELECT contributor_id, project_id,
+				100 * CUME_DIST() OVER (PARTITION BY project_id ORDER BY total) AS percentile
+			FROM totals
+		) ranked
+		WHERE contributor_id = $1
+	`
+
+	rows, err := repo.PostgreSQL.Pool.Query(ctx, projectQuery, contributorID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error retrieving project percentiles for contributor id %d: %w", contributorID, err)
+	}
+	defer rows.Close()
+
+	projectPercentiles := make(map[types.ID]float64)
+	for rows.Next() {
+		var projectID types.ID
+		var percentile float64
+
+		if err := rows.Scan(&projectID, &percentile); err != nil {
+			return 0, nil, fmt.Errorf("error scanning project percentiles for contributor id %d: %w", contributorID, err)
+		}
+
+		projectPercentiles[projectID] = percentile
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("error iterating project percentiles for contributor id %d: %w", contributorID, err)
+	}
+
+	return globalPercentile, projectPercentiles, nil
+}
+
+func (repo LeaderboardstatRepo) GetContributorPointsBetween(ctx context.Context, contributorID types.ID, fromDay, toDay string) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(score), 0)
+		FROM user_project_scores
+		WHERE contributor_id = $1 AND timeframe = 'daily' AND time_value >= $2 AND time_value < $3
+	`
+
+	var points float64
+	if err := repo.PostgreSQL.Pool.QueryRow(ctx, query, contributorID, fromDay, toDay).Scan(&points); err != nil {
+		return 0, fmt.Errorf("error retrieving points from %s to %s for contributor id %d: %w", fromDay, toDay, contributorID, err)
+	}
+
+	return points, nil
+}
+
+func (repo LeaderboardstatRepo) GetProjectedRank(ctx context.Context, contributorID types.ID, fromDay, toDay string, growth float64) (uint, error) {
+	query := `
+		WITH totals AS (
+			SELECT contributor_id,
+				SUM(score) AS total,
+				COALESCE(SUM(score) FILTER (WHERE time_value >= $2 AND time_value < $3), 0) AS recent
+			FROM user_project_scores
+			WHERE timeframe = 'daily'
+			GROUP BY contributor_id
+		),
+		projected AS (
+			SELECT contributor_id, total + recent * $4::float8 AS score
+			FROM totals
+		),
+		me AS (
+			SELECT score FROM projected WHERE contributor_id = $1
+		)
+		SELECT COUNT(p.contributor_id) + 1
+		FROM me LEFT JOIN projected p ON p.score > me.score
+		GROUP BY me.score
+	`
+
+	var rank uint
+	err := repo.PostgreSQL.Pool.QueryRow(ctx, query, contributorID, fromDay, toDay, growth).Scan(&rank)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("error retrieving projected rank for contributor id %d: %w", contributorID, err)
+	}
+
+	return rank, nil
+}
+
+func (repo LeaderboardstatRepo) AssignContributorCohorts(ctx context.Context) (int64, error) {
+	query := `
+		WITH activity AS (
+			SELECT contributor_id, project_id, TO_DATE(time_value, 'YYYY-MM-DD') AS day
+			FROM user_project_scores
+			WHERE timeframe = 'daily' AND score > 0
+		)
+		INSERT INTO contributor_cohorts (contributor_id, project_id, first_day, updated_at)
+		SELECT contributor_id, project_id, MIN(day), NOW()
+		FROM activity
+		WHERE project_id <> 0
+		GROUP BY contributor_id, project_id
+		UNION ALL
+		SELECT contributor_id, 0, MIN(day), NOW()
+		FROM activity
+		GROUP BY contributor_id
+		ON CONFLICT (contributor_id, project_id)
+		DO UPDATE SET first_day = EXCLUDED.first_day, updated_at = EXCLUDED.updated_at
+		WHERE EXCLUDED.first_day < contributor_cohorts.first_day
+	`
+
+	tag, err := repo.PostgreSQL.Pool.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to assign contributor cohorts: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// cohortPeriods maps a cohort granularity to its DATE_TRUNC unit and TO_CHAR format, the
+// formats match the weekly and monthly period keys
+var cohortPeriods = map[string]struct{ unit, format string }{
+	"weekly":  {unit: "week", format: `IYYY-"W"IW`},
+	"monthly": {unit: "month", format: "YYYY-MM"},
+}
+
+func (repo LeaderboardstatRepo) RebuildCohortRetention(ctx context.Context, granularity string) (int64, error) {
+	period, ok := cohortPeriods[granularity]
+	if !ok {
+		return 0, fmt.Errorf("unknown cohort granularity %s", granularity)
+	}
+
+	tx, err := repo.PostgreSQL.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "DELETE FROM cohort_retention WHERE granularity = $1", granularity); err != nil {
+		return 0, fmt.Errorf("failed to delete cohort retention: %w", err)
+	}
+
+	// Activity is distinct per contributor, project and period, so every count is one of
+	// contributors
+	tag, err := tx.Exec(ctx, `
+		WITH daily AS (
+			SELECT contributor_id, project_id, DATE_TRUNC($2::text, TO_DATE(time_value, 'YYYY-MM-DD'))::date AS period
+			FROM user_project_scores
+			WHERE timeframe = 'daily' AND score > 0
+		),
+		activity AS (
+			SELECT contributor_id, project_id, period FROM daily WHERE project_id <> 0
+			UNION
+			SELECT contributor_id, 0, period FROM daily
+		),
+		cohorts AS (
+			SELECT contributor_id, project_id, DATE_TRUNC($2::text, first_day)::date AS start
+			FROM contributor_cohorts
+		),
+		sizes AS (
+			SELECT project_id, start, COUNT(*) AS size
+			FROM cohorts
+			GROUP BY project_id, start
+		)
+		INSERT INTO cohort_retention (project_id, granularity, cohort, period_offset, cohort_size, retained, refreshed_at)
+		SELECT c.project_id, $1::varchar, TO_CHAR(c.start, $3::text),
+			CASE WHEN $2::text = 'week'
+				THEN (a.period - c.start) / 7
+				ELSE ((EXTRACT(YEAR FROM a.period) - EXTRACT(YEAR FROM c.start)) * 12
+					+ EXTRACT(MONTH FROM a.period) - EXTRACT(MONTH FROM c.start))::int
+			END,
+			s.size, COUNT(*), NOW()
+		FROM cohorts c
+		JOIN activity a ON a.contributor_id = c.contributor_id AND a.project_id = c.project_id AND a.period >= c.start
+		JOIN sizes s ON s.project_id = c.project_id AND s.start = c.start
+		GROUP BY c.project_id, c.start, a.period, s.size
+	`, granularity, period.unit, period.format)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert cohort retention: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit cohort retention: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (repo LeaderboardstatRepo) GetCohortRetention(ctx context.Context, projectID types.ID, granularity string, cohorts int) ([]leaderboardstat.CohortRetentionCell, error) {
+	query := `
+		SELECT cohort, cohort_size, period_offset, retained
+		FROM cohort_retention
+		WHERE project_id = $1 AND granularity = $2 AND cohort IN (
+			SELECT DISTINCT cohort
+			FROM cohort_retention
+			WHERE project_id = $1 AND granularity = $2
+			ORDER BY cohort DESC
+			LIMIT $3
+		)
+		ORDER BY cohort, period_offset
+	`
+
+	rows, err := repo.PostgreSQL.Pool.Query(ctx, query, projectID, granularity, cohorts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving cohort retention of project id %d: %w", projectID, err)
+	}
+	defer rows.Close()
+
+	var cells []leaderboardstat.CohortRetentionCell
+	for rows.Next() {
+		var cell leaderboardstat.CohortRetentionCell
+		if err := rows.Scan(&cell.Cohort, &cell.Size, &cell.Offset, &cell.Retained); err != nil {
+			return nil, fmt.Errorf("error scanning cohort retention: %w", err)
+		}
+		cells = append(cells, cell)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cohort retention: %w", err)
+	}
+
+	return cells, nil
+}
+
+func (repo LeaderboardstatRepo) RefreshScoreSeries(ctx context.Context) (int64, error) {
+	// Rows are flagged as they are added, so a row committed after a higher id is picked up
+	// by the next refresh. A concurrent refresh waits on the row locks and skips the rows
+	// flagged meanwhile. Only the ranks are taken, the rows hold daily totals that are
+	// stored again on every run; the points come from the raw events, see
+	// RecordProjectActivity.
+	tag, err := repo.PostgreSQL.Pool.Exec(ctx, `
+		WITH pending AS (
+			UPDATE scores SET in_series = TRUE
+			WHERE NOT in_series
+			RETURNING contributor_id, rank, earned_at
+		)
+		INSERT INTO score_series_hourly (entity_type, entity_id, bucket, best_rank)
+		SELECT 'contributor', contributor_id, DATE_TRUNC('hour', earned_at), MIN(rank)
+		FROM pending
+		WHERE earned_at IS NOT NULL AND rank > 0
+		GROUP BY contributor_id, DATE_TRUNC('hour', earned_at)
+		ON CONFLICT (entity_type, entity_id, bucket)
+		DO UPDATE SET best_rank = LEAST(score_series_hourly.best_rank, EXCLUDED.best_rank)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add ranks to series: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (repo LeaderboardstatRepo) GetScoreSeries(ctx context.Context, entity string, entityID types.ID, bucket string, from, to time.Time) ([]leaderboardstat.SeriesBucket, error) {
+	// Buckets are UTC, DATE_TRUNC of a week starts on Monday like the ISO week
+	query := `
+		SELECT DATE_TRUNC($3::text, bucket AT TIME ZONE 'UTC') AS start,
+			SUM(score), SUM(events)::bigint, MIN(best_rank)
+		FROM score_series_hourly
+		WHERE entity_type = $1 AND entity_id = $2 AND bucket >= $4 AND bucket < $5
+		GROUP BY start
+		ORDER BY start
+	`
+
+	rows, err := repo.PostgreSQL.Pool.Query(ctx, query, entity, entityID, bucket, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s series of id %d: %w", entity, entityID, err)
+	}
+	defer rows.Close()
+
+	var buckets []leaderboardstat.SeriesBucket
+	for rows.Next() {
+		var b leaderboardstat.SeriesBucket
+		var bestRank sql.NullInt64
+		if err := rows.Scan(&b.Start, &b.Score, &b.Events, &bestRank); err != nil {
+			return nil, fmt.Errorf("error scanning series bucket: %w", err)
+		}
+		if bestRank.Valid {
+			b.BestRank = &bestRank.Int64
+		}
+		buckets = append(buckets, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating series buckets: %w", err)
+	}
+
+	return buckets, nil
+}
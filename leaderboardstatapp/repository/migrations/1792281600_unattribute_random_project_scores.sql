@@ -0,0 +1,24 @@
+-- +migrate Up
+-- The daily aggregation stored a random project_id (0 to 4) on every score until it read
+-- the project boards. The real projects cannot be recovered from these rows, so they move
+-- to project 0, unattributed; the backfill-projects command attributes the contributors
+-- whose all-time points come from a single project.
+UPDATE scores SET project_id = 0 WHERE project_id BETWEEN 1 AND 4;
+
+-- The per-period rows of one contributor and period merge into project 0. The global rows
+-- summed every project and stay as they are.
+INSERT INTO user_project_scores (contributor_id, project_id, score, timeframe, time_value, updated_at)
+SELECT contributor_id, 0, SUM(score), timeframe, time_value, NOW()
+FROM user_project_scores
+WHERE timeframe <> 'global' AND project_id BETWEEN 0 AND 4
+GROUP BY contributor_id, timeframe, time_value
+ON CONFLICT (contributor_id, project_id, timeframe, time_value)
+DO UPDATE SET score = EXCLUDED.score, updated_at = EXCLUDED.updated_at;
+
+DELETE FROM user_project_scores WHERE timeframe <> 'global' AND project_id BETWEEN 1 AND 4;
+
+COMMENT ON COLUMN user_project_scores.project_id IS '0 = global scores, or per-period scores not attributed to a project, >0 = git repository ID of the project';
+
+-- +migrate Down
+-- The random project IDs are not restored
+COMMENT ON COLUMN user_project_scores.project_id IS '0 = global scores, >0 = specific project scores';
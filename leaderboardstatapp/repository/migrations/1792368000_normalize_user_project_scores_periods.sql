@@ -0,0 +1,28 @@
+-- +migrate Up
+-- CHAR(20) padded every time value with spaces, VARCHAR keeps them as written
+ALTER TABLE user_project_scores ALTER COLUMN time_value TYPE VARCHAR(20);
+
+-- Daily time values were written as Y/M/D without zero padding, e.g. 2025/6/1. They
+-- become dates like 2025-06-01, the format weekly (2025-W23), monthly (2025-06) and
+-- yearly (2025) rollups share with the leaderboard periods.
+UPDATE user_project_scores
+SET time_value = TO_CHAR(TO_DATE(time_value, 'YYYY/MM/DD'), 'YYYY-MM-DD')
+WHERE timeframe = 'daily' AND time_value LIKE '%/%';
+
+CREATE INDEX IF NOT EXISTS idx_user_project_scores_period
+    ON user_project_scores (timeframe, time_value);
+
+COMMENT ON COLUMN user_project_scores.time_value IS 'daily: 2025-06-01, weekly: ISO week 2025-W23, monthly: 2025-06, yearly: 2025, global: NULL';
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_user_project_scores_period;
+
+DELETE FROM user_project_scores WHERE timeframe IN ('weekly', 'monthly', 'yearly');
+
+UPDATE user_project_scores
+SET time_value = TO_CHAR(TO_DATE(time_value, 'YYYY-MM-DD'), 'FMYYYY/FMMM/FMDD')
+WHERE timeframe = 'daily';
+
+ALTER TABLE user_project_scores ALTER COLUMN time_value TYPE CHAR(20);
+
+COMMENT ON COLUMN user_project_scores.time_value IS 'Day ex: today date/yesterday date- Week ex: 1,2,3..- Month ex: 1..12';
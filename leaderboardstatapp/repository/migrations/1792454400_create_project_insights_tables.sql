@@ -0,0 +1,48 @@
+-- +migrate Up
+-- Raw events already counted, redelivered events must not count twice
+CREATE TABLE IF NOT EXISTS project_activity_events (
+    event_id    VARCHAR(255) PRIMARY KEY,
+    project_id  BIGINT NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One row per contributor active in a project in an ISO week, e.g. 2025-W23
+CREATE TABLE IF NOT EXISTS project_contributor_weeks (
+    project_id     BIGINT NOT NULL,
+    contributor_id BIGINT NOT NULL,
+    week           VARCHAR(10) NOT NULL,
+    events         INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (project_id, week, contributor_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_contributor_weeks_contributor
+    ON project_contributor_weeks (project_id, contributor_id, week);
+
+CREATE TABLE IF NOT EXISTS project_weekly_health (
+    project_id          BIGINT NOT NULL,
+    week                VARCHAR(10) NOT NULL,
+    merged_prs          INT NOT NULL DEFAULT 0,
+    closed_unmerged_prs INT NOT NULL DEFAULT 0,
+    closed_issues       INT NOT NULL DEFAULT 0,
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, week)
+);
+
+-- The last close of every issue, it times the issue from opened_at
+CREATE TABLE IF NOT EXISTS project_issue_closes (
+    project_id BIGINT NOT NULL,
+    issue_id   BIGINT NOT NULL,
+    week       VARCHAR(10) NOT NULL,
+    opened_at  TIMESTAMPTZ NOT NULL,
+    closed_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (project_id, issue_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_issue_closes_week
+    ON project_issue_closes (project_id, week);
+
+-- +migrate Down
+DROP TABLE IF EXISTS project_issue_closes;
+DROP TABLE IF EXISTS project_weekly_health;
+DROP TABLE IF EXISTS project_contributor_weeks;
+DROP TABLE IF EXISTS project_activity_events;
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- The first day of scored activity of a contributor in a project, project 0 is global
+CREATE TABLE IF NOT EXISTS contributor_cohorts (
+    contributor_id BIGINT NOT NULL,
+    project_id     BIGINT NOT NULL,
+    first_day      DATE NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (contributor_id, project_id)
+);
+
+-- retained counts the cohort members active period_offset weeks or months after the
+-- cohort period, e.g. cohort 2025-06 with offset 3 is activity in 2025-09
+CREATE TABLE IF NOT EXISTS cohort_retention (
+    project_id    BIGINT NOT NULL,
+    granularity   VARCHAR(10) NOT NULL,
+    cohort        VARCHAR(10) NOT NULL,
+    period_offset INT NOT NULL,
+    cohort_size   INT NOT NULL,
+    retained      INT NOT NULL,
+    refreshed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, granularity, cohort, period_offset)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS cohort_retention;
+DROP TABLE IF EXISTS contributor_cohorts;
@@ -0,0 +1,23 @@
+-- +migrate Up
+-- Hourly buckets of the score, raw events and best daily rank of every contributor and
+-- project, the continuous aggregate the time series are downsampled from
+CREATE TABLE IF NOT EXISTS score_series_hourly (
+    entity_type VARCHAR(16) NOT NULL CHECK (entity_type IN ('contributor', 'project')),
+    entity_id   BIGINT NOT NULL,
+    bucket      TIMESTAMPTZ NOT NULL,
+    score       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    events      BIGINT NOT NULL DEFAULT 0,
+    best_rank   INT,
+    PRIMARY KEY (entity_type, entity_id, bucket)
+);
+
+-- The last scores row added to score_series_hourly, scores ids only grow
+CREATE TABLE IF NOT EXISTS score_series_watermark (
+    name         VARCHAR(32) PRIMARY KEY,
+    last_id      BIGINT NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS score_series_watermark;
+DROP TABLE IF EXISTS score_series_hourly;
@@ -0,0 +1,25 @@
+-- +migrate Up
+-- A MAX(id) watermark skips scores rows that commit after a higher id, each row now
+-- records whether it was added to score_series_hourly
+ALTER TABLE scores ADD COLUMN IF NOT EXISTS in_series BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE scores SET in_series = TRUE
+WHERE id <= COALESCE((SELECT last_id FROM score_series_watermark WHERE name = 'scores'), 0);
+
+CREATE INDEX IF NOT EXISTS idx_scores_series_pending ON scores (id) WHERE NOT in_series;
+
+DROP TABLE IF EXISTS score_series_watermark;
+
+-- +migrate Down
+CREATE TABLE IF NOT EXISTS score_series_watermark (
+    name         VARCHAR(32) PRIMARY KEY,
+    last_id      BIGINT NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO score_series_watermark (name, last_id)
+SELECT 'scores', COALESCE((SELECT MIN(id) - 1 FROM scores WHERE NOT in_series), (SELECT MAX(id) FROM scores), 0)
+ON CONFLICT (name) DO NOTHING;
+
+DROP INDEX IF EXISTS idx_scores_series_pending;
+ALTER TABLE scores DROP COLUMN IF EXISTS in_series;
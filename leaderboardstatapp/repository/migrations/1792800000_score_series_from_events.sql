@@ -0,0 +1,9 @@
+-- +migrate Up
+-- The points of the series were summed from the daily totals of the scores table, which
+-- are stored again on every run and stamped with the time they were stored. The points now
+-- come from the raw events at their event time; the summed totals cannot be split back into
+-- events, the series start over from zero.
+UPDATE score_series_hourly SET score = 0 WHERE score <> 0;
+
+-- +migrate Down
+-- The summed daily totals are gone, nothing to restore
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -25,6 +26,26 @@ func NewRedisLeaderboardRepository(client *redis.Client) *RedisLeaderboardReposi
 	}
 }
 
+// updatePublicLeaderboardLua adds the scores ARGV[3..] as score, member pairs to the board
+// KEYS[1] when it exists, extends it to the TTL ARGV[1] in milliseconds and stamps the
+// update time ARGV[2] in KEYS[2]. It returns 0 when the board does not exist.
+var updatePublicLeaderboardLua = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+  return 0
+end
+
+for i = 3, #ARGV, 2 do
+  redis.call("ZADD", KEYS[1], ARGV[i], ARGV[i + 1])
+end
+redis.call("PEXPIRE", KEYS[1], ARGV[1])
+redis.call("SET", KEYS[2], ARGV[2], "PX", ARGV[1])
+return 1
+`)
+
+func publicLeaderboardUpdatedKey(projectID types.ID) string {
+	return fmt.Sprintf("public_leaderboard:project:%d:updated_at", projectID)
+}
+
 func (r *RedisLeaderboardRepository) GetPublicLeaderboardPaginated(ctx context.Context, projectID types.ID, page, pageSize int32) ([]leaderboardstat.UserScoreEntry, int64, error) {
 	cacheKey := fmt.Sprintf("public_leaderboard:project:%d", projectID)
 
@@ -83,7 +104,8 @@ func (r *RedisLeaderboardRepository) SetPublicLeaderboard(ctx context.Context, p
 	log := logger.L()
 	cacheKey := fmt.Sprintf("public_leaderboard:project:%d", projectID)
 
-	pipe := r.client.Pipeline()
+	// MULTI keeps readers and incremental updates from seeing the board half written
+	pipe := r.client.TxPipeline()
 
 	pipe.Del(ctx, cacheKey)
 
@@ -96,6 +118,7 @@ func (r *RedisLeaderboardRepository) SetPublicLeaderboard(ctx context.Context, p
 	}
 
 	pipe.Expire(ctx, cacheKey, ttl)
+	pipe.Set(ctx, publicLeaderboardUpdatedKey(projectID), time.Now().UnixMilli(), ttl)
 
 	_, err := pipe.Exec(ctx)
 	if err != nil {
@@ -112,3 +135,36 @@ func (r *RedisLeaderboardRepository) SetPublicLeaderboard(ctx context.Context, p
 
 	return nil
 }
+
+func (r *RedisLeaderboardRepository) UpdatePublicLeaderboard(ctx context.Context, projectID types.ID, userScores map[int]float64, ttl time.Duration) (bool, error) {
+	cacheKey := fmt.Sprintf("public_leaderboard:project:%d", projectID)
+
+	// One script checks the board exists and updates it, a board expiring or rebuilt in
+	// between is not recreated with the changed members only
+	args := make([]interface{}, 0, 2+2*len(userScores))
+	args = append(args, ttl.Milliseconds(), time.Now().UnixMilli())
+	for userID, score := range userScores {
+		args = append(args, score, strconv.Itoa(userID))
+	}
+
+	updated, err := updatePublicLeaderboardLua.Run(ctx, r.client,
+		[]string{cacheKey, publicLeaderboardUpdatedKey(projectID)}, args...).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to update leaderboard: %w", err)
+	}
+
+	return updated == 1, nil
+}
+
+func (r *RedisLeaderboardRepository) GetPublicLeaderboardUpdatedAt(ctx context.Context, projectID types.ID) (time.Time, error) {
+	updatedAt, err := r.client.Get(ctx, publicLeaderboardUpdatedKey(projectID)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, fmt.Errorf("failed to get leaderboard update time: %w", err)
+	}
+
+	return time.UnixMilli(updatedAt).UTC(), nil
+}
@@ -0,0 +1,160 @@
+package leaderboardstat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/timettl"
+	types "github.com/gocasters/rankr/type"
+)
+
+const (
+	analyticsCacheTTL = 10 * time.Minute
+	// velocityWindowDays is the window of the moving average the month end is projected with
+	velocityWindowDays = 7
+)
+
+// getContributorAnalytics returns the analytics of a contributor from the cache, or computes
+// and caches them. It returns nil when they cannot be computed, the stats are then served
+// without them.
+func (s *Service) getContributorAnalytics(ctx context.Context, contributorID types.ID) *ContributorAnalytics {
+	log := logger.L()
+	cacheKey := fmt.Sprintf("contributor:%d:analytics", contributorID)
+
+	if cached, err := s.cacheManager.Get(ctx, cacheKey); err == nil {
+		var analytics ContributorAnalytics
+		if err := json.Unmarshal([]byte(cached), &analytics); err == nil {
+			return &analytics
+		}
+	}
+
+	analytics, err := s.computeContributorAnalytics(ctx, contributorID, time.Now().UTC())
+	if err != nil {
+		log.Warn("failed to compute contributor analytics",
+			slog.Uint64("contributor_id", uint64(contributorID)),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	if b, mErr := json.Marshal(analytics); mErr == nil {
+		_ = s.cacheManager.Set(ctx, cacheKey, string(b), analyticsCacheTTL)
+	}
+
+	return &analytics
+}
+
+func (s *Service) computeContributorAnalytics(ctx context.Context, contributorID types.ID, now time.Time) (ContributorAnalytics, error) {
+	globalPercentile, projectPercentiles, err := s.repository.GetContributorPercentiles(ctx, contributorID)
+	if err != nil {
+		return ContributorAnalytics{}, fmt.Errorf("failed to get percentiles: %w", err)
+	}
+
+	analytics := ContributorAnalytics{
+		GlobalPercentile:   globalPercentile,
+		ProjectPercentiles: projectPercentiles,
+	}
+
+	for _, days := range []int{7, 30} {
+		fromDay, toDay := velocityWindow(now, days)
+		points, err := s.repository.GetContributorPointsBetween(ctx, contributorID, fromDay, toDay)
+		if err != nil {
+			return ContributorAnalytics{}, fmt.Errorf("failed to get points of the last %d days: %w", days, err)
+		}
+
+		velocity := points / float64(days)
+		if days == 7 {
+			analytics.WeeklyVelocity = velocity
+		} else {
+			analytics.MonthlyVelocity = velocity
+		}
+	}
+
+	growth, err := projectionGrowth(now, velocityWindowDays)
+	if err != nil {
+		return ContributorAnalytics{}, err
+	}
+
+	fromDay, toDay := velocityWindow(now, velocityWindowDays)
+	analytics.ProjectedMonthEndRank, err = s.repository.GetProjectedRank(ctx, contributorID, fromDay, toDay, growth)
+	if err != nil {
+		return ContributorAnalytics{}, fmt.Errorf("failed to get projected rank: %w", err)
+	}
+
+	analytics.WeeklyRankChange, analytics.MonthlyRankChange = s.getRankChanges(ctx, contributorID, now)
+
+	return analytics, nil
+}
+
+// getRankChanges compares the latest all-time snapshot of the contributor with the ones 7
+// and 30 days before, a positive change is a climb. A change is nil without a snapshot that
+// old or when leaderboardscoring cannot be reached.
+func (s *Service) getRankChanges(ctx context.Context, contributorID types.ID, now time.Time) (weekly, monthly *int64) {
+	if s.lbScoringClient == nil {
+		return nil, nil
+	}
+
+	history, err := s.lbScoringClient.GetUserRankHistory(ctx, &lbscoring.GetUserRankHistoryRequest{
+		UserID: strconv.FormatUint(uint64(contributorID), 10),
+		From:   now.AddDate(0, 0, -31),
+		To:     now,
+	})
+	if err != nil {
+		logger.L().Warn("failed to get contributor rank history",
+			slog.Uint64("contributor_id", uint64(contributorID)),
+			slog.String("error", err.Error()))
+		return nil, nil
+	}
+
+	return rankChange(history.Points, now.AddDate(0, 0, -7)), rankChange(history.Points, now.AddDate(0, 0, -30))
+}
+
+// rankChange returns the rank at the last snapshot taken at or before since minus the latest
+// rank. points are ordered by snapshot time.
+func rankChange(points []lbscoring.RankHistoryPoint, since time.Time) *int64 {
+	if len(points) == 0 {
+		return nil
+	}
+
+	var past *lbscoring.RankHistoryPoint
+	for i := range points {
+		if points[i].SnapshotAt.After(since) {
+			break
+		}
+		past = &points[i]
+	}
+	if past == nil {
+		return nil
+	}
+
+	change := past.Rank - points[len(points)-1].Rank
+
+	return &change
+}
+
+// velocityWindow returns the daily periods [fromDay, toDay) of the last days complete UTC
+// days before now. Today is left out, its points are still coming in.
+func velocityWindow(now time.Time, days int) (fromDay, toDay string) {
+	now = now.UTC()
+
+	return timettl.DayOf(now.AddDate(0, 0, -days)), timettl.DayOf(now)
+}
+
+// projectionGrowth returns how many windows of windowDays fit in the rest of the month, the
+// points of the window are projected to the month end with it
+func projectionGrowth(now time.Time, windowDays int) (float64, error) {
+	now = now.UTC()
+
+	monthEnd, err := timettl.EndOfPeriodAt("monthly", now)
+	if err != nil {
+		return 0, err
+	}
+	remainingDays := monthEnd.Sub(now).Hours() / 24
+
+	return remainingDays / float64(windowDays), nil
+}
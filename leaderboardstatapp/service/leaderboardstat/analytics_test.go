@@ -0,0 +1,137 @@
+package leaderboardstat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAnalyticsRepository answers the analytics queries and records the windows asked for
+type fakeAnalyticsRepository struct {
+	Repository
+
+	points  map[int]float64
+	windows [][2]string
+	growth  float64
+}
+
+func (f *fakeAnalyticsRepository) GetContributorPercentiles(context.Context, types.ID) (float64, map[types.ID]float64, error) {
+	return 90, map[types.ID]float64{1001: 75}, nil
+}
+
+func (f *fakeAnalyticsRepository) GetContributorPointsBetween(_ context.Context, _ types.ID, fromDay, toDay string) (float64, error) {
+	f.windows = append(f.windows, [2]string{fromDay, toDay})
+	from, _ := time.Parse(time.DateOnly, fromDay)
+	to, _ := time.Parse(time.DateOnly, toDay)
+
+	return f.points[int(to.Sub(from).Hours()/24)], nil
+}
+
+func (f *fakeAnalyticsRepository) GetProjectedRank(_ context.Context, _ types.ID, fromDay, toDay string, growth float64) (uint, error) {
+	f.windows = append(f.windows, [2]string{fromDay, toDay})
+	f.growth = growth
+
+	return 3, nil
+}
+
+func TestRankChange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 2, 0, 0, 0, time.UTC) }
+	points := []lbscoring.RankHistoryPoint{
+		{SnapshotAt: day(1), Rank: 40},
+		{SnapshotAt: day(8), Rank: 25},
+		{SnapshotAt: day(15), Rank: 30},
+	}
+	change := func(c int64) *int64 { return &c }
+
+	tests := []struct {
+		name   string
+		points []lbscoring.RankHistoryPoint
+		since  time.Time
+		want   *int64
+	}{
+		{name: "no snapshots", since: day(8)},
+		{name: "no snapshot that old", points: points, since: day(1).Add(-time.Hour)},
+		{name: "snapshot at since", points: points, since: day(8), want: change(-5)},
+		{name: "last snapshot before since", points: points, since: day(10), want: change(-5)},
+		{name: "climbed", points: points, since: day(1), want: change(10)},
+		{name: "only the latest snapshot", points: points[2:], since: day(15), want: change(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rankChange(tt.points, tt.since))
+		})
+	}
+}
+
+func TestVelocityWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		days     int
+		wantFrom string
+		wantTo   string
+	}{
+		{name: "seven complete days", now: time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC), days: 7, wantFrom: "2025-06-08", wantTo: "2025-06-15"},
+		{name: "just after midnight", now: time.Date(2025, 6, 15, 0, 0, 1, 0, time.UTC), days: 7, wantFrom: "2025-06-08", wantTo: "2025-06-15"},
+		{name: "across a month", now: time.Date(2025, 7, 3, 12, 0, 0, 0, time.UTC), days: 30, wantFrom: "2025-06-03", wantTo: "2025-07-03"},
+		{name: "UTC days", now: time.Date(2025, 6, 15, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*3600)), days: 7, wantFrom: "2025-06-07", wantTo: "2025-06-14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := velocityWindow(tt.now, tt.days)
+			assert.Equal(t, tt.wantFrom, from)
+			assert.Equal(t, tt.wantTo, to)
+		})
+	}
+}
+
+func TestProjectionGrowth(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want float64
+	}{
+		{name: "start of a 30 day month", now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), want: 30.0 / 7},
+		{name: "middle of the day", now: time.Date(2025, 6, 29, 12, 0, 0, 0, time.UTC), want: 1.5 / 7},
+		{name: "last hour of the month", now: time.Date(2025, 2, 28, 23, 0, 0, 0, time.UTC), want: 1.0 / 24 / 7},
+		{name: "end of the month in UTC", now: time.Date(2025, 7, 1, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*3600)), want: 1.0 / 24 / 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := projectionGrowth(tt.now, velocityWindowDays)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestService_ComputeContributorAnalytics(t *testing.T) {
+	repo := &fakeAnalyticsRepository{points: map[int]float64{7: 70, 30: 150}}
+	svc := newTestService(repo, nil, nil)
+	now := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	analytics, err := svc.computeContributorAnalytics(context.Background(), 8, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, ContributorAnalytics{
+		GlobalPercentile:      90,
+		ProjectPercentiles:    map[types.ID]float64{1001: 75},
+		WeeklyVelocity:        10,
+		MonthlyVelocity:       5,
+		ProjectedMonthEndRank: 3,
+	}, analytics)
+	assert.Equal(t, [][2]string{
+		{"2025-06-08", "2025-06-15"},
+		{"2025-05-16", "2025-06-15"},
+		{"2025-06-08", "2025-06-15"},
+	}, repo.windows, "today is left out of every window")
+	assert.InDelta(t, (15.0+14.0/24)/7, repo.growth, 1e-9)
+}
@@ -0,0 +1,119 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/timettl"
+)
+
+const (
+	DefaultCohorts = 12
+	MaxCohorts     = 104
+)
+
+// cohortGranularities are the periods contributors are grouped and followed by
+var cohortGranularities = []string{"weekly", "monthly"}
+
+// RefreshCohorts assigns the contributors with new scored activity to the cohort of their
+// first contribution, per project and globally, and rebuilds the retention matrices
+func (s *Service) RefreshCohorts(ctx context.Context) error {
+	log := logger.L()
+
+	assigned, err := s.repository.AssignContributorCohorts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to assign contributor cohorts: %w", err)
+	}
+	log.Info("Assigned contributor cohorts", slog.Int64("contributors", assigned))
+
+	for _, granularity := range cohortGranularities {
+		rows, err := s.repository.RebuildCohortRetention(ctx, granularity)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild %s cohort retention: %w", granularity, err)
+		}
+
+		log.Info("Rebuilt cohort retention",
+			slog.String("granularity", granularity),
+			slog.Int64("rows", rows))
+	}
+
+	return nil
+}
+
+// GetCohortRetention returns the retention matrix of the latest req.Cohorts cohorts of a
+// project, or the global one for project 0. Every cohort has a column for each period up
+// to the current one.
+func (s *Service) GetCohortRetention(ctx context.Context, req CohortRetentionRequest) (CohortRetention, error) {
+	if req.Cohorts == 0 {
+		req.Cohorts = DefaultCohorts
+	}
+	if err := s.validator.ValidateCohortRetention(req); err != nil {
+		return CohortRetention{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	cells, err := s.repository.GetCohortRetention(ctx, req.ProjectID, req.Granularity, req.Cohorts)
+	if err != nil {
+		return CohortRetention{}, err
+	}
+
+	retention := CohortRetention{
+		ProjectID:   req.ProjectID,
+		Granularity: req.Granularity,
+		Cohorts:     make([]CohortRetentionRow, 0, req.Cohorts),
+	}
+
+	now := time.Now().UTC()
+	for _, cell := range cells {
+		last := len(retention.Cohorts) - 1
+		if last < 0 || retention.Cohorts[last].Cohort != cell.Cohort {
+			periods, err := elapsedPeriods(req.Granularity, cell.Cohort, now)
+			if err != nil {
+				return CohortRetention{}, err
+			}
+
+			retention.Cohorts = append(retention.Cohorts, CohortRetentionRow{
+				Cohort:   cell.Cohort,
+				Size:     cell.Size,
+				Retained: make([]int64, periods+1),
+				Rates:    make([]float64, periods+1),
+			})
+			last++
+		}
+
+		row := &retention.Cohorts[last]
+		if cell.Offset >= len(row.Retained) {
+			continue
+		}
+		row.Retained[cell.Offset] = cell.Retained
+		if row.Size > 0 {
+			row.Rates[cell.Offset] = float64(cell.Retained) / float64(row.Size)
+		}
+	}
+
+	return retention, nil
+}
+
+// elapsedPeriods returns how many weeks or months passed from the start of cohort to now
+func elapsedPeriods(granularity, cohort string, now time.Time) (int, error) {
+	start, err := timettl.StartOfPeriod(granularity, cohort, time.UTC)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s cohort %s: %w", granularity, cohort, err)
+	}
+	if now.Before(start) {
+		return 0, nil
+	}
+
+	if granularity == "weekly" {
+		current, err := timettl.StartOfPeriod(granularity, timettl.WeekOf(now), time.UTC)
+		if err != nil {
+			return 0, err
+		}
+		return int(current.Sub(start).Hours() / 24 / 7), nil
+	}
+
+	return (now.Year()-start.Year())*12 + int(now.Month()-start.Month()), nil
+}
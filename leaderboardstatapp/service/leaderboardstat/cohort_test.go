@@ -0,0 +1,110 @@
+package leaderboardstat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/timettl"
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCohortRepository returns fixed retention cells and records the query
+type fakeCohortRepository struct {
+	Repository
+
+	cells       []CohortRetentionCell
+	projectID   types.ID
+	granularity string
+	cohorts     int
+}
+
+func (f *fakeCohortRepository) GetCohortRetention(_ context.Context, projectID types.ID, granularity string, cohorts int) ([]CohortRetentionCell, error) {
+	f.projectID, f.granularity, f.cohorts = projectID, granularity, cohorts
+
+	return f.cells, nil
+}
+
+func TestElapsedPeriods(t *testing.T) {
+	tests := []struct {
+		name        string
+		granularity string
+		cohort      string
+		now         time.Time
+		want        int
+		wantErr     bool
+	}{
+		{name: "first day of the cohort week", granularity: "weekly", cohort: "2025-W23", now: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), want: 0},
+		{name: "last moment of the cohort week", granularity: "weekly", cohort: "2025-W23", now: time.Date(2025, 6, 8, 23, 59, 59, 0, time.UTC), want: 0},
+		{name: "next week", granularity: "weekly", cohort: "2025-W23", now: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), want: 1},
+		{name: "weeks later", granularity: "weekly", cohort: "2025-W23", now: time.Date(2025, 8, 6, 12, 0, 0, 0, time.UTC), want: 9},
+		{name: "across the year", granularity: "weekly", cohort: "2024-W52", now: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), want: 2},
+		{name: "last day of the cohort month", granularity: "monthly", cohort: "2025-06", now: time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC), want: 0},
+		{name: "next month", granularity: "monthly", cohort: "2025-06", now: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), want: 1},
+		{name: "months across the year", granularity: "monthly", cohort: "2024-11", now: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC), want: 3},
+		{name: "cohort in the future", granularity: "monthly", cohort: "2025-09", now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), want: 0},
+		{name: "invalid cohort", granularity: "monthly", cohort: "June", now: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := elapsedPeriods(tt.granularity, tt.cohort, tt.now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_GetCohortRetention(t *testing.T) {
+	firstOfMonth := time.Date(time.Now().UTC().Year(), time.Now().UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	twoAgo := timettl.MonthOf(firstOfMonth.AddDate(0, -2, 0))
+	oneAgo := timettl.MonthOf(firstOfMonth.AddDate(0, -1, 0))
+	current := timettl.MonthOf(firstOfMonth)
+
+	repo := &fakeCohortRepository{cells: []CohortRetentionCell{
+		{Cohort: twoAgo, Size: 10, Offset: 0, Retained: 10},
+		{Cohort: twoAgo, Size: 10, Offset: 2, Retained: 4},
+		// a cell past the current period is left out
+		{Cohort: twoAgo, Size: 10, Offset: 5, Retained: 1},
+		{Cohort: oneAgo, Size: 0, Offset: 0, Retained: 0},
+		{Cohort: current, Size: 4, Offset: 0, Retained: 4},
+	}}
+	svc := newTestService(repo, nil, nil)
+
+	retention, err := svc.GetCohortRetention(context.Background(), CohortRetentionRequest{ProjectID: 1001, Granularity: "monthly"})
+	require.NoError(t, err)
+
+	assert.Equal(t, types.ID(1001), repo.projectID)
+	assert.Equal(t, "monthly", repo.granularity)
+	assert.Equal(t, DefaultCohorts, repo.cohorts)
+
+	assert.Equal(t, CohortRetention{
+		ProjectID:   1001,
+		Granularity: "monthly",
+		Cohorts: []CohortRetentionRow{
+			// the missing offset 1 is zero
+			{Cohort: twoAgo, Size: 10, Retained: []int64{10, 0, 4}, Rates: []float64{1, 0, 0.4}},
+			{Cohort: oneAgo, Size: 0, Retained: []int64{0, 0}, Rates: []float64{0, 0}},
+			{Cohort: current, Size: 4, Retained: []int64{4}, Rates: []float64{1}},
+		},
+	}, retention)
+}
+
+func TestService_GetCohortRetentionInvalid(t *testing.T) {
+	svc := newTestService(&fakeCohortRepository{}, nil, nil)
+
+	for _, req := range []CohortRetentionRequest{
+		{Granularity: "daily"},
+		{Granularity: ""},
+		{Granularity: "weekly", Cohorts: MaxCohorts + 1},
+	} {
+		_, err := svc.GetCohortRetention(context.Background(), req)
+		assert.ErrorIs(t, err, ErrInvalidArguments, "request %+v", req)
+	}
+}
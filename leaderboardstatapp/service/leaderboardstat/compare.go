@@ -0,0 +1,190 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
+	"github.com/gocasters/rankr/pkg/timettl"
+	types "github.com/gocasters/rankr/type"
+)
+
+const (
+	MinComparedContributors = 2
+	MaxComparedContributors = 5
+)
+
+// comparisonBuckets is the history bucket of each timeframe when none is asked for
+var comparisonBuckets = map[string]string{
+	TimeframeDaily: BucketHour,
+	"weekly":       BucketDay,
+	"monthly":      BucketDay,
+	"yearly":       BucketMonth,
+}
+
+// CompareContributors puts the scores, ranks, event types, projects and score history of
+// a few contributors in one period side by side. It fails with ErrContributorHidden when
+// one of them is an anonymous contributor the viewer may not see.
+func (s *Service) CompareContributors(ctx context.Context, req CompareContributorsRequest, viewer privacy.Viewer) (ContributorComparison, error) {
+	if err := s.validator.ValidateCompareContributors(req); err != nil {
+		return ContributorComparison{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	now := time.Now()
+	period, err := periodOrCurrent(req.Timeframe, req.Period, now)
+	if err != nil {
+		return ContributorComparison{}, err
+	}
+
+	from, err := timettl.StartOfPeriod(req.Timeframe, period, time.UTC)
+	if err != nil {
+		return ContributorComparison{}, errors.Join(ErrInvalidArguments, err)
+	}
+	if from.After(now) {
+		return ContributorComparison{}, errors.Join(ErrInvalidArguments, fmt.Errorf("period %s has not started yet", period))
+	}
+	to, err := timettl.EndOfPeriodAt(req.Timeframe, from)
+	if err != nil {
+		return ContributorComparison{}, errors.Join(ErrInvalidArguments, err)
+	}
+	// The history of the current period ends now
+	if to.After(now) {
+		to = now.UTC()
+	}
+
+	bucket := req.Bucket
+	if bucket == "" {
+		bucket = comparisonBuckets[req.Timeframe]
+	}
+
+	identities := make([]privacy.Identity, 0, len(req.ContributorIDs))
+	for _, contributorID := range req.ContributorIDs {
+		identity, err := s.GetContributorIdentity(ctx, contributorID, viewer)
+		// The error of a hidden contributor does not name it, which of the IDs is anonymous
+		// stays unknown
+		if errors.Is(err, ErrContributorHidden) {
+			return ContributorComparison{}, ErrContributorHidden
+		}
+		if err != nil {
+			return ContributorComparison{}, fmt.Errorf("contributor %d: %w", contributorID, err)
+		}
+		identities = append(identities, identity)
+	}
+
+	ranks, err := s.repository.GetPeriodRanks(ctx, req.Timeframe, period, req.ContributorIDs)
+	if err != nil {
+		return ContributorComparison{}, err
+	}
+
+	comparison := ContributorComparison{
+		Timeframe:    req.Timeframe,
+		Period:       period,
+		Bucket:       bucket,
+		From:         from,
+		To:           to,
+		Contributors: make([]ComparedContributor, 0, len(req.ContributorIDs)),
+	}
+	for i, contributorID := range req.ContributorIDs {
+		projectsScore, err := s.repository.GetContributorPeriodScores(ctx, contributorID, req.Timeframe, period)
+		if err != nil {
+			return ContributorComparison{}, err
+		}
+
+		history, err := s.GetTimeSeries(ctx, TimeSeriesRequest{
+			Entity:   SeriesContributor,
+			EntityID: contributorID,
+			Metric:   MetricScore,
+			Bucket:   bucket,
+			From:     from,
+			To:       to,
+		})
+		if err != nil {
+			return ContributorComparison{}, err
+		}
+
+		compared := ComparedContributor{
+			Identity:      identities[i],
+			Rank:          ranks[contributorID],
+			ProjectsScore: projectsScore,
+			EventTypes:    s.getContributorEventTypes(ctx, contributorID, req.Timeframe, period),
+			History:       history.Points,
+		}
+		for _, score := range projectsScore {
+			compared.TotalScore += score
+		}
+
+		comparison.Contributors = append(comparison.Contributors, compared)
+	}
+	comparison.SharedProjects = sharedProjects(req.ContributorIDs, comparison.Contributors)
+
+	return comparison, nil
+}
+
+// getContributorEventTypes reads the points per event type of the global board of a
+// period from leaderboardscoring. The comparison is served without them when the service
+// cannot be reached.
+func (s *Service) getContributorEventTypes(ctx context.Context, contributorID types.ID, timeframe, period string) []EventTypeScore {
+	if s.lbScoringClient == nil {
+		return nil
+	}
+
+	explanation, err := s.lbScoringClient.ExplainScore(ctx, lbscoring.ExplainScoreRequest{
+		UserID:    strconv.FormatUint(uint64(contributorID), 10),
+		Timeframe: timeframe,
+		Period:    period,
+	})
+	if err != nil {
+		logger.L().Warn("failed to get contributor event types",
+			slog.Uint64("contributor_id", uint64(contributorID)),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	eventTypes := make([]EventTypeScore, 0, len(explanation.ByEventType))
+	for _, group := range explanation.ByEventType {
+		eventTypes = append(eventTypes, EventTypeScore{
+			EventType: group.Key,
+			Points:    group.Points,
+			Events:    group.Events,
+		})
+	}
+
+	return eventTypes
+}
+
+// sharedProjects returns the projects at least two of the contributors scored on, by
+// project ID. Project 0 holds the scores not attributed to a project, it is left out.
+func sharedProjects(contributorIDs []types.ID, contributors []ComparedContributor) []SharedProject {
+	byProject := make(map[types.ID]map[types.ID]float64)
+	for i, contributor := range contributors {
+		for projectID, score := range contributor.ProjectsScore {
+			if projectID == 0 || score <= 0 {
+				continue
+			}
+			if byProject[projectID] == nil {
+				byProject[projectID] = make(map[types.ID]float64)
+			}
+			byProject[projectID][contributorIDs[i]] = score
+		}
+	}
+
+	shared := make([]SharedProject, 0)
+	for projectID, scores := range byProject {
+		if len(scores) < 2 {
+			continue
+		}
+		shared = append(shared, SharedProject{ProjectID: projectID, Scores: scores})
+	}
+	sort.Slice(shared, func(i, j int) bool {
+		return shared[i].ProjectID < shared[j].ProjectID
+	})
+
+	return shared
+}
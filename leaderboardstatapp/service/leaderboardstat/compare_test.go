@@ -0,0 +1,180 @@
+package leaderboardstat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/adapter/contributor"
+	"github.com/gocasters/rankr/pkg/cachemanager"
+	"github.com/gocasters/rankr/pkg/privacy"
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCompareRepository serves the period scores and ranks of the compared contributors
+type fakeCompareRepository struct {
+	Repository
+
+	scores map[types.ID]map[types.ID]float64
+	ranks  map[types.ID]uint
+}
+
+func (f *fakeCompareRepository) GetPeriodRanks(context.Context, string, string, []types.ID) (map[types.ID]uint, error) {
+	return f.ranks, nil
+}
+
+func (f *fakeCompareRepository) GetContributorPeriodScores(_ context.Context, contributorID types.ID, _, _ string) (map[types.ID]float64, error) {
+	return f.scores[contributorID], nil
+}
+
+func (f *fakeCompareRepository) GetScoreSeries(context.Context, string, types.ID, string, time.Time, time.Time) ([]SeriesBucket, error) {
+	return nil, nil
+}
+
+// fakeDirectory answers the profile lookups of the identity resolver
+type fakeDirectory struct {
+	profiles []contributor.Profile
+}
+
+func (f fakeDirectory) GetProfilesByVCS(_ context.Context, _ string, userIDs []int64) ([]contributor.Profile, error) {
+	var profiles []contributor.Profile
+	for _, id := range userIDs {
+		for _, p := range f.profiles {
+			if p.VcsUserID == id {
+				profiles = append(profiles, p)
+			}
+		}
+	}
+
+	return profiles, nil
+}
+
+func newCompareService(repo Repository) Service {
+	resolver := privacy.NewIdentityResolver(privacy.Config{PseudonymSecret: "secret"}, fakeDirectory{profiles: []contributor.Profile{
+		{ContributorID: 101, VcsUserID: 8, VcsUsername: "alice"},
+		{ContributorID: 102, VcsUserID: 12, VcsUsername: "bob", PrivacyMode: "anonymous"},
+	}})
+
+	return NewService(repo, NewValidator(nil), cachemanager.CacheManager{}, nil, nil, nil, resolver)
+}
+
+func TestSharedProjects(t *testing.T) {
+	compared := func(scores map[types.ID]float64) ComparedContributor {
+		return ComparedContributor{ProjectsScore: scores}
+	}
+
+	tests := []struct {
+		name         string
+		ids          []types.ID
+		contributors []ComparedContributor
+		want         []SharedProject
+	}{
+		{
+			name:         "no common project",
+			ids:          []types.ID{8, 31},
+			contributors: []ComparedContributor{compared(map[types.ID]float64{1001: 5}), compared(map[types.ID]float64{1002: 7})},
+			want:         []SharedProject{},
+		},
+		{
+			name: "projects of two of three, ordered by project",
+			ids:  []types.ID{8, 12, 31},
+			contributors: []ComparedContributor{
+				compared(map[types.ID]float64{1002: 5, 1001: 2}),
+				compared(map[types.ID]float64{1002: 7}),
+				compared(map[types.ID]float64{1001: 1, 1003: 9}),
+			},
+			want: []SharedProject{
+				{ProjectID: 1001, Scores: map[types.ID]float64{8: 2, 31: 1}},
+				{ProjectID: 1002, Scores: map[types.ID]float64{8: 5, 12: 7}},
+			},
+		},
+		{
+			name: "unattributed scores and zero scores are left out",
+			ids:  []types.ID{8, 12},
+			contributors: []ComparedContributor{
+				compared(map[types.ID]float64{0: 5, 1001: 0}),
+				compared(map[types.ID]float64{0: 7, 1001: 3}),
+			},
+			want: []SharedProject{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sharedProjects(tt.ids, tt.contributors))
+		})
+	}
+}
+
+func TestValidator_ValidateCompareContributors(t *testing.T) {
+	validator := NewValidator(nil)
+
+	tests := []struct {
+		name    string
+		req     CompareContributorsRequest
+		wantErr bool
+	}{
+		{name: "two contributors", req: CompareContributorsRequest{ContributorIDs: []types.ID{8, 12}, Timeframe: "monthly"}},
+		{name: "five contributors with a bucket", req: CompareContributorsRequest{ContributorIDs: []types.ID{1, 2, 3, 4, 5}, Timeframe: "daily", Bucket: BucketHour}},
+		{name: "one contributor", req: CompareContributorsRequest{ContributorIDs: []types.ID{8}, Timeframe: "monthly"}, wantErr: true},
+		{name: "six contributors", req: CompareContributorsRequest{ContributorIDs: []types.ID{1, 2, 3, 4, 5, 6}, Timeframe: "monthly"}, wantErr: true},
+		{name: "the same contributor twice", req: CompareContributorsRequest{ContributorIDs: []types.ID{8, 8}, Timeframe: "monthly"}, wantErr: true},
+		{name: "contributor zero", req: CompareContributorsRequest{ContributorIDs: []types.ID{0, 8}, Timeframe: "monthly"}, wantErr: true},
+		{name: "no timeframe", req: CompareContributorsRequest{ContributorIDs: []types.ID{8, 12}}, wantErr: true},
+		{name: "all_time has no period", req: CompareContributorsRequest{ContributorIDs: []types.ID{8, 12}, Timeframe: "all_time"}, wantErr: true},
+		{name: "unknown bucket", req: CompareContributorsRequest{ContributorIDs: []types.ID{8, 12}, Timeframe: "monthly", Bucket: "minute"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateCompareContributors(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_CompareContributors(t *testing.T) {
+	repo := &fakeCompareRepository{
+		scores: map[types.ID]map[types.ID]float64{
+			8:  {1001: 30, 1002: 5},
+			12: {1001: 10},
+		},
+		ranks: map[types.ID]uint{8: 1, 12: 4},
+	}
+	svc := newCompareService(repo)
+
+	comparison, err := svc.CompareContributors(context.Background(), CompareContributorsRequest{
+		ContributorIDs: []types.ID{8, 12},
+		Timeframe:      "monthly",
+		Period:         "2025-06",
+	}, privacy.Viewer{ContributorID: 102})
+	require.NoError(t, err)
+
+	assert.Equal(t, BucketDay, comparison.Bucket, "the default bucket of a month")
+	require.Len(t, comparison.Contributors, 2)
+	assert.Equal(t, "alice", comparison.Contributors[0].Identity.DisplayName)
+	assert.Equal(t, float64(35), comparison.Contributors[0].TotalScore)
+	assert.Equal(t, uint(1), comparison.Contributors[0].Rank)
+	assert.Len(t, comparison.Contributors[0].History, 30, "one point per day of June")
+	assert.Equal(t, "bob", comparison.Contributors[1].Identity.DisplayName, "an anonymous contributor sees itself")
+	assert.Equal(t, []SharedProject{{ProjectID: 1001, Scores: map[types.ID]float64{8: 30, 12: 10}}}, comparison.SharedProjects)
+}
+
+func TestService_CompareContributorsHidden(t *testing.T) {
+	svc := newCompareService(&fakeCompareRepository{})
+
+	_, err := svc.CompareContributors(context.Background(), CompareContributorsRequest{
+		ContributorIDs: []types.ID{8, 12},
+		Timeframe:      "monthly",
+		Period:         "2025-06",
+	}, privacy.Viewer{})
+
+	require.ErrorIs(t, err, ErrContributorHidden)
+	assert.NotContains(t, err.Error(), "12", "the error does not tell which contributor is anonymous")
+}
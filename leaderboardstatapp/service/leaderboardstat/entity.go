@@ -1,6 +1,7 @@
 package leaderboardstat
 
 import (
+	"github.com/gocasters/rankr/pkg/privacy"
 	types "github.com/gocasters/rankr/type"
 	"time"
 )
@@ -22,6 +23,8 @@ type DailyContributorScore struct {
 	ProjectID     types.ID
 	Rank          int64
 	Timeframe     string
+	// EarnedAt is when the daily board was read, its UTC day files the score
+	EarnedAt time.Time
 }
 
 type ScoreRecord struct {
@@ -45,6 +48,38 @@ type ContributorStats struct {
 	TotalScore    float64                   `koanf:"total_score"`
 	ProjectsScore map[types.ID]float64      `koanf:"project_score"`
 	ScoreHistory  map[types.ID][]ScoreEntry `koanf:"score_history"`
+	Streak        ContributorStreak         `koanf:"streak"`
+	Identity      privacy.Identity          `koanf:"identity"`
+	// Analytics is nil when they cannot be computed
+	Analytics *ContributorAnalytics `koanf:"analytics"`
+}
+
+// ContributorStreak counts the consecutive days, in the contributor's timezone, with a
+// scored event. LastActiveDay is zero for contributors without activity.
+type ContributorStreak struct {
+	Current       int64     `koanf:"current"`
+	Longest       int64     `koanf:"longest"`
+	LastActiveDay time.Time `koanf:"last_active_day"`
+	Timezone      string    `koanf:"timezone"`
+}
+
+// ContributorAnalytics tells whether a contributor is improving. Percentiles are the share
+// of the board, in percent, at or below the contributor's score, zero when they are not on
+// it.
+type ContributorAnalytics struct {
+	GlobalPercentile   float64              `koanf:"global_percentile"`
+	ProjectPercentiles map[types.ID]float64 `koanf:"project_percentiles"`
+	// WeeklyRankChange and MonthlyRankChange are the global places climbed in the last 7
+	// and 30 days, nil without a snapshot that old
+	WeeklyRankChange  *int64 `koanf:"weekly_rank_change"`
+	MonthlyRankChange *int64 `koanf:"monthly_rank_change"`
+	// WeeklyVelocity and MonthlyVelocity are the average points per day of the last 7 and
+	// 30 complete UTC days
+	WeeklyVelocity  float64 `koanf:"weekly_velocity"`
+	MonthlyVelocity float64 `koanf:"monthly_velocity"`
+	// ProjectedMonthEndRank is the global rank at the end of the month when every
+	// contributor keeps the pace of the last 7 complete days, zero when they are not on the board
+	ProjectedMonthEndRank uint `koanf:"projected_month_end_rank"`
 }
 
 type ContributorTotalStats struct {
@@ -61,15 +96,171 @@ type ProjectScoreList struct {
 	Page       int32       `koanf:"page"`
 	PageSize   int32       `koanf:"page_size"`
 	TotalPages int32       `koanf:"total_pages"`
+	// LastUpdated is when the cached board was last written, zero when it is not cached
+	LastUpdated time.Time `koanf:"last_updated"`
+	// ModifiedAt is when the page last changed for the viewer: the later of LastUpdated and
+	// the last change of a profile shown on it
+	ModifiedAt time.Time `koanf:"modified_at"`
 }
 
 type UserScore struct {
-	ContributorID types.ID `koanf:"contributor_id"`
-	Score         float64  `koanf:"score"`
-	Rank          uint64   `koanf:"rank"`
+	// ContributorID is zero for an anonymous contributor the viewer may not see
+	ContributorID types.ID         `koanf:"contributor_id"`
+	Score         float64          `koanf:"score"`
+	Rank          uint64           `koanf:"rank"`
+	Identity      privacy.Identity `koanf:"identity"`
 }
 
 type UserScoreEntry struct {
 	UserID int     `json:"user_id"`
 	Score  float64 `json:"score"`
 }
+
+// ContributorPeriodScores is the score of a contributor per project in one daily, weekly,
+// monthly or yearly period. Project 0 holds the scores not attributed to a project.
+type ContributorPeriodScores struct {
+	ContributorID types.ID             `koanf:"contributor_id"`
+	Timeframe     string               `koanf:"timeframe"`
+	Period        string               `koanf:"period"`
+	TotalScore    float64              `koanf:"total_score"`
+	ProjectsScore map[types.ID]float64 `koanf:"project_score"`
+}
+
+// EventTypeScore is the points a contributor scored with one event type
+type EventTypeScore struct {
+	EventType string `koanf:"event_type"`
+	Points    int64  `koanf:"points"`
+	Events    int64  `koanf:"events"`
+}
+
+// ComparedContributor is one contributor of a comparison. Rank is zero without a score in
+// the period, EventTypes is nil when leaderboardscoring cannot be reached. History holds
+// the score of every bucket of the comparison.
+type ComparedContributor struct {
+	Identity      privacy.Identity     `koanf:"identity"`
+	TotalScore    float64              `koanf:"total_score"`
+	Rank          uint                 `koanf:"rank"`
+	ProjectsScore map[types.ID]float64 `koanf:"project_score"`
+	EventTypes    []EventTypeScore     `koanf:"event_types"`
+	History       []TimeSeriesPoint    `koanf:"history"`
+}
+
+// SharedProject is a project at least two of the compared contributors scored on in the
+// period, Scores is keyed by contributor
+type SharedProject struct {
+	ProjectID types.ID             `koanf:"project_id"`
+	Scores    map[types.ID]float64 `koanf:"scores"`
+}
+
+// ContributorComparison compares contributors in one period, in the order they were
+// asked for. The histories share the UTC buckets of [From, To).
+type ContributorComparison struct {
+	Timeframe      string                `koanf:"timeframe"`
+	Period         string                `koanf:"period"`
+	Bucket         string                `koanf:"bucket"`
+	From           time.Time             `koanf:"from"`
+	To             time.Time             `koanf:"to"`
+	Contributors   []ComparedContributor `koanf:"contributors"`
+	SharedProjects []SharedProject       `koanf:"shared_projects"`
+}
+
+// IssueClose is an issue closed with the time it was opened, it times the close
+type IssueClose struct {
+	IssueID  uint64
+	OpenedAt time.Time
+	ClosedAt time.Time
+}
+
+// ProjectActivity is what one raw event adds to the health series of its project
+type ProjectActivity struct {
+	EventID   string
+	ProjectID types.ID
+	// ContributorID is zero when the event has no contributor to count as active
+	ContributorID types.ID
+	OccurredAt    time.Time
+	Week          string
+	// Score is the base points of the event, see lbscoring.BasePoints
+	Score            int64
+	MergedPR         bool
+	ClosedUnmergedPR bool
+	IssueClosed      bool
+	TimedIssueClose  *IssueClose
+}
+
+// WeeklyProjectHealth is the health of a project in one ISO week
+type WeeklyProjectHealth struct {
+	Week                  string `koanf:"week"`
+	ActiveContributors    int64  `koanf:"active_contributors"`
+	NewContributors       int64  `koanf:"new_contributors"`
+	ReturningContributors int64  `koanf:"returning_contributors"`
+	// BusFactor is how many top contributors account for half of the score of the week
+	BusFactor         int64   `koanf:"bus_factor"`
+	MergedPRs         int64   `koanf:"merged_prs"`
+	ClosedUnmergedPRs int64   `koanf:"closed_unmerged_prs"`
+	MergedShare       float64 `koanf:"merged_share"`
+	ClosedIssues      int64   `koanf:"closed_issues"`
+	// MedianIssueCloseSeconds is the median time from open to close of the issues closed
+	// in the week, nil in a week without one
+	MedianIssueCloseSeconds *float64 `koanf:"median_issue_close_seconds"`
+}
+
+// ProjectInsights is the weekly health series of a project, oldest week first
+type ProjectInsights struct {
+	ProjectID types.ID `koanf:"project_id"`
+	FromWeek  string   `koanf:"from_week"`
+	ToWeek    string   `koanf:"to_week"`
+	// BusFactor is the bus factor of the scores of all weeks together
+	BusFactor int64                 `koanf:"bus_factor"`
+	Weeks     []WeeklyProjectHealth `koanf:"weeks"`
+}
+
+// CohortRetentionCell is the number of contributors of a cohort active Offset weeks or
+// months after the period of their first contribution
+type CohortRetentionCell struct {
+	Cohort   string
+	Size     int64
+	Offset   int
+	Retained int64
+}
+
+// CohortRetentionRow follows the contributors who first contributed in one week or month.
+// Retained and Rates hold one entry per period since, the first one is the cohort period.
+type CohortRetentionRow struct {
+	Cohort   string    `koanf:"cohort"`
+	Size     int64     `koanf:"size"`
+	Retained []int64   `koanf:"retained"`
+	Rates    []float64 `koanf:"rates"`
+}
+
+// CohortRetention is the retention matrix of a project, oldest cohort first
+type CohortRetention struct {
+	ProjectID   types.ID             `koanf:"project_id"`
+	Granularity string               `koanf:"granularity"`
+	Cohorts     []CohortRetentionRow `koanf:"cohorts"`
+}
+
+// SeriesBucket is the activity of a contributor or project in one bucket. BestRank is the
+// best daily board rank in the bucket, nil without a ranked score.
+type SeriesBucket struct {
+	Start    time.Time
+	Score    float64
+	Events   int64
+	BestRank *int64
+}
+
+// TimeSeriesPoint is the value of one bucket, Value is nil for a rank without a score
+type TimeSeriesPoint struct {
+	Time  time.Time `koanf:"time"`
+	Value *float64  `koanf:"value"`
+}
+
+// TimeSeries is one metric of a contributor or project per bucket, oldest first
+type TimeSeries struct {
+	Entity   string            `koanf:"entity"`
+	EntityID types.ID          `koanf:"entity_id"`
+	Metric   string            `koanf:"metric"`
+	Bucket   string            `koanf:"bucket"`
+	From     time.Time         `koanf:"from"`
+	To       time.Time         `koanf:"to"`
+	Points   []TimeSeriesPoint `koanf:"points"`
+}
@@ -0,0 +1,145 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/timettl"
+	types "github.com/gocasters/rankr/type"
+)
+
+const (
+	DefaultInsightWeeks = 12
+	MaxInsightWeeks     = 104
+)
+
+// ProjectInsightsStore keeps the health series of the projects
+type ProjectInsightsStore interface {
+	// RecordProjectActivity adds an activity to the series of its project once, recorded is
+	// false when its event was recorded before
+	RecordProjectActivity(ctx context.Context, activity ProjectActivity) (recorded bool, err error)
+	// GetProjectHealth returns the weeks in [fromWeek, toWeek] with any activity
+	GetProjectHealth(ctx context.Context, projectID types.ID, fromWeek, toWeek string) (map[string]WeeklyProjectHealth, error)
+	// GetProjectBusFactor returns the bus factor of the weekly scores in [fromWeek, toWeek]
+	// summed per contributor
+	GetProjectBusFactor(ctx context.Context, projectID types.ID, fromWeek, toWeek string) (int64, error)
+}
+
+// ProjectInsightsService tells maintainers whether their project is healthy. The series
+// grow with the raw event stream, the bus factor reads the weekly score rollups.
+type ProjectInsightsService struct {
+	store     ProjectInsightsStore
+	validator Validator
+}
+
+func NewProjectInsightsService(store ProjectInsightsStore, validator Validator) ProjectInsightsService {
+	return ProjectInsightsService{
+		store:     store,
+		validator: validator,
+	}
+}
+
+// RecordEvent adds a raw event to the health series of its project. recorded is false for
+// an event recorded before or one without a project.
+func (s ProjectInsightsService) RecordEvent(ctx context.Context, event *lbscoring.EventRequest) (bool, error) {
+	if event.RepositoryID == 0 {
+		return false, nil
+	}
+
+	activity := ProjectActivity{
+		EventID:    event.ID,
+		ProjectID:  types.ID(event.RepositoryID),
+		OccurredAt: event.Timestamp,
+		Week:       timettl.WeekOf(event.Timestamp.UTC()),
+		Score:      lbscoring.BasePoints(event.EventName),
+	}
+	if contributorID, err := strconv.ParseUint(event.UserID, 10, 64); err == nil {
+		activity.ContributorID = types.ID(contributorID)
+	}
+
+	switch p := event.Payload.(type) {
+	case lbscoring.PullRequestClosedPayload:
+		switch {
+		case p.Merged || p.CloseReason == lbscoring.PrCloseReasonMerged:
+			activity.MergedPR = true
+		case p.CloseReason == lbscoring.PrCloseReasonClosedWithoutMerge:
+			activity.ClosedUnmergedPR = true
+		}
+	case lbscoring.IssueClosedPayload:
+		activity.IssueClosed = true
+		// Events without opened_at cannot be timed
+		if !p.OpenedAt.IsZero() && !p.OpenedAt.After(event.Timestamp) {
+			activity.TimedIssueClose = &IssueClose{
+				IssueID:  p.IssueID,
+				OpenedAt: p.OpenedAt,
+				ClosedAt: event.Timestamp,
+			}
+		}
+	}
+
+	recorded, err := s.store.RecordProjectActivity(ctx, activity)
+	if err != nil {
+		return false, fmt.Errorf("failed to record activity of event %s: %w", event.ID, err)
+	}
+
+	return recorded, nil
+}
+
+// GetProjectInsights returns the health of a project in the last req.Weeks ISO weeks up to
+// the current one. Weeks without activity are zero.
+func (s ProjectInsightsService) GetProjectInsights(ctx context.Context, req ProjectInsightsRequest) (ProjectInsights, error) {
+	if req.Weeks == 0 {
+		req.Weeks = DefaultInsightWeeks
+	}
+	if err := s.validator.ValidateProjectInsights(req); err != nil {
+		return ProjectInsights{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	weeks := lastWeeks(time.Now().UTC(), req.Weeks)
+	fromWeek, toWeek := weeks[0], weeks[len(weeks)-1]
+
+	health, err := s.store.GetProjectHealth(ctx, req.ProjectID, fromWeek, toWeek)
+	if err != nil {
+		return ProjectInsights{}, fmt.Errorf("failed to get project health: %w", err)
+	}
+
+	busFactor, err := s.store.GetProjectBusFactor(ctx, req.ProjectID, fromWeek, toWeek)
+	if err != nil {
+		return ProjectInsights{}, fmt.Errorf("failed to get project bus factor: %w", err)
+	}
+
+	insights := ProjectInsights{
+		ProjectID: req.ProjectID,
+		FromWeek:  fromWeek,
+		ToWeek:    toWeek,
+		BusFactor: busFactor,
+		Weeks:     make([]WeeklyProjectHealth, 0, len(weeks)),
+	}
+	for _, week := range weeks {
+		weekHealth, ok := health[week]
+		if !ok {
+			weekHealth = WeeklyProjectHealth{Week: week}
+		}
+		if closed := weekHealth.MergedPRs + weekHealth.ClosedUnmergedPRs; closed > 0 {
+			weekHealth.MergedShare = float64(weekHealth.MergedPRs) / float64(closed)
+		}
+
+		insights.Weeks = append(insights.Weeks, weekHealth)
+	}
+
+	return insights, nil
+}
+
+// lastWeeks returns the ISO weeks of the count weeks up to now, oldest first
+func lastWeeks(now time.Time, count int) []string {
+	weeks := make([]string, count)
+	for i := range weeks {
+		weeks[i] = timettl.WeekOf(now.AddDate(0, 0, -7*(count-1-i)))
+	}
+
+	return weeks
+}
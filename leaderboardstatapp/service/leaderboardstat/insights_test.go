@@ -0,0 +1,189 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seriesBucket is an hourly bucket of score_series_hourly
+type seriesBucket struct {
+	entity   string
+	entityID types.ID
+	hour     time.Time
+}
+
+// fakeInsightsStore records every activity once by event ID, and adds it to the hourly
+// series like RecordProjectActivity
+type fakeInsightsStore struct {
+	ProjectInsightsStore
+
+	activities []ProjectActivity
+	series     map[seriesBucket]float64
+	err        error
+}
+
+func (f *fakeInsightsStore) RecordProjectActivity(_ context.Context, activity ProjectActivity) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	for _, a := range f.activities {
+		if a.EventID == activity.EventID {
+			return false, nil
+		}
+	}
+	f.activities = append(f.activities, activity)
+
+	if f.series == nil {
+		f.series = make(map[seriesBucket]float64)
+	}
+	hour := activity.OccurredAt.UTC().Truncate(time.Hour)
+	f.series[seriesBucket{SeriesProject, activity.ProjectID, hour}] += float64(activity.Score)
+	if activity.ContributorID != 0 {
+		f.series[seriesBucket{SeriesContributor, activity.ContributorID, hour}] += float64(activity.Score)
+	}
+
+	return true, nil
+}
+
+func TestProjectInsightsService_RecordEvent(t *testing.T) {
+	// Sunday 2025-06-08 23:30 UTC is still in ISO week 23
+	at := time.Date(2025, 6, 8, 23, 30, 0, 0, time.UTC)
+	opened := at.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name         string
+		event        lbscoring.EventRequest
+		wantRecorded bool
+		want         ProjectActivity
+	}{
+		{
+			name: "merged pull request",
+			event: lbscoring.EventRequest{ID: "e1", UserID: "7", RepositoryID: 1001, Timestamp: at,
+				Payload: lbscoring.PullRequestClosedPayload{Merged: true}},
+			wantRecorded: true,
+			want:         ProjectActivity{EventID: "e1", ProjectID: 1001, ContributorID: 7, OccurredAt: at, Week: "2025-W23", MergedPR: true},
+		},
+		{
+			name: "merged by close reason",
+			event: lbscoring.EventRequest{ID: "e2", UserID: "7", RepositoryID: 1001, Timestamp: at,
+				Payload: lbscoring.PullRequestClosedPayload{CloseReason: lbscoring.PrCloseReasonMerged}},
+			wantRecorded: true,
+			want:         ProjectActivity{EventID: "e2", ProjectID: 1001, ContributorID: 7, OccurredAt: at, Week: "2025-W23", MergedPR: true},
+		},
+		{
+			name: "pull request closed without merge",
+			event: lbscoring.EventRequest{ID: "e3", UserID: "7", RepositoryID: 1001, Timestamp: at,
+				Payload: lbscoring.PullRequestClosedPayload{CloseReason: lbscoring.PrCloseReasonClosedWithoutMerge}},
+			wantRecorded: true,
+			want:         ProjectActivity{EventID: "e3", ProjectID: 1001, ContributorID: 7, OccurredAt: at, Week: "2025-W23", ClosedUnmergedPR: true},
+		},
+		{
+			name: "issue closed with its opening time",
+			event: lbscoring.EventRequest{ID: "e4", UserID: "7", RepositoryID: 1001, Timestamp: at,
+				Payload: lbscoring.IssueClosedPayload{IssueID: 55, OpenedAt: opened}},
+			wantRecorded: true,
+			want: ProjectActivity{EventID: "e4", ProjectID: 1001, ContributorID: 7, OccurredAt: at, Week: "2025-W23", IssueClosed: true,
+				TimedIssueClose: &IssueClose{IssueID: 55, OpenedAt: opened, ClosedAt: at}},
+		},
+		{
+			name: "issue closed without opened_at is not timed",
+			event: lbscoring.EventRequest{ID: "e5", UserID: "7", RepositoryID: 1001, Timestamp: at,
+				Payload: lbscoring.IssueClosedPayload{IssueID: 56}},
+			wantRecorded: true,
+			want:         ProjectActivity{EventID: "e5", ProjectID: 1001, ContributorID: 7, OccurredAt: at, Week: "2025-W23", IssueClosed: true},
+		},
+		{
+			name: "issue opened after it closed is not timed",
+			event: lbscoring.EventRequest{ID: "e6", UserID: "7", RepositoryID: 1001, Timestamp: at,
+				Payload: lbscoring.IssueClosedPayload{IssueID: 57, OpenedAt: at.Add(time.Hour)}},
+			wantRecorded: true,
+			want:         ProjectActivity{EventID: "e6", ProjectID: 1001, ContributorID: 7, OccurredAt: at, Week: "2025-W23", IssueClosed: true},
+		},
+		{
+			name:         "user ID that is not a contributor",
+			event:        lbscoring.EventRequest{ID: "e7", UserID: "bot", RepositoryID: 1001, Timestamp: at},
+			wantRecorded: true,
+			want:         ProjectActivity{EventID: "e7", ProjectID: 1001, OccurredAt: at, Week: "2025-W23"},
+		},
+		{
+			name:  "event without a project",
+			event: lbscoring.EventRequest{ID: "e8", UserID: "7", Timestamp: at},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeInsightsStore{}
+			svc := NewProjectInsightsService(store, NewValidator(nil))
+
+			recorded, err := svc.RecordEvent(context.Background(), &tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRecorded, recorded)
+
+			if !tt.wantRecorded {
+				assert.Empty(t, store.activities)
+				return
+			}
+			require.Len(t, store.activities, 1)
+			assert.Equal(t, tt.want, store.activities[0])
+		})
+	}
+}
+
+func TestProjectInsightsService_RecordEventOnce(t *testing.T) {
+	store := &fakeInsightsStore{}
+	svc := NewProjectInsightsService(store, NewValidator(nil))
+	event := &lbscoring.EventRequest{ID: "e1", UserID: "7", RepositoryID: 1001, Timestamp: time.Now()}
+
+	recorded, err := svc.RecordEvent(context.Background(), event)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	recorded, err = svc.RecordEvent(context.Background(), event)
+	require.NoError(t, err)
+	assert.False(t, recorded, "a redelivered event is not counted again")
+	assert.Len(t, store.activities, 1)
+}
+
+func TestProjectInsightsService_ScoreSeriesOfADay(t *testing.T) {
+	day := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+	events := []lbscoring.EventRequest{
+		{ID: "e1", UserID: "7", RepositoryID: 1001, EventName: lbscoring.PullRequestOpened.String(), Timestamp: day.Add(9*time.Hour + 5*time.Minute)},
+		{ID: "e2", UserID: "7", RepositoryID: 1001, EventName: lbscoring.CommitPush.String(), Timestamp: day.Add(9*time.Hour + 50*time.Minute)},
+		{ID: "e3", UserID: "8", RepositoryID: 1001, EventName: lbscoring.IssueClosed.String(), Timestamp: day.Add(17 * time.Hour)},
+		{ID: "e4", UserID: "bot", RepositoryID: 1001, EventName: lbscoring.IssueComment.String(), Timestamp: day.Add(17*time.Hour + 30*time.Minute)},
+	}
+	store := &fakeInsightsStore{}
+	svc := NewProjectInsightsService(store, NewValidator(nil))
+
+	// The day is aggregated twice, as when the stream is replayed
+	for run := 0; run < 2; run++ {
+		for _, event := range events {
+			_, err := svc.RecordEvent(context.Background(), &event)
+			require.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, map[seriesBucket]float64{
+		{SeriesContributor, 7, day.Add(9 * time.Hour)}:  8,
+		{SeriesContributor, 8, day.Add(17 * time.Hour)}: 5,
+		{SeriesProject, 1001, day.Add(9 * time.Hour)}:   8,
+		{SeriesProject, 1001, day.Add(17 * time.Hour)}:  11,
+	}, store.series)
+}
+
+func TestProjectInsightsService_RecordEventError(t *testing.T) {
+	store := &fakeInsightsStore{err: errors.New("database down")}
+	svc := NewProjectInsightsService(store, NewValidator(nil))
+
+	recorded, err := svc.RecordEvent(context.Background(), &lbscoring.EventRequest{ID: "e1", RepositoryID: 1001})
+	assert.Error(t, err)
+	assert.False(t, recorded)
+}
@@ -0,0 +1,10 @@
+package leaderboardstat
+
+import "errors"
+
+var (
+	ErrInvalidArguments = errors.New("invalid arguments provided for the request")
+	// ErrContributorHidden is returned for the stats of an anonymous contributor the
+	// viewer may not see, they would link the pseudonym to the user ID
+	ErrContributorHidden = errors.New("contributor not found")
+)
@@ -1,6 +1,10 @@
 package leaderboardstat
 
-import types "github.com/gocasters/rankr/type"
+import (
+	"time"
+
+	types "github.com/gocasters/rankr/type"
+)
 
 type ContributorStatsRequest struct {
 	ContributorID types.ID
@@ -11,6 +15,7 @@ type ContributorStatsResponse struct {
 	TotalScore    float64                   `koanf:"total_score"`
 	ProjectsScore map[types.ID]float64      `koanf:"project_score"`
 	ScoreHistory  map[types.ID][]ScoreEntry `koanf:"score_history"`
+	Analytics     ContributorAnalytics      `koanf:"analytics"`
 }
 
 type ScoresListResponse struct{}
@@ -32,3 +37,54 @@ type LeaderboardFilterRequest struct {
 	Page      int          `koanf:"page"`
 	PageSize  int          `koanf:"page_size"`
 }
+
+// RollupRequest names one weekly, monthly or yearly period to build from the daily scores
+type RollupRequest struct {
+	Timeframe string
+	Period    string
+}
+
+// ContributorPeriodScoresRequest reads the scores of one period, the current one when
+// Period is empty
+type ContributorPeriodScoresRequest struct {
+	ContributorID types.ID
+	Timeframe     string
+	Period        string
+}
+
+// CompareContributorsRequest compares MinComparedContributors to MaxComparedContributors
+// contributors in one period, the current one when Period is empty. Bucket sizes the score
+// history, the default bucket of the timeframe when empty.
+type CompareContributorsRequest struct {
+	ContributorIDs []types.ID
+	Timeframe      string
+	Period         string
+	Bucket         string
+}
+
+// ProjectInsightsRequest reads the health of a project in the last Weeks ISO weeks,
+// DefaultInsightWeeks when zero
+type ProjectInsightsRequest struct {
+	ProjectID types.ID
+	Weeks     int
+}
+
+// CohortRetentionRequest reads the retention of the latest Cohorts weekly or monthly
+// cohorts of a project, of every contributor for project 0. Cohorts is DefaultCohorts when
+// zero.
+type CohortRetentionRequest struct {
+	ProjectID   types.ID
+	Granularity string
+	Cohorts     int
+}
+
+// TimeSeriesRequest reads one metric of a contributor or project in UTC buckets of
+// [From, To). To is now when zero, From DefaultSeriesPoints buckets before To.
+type TimeSeriesRequest struct {
+	Entity   string
+	EntityID types.ID
+	Metric   string
+	Bucket   string
+	From     time.Time
+	To       time.Time
+}
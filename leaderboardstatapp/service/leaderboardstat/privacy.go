@@ -0,0 +1,39 @@
+package leaderboardstat
+
+import (
+	"context"
+
+	"github.com/gocasters/rankr/pkg/privacy"
+	types "github.com/gocasters/rankr/type"
+)
+
+// GetContributorIdentity returns the identity of a contributor for its stats. It fails with
+// ErrContributorHidden for an anonymous contributor the viewer may not see.
+func (s *Service) GetContributorIdentity(ctx context.Context, contributorID types.ID, viewer privacy.Viewer) (privacy.Identity, error) {
+	if s.identityResolver == nil {
+		return privacy.Identity{UserID: contributorID}, nil
+	}
+
+	identity, visible, err := s.identityResolver.Identity(ctx, contributorID, viewer)
+	if err != nil {
+		return privacy.Identity{}, err
+	}
+	if !visible {
+		return privacy.Identity{}, ErrContributorHidden
+	}
+
+	return identity, nil
+}
+
+// getIdentities returns the identities of the users of a board as the viewer may see them
+func (s *Service) getIdentities(ctx context.Context, userIDs []types.ID, viewer privacy.Viewer) map[types.ID]privacy.Identity {
+	if s.identityResolver == nil {
+		identities := make(map[types.ID]privacy.Identity, len(userIDs))
+		for _, userID := range userIDs {
+			identities[userID] = privacy.Identity{UserID: userID}
+		}
+		return identities
+	}
+
+	return s.identityResolver.Identities(ctx, userIDs, viewer)
+}
@@ -0,0 +1,183 @@
+package leaderboardstat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+	types "github.com/gocasters/rankr/type"
+)
+
+// publicLeaderboardTTL keeps a public board cached between the scheduled refreshes
+const publicLeaderboardTTL = 3 * time.Minute
+
+// PublicBoardRefreshConfig tunes the refresh of the public boards from the scoring updates
+type PublicBoardRefreshConfig struct {
+	Enabled bool `koanf:"enabled"`
+	// Debounce is how long a project's board waits for more updates before it is written
+	Debounce time.Duration `koanf:"debounce"`
+	// MaxDelay bounds the wait of a board that keeps receiving updates
+	MaxDelay time.Duration `koanf:"max_delay"`
+	// MaxEventAge skips updates older than this, e.g. when a consumer replays its backlog
+	MaxEventAge time.Duration `koanf:"max_event_age"`
+}
+
+// BoardUpdate is a change of the scores on the daily board of a project
+type BoardUpdate struct {
+	ProjectID types.ID
+	// Period is the day of the board, in the timezone of the project
+	Period string
+	Scores map[int]float64
+	At     time.Time
+}
+
+type pendingBoard struct {
+	// full replaces the whole board instead of applying scores
+	full   bool
+	scores map[int]float64
+	first  time.Time
+	last   time.Time
+}
+
+// PublicBoardRefresher keeps the cached public boards up to date between the scheduled
+// refreshes. Updates are merged per project until the project is quiet for Debounce, then
+// applied to the cached board. A board whose day changed, or that this process has not
+// written yet, is fetched again as a whole.
+type PublicBoardRefresher struct {
+	config PublicBoardRefreshConfig
+	svc    *Service
+
+	mu      sync.Mutex
+	pending map[types.ID]*pendingBoard
+	periods map[types.ID]string
+}
+
+func NewPublicBoardRefresher(config PublicBoardRefreshConfig, svc *Service) *PublicBoardRefresher {
+	if config.Debounce <= 0 {
+		config.Debounce = 2 * time.Second
+	}
+	if config.MaxDelay < config.Debounce {
+		config.MaxDelay = 5 * config.Debounce
+	}
+	if config.MaxEventAge <= 0 {
+		config.MaxEventAge = 5 * time.Minute
+	}
+
+	return &PublicBoardRefresher{
+		config:  config,
+		svc:     svc,
+		pending: make(map[types.ID]*pendingBoard),
+		periods: make(map[types.ID]string),
+	}
+}
+
+// Notify queues an update of a project's board, it returns false for an update that is
+// too old or of an earlier day than the board
+func (r *PublicBoardRefresher) Notify(update BoardUpdate, now time.Time) bool {
+	if now.Sub(update.At) > r.config.MaxEventAge {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	period, known := r.periods[update.ProjectID]
+	if known && update.Period < period {
+		return false
+	}
+
+	board, ok := r.pending[update.ProjectID]
+	if !ok {
+		board = &pendingBoard{scores: make(map[int]float64), first: now}
+		r.pending[update.ProjectID] = board
+	}
+	board.last = now
+
+	if !known || update.Period > period {
+		r.periods[update.ProjectID] = update.Period
+		board.full = true
+		board.scores = make(map[int]float64)
+	}
+	if !board.full {
+		for contributorID, score := range update.Scores {
+			board.scores[contributorID] = score
+		}
+	}
+
+	return true
+}
+
+// Start writes the due boards until ctx is done
+func (r *PublicBoardRefresher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.config.Debounce / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.L().Info("public board refresher stopped by context")
+			return ctx.Err()
+
+		case now := <-ticker.C:
+			r.Flush(ctx, now)
+		}
+	}
+}
+
+// Flush writes the boards quiet for Debounce or waiting for MaxDelay
+func (r *PublicBoardRefresher) Flush(ctx context.Context, now time.Time) {
+	log := logger.L()
+
+	due := make(map[types.ID]*pendingBoard)
+	r.mu.Lock()
+	for projectID, board := range r.pending {
+		if now.Sub(board.last) >= r.config.Debounce || now.Sub(board.first) >= r.config.MaxDelay {
+			due[projectID] = board
+			delete(r.pending, projectID)
+		}
+	}
+	r.mu.Unlock()
+
+	for projectID, board := range due {
+		if err := r.write(ctx, projectID, board); err != nil {
+			log.Error("Failed to refresh public leaderboard",
+				slog.Uint64("project_id", uint64(projectID)),
+				slog.String("error", err.Error()))
+
+			// The next update, or the scheduled refresh, writes the whole board
+			r.mu.Lock()
+			delete(r.periods, projectID)
+			r.mu.Unlock()
+		}
+	}
+}
+
+func (r *PublicBoardRefresher) write(ctx context.Context, projectID types.ID, board *pendingBoard) error {
+	if !board.full {
+		updated, err := r.svc.redisLeaderboardRepo.UpdatePublicLeaderboard(ctx, projectID, board.scores, publicLeaderboardTTL)
+		if err != nil {
+			return fmt.Errorf("failed to update public leaderboard: %w", err)
+		}
+		if updated {
+			return nil
+		}
+		// The board expired, a part of it would hide the other contributors
+	}
+
+	return r.svc.refreshPublicLeaderboard(ctx, strconv.FormatUint(uint64(projectID), 10))
+}
+
+// GetPublicLeaderboardLastUpdated returns when the public board of a project was last
+// written, zero when it is not cached. It lets clients poll without reading the board.
+func (s *Service) GetPublicLeaderboardLastUpdated(ctx context.Context, projectID types.ID) (time.Time, error) {
+	updatedAt, err := s.redisLeaderboardRepo.GetPublicLeaderboardUpdatedAt(ctx, projectID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get public leaderboard update time: %w", err)
+	}
+
+	return updatedAt, nil
+}
@@ -0,0 +1,170 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/cachemanager"
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBoardCache keeps the public boards in memory and records the writes
+type fakeBoardCache struct {
+	RedisLeaderboardRepository
+
+	boards    map[types.ID]map[int]float64
+	sets      []map[int]float64
+	updates   []map[int]float64
+	updateErr error
+}
+
+func (f *fakeBoardCache) SetPublicLeaderboard(_ context.Context, projectID types.ID, userScores map[int]float64, _ time.Duration) error {
+	f.sets = append(f.sets, userScores)
+	f.boards[projectID] = userScores
+
+	return nil
+}
+
+func (f *fakeBoardCache) UpdatePublicLeaderboard(_ context.Context, projectID types.ID, userScores map[int]float64, _ time.Duration) (bool, error) {
+	if f.updateErr != nil {
+		return false, f.updateErr
+	}
+	f.updates = append(f.updates, userScores)
+
+	board, ok := f.boards[projectID]
+	if !ok {
+		return false, nil
+	}
+	for contributorID, score := range userScores {
+		board[contributorID] = score
+	}
+
+	return true, nil
+}
+
+const boardProject = types.ID(1001)
+
+func newTestRefresher() (*PublicBoardRefresher, *fakeBoardCache) {
+	cache := &fakeBoardCache{boards: make(map[types.ID]map[int]float64)}
+	client := &fakeScoringClient{boards: map[string][]lbscoring.LeaderboardRow{
+		"1001": {{Rank: 1, UserID: "7", Score: 40}, {Rank: 2, UserID: "8", Score: 10}},
+	}}
+	svc := NewService(nil, NewValidator(nil), cachemanager.CacheManager{}, cache, client, nil, nil)
+
+	return NewPublicBoardRefresher(PublicBoardRefreshConfig{
+		Debounce:    2 * time.Second,
+		MaxDelay:    10 * time.Second,
+		MaxEventAge: time.Minute,
+	}, &svc), cache
+}
+
+func boardUpdate(period string, at time.Time, scores map[int]float64) BoardUpdate {
+	return BoardUpdate{ProjectID: boardProject, Period: period, Scores: scores, At: at}
+}
+
+func TestPublicBoardRefresher_Notify(t *testing.T) {
+	refresher, _ := newTestRefresher()
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, refresher.Notify(boardUpdate("2025-06-10", now.Add(-2*time.Minute), nil), now), "older than MaxEventAge")
+	assert.True(t, refresher.Notify(boardUpdate("2025-06-10", now, map[int]float64{7: 5}), now))
+	assert.True(t, refresher.Notify(boardUpdate("2025-06-11", now, map[int]float64{7: 1}), now), "a new day")
+	assert.False(t, refresher.Notify(boardUpdate("2025-06-10", now, map[int]float64{7: 6}), now), "an earlier day than the board")
+}
+
+func TestPublicBoardRefresher_Debounce(t *testing.T) {
+	refresher, cache := newTestRefresher()
+	ctx := context.Background()
+	t0 := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	// The first update of a board this process has not written fetches it as a whole
+	require.True(t, refresher.Notify(boardUpdate("2025-06-10", t0, map[int]float64{7: 5}), t0))
+	refresher.Flush(ctx, t0.Add(time.Second))
+	assert.Empty(t, cache.sets, "not quiet for Debounce yet")
+
+	refresher.Flush(ctx, t0.Add(2*time.Second))
+	require.Len(t, cache.sets, 1)
+	assert.Equal(t, map[int]float64{7: 40, 8: 10}, cache.sets[0])
+
+	// Later updates of the same day are merged and applied to the cached board
+	t1 := t0.Add(3 * time.Second)
+	refresher.Notify(boardUpdate("2025-06-10", t1, map[int]float64{7: 45}), t1)
+	refresher.Notify(boardUpdate("2025-06-10", t1, map[int]float64{7: 50, 9: 3}), t1.Add(time.Second))
+
+	refresher.Flush(ctx, t1.Add(2*time.Second))
+	assert.Empty(t, cache.updates, "the second update restarted the wait")
+
+	refresher.Flush(ctx, t1.Add(3*time.Second))
+	require.Len(t, cache.updates, 1)
+	assert.Equal(t, map[int]float64{7: 50, 9: 3}, cache.updates[0])
+	assert.Len(t, cache.sets, 1)
+
+	refresher.Flush(ctx, t1.Add(10*time.Second))
+	assert.Len(t, cache.updates, 1, "a written board is not pending anymore")
+}
+
+func TestPublicBoardRefresher_MaxDelay(t *testing.T) {
+	refresher, cache := newTestRefresher()
+	ctx := context.Background()
+	t0 := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	// A board updated every second is never quiet, MaxDelay bounds its wait
+	for i := 0; i < 10; i++ {
+		now := t0.Add(time.Duration(i) * time.Second)
+		refresher.Notify(boardUpdate("2025-06-10", now, map[int]float64{7: float64(i)}), now)
+		refresher.Flush(ctx, now)
+	}
+	assert.Empty(t, cache.sets)
+
+	refresher.Flush(ctx, t0.Add(10*time.Second))
+	assert.Len(t, cache.sets, 1)
+}
+
+func TestPublicBoardRefresher_ExpiredBoard(t *testing.T) {
+	refresher, cache := newTestRefresher()
+	ctx := context.Background()
+	t0 := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	refresher.Notify(boardUpdate("2025-06-10", t0, nil), t0)
+	refresher.Flush(ctx, t0.Add(2*time.Second))
+	require.Len(t, cache.sets, 1)
+
+	// The cached board expired, the update alone would hide the other contributors
+	delete(cache.boards, boardProject)
+	t1 := t0.Add(time.Minute)
+	refresher.Notify(boardUpdate("2025-06-10", t1, map[int]float64{9: 3}), t1)
+	refresher.Flush(ctx, t1.Add(2*time.Second))
+
+	assert.Len(t, cache.updates, 1)
+	require.Len(t, cache.sets, 2)
+	assert.Equal(t, map[int]float64{7: 40, 8: 10}, cache.sets[1])
+}
+
+func TestPublicBoardRefresher_FailedWriteRefetches(t *testing.T) {
+	refresher, cache := newTestRefresher()
+	ctx := context.Background()
+	t0 := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	refresher.Notify(boardUpdate("2025-06-10", t0, nil), t0)
+	refresher.Flush(ctx, t0.Add(2*time.Second))
+	require.Len(t, cache.sets, 1)
+
+	cache.updateErr = errors.New("redis down")
+	t1 := t0.Add(time.Minute)
+	refresher.Notify(boardUpdate("2025-06-10", t1, map[int]float64{7: 41}), t1)
+	refresher.Flush(ctx, t1.Add(2*time.Second))
+
+	// The failed update is lost, the next update writes the whole board
+	cache.updateErr = nil
+	t2 := t1.Add(time.Minute)
+	refresher.Notify(boardUpdate("2025-06-10", t2, map[int]float64{7: 42}), t2)
+	refresher.Flush(ctx, t2.Add(2*time.Second))
+
+	assert.Empty(t, cache.updates)
+	assert.Len(t, cache.sets, 2)
+}
@@ -0,0 +1,126 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/timettl"
+)
+
+const TimeframeDaily = "daily"
+
+// rollupTimeframes are built from the daily rows of user_project_scores
+var rollupTimeframes = []string{"weekly", "monthly", "yearly"}
+
+// periodTimeframes are the timeframes of user_project_scores with a period
+var periodTimeframes = append([]string{TimeframeDaily}, rollupTimeframes...)
+
+// RollupScores rebuilds the rows of one weekly, monthly or yearly period from the daily
+// rows it covers and returns the number of rows written. The period is replaced as a whole,
+// so it can be rolled up again after its daily rows changed.
+func (s *Service) RollupScores(ctx context.Context, req RollupRequest) (int64, error) {
+	if err := s.validator.ValidateRollup(req); err != nil {
+		return 0, errors.Join(ErrInvalidArguments, err)
+	}
+
+	// Periods are calendar dates, their daily keys compare as text
+	start, err := timettl.StartOfPeriod(req.Timeframe, req.Period, time.UTC)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidArguments, err)
+	}
+	end, err := timettl.EndOfPeriodAt(req.Timeframe, start)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidArguments, err)
+	}
+
+	rows, err := s.repository.RollupUserProjectScores(ctx, req.Timeframe, req.Period, timettl.DayOf(start), timettl.DayOf(end))
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up %s period %s: %w", req.Timeframe, req.Period, err)
+	}
+
+	return rows, nil
+}
+
+// RollupRecentScores rolls up the periods of now and of the day before, which a daily
+// calculation may still have changed
+func (s *Service) RollupRecentScores(ctx context.Context, now time.Time) error {
+	log := logger.L()
+
+	for _, timeframe := range rollupTimeframes {
+		var periods []string
+		for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+			period, err := timettl.PeriodKeyAt(timeframe, day)
+			if err != nil {
+				return err
+			}
+			if len(periods) == 0 || periods[len(periods)-1] != period {
+				periods = append(periods, period)
+			}
+		}
+
+		for _, period := range periods {
+			rows, err := s.RollupScores(ctx, RollupRequest{Timeframe: timeframe, Period: period})
+			if err != nil {
+				return err
+			}
+
+			log.Info("Rolled up user project scores",
+				slog.String("timeframe", timeframe),
+				slog.String("period", period),
+				slog.Int64("rows", rows))
+		}
+	}
+
+	return nil
+}
+
+// GetContributorPeriodScores returns the scores of a contributor in one period per project
+func (s *Service) GetContributorPeriodScores(ctx context.Context, req ContributorPeriodScoresRequest) (ContributorPeriodScores, error) {
+	if err := s.validator.ValidateContributorPeriodScores(req); err != nil {
+		return ContributorPeriodScores{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	period, err := periodOrCurrent(req.Timeframe, req.Period, time.Now())
+	if err != nil {
+		return ContributorPeriodScores{}, err
+	}
+
+	projectsScore, err := s.repository.GetContributorPeriodScores(ctx, req.ContributorID, req.Timeframe, period)
+	if err != nil {
+		return ContributorPeriodScores{}, err
+	}
+
+	scores := ContributorPeriodScores{
+		ContributorID: req.ContributorID,
+		Timeframe:     req.Timeframe,
+		Period:        period,
+		ProjectsScore: projectsScore,
+	}
+	for _, score := range projectsScore {
+		scores.TotalScore += score
+	}
+
+	return scores, nil
+}
+
+// periodOrCurrent checks the key of a period, it returns the key of the period now falls
+// in for an empty one
+func periodOrCurrent(timeframe, period string, now time.Time) (string, error) {
+	if period == "" {
+		current, err := timettl.PeriodKeyAt(timeframe, now)
+		if err != nil {
+			return "", errors.Join(ErrInvalidArguments, err)
+		}
+		return current, nil
+	}
+
+	if _, err := timettl.StartOfPeriod(timeframe, period, time.UTC); err != nil {
+		return "", errors.Join(ErrInvalidArguments, err)
+	}
+
+	return period, nil
+}
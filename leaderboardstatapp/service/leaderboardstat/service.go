@@ -3,16 +3,16 @@ package leaderboardstat
 import (
 	"context"
 	"fmt"
-	"github.com/gocasters/rankr/adapter/leaderboardscoring"
 	"github.com/gocasters/rankr/adapter/project"
 	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
 
 	"github.com/gocasters/rankr/pkg/cachemanager"
 	"github.com/gocasters/rankr/pkg/logger"
+	"github.com/gocasters/rankr/pkg/privacy"
+	"github.com/gocasters/rankr/pkg/timettl"
 	types "github.com/gocasters/rankr/type"
 
 	"log/slog"
-	"math/rand"
 	"sort"
 	"strconv"
 	"time"
@@ -29,11 +29,74 @@ type Repository interface {
 	UpdateUserProjectScores(ctx context.Context, userProjectScores []UserProjectScore) error
 	UpdateGlobalScores(ctx context.Context, userProjectScores []UserProjectScore) error
 	MarkDailyScoresAsProcessed(ctx context.Context, scoreIDs []types.ID) error
+
+	// GetUnattributedContributors returns the contributors with scores on project 0, stored
+	// before the daily aggregation knew projects
+	GetUnattributedContributors(ctx context.Context) ([]types.ID, error)
+	// AttributeContributorScores moves the project 0 scores of a contributor to projectID
+	AttributeContributorScores(ctx context.Context, contributorID, projectID types.ID) error
+
+	// RollupUserProjectScores replaces the rows of a timeframe period with the sums of the
+	// daily rows in [fromDay, toDay), it returns the number of rows written
+	RollupUserProjectScores(ctx context.Context, timeframe, period, fromDay, toDay string) (int64, error)
+	// GetContributorPeriodScores returns the scores of a contributor in a period by project
+	GetContributorPeriodScores(ctx context.Context, contributorID types.ID, timeframe, period string) (map[types.ID]float64, error)
+	// GetPeriodRanks ranks the contributors by their score in a period, contributors
+	// without a score in the period are left out
+	GetPeriodRanks(ctx context.Context, timeframe, period string, contributorIDs []types.ID) (map[types.ID]uint, error)
+
+	// GetContributorPercentiles returns the percentile of a contributor on the global board
+	// and on the board of each of their projects, by the sums of the daily scores
+	GetContributorPercentiles(ctx context.Context, contributorID types.ID) (float64, map[types.ID]float64, error)
+	// GetContributorPointsBetween sums the daily scores of a contributor in the days
+	// [fromDay, toDay)
+	GetContributorPointsBetween(ctx context.Context, contributorID types.ID, fromDay, toDay string) (float64, error)
+	// GetProjectedRank ranks the totals grown by growth times the points of the days
+	// [fromDay, toDay), zero when the contributor has no score
+	GetProjectedRank(ctx context.Context, contributorID types.ID, fromDay, toDay string, growth float64) (uint, error)
+
+	// AssignContributorCohorts stores the first day of scored activity of every contributor
+	// per project and globally, under project 0, and returns the number of assignments
+	// that changed
+	AssignContributorCohorts(ctx context.Context) (int64, error)
+	// RebuildCohortRetention replaces the weekly or monthly retention matrices of every
+	// project and returns the number of cells written
+	RebuildCohortRetention(ctx context.Context, granularity string) (int64, error)
+	// GetCohortRetention returns the cells of the latest cohorts of a project, ordered by
+	// cohort and offset
+	GetCohortRetention(ctx context.Context, projectID types.ID, granularity string, cohorts int) ([]CohortRetentionCell, error)
+
+	// RefreshScoreSeries adds the ranks of the scores stored since the last refresh to their
+	// hourly buckets and returns the number of buckets changed
+	RefreshScoreSeries(ctx context.Context) (int64, error)
+	// GetScoreSeries returns the buckets of an entity with activity in [from, to), the
+	// hourly buckets summed per bucket unit
+	GetScoreSeries(ctx context.Context, entity string, entityID types.ID, bucket string, from, to time.Time) ([]SeriesBucket, error)
 }
 
 type RedisLeaderboardRepository interface {
 	GetPublicLeaderboardPaginated(ctx context.Context, projectID types.ID, page int32, pageSize int32) ([]UserScoreEntry, int64, error)
 	SetPublicLeaderboard(ctx context.Context, projectID types.ID, userScores map[int]float64, ttl time.Duration) error
+	// UpdatePublicLeaderboard sets the scores of some contributors on a cached board, it
+	// returns false without a change when the board is not cached
+	UpdatePublicLeaderboard(ctx context.Context, projectID types.ID, userScores map[int]float64, ttl time.Duration) (bool, error)
+	// GetPublicLeaderboardUpdatedAt returns when a board was last written, zero when it is
+	// not cached
+	GetPublicLeaderboardUpdatedAt(ctx context.Context, projectID types.ID) (time.Time, error)
+}
+
+// LeaderboardScoringClient reads boards, rank histories, streaks and score explanations
+// from leaderboardscoring, *leaderboardscoring.Client implements it
+type LeaderboardScoringClient interface {
+	GetLeaderboard(ctx context.Context, req *lbscoring.GetLeaderboardRequest) (*lbscoring.GetLeaderboardResponse, error)
+	GetUserRankHistory(ctx context.Context, req *lbscoring.GetUserRankHistoryRequest) (*lbscoring.GetUserRankHistoryResponse, error)
+	GetUserStreak(ctx context.Context, req lbscoring.GetUserStreakRequest) (lbscoring.Streak, error)
+	ExplainScore(ctx context.Context, req lbscoring.ExplainScoreRequest) (lbscoring.ScoreExplanation, error)
+}
+
+// ProjectClient lists the projects of the project service, *project.Client implements it
+type ProjectClient interface {
+	ListProjects(ctx context.Context, req *project.ListProjectsRequest) (*project.ListProjectsResponse, error)
 }
 
 type Service struct {
@@ -41,11 +104,14 @@ type Service struct {
 	validator            Validator
 	cacheManager         cachemanager.CacheManager
 	redisLeaderboardRepo RedisLeaderboardRepository
-	lbScoringClient      *leaderboardscoring.Client
-	projectClient        *project.Client
+	lbScoringClient      LeaderboardScoringClient
+	projectClient        ProjectClient
+	identityResolver     *privacy.IdentityResolver
 }
 
-func NewService(repo Repository, validator Validator, cacheManger cachemanager.CacheManager, redisLeaderboardRepo RedisLeaderboardRepository, lbClient *leaderboardscoring.Client, projectClient *project.Client) Service {
+// NewService returns the stat service. Without an identity resolver, e.g. in the scheduler,
+// contributors are shown by user ID only.
+func NewService(repo Repository, validator Validator, cacheManger cachemanager.CacheManager, redisLeaderboardRepo RedisLeaderboardRepository, lbClient LeaderboardScoringClient, projectClient ProjectClient, identityResolver *privacy.IdentityResolver) Service {
 	return Service{
 		repository:           repo,
 		validator:            validator,
@@ -53,9 +119,13 @@ func NewService(repo Repository, validator Validator, cacheManger cachemanager.C
 		redisLeaderboardRepo: redisLeaderboardRepo,
 		lbScoringClient:      lbClient,
 		projectClient:        projectClient,
+		identityResolver:     identityResolver,
 	}
 }
 
+// GetDailyContributorScores stores the daily board of every project as the scores of the
+// day. The global totals are the sums of these project scores, points scored in a
+// repository the project service does not list are not counted.
 func (s *Service) GetDailyContributorScores(ctx context.Context) error {
 	log := logger.L()
 	log.Info("Starting daily contributor scores calculation")
@@ -64,37 +134,35 @@ func (s *Service) GetDailyContributorScores(ctx context.Context) error {
 		return fmt.Errorf("leaderboardscoring client is not initialized")
 	}
 
-	var allDailyScores []DailyContributorScore
-	pageSize := int32(10) // TODO- Adjust based on what the service can handle
-	offset := int32(0)
+	if s.projectClient == nil {
+		return fmt.Errorf("project client is not initialized")
+	}
 
-	for {
-		// Get daily leaderboard from LeaderboardScoring service
-		getLeaderboardReq := &lbscoring.GetLeaderboardRequest{
-			Timeframe: "daily", // TODO - set proper timestamp
-			PageSize:  pageSize,
-			Offset:    offset,
+	projects, err := s.listProjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	var allDailyScores []DailyContributorScore
+	for _, proj := range projects {
+		projectID, ok := projectBoardID(proj)
+		if !ok {
+			continue
 		}
 
-		leaderboardRes, err := s.lbScoringClient.GetLeaderboard(ctx, getLeaderboardReq)
+		fetchedAt := time.Now().UTC()
+		rows, err := s.getBoardRows(ctx, "daily", proj.GitRepoID, 100*time.Millisecond)
 		if err != nil {
-			return fmt.Errorf("failed to get leaderboard data at offset %d: %w", offset, err)
+			// Nothing is stored yet, the whole day can be calculated again
+			return fmt.Errorf("failed to get daily leaderboard of project %s: %w", proj.GitRepoID, err)
 		}
 
-		log.Info("Retrieved leaderboard data",
-			slog.Int("row_count", len(leaderboardRes.LeaderboardRows)),
-			slog.String("timeframe", string(leaderboardRes.Timeframe)),
+		log.Info("Retrieved daily leaderboard of project",
+			slog.String("git_repo_id", proj.GitRepoID),
+			slog.Int("row_count", len(rows)),
 		)
 
-		var dailyScores []DailyContributorScore
-
-		for _, row := range leaderboardRes.LeaderboardRows {
-			//contributorID, err := s.mapUserIDToContributorID(ctx, row.UserID)
-			log.Info("getLeaderboard row:",
-				slog.String("user_id:", row.UserID),
-				slog.String("score:", strconv.Itoa(int(row.Score))),
-				slog.String("rank:", strconv.Itoa(int(row.Rank))),
-			)
+		for _, row := range rows {
 			contributorID, err := strconv.Atoi(row.UserID)
 			if err != nil {
 				log.Warn("Failed to map user ID to contributor ID",
@@ -104,26 +172,17 @@ func (s *Service) GetDailyContributorScores(ctx context.Context) error {
 
 				continue
 			}
-			randPrjRand := rand.Intn(5)
-			dailyScore := DailyContributorScore{
+
+			allDailyScores = append(allDailyScores, DailyContributorScore{
 				ContributorID: types.ID(contributorID),
 				UserID:        row.UserID,
 				Score:         float64(row.Score), // TODO - define is score data type float or int
-				Rank:          row.Rank,           // TODO
-				Timeframe:     string(leaderboardRes.Timeframe),
-				ProjectID:     types.ID(randPrjRand), // TODO add project_id to row response
-			}
-			dailyScores = append(dailyScores, dailyScore)
-		}
-
-		allDailyScores = append(allDailyScores, dailyScores...)
-		if len(leaderboardRes.LeaderboardRows) < int(pageSize) {
-			break
+				Rank:          row.Rank,
+				Timeframe:     "daily",
+				ProjectID:     projectID,
+				EarnedAt:      fetchedAt,
+			})
 		}
-
-		offset += pageSize
-
-		time.Sleep(100 * time.Millisecond)
 	}
 
 	if len(allDailyScores) == 0 {
@@ -145,12 +204,186 @@ func (s *Service) GetDailyContributorScores(ctx context.Context) error {
 	//}
 
 	log.Info("Successfully calculated and stored daily contributor scores",
+		slog.Int("projects", len(projects)),
 		slog.Int("processed_count", len(allDailyScores)),
 	)
 
 	return nil
 }
 
+// BackfillProjectAttribution moves the scores stored before the daily aggregation knew
+// projects to their project. Only a contributor whose all-time points all come from one
+// listed project can be attributed, the scores of everyone else stay on project 0.
+func (s *Service) BackfillProjectAttribution(ctx context.Context) (int, error) {
+	log := logger.L()
+
+	if s.lbScoringClient == nil {
+		return 0, fmt.Errorf("leaderboardscoring client is not initialized")
+	}
+
+	if s.projectClient == nil {
+		return 0, fmt.Errorf("project client is not initialized")
+	}
+
+	contributorIDs, err := s.repository.GetUnattributedContributors(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unattributed contributors: %w", err)
+	}
+	if len(contributorIDs) == 0 {
+		return 0, nil
+	}
+
+	globalRows, err := s.getBoardRows(ctx, "all_time", "", 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get global all-time leaderboard: %w", err)
+	}
+	globalScores := make(map[string]int64, len(globalRows))
+	for _, row := range globalRows {
+		globalScores[row.UserID] = row.Score
+	}
+
+	projects, err := s.listProjects(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// userID -> the projects whose all-time board lists the contributor
+	userProjects := make(map[string][]types.ID)
+	projectScores := make(map[string]int64)
+	for _, proj := range projects {
+		projectID, ok := projectBoardID(proj)
+		if !ok {
+			continue
+		}
+
+		rows, err := s.getBoardRows(ctx, "all_time", proj.GitRepoID, 0)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get all-time leaderboard of project %s: %w", proj.GitRepoID, err)
+		}
+
+		for _, row := range rows {
+			userProjects[row.UserID] = append(userProjects[row.UserID], projectID)
+			projectScores[row.UserID] = row.Score
+		}
+	}
+
+	attributed := 0
+	for _, contributorID := range contributorIDs {
+		userID := strconv.FormatUint(uint64(contributorID), 10)
+
+		// Points of an unlisted repository would make the global score larger
+		if len(userProjects[userID]) != 1 || projectScores[userID] != globalScores[userID] {
+			continue
+		}
+
+		projectID := userProjects[userID][0]
+		if err := s.repository.AttributeContributorScores(ctx, contributorID, projectID); err != nil {
+			return attributed, fmt.Errorf("failed to attribute scores of contributor %d: %w", contributorID, err)
+		}
+		attributed++
+	}
+
+	log.Info("Backfilled project attribution",
+		slog.Int("unattributed", len(contributorIDs)),
+		slog.Int("attributed", attributed),
+	)
+
+	return attributed, nil
+}
+
+// listProjects returns every project of the project service
+func (s *Service) listProjects(ctx context.Context) ([]project.ProjectItem, error) {
+	log := logger.L()
+
+	var allProjects []project.ProjectItem
+	projectPageSize := int32(100)
+	projectOffset := int32(0)
+
+	for {
+		projectsRes, err := s.projectClient.ListProjects(ctx, &project.ListProjectsRequest{
+			PageSize: projectPageSize,
+			Offset:   projectOffset,
+		})
+		if err != nil {
+			log.Error("Failed to fetch projects from project service",
+				slog.Int("offset", int(projectOffset)),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("failed to fetch projects at offset %d: %w", projectOffset, err)
+		}
+
+		if len(projectsRes.Projects) == 0 {
+			break
+		}
+
+		allProjects = append(allProjects, projectsRes.Projects...)
+
+		if int32(len(projectsRes.Projects)) < projectPageSize {
+			break
+		}
+
+		projectOffset += projectPageSize
+	}
+
+	return allProjects, nil
+}
+
+// projectBoardID returns the ID the boards of a project are kept under, its git repository ID
+func projectBoardID(proj project.ProjectItem) (types.ID, bool) {
+	log := logger.L()
+
+	if proj.GitRepoID == "" {
+		log.Warn("Project has no git_repo_id, skipping",
+			slog.String("project_id", proj.ProjectID),
+			slog.String("name", proj.Name))
+		return 0, false
+	}
+
+	gitRepoID, err := strconv.ParseUint(proj.GitRepoID, 10, 64)
+	if err != nil {
+		log.Error("Failed to parse git_repo_id as uint64",
+			slog.String("git_repo_id", proj.GitRepoID),
+			slog.String("error", err.Error()))
+		return 0, false
+	}
+
+	return types.ID(gitRepoID), true
+}
+
+// getBoardRows pages through a whole board of leaderboardscoring, the global board when
+// gitRepoID is empty. pause is waited between two pages.
+func (s *Service) getBoardRows(ctx context.Context, timeframe, gitRepoID string, pause time.Duration) ([]lbscoring.LeaderboardRow, error) {
+	var rows []lbscoring.LeaderboardRow
+	pageSize := int32(100)
+	offset := int32(0)
+
+	for {
+		getLeaderboardReq := &lbscoring.GetLeaderboardRequest{
+			Timeframe: timeframe,
+			PageSize:  pageSize,
+			Offset:    offset,
+		}
+		if gitRepoID != "" {
+			getLeaderboardReq.ProjectID = &gitRepoID
+		}
+
+		leaderboardRes, err := s.lbScoringClient.GetLeaderboard(ctx, getLeaderboardReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get leaderboard data at offset %d: %w", offset, err)
+		}
+
+		rows = append(rows, leaderboardRes.LeaderboardRows...)
+		if len(leaderboardRes.LeaderboardRows) < int(pageSize) {
+			return rows, nil
+		}
+
+		offset += pageSize
+
+		if pause > 0 {
+			time.Sleep(pause)
+		}
+	}
+}
+
 func (s *Service) processDailyScoreCalculations(ctx context.Context, dailyScores []DailyContributorScore) error {
 	log := logger.L()
 	log.Info("Starting background processing of daily score calculations")
@@ -219,27 +452,38 @@ func (s *Service) calculateUserProjectScores(ctx context.Context, dailyScores []
 	// TODO - Implement project mapping logic
 	sortedUserProjects := s.sortDailyScores(dailyScores)
 
-	// to store sums: userID -> projectID -> total score
-	userProjectSums := make(map[types.ID]map[types.ID]float64)
+	type scoreKey struct {
+		contributorID types.ID
+		projectID     types.ID
+		day           string
+	}
+
+	// to store sums per contributor, project and the UTC day the points were earned
+	sums := make(map[scoreKey]float64)
+	var keys []scoreKey
 
 	for _, score := range sortedUserProjects {
-		if userProjectSums[score.ContributorID] == nil {
-			userProjectSums[score.ContributorID] = make(map[types.ID]float64)
+		earnedAt := score.EarnedAt
+		if earnedAt.IsZero() {
+			earnedAt = time.Now()
 		}
-		userProjectSums[score.ContributorID][score.ProjectID] += score.Score
-	}
 
-	var userProjectScores []UserProjectScore
-	for contributorID, projectMap := range userProjectSums {
-		for projectID, totalScore := range projectMap {
-			userProjectScores = append(userProjectScores, UserProjectScore{
-				ContributorID: contributorID, // TODO-  Convert string to types.ID if needed
-				ProjectID:     projectID,
-				Score:         totalScore,
-				Timeframe:     "daily",
-				TimeValue:     fmt.Sprintf("%d/%d/%d", time.Now().Year(), time.Now().Month(), time.Now().Day()),
-			})
+		key := scoreKey{contributorID: score.ContributorID, projectID: score.ProjectID, day: timettl.DayOf(earnedAt.UTC())}
+		if _, ok := sums[key]; !ok {
+			keys = append(keys, key)
 		}
+		sums[key] += score.Score
+	}
+
+	userProjectScores := make([]UserProjectScore, 0, len(keys))
+	for _, key := range keys {
+		userProjectScores = append(userProjectScores, UserProjectScore{
+			ContributorID: key.contributorID,
+			ProjectID:     key.projectID,
+			Score:         sums[key],
+			Timeframe:     TimeframeDaily,
+			TimeValue:     key.day,
+		})
 	}
 
 	return userProjectScores, nil
@@ -320,10 +564,37 @@ func (s *Service) GetContributorStats(ctx context.Context, contributorID types.I
 		TotalScore:    totalScore,
 		ProjectsScore: projectsScore,
 		ScoreHistory:  scoreHistory,
+		Streak:        s.getContributorStreak(ctx, contributorID),
+		Analytics:     s.getContributorAnalytics(ctx, contributorID),
 	}
 	return stats, nil
 }
 
+// getContributorStreak reads the streak from leaderboardscoring. The stats are served
+// without it when the service cannot be reached.
+func (s *Service) getContributorStreak(ctx context.Context, contributorID types.ID) ContributorStreak {
+	if s.lbScoringClient == nil {
+		return ContributorStreak{}
+	}
+
+	streak, err := s.lbScoringClient.GetUserStreak(ctx, lbscoring.GetUserStreakRequest{
+		UserID: strconv.FormatUint(uint64(contributorID), 10),
+	})
+	if err != nil {
+		logger.L().Warn("failed to get contributor streak",
+			slog.Uint64("contributor_id", uint64(contributorID)),
+			slog.String("error", err.Error()))
+		return ContributorStreak{}
+	}
+
+	return ContributorStreak{
+		Current:       streak.Current,
+		Longest:       streak.Longest,
+		LastActiveDay: streak.LastActiveDay,
+		Timezone:      streak.Timezone,
+	}
+}
+
 /*func (s *Service) GetContributorTotalStats(ctx context.Context, contributorID types.ID) (ContributorTotalStats, error) {
 	cacheKey := fmt.Sprintf("contributor:%d:total_stats", contributorID)
 	cached, err := s.cacheManager.Get(ctx, cacheKey)
@@ -383,10 +654,19 @@ func convertProtoProjectsScore(protoScores map[uint64]float64) map[types.ID]floa
 	return projectsScore
 }
 
-func (s *Service) GetPublicLeaderboard(ctx context.Context, projectID types.ID, pageSize int32, page int32) (ProjectScoreList, error) {
+// GetPublicLeaderboard returns a page of the cached board of a project. Anonymous
+// contributors keep their rank, their identity is masked unless the viewer may see it.
+func (s *Service) GetPublicLeaderboard(ctx context.Context, projectID types.ID, pageSize int32, page int32, viewer privacy.Viewer) (ProjectScoreList, error) {
 	log := logger.L()
 	log.Info("GetPublicLeaderboard called")
 
+	// Read before the board, a write in between then leaves the page newer than its time
+	// and a poll gets it again instead of missing it
+	lastUpdated, err := s.GetPublicLeaderboardLastUpdated(ctx, projectID)
+	if err != nil {
+		return ProjectScoreList{}, err
+	}
+
 	userScoreEntries, total, err := s.redisLeaderboardRepo.GetPublicLeaderboardPaginated(ctx, projectID, page, pageSize)
 	if err != nil {
 		log.Error("Failed to get public leaderboard",
@@ -400,12 +680,20 @@ func (s *Service) GetPublicLeaderboard(ctx context.Context, projectID types.ID,
 	}
 	startRank := (page-1)*pageSize + 1
 
+	userIDs := make([]types.ID, 0, len(userScoreEntries))
+	for _, entry := range userScoreEntries {
+		userIDs = append(userIDs, types.ID(entry.UserID))
+	}
+	identities := s.getIdentities(ctx, userIDs, viewer)
+
 	var userScoreList []UserScore
 	for i, entry := range userScoreEntries {
+		identity := identities[types.ID(entry.UserID)]
 		userScoreList = append(userScoreList, UserScore{
-			ContributorID: types.ID(entry.UserID),
+			ContributorID: identity.UserID,
 			Score:         entry.Score,
 			Rank:          uint64(startRank + int32(i)),
+			Identity:      identity,
 		})
 	}
 
@@ -414,13 +702,22 @@ func (s *Service) GetPublicLeaderboard(ctx context.Context, projectID types.ID,
 		totalPages = int32((total + int64(pageSize) - 1) / int64(pageSize))
 	}
 
+	modifiedAt := lastUpdated
+	if s.identityResolver != nil {
+		if changedAt := s.identityResolver.ChangedAt(userIDs); changedAt.After(modifiedAt) {
+			modifiedAt = changedAt
+		}
+	}
+
 	return ProjectScoreList{
-		ProjectID:  projectID,
-		UsersScore: userScoreList,
-		Total:      uint64(total),
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
+		ProjectID:   projectID,
+		UsersScore:  userScoreList,
+		Total:       uint64(total),
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  totalPages,
+		LastUpdated: lastUpdated,
+		ModifiedAt:  modifiedAt,
 	}, nil
 }
 
@@ -436,33 +733,9 @@ func (s *Service) SetPublicLeaderboard(ctx context.Context) error {
 		return fmt.Errorf("project client is not initialized")
 	}
 
-	var allProjects []project.ProjectItem
-	projectPageSize := int32(100)
-	projectOffset := int32(0)
-
-	for {
-		projectsRes, err := s.projectClient.ListProjects(ctx, &project.ListProjectsRequest{
-			PageSize: projectPageSize,
-			Offset:   projectOffset,
-		})
-		if err != nil {
-			log.Error("Failed to fetch projects from project service",
-				slog.Int("offset", int(projectOffset)),
-				slog.String("error", err.Error()))
-			return fmt.Errorf("failed to fetch projects at offset %d: %w", projectOffset, err)
-		}
-
-		if len(projectsRes.Projects) == 0 {
-			break
-		}
-
-		allProjects = append(allProjects, projectsRes.Projects...)
-
-		if int32(len(projectsRes.Projects)) < projectPageSize {
-			break
-		}
-
-		projectOffset += projectPageSize
+	allProjects, err := s.listProjects(ctx)
+	if err != nil {
+		return err
 	}
 
 	if len(allProjects) == 0 {
@@ -472,8 +745,6 @@ func (s *Service) SetPublicLeaderboard(ctx context.Context) error {
 
 	log.Info("Fetched projects from project service", slog.Int("count", len(allProjects)))
 
-	ttl := 3 * time.Minute
-
 	for _, proj := range allProjects {
 		if proj.GitRepoID == "" {
 			log.Warn("Project has no git_repo_id, skipping",
@@ -487,74 +758,58 @@ func (s *Service) SetPublicLeaderboard(ctx context.Context) error {
 			slog.String("git_repo_id", proj.GitRepoID),
 			slog.String("name", proj.Name))
 
-		userScores := make(map[int]float64)
-
-		pageSize := int32(100)
-		offset := int32(0)
-
-		for {
-			getLeaderboardReq := &lbscoring.GetLeaderboardRequest{
-				Timeframe: "daily",
-				ProjectID: &proj.GitRepoID,
-				PageSize:  pageSize,
-				Offset:    offset,
-			}
+		if err := s.refreshPublicLeaderboard(ctx, proj.GitRepoID); err != nil {
+			log.Error("Failed to update public leaderboard for project",
+				slog.String("git_repo_id", proj.GitRepoID),
+				slog.String("name", proj.Name),
+				slog.String("error", err.Error()))
+		}
+	}
 
-			leaderboardRes, err := s.lbScoringClient.GetLeaderboard(ctx, getLeaderboardReq)
-			if err != nil {
-				log.Error("Failed to get leaderboard data for project",
-					slog.String("git_repo_id", proj.GitRepoID),
-					slog.String("error", err.Error()))
-				break
-			}
+	log.Info("Public leaderboard updated successfully")
+	return nil
+}
 
-			for _, row := range leaderboardRes.LeaderboardRows {
-				contributorID, err := strconv.Atoi(row.UserID)
-				if err != nil {
-					log.Warn("Failed to convert user ID",
-						slog.String("user_id", row.UserID))
-					continue
-				}
+// refreshPublicLeaderboard replaces the cached public board of a project with its current
+// daily board
+func (s *Service) refreshPublicLeaderboard(ctx context.Context, gitRepoID string) error {
+	log := logger.L()
 
-				userScores[contributorID] = float64(row.Score)
-			}
+	projectID, err := strconv.ParseUint(gitRepoID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse git_repo_id %s as uint64: %w", gitRepoID, err)
+	}
 
-			if len(leaderboardRes.LeaderboardRows) < int(pageSize) {
-				break
-			}
+	rows, err := s.getBoardRows(ctx, "daily", gitRepoID, 0)
+	if err != nil {
+		return err
+	}
 
-			offset += pageSize
+	userScores := make(map[int]float64, len(rows))
+	for _, row := range rows {
+		contributorID, err := strconv.Atoi(row.UserID)
+		if err != nil {
+			log.Warn("Failed to convert user ID",
+				slog.String("user_id", row.UserID))
+			continue
 		}
 
-		if len(userScores) > 0 {
-			gitRepoIDInt, parseErr := strconv.ParseUint(proj.GitRepoID, 10, 64)
-			if parseErr != nil {
-				log.Error("Failed to parse git_repo_id as uint64",
-					slog.String("git_repo_id", proj.GitRepoID),
-					slog.String("error", parseErr.Error()))
-				continue
-			}
+		userScores[contributorID] = float64(row.Score)
+	}
 
-			err := s.redisLeaderboardRepo.SetPublicLeaderboard(ctx, types.ID(gitRepoIDInt), userScores, ttl)
-			if err != nil {
-				log.Error("Failed to set public leaderboard in Redis",
-					slog.String("git_repo_id", proj.GitRepoID),
-					slog.String("error", err.Error()))
-				continue
-			}
+	if len(userScores) == 0 {
+		log.Warn("No scores found for project", slog.String("git_repo_id", gitRepoID))
+		return nil
+	}
 
-			log.Info("Updated public leaderboard for project",
-				slog.String("git_repo_id", proj.GitRepoID),
-				slog.String("name", proj.Name),
-				slog.Int("total_contributors", len(userScores)),
-				slog.String("ttl", ttl.String()))
-		} else {
-			log.Warn("No scores found for project",
-				slog.String("git_repo_id", proj.GitRepoID),
-				slog.String("name", proj.Name))
-		}
+	if err := s.redisLeaderboardRepo.SetPublicLeaderboard(ctx, types.ID(projectID), userScores, publicLeaderboardTTL); err != nil {
+		return fmt.Errorf("failed to set public leaderboard in Redis: %w", err)
 	}
 
-	log.Info("Public leaderboard updated successfully")
+	log.Info("Updated public leaderboard for project",
+		slog.String("git_repo_id", gitRepoID),
+		slog.Int("total_contributors", len(userScores)),
+		slog.String("ttl", publicLeaderboardTTL.String()))
+
 	return nil
 }
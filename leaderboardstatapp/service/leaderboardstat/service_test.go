@@ -0,0 +1,250 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/adapter/project"
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/cachemanager"
+	"github.com/gocasters/rankr/pkg/logger"
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// the logger only accepts relative paths, so it writes below a scratch working directory
+	dir, err := os.MkdirTemp("", "leaderboardstat-test")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	if err := logger.Init(logger.Config{Level: "error", FilePath: "logs/test.log"}); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// fakeRepository implements the calls a test makes, the embedded nil Repository panics on
+// the others
+type fakeRepository struct {
+	Repository
+
+	unattributed []types.ID
+	attributed   map[types.ID]types.ID
+}
+
+func (f *fakeRepository) GetUnattributedContributors(context.Context) ([]types.ID, error) {
+	return f.unattributed, nil
+}
+
+func (f *fakeRepository) AttributeContributorScores(_ context.Context, contributorID, projectID types.ID) error {
+	if f.attributed == nil {
+		f.attributed = make(map[types.ID]types.ID)
+	}
+	f.attributed[contributorID] = projectID
+
+	return nil
+}
+
+// fakeScoringClient serves boards by project, "" is the global board, and records the
+// requests
+type fakeScoringClient struct {
+	LeaderboardScoringClient
+
+	boards   map[string][]lbscoring.LeaderboardRow
+	err      error
+	requests []lbscoring.GetLeaderboardRequest
+}
+
+func (f *fakeScoringClient) GetLeaderboard(_ context.Context, req *lbscoring.GetLeaderboardRequest) (*lbscoring.GetLeaderboardResponse, error) {
+	f.requests = append(f.requests, *req)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	projectID := ""
+	if req.ProjectID != nil {
+		projectID = *req.ProjectID
+	}
+
+	rows := f.boards[projectID]
+	start, end := min(int(req.Offset), len(rows)), min(int(req.Offset+req.PageSize), len(rows))
+
+	return &lbscoring.GetLeaderboardResponse{Timeframe: req.Timeframe, LeaderboardRows: rows[start:end]}, nil
+}
+
+type fakeProjectClient struct {
+	projects []project.ProjectItem
+}
+
+func (f *fakeProjectClient) ListProjects(_ context.Context, req *project.ListProjectsRequest) (*project.ListProjectsResponse, error) {
+	start, end := min(int(req.Offset), len(f.projects)), min(int(req.Offset+req.PageSize), len(f.projects))
+
+	return &project.ListProjectsResponse{Projects: f.projects[start:end], TotalCount: int32(len(f.projects))}, nil
+}
+
+func newTestService(repo Repository, scoring LeaderboardScoringClient, projects ProjectClient) Service {
+	return NewService(repo, NewValidator(nil), cachemanager.CacheManager{}, nil, scoring, projects, nil)
+}
+
+func boardRows(n int) []lbscoring.LeaderboardRow {
+	rows := make([]lbscoring.LeaderboardRow, n)
+	for i := range rows {
+		rows[i] = lbscoring.LeaderboardRow{Rank: int64(i + 1), UserID: fmt.Sprint(i + 1), Score: int64(1000 - i)}
+	}
+
+	return rows
+}
+
+func TestProjectBoardID(t *testing.T) {
+	tests := []struct {
+		name   string
+		proj   project.ProjectItem
+		want   types.ID
+		wantOK bool
+	}{
+		{name: "git repository ID", proj: project.ProjectItem{ProjectID: "p1", GitRepoID: "1001"}, want: 1001, wantOK: true},
+		{name: "no git repository ID", proj: project.ProjectItem{ProjectID: "p2"}},
+		{name: "not a number", proj: project.ProjectItem{ProjectID: "p3", GitRepoID: "rankr"}},
+		{name: "negative", proj: project.ProjectItem{ProjectID: "p4", GitRepoID: "-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := projectBoardID(tt.proj)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_GetBoardRows(t *testing.T) {
+	tests := []struct {
+		name      string
+		gitRepoID string
+		rows      int
+		wantPages int
+	}{
+		{name: "empty board", rows: 0, wantPages: 1},
+		{name: "one partial page", rows: 42, wantPages: 1},
+		{name: "full last page needs an empty one", gitRepoID: "1001", rows: 200, wantPages: 3},
+		{name: "several pages", gitRepoID: "1001", rows: 250, wantPages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeScoringClient{boards: map[string][]lbscoring.LeaderboardRow{tt.gitRepoID: boardRows(tt.rows)}}
+			svc := newTestService(nil, client, nil)
+
+			rows, err := svc.getBoardRows(context.Background(), "daily", tt.gitRepoID, 0)
+			require.NoError(t, err)
+			assert.Len(t, rows, tt.rows)
+			require.Len(t, client.requests, tt.wantPages)
+
+			for i, req := range client.requests {
+				assert.Equal(t, "daily", req.Timeframe)
+				assert.Equal(t, int32(i*100), req.Offset)
+				if tt.gitRepoID == "" {
+					assert.Nil(t, req.ProjectID, "the global board has no project")
+				} else {
+					require.NotNil(t, req.ProjectID)
+					assert.Equal(t, tt.gitRepoID, *req.ProjectID)
+				}
+			}
+		})
+	}
+}
+
+func TestService_GetBoardRowsError(t *testing.T) {
+	client := &fakeScoringClient{err: errors.New("unavailable")}
+	svc := newTestService(nil, client, nil)
+
+	_, err := svc.getBoardRows(context.Background(), "daily", "1001", 0)
+	assert.Error(t, err)
+}
+
+func TestService_BackfillProjectAttribution(t *testing.T) {
+	client := &fakeScoringClient{boards: map[string][]lbscoring.LeaderboardRow{
+		"": {
+			{Rank: 1, UserID: "1", Score: 50},
+			{Rank: 2, UserID: "2", Score: 30},
+			{Rank: 3, UserID: "3", Score: 20},
+			{Rank: 4, UserID: "4", Score: 10},
+		},
+		"1001": {
+			{Rank: 1, UserID: "1", Score: 50},
+			{Rank: 2, UserID: "2", Score: 10},
+		},
+		"1002": {
+			{Rank: 1, UserID: "2", Score: 20},
+			{Rank: 2, UserID: "3", Score: 15},
+		},
+	}}
+	projects := &fakeProjectClient{projects: []project.ProjectItem{
+		{ProjectID: "p1", GitRepoID: "1001"},
+		{ProjectID: "p2", GitRepoID: "1002"},
+		{ProjectID: "p3"},
+	}}
+	repo := &fakeRepository{unattributed: []types.ID{1, 2, 3, 4, 5}}
+	svc := newTestService(repo, client, projects)
+
+	attributed, err := svc.BackfillProjectAttribution(context.Background())
+	require.NoError(t, err)
+
+	// 2 scored on two projects, 3 in an unlisted repository too, 4 on no listed project
+	// and 5 on no board at all
+	assert.Equal(t, 1, attributed)
+	assert.Equal(t, map[types.ID]types.ID{1: 1001}, repo.attributed)
+}
+
+func TestService_BackfillProjectAttributionNothingToDo(t *testing.T) {
+	client := &fakeScoringClient{}
+	svc := newTestService(&fakeRepository{}, client, &fakeProjectClient{})
+
+	attributed, err := svc.BackfillProjectAttribution(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, attributed)
+	assert.Empty(t, client.requests, "no board is read without unattributed contributors")
+}
+
+func TestService_BackfillProjectAttributionWithoutClients(t *testing.T) {
+	repo := &fakeRepository{unattributed: []types.ID{1}}
+
+	withoutScoring := newTestService(repo, nil, &fakeProjectClient{})
+	_, err := withoutScoring.BackfillProjectAttribution(context.Background())
+	assert.Error(t, err)
+
+	withoutProjects := newTestService(repo, &fakeScoringClient{}, nil)
+	_, err = withoutProjects.BackfillProjectAttribution(context.Background())
+	assert.Error(t, err)
+}
+
+func TestService_CalculateUserProjectScores(t *testing.T) {
+	svc := newTestService(nil, nil, nil)
+	tehran := time.FixedZone("UTC+3:30", 3*3600+1800)
+
+	scores, err := svc.calculateUserProjectScores(context.Background(), []DailyContributorScore{
+		{ID: 1, ContributorID: 8, ProjectID: 1001, Score: 40, EarnedAt: time.Date(2025, 6, 14, 23, 30, 0, 0, time.UTC)},
+		{ID: 2, ContributorID: 8, ProjectID: 1001, Score: 5, EarnedAt: time.Date(2025, 6, 15, 1, 0, 0, 0, time.UTC)},
+		{ID: 3, ContributorID: 8, ProjectID: 1001, Score: 7, EarnedAt: time.Date(2025, 6, 15, 2, 0, 0, 0, tehran)},
+		{ID: 4, ContributorID: 9, ProjectID: 1002, Score: 12, EarnedAt: time.Date(2025, 6, 15, 2, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []UserProjectScore{
+		{ContributorID: 8, ProjectID: 1001, Score: 47, Timeframe: TimeframeDaily, TimeValue: "2025-06-14"},
+		{ContributorID: 8, ProjectID: 1001, Score: 5, Timeframe: TimeframeDaily, TimeValue: "2025-06-15"},
+		{ContributorID: 9, ProjectID: 1002, Score: 12, Timeframe: TimeframeDaily, TimeValue: "2025-06-15"},
+	}, scores, "scores are filed under the UTC day they were earned, not the day of the run")
+}
@@ -0,0 +1,146 @@
+package leaderboardstat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	SeriesContributor = "contributor"
+	SeriesProject     = "project"
+)
+
+const (
+	MetricScore  = "score"
+	MetricEvents = "events"
+	MetricRank   = "rank"
+)
+
+const (
+	BucketHour  = "hour"
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+const (
+	// DefaultSeriesPoints is the number of buckets up to To when no From is given
+	DefaultSeriesPoints = 30
+	MaxSeriesPoints     = 1000
+)
+
+var (
+	seriesEntities = []string{SeriesContributor, SeriesProject}
+	seriesMetrics  = []string{MetricScore, MetricEvents, MetricRank}
+	seriesBuckets  = []string{BucketHour, BucketDay, BucketWeek, BucketMonth}
+)
+
+// RefreshScoreSeries adds the daily ranks stored since the last refresh to the hourly
+// series and returns the number of buckets changed. The points and events of the series
+// are added as the raw events arrive, see ProjectInsightsService.RecordEvent.
+func (s *Service) RefreshScoreSeries(ctx context.Context) (int64, error) {
+	buckets, err := s.repository.RefreshScoreSeries(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh score series: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// GetTimeSeries returns one metric of a contributor or project per UTC bucket in
+// [From, To). Every bucket is present: score and events are zero without activity, rank
+// is nil without a ranked score.
+func (s *Service) GetTimeSeries(ctx context.Context, req TimeSeriesRequest) (TimeSeries, error) {
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if req.From.IsZero() && req.Bucket != "" {
+		req.From = stepBucket(req.Bucket, truncateBucket(req.Bucket, req.To.UTC()), -(DefaultSeriesPoints - 1))
+	}
+	if err := s.validator.ValidateTimeSeries(req); err != nil {
+		return TimeSeries{}, errors.Join(ErrInvalidArguments, err)
+	}
+
+	from := truncateBucket(req.Bucket, req.From.UTC())
+	var starts []time.Time
+	for start := from; start.Before(req.To); start = stepBucket(req.Bucket, start, 1) {
+		if len(starts) == MaxSeriesPoints {
+			return TimeSeries{}, errors.Join(ErrInvalidArguments,
+				fmt.Errorf("the range holds more than %d %s buckets", MaxSeriesPoints, req.Bucket))
+		}
+		starts = append(starts, start)
+	}
+
+	buckets, err := s.repository.GetScoreSeries(ctx, req.Entity, req.EntityID, req.Bucket, from, req.To.UTC())
+	if err != nil {
+		return TimeSeries{}, err
+	}
+	byStart := make(map[time.Time]SeriesBucket, len(buckets))
+	for _, bucket := range buckets {
+		byStart[bucket.Start.UTC()] = bucket
+	}
+
+	series := TimeSeries{
+		Entity:   req.Entity,
+		EntityID: req.EntityID,
+		Metric:   req.Metric,
+		Bucket:   req.Bucket,
+		From:     from,
+		To:       req.To.UTC(),
+		Points:   make([]TimeSeriesPoint, 0, len(starts)),
+	}
+	for _, start := range starts {
+		bucket := byStart[start]
+
+		point := TimeSeriesPoint{Time: start}
+		switch req.Metric {
+		case MetricScore:
+			point.Value = &bucket.Score
+		case MetricEvents:
+			events := float64(bucket.Events)
+			point.Value = &events
+		case MetricRank:
+			if bucket.BestRank != nil {
+				rank := float64(*bucket.BestRank)
+				point.Value = &rank
+			}
+		}
+
+		series.Points = append(series.Points, point)
+	}
+
+	return series, nil
+}
+
+// truncateBucket returns the start of the UTC bucket t falls in, weeks start on Monday
+func truncateBucket(bucket string, t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	switch bucket {
+	case BucketHour:
+		return t.Truncate(time.Hour)
+	case BucketWeek:
+		start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	case BucketMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// stepBucket moves the bucket start by n buckets
+func stepBucket(bucket string, start time.Time, n int) time.Time {
+	switch bucket {
+	case BucketHour:
+		return start.Add(time.Duration(n) * time.Hour)
+	case BucketWeek:
+		return start.AddDate(0, 0, 7*n)
+	case BucketMonth:
+		return start.AddDate(0, n, 0)
+	default:
+		return start.AddDate(0, 0, n)
+	}
+}
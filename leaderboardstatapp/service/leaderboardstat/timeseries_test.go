@@ -0,0 +1,135 @@
+package leaderboardstat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	types "github.com/gocasters/rankr/type"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSeriesRepository returns fixed buckets and records the range asked for
+type fakeSeriesRepository struct {
+	Repository
+
+	buckets  []SeriesBucket
+	from, to time.Time
+}
+
+func (f *fakeSeriesRepository) GetScoreSeries(_ context.Context, _ string, _ types.ID, _ string, from, to time.Time) ([]SeriesBucket, error) {
+	f.from, f.to = from, to
+
+	return f.buckets, nil
+}
+
+func TestTruncateBucket(t *testing.T) {
+	// Wednesday
+	at := time.Date(2025, 6, 11, 14, 35, 20, 0, time.UTC)
+
+	tests := []struct {
+		bucket string
+		t      time.Time
+		want   time.Time
+	}{
+		{bucket: BucketHour, t: at, want: time.Date(2025, 6, 11, 14, 0, 0, 0, time.UTC)},
+		{bucket: BucketDay, t: at, want: time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC)},
+		{bucket: BucketWeek, t: at, want: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)},
+		{bucket: BucketMonth, t: at, want: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		// weeks start on Monday, a Sunday belongs to the week before
+		{bucket: BucketWeek, t: time.Date(2025, 6, 15, 23, 0, 0, 0, time.UTC), want: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)},
+		{bucket: BucketWeek, t: time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), want: time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)},
+		{bucket: BucketWeek, t: time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC), want: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bucket+" "+tt.t.Format(time.RFC3339), func(t *testing.T) {
+			assert.Equal(t, tt.want, truncateBucket(tt.bucket, tt.t))
+		})
+	}
+}
+
+func TestStepBucket(t *testing.T) {
+	start := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 1, 31, 3, 0, 0, 0, time.UTC), stepBucket(BucketHour, start, 3))
+	assert.Equal(t, time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC), stepBucket(BucketDay, start, -1))
+	assert.Equal(t, time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC), stepBucket(BucketWeek, start, 2))
+	// month buckets always start on the first, stepping never overflows into the next month
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), stepBucket(BucketMonth, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 2))
+}
+
+func TestService_GetTimeSeriesGapFill(t *testing.T) {
+	rank := int64(3)
+	repo := &fakeSeriesRepository{buckets: []SeriesBucket{
+		{Start: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), Score: 12, Events: 4, BestRank: &rank},
+		{Start: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), Score: 5, Events: 1},
+	}}
+	svc := newTestService(repo, nil, nil)
+
+	value := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		metric string
+		want   []*float64
+	}{
+		{metric: MetricScore, want: []*float64{value(12), value(0), value(5), value(0)}},
+		{metric: MetricEvents, want: []*float64{value(4), value(0), value(1), value(0)}},
+		{metric: MetricRank, want: []*float64{value(3), nil, nil, nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			series, err := svc.GetTimeSeries(context.Background(), TimeSeriesRequest{
+				Entity:   SeriesContributor,
+				EntityID: 7,
+				Metric:   tt.metric,
+				Bucket:   BucketDay,
+				// From is truncated to its day, To is exclusive
+				From: time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC),
+				To:   time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC),
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), repo.from)
+			assert.Equal(t, time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC), repo.to)
+
+			require.Len(t, series.Points, len(tt.want))
+			for i, point := range series.Points {
+				assert.Equal(t, time.Date(2025, 6, 2+i, 0, 0, 0, 0, time.UTC), point.Time)
+				assert.Equal(t, tt.want[i], point.Value, "point %d", i)
+			}
+		})
+	}
+}
+
+func TestService_GetTimeSeriesDefaultRange(t *testing.T) {
+	svc := newTestService(&fakeSeriesRepository{}, nil, nil)
+
+	series, err := svc.GetTimeSeries(context.Background(), TimeSeriesRequest{
+		Entity:   SeriesProject,
+		EntityID: 1001,
+		Metric:   MetricScore,
+		Bucket:   BucketWeek,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, series.Points, DefaultSeriesPoints)
+	assert.Equal(t, truncateBucket(BucketWeek, time.Now().UTC()), series.Points[DefaultSeriesPoints-1].Time, "the last bucket is the current one")
+}
+
+func TestService_GetTimeSeriesTooManyBuckets(t *testing.T) {
+	svc := newTestService(&fakeSeriesRepository{}, nil, nil)
+
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, err := svc.GetTimeSeries(context.Background(), TimeSeriesRequest{
+		Entity:   SeriesContributor,
+		EntityID: 7,
+		Metric:   MetricScore,
+		Bucket:   BucketHour,
+		From:     to.Add(-(MaxSeriesPoints + 1) * time.Hour),
+		To:       to,
+	})
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
@@ -1,5 +1,12 @@
 package leaderboardstat
 
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	types "github.com/gocasters/rankr/type"
+)
+
 type ValidatorLeaderboardstatRepository interface {
 }
 type Validator struct {
@@ -9,3 +16,77 @@ type Validator struct {
 func NewValidator(repo ValidatorLeaderboardstatRepository) Validator {
 	return Validator{repo: repo}
 }
+
+func (v Validator) ValidateRollup(req RollupRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Timeframe, validation.Required, validation.In(toAny(rollupTimeframes)...)),
+		validation.Field(&req.Period, validation.Required),
+	)
+}
+
+func (v Validator) ValidateContributorPeriodScores(req ContributorPeriodScoresRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.ContributorID, validation.Required),
+		validation.Field(&req.Timeframe, validation.Required, validation.In(toAny(periodTimeframes)...)),
+	)
+}
+
+func (v Validator) ValidateCompareContributors(req CompareContributorsRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.ContributorIDs, validation.Required,
+			validation.Length(MinComparedContributors, MaxComparedContributors),
+			validation.Each(validation.Required),
+			validation.By(uniqueIDs)),
+		validation.Field(&req.Timeframe, validation.Required, validation.In(toAny(periodTimeframes)...)),
+		validation.Field(&req.Bucket, validation.In(toAny(seriesBuckets)...)),
+	)
+}
+
+func (v Validator) ValidateProjectInsights(req ProjectInsightsRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.ProjectID, validation.Required),
+		validation.Field(&req.Weeks, validation.Min(1), validation.Max(MaxInsightWeeks)),
+	)
+}
+
+func (v Validator) ValidateCohortRetention(req CohortRetentionRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Granularity, validation.Required, validation.In(toAny(cohortGranularities)...)),
+		validation.Field(&req.Cohorts, validation.Min(1), validation.Max(MaxCohorts)),
+	)
+}
+
+func (v Validator) ValidateTimeSeries(req TimeSeriesRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Entity, validation.Required, validation.In(toAny(seriesEntities)...)),
+		validation.Field(&req.EntityID, validation.Required),
+		validation.Field(&req.Metric, validation.Required, validation.In(toAny(seriesMetrics)...),
+			// Only contributors are ranked
+			validation.When(req.Entity == SeriesProject, validation.NotIn(MetricRank).Error("projects have no rank"))),
+		validation.Field(&req.Bucket, validation.Required, validation.In(toAny(seriesBuckets)...)),
+		validation.Field(&req.From, validation.Required, validation.Max(req.To).Exclusive()),
+	)
+}
+
+func uniqueIDs(value interface{}) error {
+	ids, _ := value.([]types.ID)
+
+	seen := make(map[types.ID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return fmt.Errorf("contributor %d is given twice", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+func toAny(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+
+	return result
+}
@@ -0,0 +1,306 @@
+/*
+Package privacy applies the privacy_mode of contributors to public responses. Every
+service showing leaderboard users resolves them through an IdentityResolver, so an
+anonymous contributor carries the same pseudonym everywhere.
+*/
+package privacy
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gocasters/rankr/adapter/contributor"
+	"github.com/gocasters/rankr/pkg/logger"
+	types "github.com/gocasters/rankr/type"
+)
+
+// Leaderboard members are GitHub user IDs
+const vcsProviderGitHub = "GITHUB"
+
+const privacyModeAnonymous = "anonymous"
+
+const (
+	defaultProfileCacheTTL  = 2 * time.Minute
+	defaultProfileCacheSize = 10000
+)
+
+// placeholderPseudonymSecret is the value the shipped configs carry until a real secret is set
+const placeholderPseudonymSecret = "change_me_pseudonym_secret"
+
+// pseudonymBytes is the HMAC prefix a pseudonym shows, 4 bytes keep collisions unlikely
+// on boards with thousands of anonymous contributors
+const pseudonymBytes = 4
+
+// Config tunes how contributors in privacy_mode "anonymous" are hidden
+type Config struct {
+	// ProfileCacheTTL is how long a privacy_mode is cached, it bounds how long a change
+	// takes to reach the responses
+	ProfileCacheTTL time.Duration `koanf:"profile_cache_ttl"`
+	// ProfileCacheSize is the most profiles kept in the cache
+	ProfileCacheSize int `koanf:"profile_cache_size"`
+	// PseudonymSecret keys the pseudonyms. Without it a pseudonym can be matched to its
+	// user ID by trying the IDs.
+	PseudonymSecret string `koanf:"pseudonym_secret"`
+}
+
+// Validate rejects a missing or placeholder pseudonym secret, with a known key anyone can
+// match the pseudonyms to their user IDs
+func (c Config) Validate() error {
+	switch c.PseudonymSecret {
+	case "":
+		return fmt.Errorf("privacy pseudonym_secret is required")
+	case placeholderPseudonymSecret:
+		return fmt.Errorf("privacy pseudonym_secret is still the placeholder %q", placeholderPseudonymSecret)
+	}
+
+	return nil
+}
+
+// Viewer is the caller of a public response
+type Viewer struct {
+	// ContributorID is the Rankr contributor ID of the caller, it sees itself unmasked
+	ContributorID types.ID
+	// RevealIdentities is set for callers allowed to read contributors, e.g. admins
+	RevealIdentities bool
+}
+
+// Identity is how a leaderboard user is shown on public responses. UserID is the VCS user
+// ID the boards hold, not the Rankr contributor ID. UserID is zero and DisplayName a stable
+// pseudonym for an anonymous contributor the viewer may not see.
+type Identity struct {
+	UserID       types.ID
+	DisplayName  string
+	ProfileImage string
+	Anonymous    bool
+}
+
+// Masked reports whether the identity hides its user
+func (i Identity) Masked() bool {
+	return i.UserID == 0
+}
+
+// ProfileDirectory returns the contributor profiles of VCS user IDs, *contributor.Client
+// implements it
+type ProfileDirectory interface {
+	GetProfilesByVCS(ctx context.Context, vcsProvider string, userIDs []int64) ([]contributor.Profile, error)
+}
+
+type contributorProfile struct {
+	// registered is false for users without a Rankr contributor, they have no privacy_mode
+	registered    bool
+	contributorID types.ID
+	displayName   string
+	profileImage  string
+	anonymous     bool
+}
+
+type cachedProfile struct {
+	profile   contributorProfile
+	expiresAt time.Time
+	// changedAt is when the profile was first cached or last fetched with a change
+	changedAt time.Time
+}
+
+// IdentityResolver applies the privacy_mode of the contributors to public responses. At
+// most ProfileCacheSize profiles are cached, each for ProfileCacheTTL.
+type IdentityResolver struct {
+	config    Config
+	directory ProfileDirectory
+
+	mu       sync.Mutex
+	profiles map[types.ID]cachedProfile
+}
+
+func NewIdentityResolver(config Config, directory ProfileDirectory) *IdentityResolver {
+	if config.ProfileCacheTTL <= 0 {
+		config.ProfileCacheTTL = defaultProfileCacheTTL
+	}
+	if config.ProfileCacheSize <= 0 {
+		config.ProfileCacheSize = defaultProfileCacheSize
+	}
+
+	return &IdentityResolver{
+		config:    config,
+		directory: directory,
+		profiles:  make(map[types.ID]cachedProfile),
+	}
+}
+
+// Identities returns the identity of every user as the viewer may see it. When the
+// contributor service cannot be reached every user is masked, a missing privacy_mode
+// must not reveal anyone.
+func (r *IdentityResolver) Identities(ctx context.Context, userIDs []types.ID, viewer Viewer) map[types.ID]Identity {
+	identities := make(map[types.ID]Identity, len(userIDs))
+
+	profiles, err := r.lookup(ctx, userIDs)
+	if err != nil {
+		logger.L().Warn("failed to get contributor profiles, masking every contributor",
+			slog.Int("count", len(userIDs)),
+			slog.String("error", err.Error()))
+
+		for _, userID := range userIDs {
+			identities[userID] = r.masked(userID)
+		}
+		return identities
+	}
+
+	for _, userID := range userIDs {
+		identities[userID] = r.identity(userID, profiles[userID], viewer)
+	}
+
+	return identities
+}
+
+// Identity returns the identity of one user, visible is false when the viewer may not
+// link the user ID to its activity
+func (r *IdentityResolver) Identity(ctx context.Context, userID types.ID, viewer Viewer) (identity Identity, visible bool, err error) {
+	profiles, err := r.lookup(ctx, []types.ID{userID})
+	if err != nil {
+		return Identity{}, false, fmt.Errorf("failed to get contributor profile: %w", err)
+	}
+
+	identity = r.identity(userID, profiles[userID], viewer)
+
+	return identity, !identity.Masked(), nil
+}
+
+// ChangedAt returns the last time the profile of any of the users changed, as far as the
+// cache knows. A user missing from the cache counts as changed now. Call it after the
+// identities of the users were resolved, a response built from them is current as of it.
+func (r *IdentityResolver) ChangedAt(userIDs []types.ID) time.Time {
+	var changedAt time.Time
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, userID := range userIDs {
+		cached, ok := r.profiles[userID]
+		if !ok {
+			return time.Now()
+		}
+		if cached.changedAt.After(changedAt) {
+			changedAt = cached.changedAt
+		}
+	}
+
+	return changedAt
+}
+
+// Pseudonym is the stable name of an anonymous contributor, e.g. "Contributor #a3f9c2d1"
+func (r *IdentityResolver) Pseudonym(userID types.ID) string {
+	mac := hmac.New(sha256.New, []byte(r.config.PseudonymSecret))
+	mac.Write([]byte(strconv.FormatUint(uint64(userID), 10)))
+
+	return "Contributor #" + hex.EncodeToString(mac.Sum(nil)[:pseudonymBytes])
+}
+
+func (r *IdentityResolver) identity(userID types.ID, profile contributorProfile, viewer Viewer) Identity {
+	if !profile.registered {
+		return Identity{UserID: userID}
+	}
+
+	if profile.anonymous && !viewer.RevealIdentities && viewer.ContributorID != profile.contributorID {
+		return r.masked(userID)
+	}
+
+	return Identity{
+		UserID:       userID,
+		DisplayName:  profile.displayName,
+		ProfileImage: profile.profileImage,
+		Anonymous:    profile.anonymous,
+	}
+}
+
+func (r *IdentityResolver) masked(userID types.ID) Identity {
+	return Identity{DisplayName: r.Pseudonym(userID), Anonymous: true}
+}
+
+// lookup returns the profiles of the users from the cache, the missing ones are fetched
+// and cached, unregistered users included
+func (r *IdentityResolver) lookup(ctx context.Context, userIDs []types.ID) (map[types.ID]contributorProfile, error) {
+	now := time.Now()
+	profiles := make(map[types.ID]contributorProfile, len(userIDs))
+
+	var missing []int64
+	r.mu.Lock()
+	for _, userID := range userIDs {
+		if cached, ok := r.profiles[userID]; ok && now.Before(cached.expiresAt) {
+			profiles[userID] = cached.profile
+			continue
+		}
+		missing = append(missing, int64(userID))
+	}
+	r.mu.Unlock()
+
+	if len(missing) == 0 {
+		return profiles, nil
+	}
+
+	if r.directory == nil {
+		return nil, fmt.Errorf("contributor directory is not initialized")
+	}
+
+	res, err := r.directory.GetProfilesByVCS(ctx, vcsProviderGitHub, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	fetched := make(map[types.ID]contributorProfile, len(missing))
+	for _, userID := range missing {
+		fetched[types.ID(userID)] = contributorProfile{}
+	}
+	for _, p := range res {
+		displayName := p.DisplayName
+		if displayName == "" {
+			displayName = p.VcsUsername
+		}
+
+		fetched[types.ID(p.VcsUserID)] = contributorProfile{
+			registered:    true,
+			contributorID: p.ContributorID,
+			displayName:   displayName,
+			profileImage:  p.ProfileImage,
+			anonymous:     p.PrivacyMode == privacyModeAnonymous,
+		}
+	}
+
+	expiresAt := now.Add(r.config.ProfileCacheTTL)
+	r.mu.Lock()
+	for userID, profile := range fetched {
+		if _, ok := r.profiles[userID]; !ok && len(r.profiles) >= r.config.ProfileCacheSize {
+			r.evictProfiles(now)
+		}
+		changedAt := now
+		if cached, ok := r.profiles[userID]; ok && cached.profile == profile {
+			changedAt = cached.changedAt
+		}
+		r.profiles[userID] = cachedProfile{profile: profile, expiresAt: expiresAt, changedAt: changedAt}
+		profiles[userID] = profile
+	}
+	r.mu.Unlock()
+
+	return profiles, nil
+}
+
+// evictProfiles makes room for one more profile in the full cache. It drops the expired
+// profiles and, when they are not enough, arbitrary ones. The caller holds r.mu.
+func (r *IdentityResolver) evictProfiles(now time.Time) {
+	for userID, cached := range r.profiles {
+		if !now.Before(cached.expiresAt) {
+			delete(r.profiles, userID)
+		}
+	}
+	for userID := range r.profiles {
+		if len(r.profiles) < r.config.ProfileCacheSize {
+			return
+		}
+		delete(r.profiles, userID)
+	}
+}
@@ -0,0 +1,268 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gocasters/rankr/adapter/contributor"
+	"github.com/gocasters/rankr/pkg/logger"
+	types "github.com/gocasters/rankr/type"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// the logger only accepts relative paths, so it writes below a scratch working directory
+	dir, err := os.MkdirTemp("", "privacy-test")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	if err := logger.Init(logger.Config{Level: "error", FilePath: "logs/test.log"}); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// fakeDirectory answers profile lookups from memory and counts them
+type fakeDirectory struct {
+	profiles []contributor.Profile
+	err      error
+	calls    int
+}
+
+func (f *fakeDirectory) GetProfilesByVCS(_ context.Context, vcsProvider string, userIDs []int64) ([]contributor.Profile, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	if vcsProvider != vcsProviderGitHub {
+		return nil, errors.New("unexpected VCS provider")
+	}
+
+	var result []contributor.Profile
+	for _, id := range userIDs {
+		for _, p := range f.profiles {
+			if p.VcsUserID == id {
+				result = append(result, p)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func newDirectory() *fakeDirectory {
+	return &fakeDirectory{profiles: []contributor.Profile{
+		{ContributorID: 11, VcsUserID: 1, VcsUsername: "alice", DisplayName: "Alice", ProfileImage: "a.png"},
+		{ContributorID: 12, VcsUserID: 2, VcsUsername: "bob", PrivacyMode: privacyModeAnonymous, ProfileImage: "b.png"},
+	}}
+}
+
+func TestIdentityResolver_Identities(t *testing.T) {
+	resolver := NewIdentityResolver(Config{PseudonymSecret: "secret"}, newDirectory())
+	masked := Identity{DisplayName: resolver.Pseudonym(2), Anonymous: true}
+
+	tests := []struct {
+		name   string
+		viewer Viewer
+		want   map[types.ID]Identity
+	}{
+		{
+			name:   "public",
+			viewer: Viewer{},
+			want: map[types.ID]Identity{
+				1: {UserID: 1, DisplayName: "Alice", ProfileImage: "a.png"},
+				2: masked,
+				3: {UserID: 3},
+			},
+		},
+		{
+			name:   "other contributor",
+			viewer: Viewer{ContributorID: 11},
+			want: map[types.ID]Identity{
+				1: {UserID: 1, DisplayName: "Alice", ProfileImage: "a.png"},
+				2: masked,
+				3: {UserID: 3},
+			},
+		},
+		{
+			name:   "the anonymous contributor itself",
+			viewer: Viewer{ContributorID: 12},
+			want: map[types.ID]Identity{
+				1: {UserID: 1, DisplayName: "Alice", ProfileImage: "a.png"},
+				2: {UserID: 2, DisplayName: "bob", ProfileImage: "b.png", Anonymous: true},
+				3: {UserID: 3},
+			},
+		},
+		{
+			name:   "contributor:read",
+			viewer: Viewer{RevealIdentities: true},
+			want: map[types.ID]Identity{
+				1: {UserID: 1, DisplayName: "Alice", ProfileImage: "a.png"},
+				2: {UserID: 2, DisplayName: "bob", ProfileImage: "b.png", Anonymous: true},
+				3: {UserID: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolver.Identities(context.Background(), []types.ID{1, 2, 3}, tt.viewer)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdentityResolver_LookupFailureMasksEveryone(t *testing.T) {
+	resolver := NewIdentityResolver(Config{PseudonymSecret: "secret"}, &fakeDirectory{err: errors.New("unavailable")})
+
+	got := resolver.Identities(context.Background(), []types.ID{1, 3}, Viewer{RevealIdentities: true})
+
+	assert.Equal(t, map[types.ID]Identity{
+		1: {DisplayName: resolver.Pseudonym(1), Anonymous: true},
+		3: {DisplayName: resolver.Pseudonym(3), Anonymous: true},
+	}, got)
+
+	_, _, err := resolver.Identity(context.Background(), 1, Viewer{})
+	assert.Error(t, err)
+}
+
+func TestIdentityResolver_CachesProfiles(t *testing.T) {
+	directory := newDirectory()
+	resolver := NewIdentityResolver(Config{PseudonymSecret: "secret"}, directory)
+
+	resolver.Identities(context.Background(), []types.ID{1, 3}, Viewer{})
+	resolver.Identities(context.Background(), []types.ID{1, 3}, Viewer{})
+	assert.Equal(t, 1, directory.calls, "unregistered users are cached too")
+
+	resolver.Identities(context.Background(), []types.ID{1, 2}, Viewer{})
+	assert.Equal(t, 2, directory.calls, "only the missing user is fetched")
+}
+
+func TestIdentityResolver_ProfileCacheSize(t *testing.T) {
+	directory := newDirectory()
+	resolver := NewIdentityResolver(Config{PseudonymSecret: "secret", ProfileCacheSize: 2}, directory)
+
+	resolver.Identities(context.Background(), []types.ID{1, 2}, Viewer{})
+	resolver.Identities(context.Background(), []types.ID{3}, Viewer{})
+	assert.Len(t, resolver.profiles, 2, "a full cache makes room for the new profile")
+	assert.Contains(t, resolver.profiles, types.ID(3))
+
+	// Expired profiles are dropped first
+	for userID, cached := range resolver.profiles {
+		if userID != 3 {
+			cached.expiresAt = time.Now().Add(-time.Second)
+			resolver.profiles[userID] = cached
+		}
+	}
+	resolver.Identities(context.Background(), []types.ID{4}, Viewer{})
+	assert.Len(t, resolver.profiles, 2)
+	assert.Contains(t, resolver.profiles, types.ID(3))
+	assert.Contains(t, resolver.profiles, types.ID(4))
+}
+
+func TestIdentityResolver_ChangedAt(t *testing.T) {
+	directory := newDirectory()
+	resolver := NewIdentityResolver(Config{PseudonymSecret: "secret"}, directory)
+	expire := func() {
+		for userID, cached := range resolver.profiles {
+			cached.expiresAt = time.Now().Add(-time.Second)
+			resolver.profiles[userID] = cached
+		}
+	}
+
+	resolver.Identities(context.Background(), []types.ID{1, 2}, Viewer{})
+	cachedAt := resolver.ChangedAt([]types.ID{1, 2})
+	require.False(t, cachedAt.IsZero())
+
+	expire()
+	time.Sleep(time.Millisecond)
+	resolver.Identities(context.Background(), []types.ID{1, 2}, Viewer{})
+	assert.Equal(t, cachedAt, resolver.ChangedAt([]types.ID{1, 2}), "an unchanged profile keeps its time")
+
+	directory.profiles[0].PrivacyMode = privacyModeAnonymous
+	expire()
+	resolver.Identities(context.Background(), []types.ID{1, 2}, Viewer{})
+	assert.True(t, resolver.ChangedAt([]types.ID{1, 2}).After(cachedAt), "turning anonymous is a change")
+	assert.Equal(t, cachedAt, resolver.ChangedAt([]types.ID{2}))
+
+	assert.False(t, resolver.ChangedAt([]types.ID{5}).Before(time.Now().Add(-time.Second)), "a user not cached changed now")
+}
+
+func TestIdentityResolver_Identity(t *testing.T) {
+	resolver := NewIdentityResolver(Config{PseudonymSecret: "secret"}, newDirectory())
+
+	_, visible, err := resolver.Identity(context.Background(), 2, Viewer{})
+	require.NoError(t, err)
+	assert.False(t, visible)
+
+	identity, visible, err := resolver.Identity(context.Background(), 2, Viewer{ContributorID: 12})
+	require.NoError(t, err)
+	assert.True(t, visible)
+	assert.Equal(t, types.ID(2), identity.UserID)
+}
+
+func TestIdentityResolver_Pseudonym(t *testing.T) {
+	resolver := NewIdentityResolver(Config{PseudonymSecret: "secret"}, nil)
+	other := NewIdentityResolver(Config{PseudonymSecret: "other"}, nil)
+
+	assert.Equal(t, resolver.Pseudonym(42), resolver.Pseudonym(42))
+	assert.NotEqual(t, resolver.Pseudonym(42), other.Pseudonym(42), "the secret keys the pseudonym")
+	assert.Len(t, resolver.Pseudonym(42), len("Contributor #")+2*pseudonymBytes)
+
+	seen := make(map[string]types.ID)
+	for id := types.ID(1); id <= 5000; id++ {
+		name := resolver.Pseudonym(id)
+		if prev, ok := seen[name]; ok {
+			t.Fatalf("users %d and %d share the pseudonym %q", prev, id, name)
+		}
+		seen[name] = id
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.Error(t, Config{}.Validate())
+	assert.Error(t, Config{PseudonymSecret: placeholderPseudonymSecret}.Validate())
+	assert.NoError(t, Config{PseudonymSecret: "a-real-secret"}.Validate())
+}
+
+func TestViewerOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		claim  *types.UserClaim
+		access string
+		want   Viewer
+	}{
+		{name: "anonymous caller", want: Viewer{}},
+		{name: "contributor", claim: &types.UserClaim{ID: 12}, access: "leaderboardstat:read", want: Viewer{ContributorID: 12}},
+		{name: "contributor:read", claim: &types.UserClaim{ID: 7}, access: "leaderboardstat:read, contributor:read", want: Viewer{ContributorID: 7, RevealIdentities: true}},
+		{name: "all permissions", access: "*", want: Viewer{RevealIdentities: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.access != "" {
+				req.Header.Set("X-Access", tt.access)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			if tt.claim != nil {
+				c.Set("userInfo", tt.claim)
+			}
+
+			assert.Equal(t, tt.want, ViewerOf(c))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package privacy
+
+import (
+	"strings"
+
+	"github.com/gocasters/rankr/pkg/role"
+	types "github.com/gocasters/rankr/type"
+	"github.com/labstack/echo/v4"
+)
+
+// PermissionReadContributors lets a caller see anonymous contributors
+const PermissionReadContributors role.Permission = "contributor:read"
+
+// ViewerOf returns the caller of a request. The user comes from the X-User-Info claim, the
+// access list from the X-Access header the gateway sets from the auth service.
+func ViewerOf(c echo.Context) Viewer {
+	var viewer Viewer
+
+	if claim, ok := c.Get("userInfo").(*types.UserClaim); ok && claim != nil {
+		viewer.ContributorID = claim.ID
+	}
+
+	var access []string
+	for _, perm := range strings.Split(c.Request().Header.Get("X-Access"), ",") {
+		if perm = strings.TrimSpace(perm); perm != "" {
+			access = append(access, perm)
+		}
+	}
+	viewer.RevealIdentities = role.HasPermission(access, PermissionReadContributors)
+
+	return viewer
+}
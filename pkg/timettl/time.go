@@ -5,66 +5,93 @@ import (
 	"time"
 )
 
-// GetYear returns current year as string (e.g., "2025")
+// Period keys and boundaries are computed in the location of the given time.
+// Callers convert a timestamp with t.In(loc) to get keys for a project-local
+// calendar, and use UTC for global leaderboards.
+
+// YearOf returns the year of t as string (e.g., "2025")
+func YearOf(t time.Time) string {
+	return fmt.Sprintf("%d", t.Year())
+}
+
+// MonthOf returns the year-month of t as string (e.g., "2025-11")
+func MonthOf(t time.Time) string {
+	return fmt.Sprintf("%d-%02d", t.Year(), t.Month())
+}
+
+// WeekOf returns the ISO week of t as string (e.g., "2025-W44")
+func WeekOf(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// DayOf returns the date of t as string (e.g., "2025-11-02")
+func DayOf(t time.Time) string {
+	return fmt.Sprintf("%d-%02d-%02d", t.Year(), t.Month(), t.Day())
+}
+
+// GetYear returns current UTC year as string (e.g., "2025")
 func GetYear() string {
-	now := time.Now()
-	return fmt.Sprintf("%d", now.Year())
+	return YearOf(time.Now().UTC())
 }
 
-// GetMonth returns current year-month as string (e.g., "2025-11")
+// GetMonth returns current UTC year-month as string (e.g., "2025-11")
 func GetMonth() string {
-	now := time.Now()
-	return fmt.Sprintf("%d-%02d", now.Year(), now.Month())
+	return MonthOf(time.Now().UTC())
 }
 
-// GetWeek returns current ISO week as string (e.g., "2025-W44")
+// GetWeek returns current UTC ISO week as string (e.g., "2025-W44")
 func GetWeek() string {
-	now := time.Now()
-	year, week := now.ISOWeek()
-	return fmt.Sprintf("%d-W%02d", year, week)
+	return WeekOf(time.Now().UTC())
 }
 
-// GetDay returns current date as string (e.g., "2025-11-02")
+// GetDay returns current UTC date as string (e.g., "2025-11-02")
 func GetDay() string {
-	now := time.Now()
-	return fmt.Sprintf("%d-%02d-%02d", now.Year(), now.Month(), now.Day())
+	return DayOf(time.Now().UTC())
 }
 
-// CalculateEndOfPeriod returns the expiration time for a given timeframe
-// This ensures all keys for the same period expire at the same time
-func CalculateEndOfPeriod(timeframe string) (time.Time, error) {
-	now := time.Now().UTC()
+// PeriodKeyAt returns the period string of t for the given timeframe
+func PeriodKeyAt(timeframe string, t time.Time) (string, error) {
+	switch timeframe {
+	case "daily":
+		return DayOf(t), nil
+	case "weekly":
+		return WeekOf(t), nil
+	case "monthly":
+		return MonthOf(t), nil
+	case "yearly":
+		return YearOf(t), nil
+	case "all_time":
+		return "", nil
+	default:
+		return "", fmt.Errorf("unknown timeframe: %s", timeframe)
+	}
+}
+
+// EndOfPeriodAt returns the first instant after the period that contains t, in t's location.
+// Day lengths follow the location's calendar, so a daily period is 23 or 25 hours long
+// on DST changes.
+func EndOfPeriodAt(timeframe string, t time.Time) (time.Time, error) {
+	loc := t.Location()
 
 	switch timeframe {
 	case "daily":
-		// Expire at end of current day (23:59:59 UTC)
-		return time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, time.UTC), nil
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc), nil
 
 	case "weekly":
-		// Expire at end of current ISO week (Sunday 23:59:59 UTC)
 		// ISO week starts Monday, ends Sunday
-		weekday := int(now.Weekday())
+		weekday := int(t.Weekday())
 		if weekday == 0 { // Sunday
 			weekday = 7
 		}
-		daysUntilSunday := 7 - weekday
-		endOfWeek := now.AddDate(0, 0, daysUntilSunday)
-		return time.Date(endOfWeek.Year(), endOfWeek.Month(), endOfWeek.Day(), 23, 59, 59, 0, time.UTC), nil
+		daysUntilNextMonday := 8 - weekday
+		return time.Date(t.Year(), t.Month(), t.Day()+daysUntilNextMonday, 0, 0, 0, 0, loc), nil
 
 	case "monthly":
-		// Expire at end of current month (last second)
-		year := now.Year()
-		month := now.Month()
-
-		// First day of next month
-		firstOfNextMonth := time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
-
-		// Last second of current month
-		return firstOfNextMonth.Add(-time.Second), nil
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc), nil
 
 	case "yearly":
-		// Expire at end of current year (December 31, 23:59:59 UTC)
-		return time.Date(now.Year(), 12, 31, 23, 59, 59, 0, time.UTC), nil
+		return time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, loc), nil
 
 	case "all_time":
 		// No expiration for all_time keys
@@ -75,6 +102,60 @@ func CalculateEndOfPeriod(timeframe string) (time.Time, error) {
 	}
 }
 
+// StartOfPeriod parses a period string produced by PeriodKeyAt and returns the first
+// instant of that period in loc.
+func StartOfPeriod(timeframe, period string, loc *time.Location) (time.Time, error) {
+	var (
+		year, month, day, week int
+		n                      int
+		err                    error
+	)
+
+	switch timeframe {
+	case "daily":
+		n, err = fmt.Sscanf(period, "%4d-%2d-%2d", &year, &month, &day)
+	case "weekly":
+		n, err = fmt.Sscanf(period, "%4d-W%2d", &year, &week)
+	case "monthly":
+		n, err = fmt.Sscanf(period, "%4d-%2d", &year, &month)
+	case "yearly":
+		n, err = fmt.Sscanf(period, "%4d", &year)
+	default:
+		return time.Time{}, fmt.Errorf("timeframe %s has no periods", timeframe)
+	}
+	if err != nil || n == 0 {
+		return time.Time{}, fmt.Errorf("invalid %s period %q", timeframe, period)
+	}
+
+	var start time.Time
+	switch timeframe {
+	case "daily":
+		start = time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	case "weekly":
+		// ISO week 1 is the week containing January 4th
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+		offset := (int(jan4.Weekday()) + 6) % 7 // days since Monday
+		start = time.Date(year, time.January, 4-offset+(week-1)*7, 0, 0, 0, 0, loc)
+	case "monthly":
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	case "yearly":
+		start = time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	}
+
+	// Reject periods that were normalized by time.Date (e.g., "2025-13" or "2025-W60")
+	if key, _ := PeriodKeyAt(timeframe, start); key != period {
+		return time.Time{}, fmt.Errorf("invalid %s period %q", timeframe, period)
+	}
+
+	return start, nil
+}
+
+// CalculateEndOfPeriod returns the expiration time for a given timeframe of the current UTC period
+// This ensures all keys for the same period expire at the same time
+func CalculateEndOfPeriod(timeframe string) (time.Time, error) {
+	return EndOfPeriodAt(timeframe, time.Now().UTC())
+}
+
 // GetExpirationDuration returns the duration until end of period
 // Useful for debugging or calculating time remaining
 func GetExpirationDuration(timeframe string) (time.Duration, error) {
@@ -128,21 +209,8 @@ func IsWithinPeriod(t time.Time, timeframe string) bool {
 	}
 }
 
-// GetPeriodKey returns the period string for current time
+// GetPeriodKey returns the period string for current UTC time
 // Useful for generating leaderboard keys
 func GetPeriodKey(timeframe string) (string, error) {
-	switch timeframe {
-	case "daily":
-		return GetDay(), nil
-	case "weekly":
-		return GetWeek(), nil
-	case "monthly":
-		return GetMonth(), nil
-	case "yearly":
-		return GetYear(), nil
-	case "all_time":
-		return "", nil
-	default:
-		return "", fmt.Errorf("unknown timeframe: %s", timeframe)
-	}
+	return PeriodKeyAt(timeframe, time.Now().UTC())
 }
@@ -0,0 +1,249 @@
+package timettl
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+
+	return loc
+}
+
+func TestPeriodKeyAt_ISOWeekBoundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{name: "monday of week 1 in previous year", at: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), want: "2025-W01"},
+		{name: "sunday of week 52", at: time.Date(2024, 12, 29, 23, 59, 59, 0, time.UTC), want: "2024-W52"},
+		{name: "january days in week 53", at: time.Date(2021, 1, 3, 12, 0, 0, 0, time.UTC), want: "2020-W53"},
+		{name: "first monday after week 53", at: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), want: "2021-W01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PeriodKeyAt("weekly", tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PeriodKeyAt(weekly, %s) = %s, want %s", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPeriodKeyAt_UsesLocationOfTime(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	// Sunday evening in UTC is already Monday of the next ISO week (and year) in Tokyo
+	at := time.Date(2025, 12, 28, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		timeframe string
+		utc       string
+		local     string
+	}{
+		{timeframe: "daily", utc: "2025-12-28", local: "2025-12-29"},
+		{timeframe: "weekly", utc: "2025-W52", local: "2026-W01"},
+		{timeframe: "monthly", utc: "2025-12", local: "2025-12"},
+		{timeframe: "yearly", utc: "2025", local: "2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeframe, func(t *testing.T) {
+			utc, _ := PeriodKeyAt(tt.timeframe, at)
+			local, _ := PeriodKeyAt(tt.timeframe, at.In(tokyo))
+
+			if utc != tt.utc {
+				t.Errorf("utc period = %s, want %s", utc, tt.utc)
+			}
+			if local != tt.local {
+				t.Errorf("local period = %s, want %s", local, tt.local)
+			}
+		})
+	}
+}
+
+func TestEndOfPeriodAt_DSTChanges(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name       string
+		at         time.Time
+		wantEnd    time.Time
+		wantLength time.Duration
+	}{
+		{
+			name:       "spring forward day is 23 hours",
+			at:         time.Date(2025, 3, 9, 0, 30, 0, 0, newYork),
+			wantEnd:    time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC),
+			wantLength: 23 * time.Hour,
+		},
+		{
+			name:       "fall back day is 25 hours",
+			at:         time.Date(2025, 11, 2, 0, 30, 0, 0, newYork),
+			wantEnd:    time.Date(2025, 11, 3, 5, 0, 0, 0, time.UTC),
+			wantLength: 25 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, err := EndOfPeriodAt("daily", tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !end.Equal(tt.wantEnd) {
+				t.Errorf("end = %s, want %s", end.UTC(), tt.wantEnd)
+			}
+
+			start := time.Date(tt.at.Year(), tt.at.Month(), tt.at.Day(), 0, 0, 0, 0, newYork)
+			if got := end.Sub(start); got != tt.wantLength {
+				t.Errorf("day length = %s, want %s", got, tt.wantLength)
+			}
+		})
+	}
+}
+
+func TestEndOfPeriodAt_Boundaries(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeframe string
+		at        time.Time
+		want      time.Time
+	}{
+		{
+			name:      "weekly from sunday ends next monday",
+			timeframe: "weekly",
+			at:        time.Date(2025, 11, 2, 23, 0, 0, 0, time.UTC),
+			want:      time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "weekly from monday ends in seven days",
+			timeframe: "weekly",
+			at:        time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "weekly across year end",
+			timeframe: "weekly",
+			at:        time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC),
+			want:      time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "monthly in december",
+			timeframe: "monthly",
+			at:        time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "yearly",
+			timeframe: "yearly",
+			at:        time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EndOfPeriodAt(tt.timeframe, tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("EndOfPeriodAt(%s, %s) = %s, want %s", tt.timeframe, tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndOfPeriodAt_KeyAndExpiryAgree(t *testing.T) {
+	sydney := mustLoadLocation(t, "Australia/Sydney")
+
+	// Walk across the April DST change in Sydney hour by hour: the last instant
+	// before the end of the period must belong to the same period key.
+	at := time.Date(2025, 4, 4, 0, 0, 0, 0, sydney)
+	for i := 0; i < 96; i++ {
+		current := at.Add(time.Duration(i) * time.Hour)
+
+		for _, timeframe := range []string{"daily", "weekly", "monthly", "yearly"} {
+			key, _ := PeriodKeyAt(timeframe, current)
+			end, _ := EndOfPeriodAt(timeframe, current)
+
+			lastInstant, _ := PeriodKeyAt(timeframe, end.Add(-time.Nanosecond))
+			nextPeriod, _ := PeriodKeyAt(timeframe, end)
+
+			if lastInstant != key {
+				t.Fatalf("%s at %s: last instant key %s, want %s", timeframe, current, lastInstant, key)
+			}
+			if nextPeriod == key {
+				t.Fatalf("%s at %s: end %s still in period %s", timeframe, current, end, key)
+			}
+		}
+	}
+}
+
+func TestEndOfPeriodAt_UnknownTimeframe(t *testing.T) {
+	if _, err := EndOfPeriodAt("hourly", time.Now()); err == nil {
+		t.Error("expected error for unknown timeframe")
+	}
+}
+
+func TestStartOfPeriod_RoundTrip(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	tests := []struct {
+		timeframe string
+		period    string
+		want      time.Time
+	}{
+		{timeframe: "daily", period: "2025-03-09", want: time.Date(2025, 3, 9, 0, 0, 0, 0, tokyo)},
+		{timeframe: "weekly", period: "2025-W01", want: time.Date(2024, 12, 30, 0, 0, 0, 0, tokyo)},
+		{timeframe: "weekly", period: "2020-W53", want: time.Date(2020, 12, 28, 0, 0, 0, 0, tokyo)},
+		{timeframe: "monthly", period: "2025-06", want: time.Date(2025, 6, 1, 0, 0, 0, 0, tokyo)},
+		{timeframe: "yearly", period: "2025", want: time.Date(2025, 1, 1, 0, 0, 0, 0, tokyo)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			got, err := StartOfPeriod(tt.timeframe, tt.period, tokyo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("StartOfPeriod(%s, %s) = %s, want %s", tt.timeframe, tt.period, got, tt.want)
+			}
+			if key, _ := PeriodKeyAt(tt.timeframe, got); key != tt.period {
+				t.Errorf("PeriodKeyAt(%s) = %s, want %s", got, key, tt.period)
+			}
+		})
+	}
+}
+
+func TestStartOfPeriod_Invalid(t *testing.T) {
+	tests := []struct {
+		timeframe string
+		period    string
+	}{
+		{timeframe: "monthly", period: "2025-13"},
+		{timeframe: "weekly", period: "2025-W54"},
+		{timeframe: "daily", period: "2025-02-30"},
+		{timeframe: "daily", period: "yesterday"},
+		{timeframe: "all_time", period: ""},
+	}
+
+	for _, tt := range tests {
+		if _, err := StartOfPeriod(tt.timeframe, tt.period, time.UTC); err == nil {
+			t.Errorf("expected error for %s period %q", tt.timeframe, tt.period)
+		}
+	}
+}
@@ -9,6 +9,8 @@ const (
 	TopicTaskCompleted      = "task.completed"
 	TopicLeaderboardScored  = "leaderboard.scored"
 	TopicLeaderboardUpdated = "leaderboard.updated"
+	TopicSeasonEnded        = "leaderboard.season.ended"
+	TopicBadgeAwarded       = "leaderboard.badge.awarded"
 	TopicProjectCreated     = "project.created"
 	TopicProjectUpdated     = "project.updated"
 
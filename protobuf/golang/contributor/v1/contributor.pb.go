@@ -428,6 +428,214 @@ func (x *GetContributorsByVCSResponse) GetContributors() []*ContributorMapping {
 	return nil
 }
 
+// Request for fetching public profiles by VCS provider and VCS user IDs.
+type GetContributorProfilesByVCSRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VcsProvider string  `protobuf:"bytes,1,opt,name=vcs_provider,json=vcsProvider,proto3" json:"vcs_provider,omitempty"`        // e.g., "GITHUB", "GITLAB", "BITBUCKET"
+	VcsUserIds  []int64 `protobuf:"varint,2,rep,packed,name=vcs_user_ids,json=vcsUserIds,proto3" json:"vcs_user_ids,omitempty"` // VCS user IDs, as used by leaderboards
+}
+
+func (x *GetContributorProfilesByVCSRequest) Reset() {
+	*x = GetContributorProfilesByVCSRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contributor_v1_contributor_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetContributorProfilesByVCSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContributorProfilesByVCSRequest) ProtoMessage() {}
+
+func (x *GetContributorProfilesByVCSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contributor_v1_contributor_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContributorProfilesByVCSRequest.ProtoReflect.Descriptor instead.
+func (*GetContributorProfilesByVCSRequest) Descriptor() ([]byte, []int) {
+	return file_contributor_v1_contributor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetContributorProfilesByVCSRequest) GetVcsProvider() string {
+	if x != nil {
+		return x.VcsProvider
+	}
+	return ""
+}
+
+func (x *GetContributorProfilesByVCSRequest) GetVcsUserIds() []int64 {
+	if x != nil {
+		return x.VcsUserIds
+	}
+	return nil
+}
+
+// Public profile of a single contributor.
+type ContributorProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContributorId int64  `protobuf:"varint,1,opt,name=contributor_id,json=contributorId,proto3" json:"contributor_id,omitempty"` // Internal Rankr contributor ID
+	VcsUserId     int64  `protobuf:"varint,2,opt,name=vcs_user_id,json=vcsUserId,proto3" json:"vcs_user_id,omitempty"`
+	VcsUsername   string `protobuf:"bytes,3,opt,name=vcs_username,json=vcsUsername,proto3" json:"vcs_username,omitempty"`
+	DisplayName   string `protobuf:"bytes,4,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ProfileImage  string `protobuf:"bytes,5,opt,name=profile_image,json=profileImage,proto3" json:"profile_image,omitempty"`
+	PrivacyMode   string `protobuf:"bytes,6,opt,name=privacy_mode,json=privacyMode,proto3" json:"privacy_mode,omitempty"` // "real" or "anonymous"
+	Timezone      string `protobuf:"bytes,7,opt,name=timezone,proto3" json:"timezone,omitempty"`                          // IANA timezone, e.g. "Europe/Berlin"
+}
+
+func (x *ContributorProfile) Reset() {
+	*x = ContributorProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contributor_v1_contributor_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContributorProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributorProfile) ProtoMessage() {}
+
+func (x *ContributorProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_contributor_v1_contributor_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributorProfile.ProtoReflect.Descriptor instead.
+func (*ContributorProfile) Descriptor() ([]byte, []int) {
+	return file_contributor_v1_contributor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ContributorProfile) GetContributorId() int64 {
+	if x != nil {
+		return x.ContributorId
+	}
+	return 0
+}
+
+func (x *ContributorProfile) GetVcsUserId() int64 {
+	if x != nil {
+		return x.VcsUserId
+	}
+	return 0
+}
+
+func (x *ContributorProfile) GetVcsUsername() string {
+	if x != nil {
+		return x.VcsUsername
+	}
+	return ""
+}
+
+func (x *ContributorProfile) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ContributorProfile) GetProfileImage() string {
+	if x != nil {
+		return x.ProfileImage
+	}
+	return ""
+}
+
+func (x *ContributorProfile) GetPrivacyMode() string {
+	if x != nil {
+		return x.PrivacyMode
+	}
+	return ""
+}
+
+func (x *ContributorProfile) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// Response containing the profiles of the known contributors; unknown IDs are omitted.
+type GetContributorProfilesByVCSResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VcsProvider string                `protobuf:"bytes,1,opt,name=vcs_provider,json=vcsProvider,proto3" json:"vcs_provider,omitempty"`
+	Profiles    []*ContributorProfile `protobuf:"bytes,2,rep,name=profiles,proto3" json:"profiles,omitempty"`
+}
+
+func (x *GetContributorProfilesByVCSResponse) Reset() {
+	*x = GetContributorProfilesByVCSResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contributor_v1_contributor_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetContributorProfilesByVCSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContributorProfilesByVCSResponse) ProtoMessage() {}
+
+func (x *GetContributorProfilesByVCSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contributor_v1_contributor_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContributorProfilesByVCSResponse.ProtoReflect.Descriptor instead.
+func (*GetContributorProfilesByVCSResponse) Descriptor() ([]byte, []int) {
+	return file_contributor_v1_contributor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetContributorProfilesByVCSResponse) GetVcsProvider() string {
+	if x != nil {
+		return x.VcsProvider
+	}
+	return ""
+}
+
+func (x *GetContributorProfilesByVCSResponse) GetProfiles() []*ContributorProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
 var File_contributor_v1_contributor_proto protoreflect.FileDescriptor
 
 var file_contributor_v1_contributor_proto_rawDesc = []byte{
@@ -481,33 +689,74 @@ var file_contributor_v1_contributor_proto_rawDesc = []byte{
 	0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63,
 	0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
 	0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67,
-	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x32, 0xc9,
-	0x02, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74,
-	0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74,
-	0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
+	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x69,
+	0x0a, 0x22, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x56, 0x43, 0x53, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x63, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x63, 0x73, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0c, 0x76, 0x63, 0x73, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0a, 0x76,
+	0x63, 0x73, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x85, 0x02, 0x0a, 0x12, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x76, 0x63, 0x73, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x76, 0x63,
+	0x73, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x63, 0x73, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76,
+	0x63, 0x73, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x63,
+	0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x22, 0x88, 0x01, 0x0a, 0x23, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x56, 0x43,
+	0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x63, 0x73,
+	0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x76, 0x63, 0x73, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
 	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x25, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
-	0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x26, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x56, 0x43, 0x53, 0x12,
-	0x2b, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73,
-	0x42, 0x79, 0x56, 0x43, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x32, 0xd2, 0x03, 0x0a,
+	0x12, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x25, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x56, 0x43, 0x53, 0x12, 0x2b, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x42, 0x79,
+	0x56, 0x43, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x56, 0x43, 0x53,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x86, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x42, 0x79, 0x56, 0x43, 0x53, 0x12, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x42, 0x79, 0x56, 0x43, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x63,
 	0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x56,
-	0x43, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x49, 0x5a, 0x47, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x63, 0x61, 0x73, 0x74, 0x65,
-	0x72, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62,
-	0x75, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75,
-	0x74, 0x6f, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x56, 0x43, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x49, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x67, 0x6f, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x72, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -522,29 +771,35 @@ func file_contributor_v1_contributor_proto_rawDescGZIP() []byte {
 	return file_contributor_v1_contributor_proto_rawDescData
 }
 
-var file_contributor_v1_contributor_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_contributor_v1_contributor_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_contributor_v1_contributor_proto_goTypes = []any{
-	(*GetContributorRequest)(nil),        // 0: contributor.v1.GetContributorRequest
-	(*GetContributorResponse)(nil),       // 1: contributor.v1.GetContributorResponse
-	(*VerifyPasswordRequest)(nil),        // 2: contributor.v1.VerifyPasswordRequest
-	(*VerifyPasswordResponse)(nil),       // 3: contributor.v1.VerifyPasswordResponse
-	(*GetContributorsByVCSRequest)(nil),  // 4: contributor.v1.GetContributorsByVCSRequest
-	(*ContributorMapping)(nil),           // 5: contributor.v1.ContributorMapping
-	(*GetContributorsByVCSResponse)(nil), // 6: contributor.v1.GetContributorsByVCSResponse
+	(*GetContributorRequest)(nil),               // 0: contributor.v1.GetContributorRequest
+	(*GetContributorResponse)(nil),              // 1: contributor.v1.GetContributorResponse
+	(*VerifyPasswordRequest)(nil),               // 2: contributor.v1.VerifyPasswordRequest
+	(*VerifyPasswordResponse)(nil),              // 3: contributor.v1.VerifyPasswordResponse
+	(*GetContributorsByVCSRequest)(nil),         // 4: contributor.v1.GetContributorsByVCSRequest
+	(*ContributorMapping)(nil),                  // 5: contributor.v1.ContributorMapping
+	(*GetContributorsByVCSResponse)(nil),        // 6: contributor.v1.GetContributorsByVCSResponse
+	(*GetContributorProfilesByVCSRequest)(nil),  // 7: contributor.v1.GetContributorProfilesByVCSRequest
+	(*ContributorProfile)(nil),                  // 8: contributor.v1.ContributorProfile
+	(*GetContributorProfilesByVCSResponse)(nil), // 9: contributor.v1.GetContributorProfilesByVCSResponse
 }
 var file_contributor_v1_contributor_proto_depIdxs = []int32{
 	5, // 0: contributor.v1.GetContributorsByVCSResponse.contributors:type_name -> contributor.v1.ContributorMapping
-	0, // 1: contributor.v1.ContributorService.GetContributor:input_type -> contributor.v1.GetContributorRequest
-	2, // 2: contributor.v1.ContributorService.VerifyPassword:input_type -> contributor.v1.VerifyPasswordRequest
-	4, // 3: contributor.v1.ContributorService.GetContributorsByVCS:input_type -> contributor.v1.GetContributorsByVCSRequest
-	1, // 4: contributor.v1.ContributorService.GetContributor:output_type -> contributor.v1.GetContributorResponse
-	3, // 5: contributor.v1.ContributorService.VerifyPassword:output_type -> contributor.v1.VerifyPasswordResponse
-	6, // 6: contributor.v1.ContributorService.GetContributorsByVCS:output_type -> contributor.v1.GetContributorsByVCSResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	8, // 1: contributor.v1.GetContributorProfilesByVCSResponse.profiles:type_name -> contributor.v1.ContributorProfile
+	0, // 2: contributor.v1.ContributorService.GetContributor:input_type -> contributor.v1.GetContributorRequest
+	2, // 3: contributor.v1.ContributorService.VerifyPassword:input_type -> contributor.v1.VerifyPasswordRequest
+	4, // 4: contributor.v1.ContributorService.GetContributorsByVCS:input_type -> contributor.v1.GetContributorsByVCSRequest
+	7, // 5: contributor.v1.ContributorService.GetContributorProfilesByVCS:input_type -> contributor.v1.GetContributorProfilesByVCSRequest
+	1, // 6: contributor.v1.ContributorService.GetContributor:output_type -> contributor.v1.GetContributorResponse
+	3, // 7: contributor.v1.ContributorService.VerifyPassword:output_type -> contributor.v1.VerifyPasswordResponse
+	6, // 8: contributor.v1.ContributorService.GetContributorsByVCS:output_type -> contributor.v1.GetContributorsByVCSResponse
+	9, // 9: contributor.v1.ContributorService.GetContributorProfilesByVCS:output_type -> contributor.v1.GetContributorProfilesByVCSResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_contributor_v1_contributor_proto_init() }
@@ -637,6 +892,42 @@ func file_contributor_v1_contributor_proto_init() {
 				return nil
 			}
 		}
+		file_contributor_v1_contributor_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetContributorProfilesByVCSRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contributor_v1_contributor_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ContributorProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contributor_v1_contributor_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetContributorProfilesByVCSResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -644,7 +935,7 @@ func file_contributor_v1_contributor_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_contributor_v1_contributor_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ContributorService_GetContributor_FullMethodName       = "/contributor.v1.ContributorService/GetContributor"
-	ContributorService_VerifyPassword_FullMethodName       = "/contributor.v1.ContributorService/VerifyPassword"
-	ContributorService_GetContributorsByVCS_FullMethodName = "/contributor.v1.ContributorService/GetContributorsByVCS"
+	ContributorService_GetContributor_FullMethodName              = "/contributor.v1.ContributorService/GetContributor"
+	ContributorService_VerifyPassword_FullMethodName              = "/contributor.v1.ContributorService/VerifyPassword"
+	ContributorService_GetContributorsByVCS_FullMethodName        = "/contributor.v1.ContributorService/GetContributorsByVCS"
+	ContributorService_GetContributorProfilesByVCS_FullMethodName = "/contributor.v1.ContributorService/GetContributorProfilesByVCS"
 )
 
 // ContributorServiceClient is the client API for ContributorService service.
@@ -35,6 +36,9 @@ type ContributorServiceClient interface {
 	// Lookup contributors by VCS provider and usernames.
 	// Used by webhook service to map VCS users to internal contributor IDs.
 	GetContributorsByVCS(ctx context.Context, in *GetContributorsByVCSRequest, opts ...grpc.CallOption) (*GetContributorsByVCSResponse, error)
+	// Lookup public profiles by VCS provider and VCS user IDs.
+	// Used to show display names next to leaderboard rows.
+	GetContributorProfilesByVCS(ctx context.Context, in *GetContributorProfilesByVCSRequest, opts ...grpc.CallOption) (*GetContributorProfilesByVCSResponse, error)
 }
 
 type contributorServiceClient struct {
@@ -75,6 +79,16 @@ func (c *contributorServiceClient) GetContributorsByVCS(ctx context.Context, in
 	return out, nil
 }
 
+func (c *contributorServiceClient) GetContributorProfilesByVCS(ctx context.Context, in *GetContributorProfilesByVCSRequest, opts ...grpc.CallOption) (*GetContributorProfilesByVCSResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContributorProfilesByVCSResponse)
+	err := c.cc.Invoke(ctx, ContributorService_GetContributorProfilesByVCS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ContributorServiceServer is the server API for ContributorService service.
 // All implementations must embed UnimplementedContributorServiceServer
 // for forward compatibility.
@@ -86,6 +100,9 @@ type ContributorServiceServer interface {
 	// Lookup contributors by VCS provider and usernames.
 	// Used by webhook service to map VCS users to internal contributor IDs.
 	GetContributorsByVCS(context.Context, *GetContributorsByVCSRequest) (*GetContributorsByVCSResponse, error)
+	// Lookup public profiles by VCS provider and VCS user IDs.
+	// Used to show display names next to leaderboard rows.
+	GetContributorProfilesByVCS(context.Context, *GetContributorProfilesByVCSRequest) (*GetContributorProfilesByVCSResponse, error)
 	mustEmbedUnimplementedContributorServiceServer()
 }
 
@@ -105,6 +122,9 @@ func (UnimplementedContributorServiceServer) VerifyPassword(context.Context, *Ve
 func (UnimplementedContributorServiceServer) GetContributorsByVCS(context.Context, *GetContributorsByVCSRequest) (*GetContributorsByVCSResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetContributorsByVCS not implemented")
 }
+func (UnimplementedContributorServiceServer) GetContributorProfilesByVCS(context.Context, *GetContributorProfilesByVCSRequest) (*GetContributorProfilesByVCSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContributorProfilesByVCS not implemented")
+}
 func (UnimplementedContributorServiceServer) mustEmbedUnimplementedContributorServiceServer() {}
 func (UnimplementedContributorServiceServer) testEmbeddedByValue()                            {}
 
@@ -180,6 +200,24 @@ func _ContributorService_GetContributorsByVCS_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContributorService_GetContributorProfilesByVCS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContributorProfilesByVCSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContributorServiceServer).GetContributorProfilesByVCS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContributorService_GetContributorProfilesByVCS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContributorServiceServer).GetContributorProfilesByVCS(ctx, req.(*GetContributorProfilesByVCSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ContributorService_ServiceDesc is the grpc.ServiceDesc for ContributorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,6 +237,10 @@ var ContributorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetContributorsByVCS",
 			Handler:    _ContributorService_GetContributorsByVCS_Handler,
 		},
+		{
+			MethodName: "GetContributorProfilesByVCS",
+			Handler:    _ContributorService_GetContributorProfilesByVCS_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "contributor/v1/contributor.proto",
@@ -9,6 +9,7 @@ package leaderboardscoringpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -31,6 +32,7 @@ const (
 	Timeframe_TIMEFRAME_MONTHLY     Timeframe = 3 // Represents the last month
 	Timeframe_TIMEFRAME_WEEKLY      Timeframe = 4 // Represents the last week
 	Timeframe_TIMEFRAME_DAILY       Timeframe = 5 // Represents the last day
+	Timeframe_TIMEFRAME_TRENDING    Timeframe = 6 // Time-decayed score, recent contributions weigh more
 )
 
 // Enum value maps for Timeframe.
@@ -42,6 +44,7 @@ var (
 		3: "TIMEFRAME_MONTHLY",
 		4: "TIMEFRAME_WEEKLY",
 		5: "TIMEFRAME_DAILY",
+		6: "TIMEFRAME_TRENDING",
 	}
 	Timeframe_value = map[string]int32{
 		"TIMEFRAME_UNSPECIFIED": 0,
@@ -50,6 +53,7 @@ var (
 		"TIMEFRAME_MONTHLY":     3,
 		"TIMEFRAME_WEEKLY":      4,
 		"TIMEFRAME_DAILY":       5,
+		"TIMEFRAME_TRENDING":    6,
 	}
 )
 
@@ -80,6 +84,62 @@ func (Timeframe) EnumDescriptor() ([]byte, []int) {
 	return file_leaderboardscoring_proto_rawDescGZIP(), []int{0}
 }
 
+// How ranks are assigned to equal scores, configured per leaderboard.
+type RankingMode int32
+
+const (
+	RankingMode_RANKING_MODE_UNSPECIFIED   RankingMode = 0
+	RankingMode_RANKING_MODE_ORDINAL       RankingMode = 1 // 1,2,3,4: equal scores are ordered by user ID
+	RankingMode_RANKING_MODE_COMPETITION   RankingMode = 2 // 1,2,2,4: equal scores share a rank, the next rank is skipped
+	RankingMode_RANKING_MODE_DENSE         RankingMode = 3 // 1,2,2,3: equal scores share a rank, no rank is skipped
+	RankingMode_RANKING_MODE_FIRST_REACHED RankingMode = 4 // 1,2,3,4: equal scores are ordered by who reached them first
+)
+
+// Enum value maps for RankingMode.
+var (
+	RankingMode_name = map[int32]string{
+		0: "RANKING_MODE_UNSPECIFIED",
+		1: "RANKING_MODE_ORDINAL",
+		2: "RANKING_MODE_COMPETITION",
+		3: "RANKING_MODE_DENSE",
+		4: "RANKING_MODE_FIRST_REACHED",
+	}
+	RankingMode_value = map[string]int32{
+		"RANKING_MODE_UNSPECIFIED":   0,
+		"RANKING_MODE_ORDINAL":       1,
+		"RANKING_MODE_COMPETITION":   2,
+		"RANKING_MODE_DENSE":         3,
+		"RANKING_MODE_FIRST_REACHED": 4,
+	}
+)
+
+func (x RankingMode) Enum() *RankingMode {
+	p := new(RankingMode)
+	*p = x
+	return p
+}
+
+func (x RankingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RankingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_leaderboardscoring_proto_enumTypes[1].Descriptor()
+}
+
+func (RankingMode) Type() protoreflect.EnumType {
+	return &file_leaderboardscoring_proto_enumTypes[1]
+}
+
+func (x RankingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RankingMode.Descriptor instead.
+func (RankingMode) EnumDescriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{1}
+}
+
 // Represents a single entry in any leaderboard.
 type LeaderboardRow struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -217,6 +277,7 @@ type GetLeaderboardResponse struct {
 	Timeframe     Timeframe              `protobuf:"varint,1,opt,name=timeframe,proto3,enum=leaderboardscoring.v1.Timeframe" json:"timeframe,omitempty"`
 	ProjectId     *string                `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
 	Rows          []*LeaderboardRow      `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	RankingMode   RankingMode            `protobuf:"varint,4,opt,name=ranking_mode,json=rankingMode,proto3,enum=leaderboardscoring.v1.RankingMode" json:"ranking_mode,omitempty"` // How the ranks of the rows were assigned.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -272,37 +333,1190 @@ func (x *GetLeaderboardResponse) GetRows() []*LeaderboardRow {
 	return nil
 }
 
-var File_leaderboardscoring_proto protoreflect.FileDescriptor
+func (x *GetLeaderboardResponse) GetRankingMode() RankingMode {
+	if x != nil {
+		return x.RankingMode
+	}
+	return RankingMode_RANKING_MODE_UNSPECIFIED
+}
 
-const file_leaderboardscoring_proto_rawDesc = "" +
-	"\n" +
-	"\x18leaderboardscoring.proto\x12\x15leaderboardscoring.v1\"S\n" +
-	"\x0eLeaderboardRow\x12\x12\n" +
-	"\x04rank\x18\x01 \x01(\x04R\x04rank\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05score\x18\x03 \x01(\x04R\x05score\"\xbf\x01\n" +
-	"\x15GetLeaderboardRequest\x12>\n" +
-	"\ttimeframe\x18\x01 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
-	"\x06offset\x18\x04 \x01(\x05R\x06offsetB\r\n" +
-	"\v_project_id\"\xc6\x01\n" +
-	"\x16GetLeaderboardResponse\x12>\n" +
-	"\ttimeframe\x18\x01 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
-	"\n" +
-	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x129\n" +
-	"\x04rows\x18\x03 \x03(\v2%.leaderboardscoring.v1.LeaderboardRowR\x04rowsB\r\n" +
-	"\v_project_id*\x96\x01\n" +
+// Subscribes to the top rows of one leaderboard.
+type WatchLeaderboardRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timeframe Timeframe              `protobuf:"varint,1,opt,name=timeframe,proto3,enum=leaderboardscoring.v1.Timeframe" json:"timeframe,omitempty"`
+	ProjectId *string                `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"` // If provided, watches a per-project leaderboard.
+	PageSize  int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`         // How many top rows to watch.
+	// Minimum time between two updates on this stream, in milliseconds.
+	// Values below the server minimum are raised to it.
+	MinIntervalMs int32 `protobuf:"varint,4,opt,name=min_interval_ms,json=minIntervalMs,proto3" json:"min_interval_ms,omitempty"`
+	// Version of the last update received before a reconnect. When the board has not
+	// changed since, the server skips the initial page.
+	ResumeVersion uint64 `protobuf:"varint,5,opt,name=resume_version,json=resumeVersion,proto3" json:"resume_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLeaderboardRequest) Reset() {
+	*x = WatchLeaderboardRequest{}
+	mi := &file_leaderboardscoring_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLeaderboardRequest) ProtoMessage() {}
+
+func (x *WatchLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*WatchLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchLeaderboardRequest) GetTimeframe() Timeframe {
+	if x != nil {
+		return x.Timeframe
+	}
+	return Timeframe_TIMEFRAME_UNSPECIFIED
+}
+
+func (x *WatchLeaderboardRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *WatchLeaderboardRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *WatchLeaderboardRequest) GetMinIntervalMs() int32 {
+	if x != nil {
+		return x.MinIntervalMs
+	}
+	return 0
+}
+
+func (x *WatchLeaderboardRequest) GetResumeVersion() uint64 {
+	if x != nil {
+		return x.ResumeVersion
+	}
+	return 0
+}
+
+type LeaderboardUpdate struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timeframe Timeframe              `protobuf:"varint,1,opt,name=timeframe,proto3,enum=leaderboardscoring.v1.Timeframe" json:"timeframe,omitempty"`
+	ProjectId *string                `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Rows      []*LeaderboardRow      `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Identifies the content of this update (key and rows), used to resume a stream.
+	Version uint64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Set on the first update of a stream.
+	Initial       bool        `protobuf:"varint,5,opt,name=initial,proto3" json:"initial,omitempty"`
+	RankingMode   RankingMode `protobuf:"varint,6,opt,name=ranking_mode,json=rankingMode,proto3,enum=leaderboardscoring.v1.RankingMode" json:"ranking_mode,omitempty"` // How the ranks of the rows were assigned.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardUpdate) Reset() {
+	*x = LeaderboardUpdate{}
+	mi := &file_leaderboardscoring_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardUpdate) ProtoMessage() {}
+
+func (x *LeaderboardUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardUpdate.ProtoReflect.Descriptor instead.
+func (*LeaderboardUpdate) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LeaderboardUpdate) GetTimeframe() Timeframe {
+	if x != nil {
+		return x.Timeframe
+	}
+	return Timeframe_TIMEFRAME_UNSPECIFIED
+}
+
+func (x *LeaderboardUpdate) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *LeaderboardUpdate) GetRows() []*LeaderboardRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *LeaderboardUpdate) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LeaderboardUpdate) GetInitial() bool {
+	if x != nil {
+		return x.Initial
+	}
+	return false
+}
+
+func (x *LeaderboardUpdate) GetRankingMode() RankingMode {
+	if x != nil {
+		return x.RankingMode
+	}
+	return RankingMode_RANKING_MODE_UNSPECIFIED
+}
+
+// Reads an all-time leaderboard as captured by the latest snapshot taken at or before as_of.
+type GetLeaderboardAsOfRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"` // If provided, reads a per-project leaderboard.
+	AsOf      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Pagination parameters
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Offset        int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardAsOfRequest) Reset() {
+	*x = GetLeaderboardAsOfRequest{}
+	mi := &file_leaderboardscoring_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardAsOfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardAsOfRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardAsOfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardAsOfRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardAsOfRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetLeaderboardAsOfRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetLeaderboardAsOfRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *GetLeaderboardAsOfRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetLeaderboardAsOfRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetLeaderboardAsOfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	SnapshotAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=snapshot_at,json=snapshotAt,proto3" json:"snapshot_at,omitempty"` // When the returned snapshot was taken.
+	Rows          []*LeaderboardRow      `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardAsOfResponse) Reset() {
+	*x = GetLeaderboardAsOfResponse{}
+	mi := &file_leaderboardscoring_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardAsOfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardAsOfResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardAsOfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardAsOfResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardAsOfResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLeaderboardAsOfResponse) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetLeaderboardAsOfResponse) GetSnapshotAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SnapshotAt
+	}
+	return nil
+}
+
+func (x *GetLeaderboardAsOfResponse) GetRows() []*LeaderboardRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// Reads the rank and score of one user in every snapshot of an all-time leaderboard.
+type GetUserRankHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProjectId     *string                `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"` // If provided, reads a per-project leaderboard.
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`                                  // Defaults to 90 days before to.
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`                                      // Defaults to now.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRankHistoryRequest) Reset() {
+	*x = GetUserRankHistoryRequest{}
+	mi := &file_leaderboardscoring_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRankHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRankHistoryRequest) ProtoMessage() {}
+
+func (x *GetUserRankHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRankHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRankHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUserRankHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserRankHistoryRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetUserRankHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetUserRankHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type RankHistoryPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SnapshotAt    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=snapshot_at,json=snapshotAt,proto3" json:"snapshot_at,omitempty"`
+	Rank          uint64                 `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Score         uint64                 `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankHistoryPoint) Reset() {
+	*x = RankHistoryPoint{}
+	mi := &file_leaderboardscoring_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankHistoryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankHistoryPoint) ProtoMessage() {}
+
+func (x *RankHistoryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankHistoryPoint.ProtoReflect.Descriptor instead.
+func (*RankHistoryPoint) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RankHistoryPoint) GetSnapshotAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SnapshotAt
+	}
+	return nil
+}
+
+func (x *RankHistoryPoint) GetRank() uint64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankHistoryPoint) GetScore() uint64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetUserRankHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProjectId     *string                `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Points        []*RankHistoryPoint    `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"` // Oldest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRankHistoryResponse) Reset() {
+	*x = GetUserRankHistoryResponse{}
+	mi := &file_leaderboardscoring_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRankHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRankHistoryResponse) ProtoMessage() {}
+
+func (x *GetUserRankHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRankHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetUserRankHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetUserRankHistoryResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserRankHistoryResponse) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetUserRankHistoryResponse) GetPoints() []*RankHistoryPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type ListUserBadgesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserBadgesRequest) Reset() {
+	*x = ListUserBadgesRequest{}
+	mi := &file_leaderboardscoring_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserBadgesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserBadgesRequest) ProtoMessage() {}
+
+func (x *ListUserBadgesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserBadgesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserBadgesRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListUserBadgesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Badge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ProjectId     *string                `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"` // Set for badges counted per project.
+	EventId       string                 `protobuf:"bytes,5,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`             // The event that earned the badge.
+	AwardedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=awarded_at,json=awardedAt,proto3" json:"awarded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Badge) Reset() {
+	*x = Badge{}
+	mi := &file_leaderboardscoring_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Badge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Badge) ProtoMessage() {}
+
+func (x *Badge) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Badge.ProtoReflect.Descriptor instead.
+func (*Badge) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Badge) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Badge) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Badge) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Badge) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *Badge) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Badge) GetAwardedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AwardedAt
+	}
+	return nil
+}
+
+type ListUserBadgesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Badges        []*Badge               `protobuf:"bytes,2,rep,name=badges,proto3" json:"badges,omitempty"` // Earliest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserBadgesResponse) Reset() {
+	*x = ListUserBadgesResponse{}
+	mi := &file_leaderboardscoring_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserBadgesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserBadgesResponse) ProtoMessage() {}
+
+func (x *ListUserBadgesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserBadgesResponse.ProtoReflect.Descriptor instead.
+func (*ListUserBadgesResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListUserBadgesResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserBadgesResponse) GetBadges() []*Badge {
+	if x != nil {
+		return x.Badges
+	}
+	return nil
+}
+
+type GetUserStreakRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStreakRequest) Reset() {
+	*x = GetUserStreakRequest{}
+	mi := &file_leaderboardscoring_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStreakRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStreakRequest) ProtoMessage() {}
+
+func (x *GetUserStreakRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStreakRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStreakRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserStreakRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Streak counts consecutive days, in the contributor's timezone, with a scored event.
+type GetUserStreakResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CurrentStreak int64                  `protobuf:"varint,2,opt,name=current_streak,json=currentStreak,proto3" json:"current_streak,omitempty"` // Zero once a day was missed.
+	LongestStreak int64                  `protobuf:"varint,3,opt,name=longest_streak,json=longestStreak,proto3" json:"longest_streak,omitempty"`
+	LastActiveDay string                 `protobuf:"bytes,4,opt,name=last_active_day,json=lastActiveDay,proto3" json:"last_active_day,omitempty"` // Local date as YYYY-MM-DD, empty without any activity.
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStreakResponse) Reset() {
+	*x = GetUserStreakResponse{}
+	mi := &file_leaderboardscoring_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStreakResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStreakResponse) ProtoMessage() {}
+
+func (x *GetUserStreakResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStreakResponse.ProtoReflect.Descriptor instead.
+func (*GetUserStreakResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetUserStreakResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserStreakResponse) GetCurrentStreak() int64 {
+	if x != nil {
+		return x.CurrentStreak
+	}
+	return 0
+}
+
+func (x *GetUserStreakResponse) GetLongestStreak() int64 {
+	if x != nil {
+		return x.LongestStreak
+	}
+	return 0
+}
+
+func (x *GetUserStreakResponse) GetLastActiveDay() string {
+	if x != nil {
+		return x.LastActiveDay
+	}
+	return ""
+}
+
+func (x *GetUserStreakResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// Splits the score of a user on one leaderboard by the persisted events behind it.
+type ExplainScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Timeframe     Timeframe              `protobuf:"varint,2,opt,name=timeframe,proto3,enum=leaderboardscoring.v1.Timeframe" json:"timeframe,omitempty"` // One of ALL_TIME, YEARLY, MONTHLY, WEEKLY or DAILY.
+	ProjectId     *string                `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`                // If provided, explains a per-project leaderboard.
+	Period        string                 `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`                                             // Period key like 2025-06 or 2025-W23, empty for the current period.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainScoreRequest) Reset() {
+	*x = ExplainScoreRequest{}
+	mi := &file_leaderboardscoring_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainScoreRequest) ProtoMessage() {}
+
+func (x *ExplainScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainScoreRequest.ProtoReflect.Descriptor instead.
+func (*ExplainScoreRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExplainScoreRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExplainScoreRequest) GetTimeframe() Timeframe {
+	if x != nil {
+		return x.Timeframe
+	}
+	return Timeframe_TIMEFRAME_UNSPECIFIED
+}
+
+func (x *ExplainScoreRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *ExplainScoreRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+// The points and number of events behind one part of a score.
+type ScoreGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Points        int64                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	Events        int64                  `protobuf:"varint,3,opt,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreGroup) Reset() {
+	*x = ScoreGroup{}
+	mi := &file_leaderboardscoring_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreGroup) ProtoMessage() {}
+
+func (x *ScoreGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreGroup.ProtoReflect.Descriptor instead.
+func (*ScoreGroup) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ScoreGroup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ScoreGroup) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *ScoreGroup) GetEvents() int64 {
+	if x != nil {
+		return x.Events
+	}
+	return 0
+}
+
+type ExplainScoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Timeframe     Timeframe              `protobuf:"varint,2,opt,name=timeframe,proto3,enum=leaderboardscoring.v1.Timeframe" json:"timeframe,omitempty"`
+	ProjectId     *string                `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"` // Unset for ALL_TIME.
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`     // Unset for ALL_TIME.
+	Total         int64                  `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	Events        int64                  `protobuf:"varint,7,opt,name=events,proto3" json:"events,omitempty"`
+	ByEventType   []*ScoreGroup          `protobuf:"bytes,8,rep,name=by_event_type,json=byEventType,proto3" json:"by_event_type,omitempty"` // Highest points first.
+	ByProject     []*ScoreGroup          `protobuf:"bytes,9,rep,name=by_project,json=byProject,proto3" json:"by_project,omitempty"`         // Highest points first.
+	ByDay         []*ScoreGroup          `protobuf:"bytes,10,rep,name=by_day,json=byDay,proto3" json:"by_day,omitempty"`                    // Oldest first, keyed by the local date.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainScoreResponse) Reset() {
+	*x = ExplainScoreResponse{}
+	mi := &file_leaderboardscoring_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainScoreResponse) ProtoMessage() {}
+
+func (x *ExplainScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardscoring_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainScoreResponse.ProtoReflect.Descriptor instead.
+func (*ExplainScoreResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboardscoring_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExplainScoreResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExplainScoreResponse) GetTimeframe() Timeframe {
+	if x != nil {
+		return x.Timeframe
+	}
+	return Timeframe_TIMEFRAME_UNSPECIFIED
+}
+
+func (x *ExplainScoreResponse) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *ExplainScoreResponse) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExplainScoreResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExplainScoreResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ExplainScoreResponse) GetEvents() int64 {
+	if x != nil {
+		return x.Events
+	}
+	return 0
+}
+
+func (x *ExplainScoreResponse) GetByEventType() []*ScoreGroup {
+	if x != nil {
+		return x.ByEventType
+	}
+	return nil
+}
+
+func (x *ExplainScoreResponse) GetByProject() []*ScoreGroup {
+	if x != nil {
+		return x.ByProject
+	}
+	return nil
+}
+
+func (x *ExplainScoreResponse) GetByDay() []*ScoreGroup {
+	if x != nil {
+		return x.ByDay
+	}
+	return nil
+}
+
+var File_leaderboardscoring_proto protoreflect.FileDescriptor
+
+const file_leaderboardscoring_proto_rawDesc = "" +
+	"\n" +
+	"\x18leaderboardscoring.proto\x12\x15leaderboardscoring.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"S\n" +
+	"\x0eLeaderboardRow\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x04R\x04rank\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x04R\x05score\"\xbf\x01\n" +
+	"\x15GetLeaderboardRequest\x12>\n" +
+	"\ttimeframe\x18\x01 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offsetB\r\n" +
+	"\v_project_id\"\x8d\x02\n" +
+	"\x16GetLeaderboardResponse\x12>\n" +
+	"\ttimeframe\x18\x01 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x129\n" +
+	"\x04rows\x18\x03 \x03(\v2%.leaderboardscoring.v1.LeaderboardRowR\x04rows\x12E\n" +
+	"\franking_mode\x18\x04 \x01(\x0e2\".leaderboardscoring.v1.RankingModeR\vrankingModeB\r\n" +
+	"\v_project_id\"\xf8\x01\n" +
+	"\x17WatchLeaderboardRequest\x12>\n" +
+	"\ttimeframe\x18\x01 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fmin_interval_ms\x18\x04 \x01(\x05R\rminIntervalMs\x12%\n" +
+	"\x0eresume_version\x18\x05 \x01(\x04R\rresumeVersionB\r\n" +
+	"\v_project_id\"\xbc\x02\n" +
+	"\x11LeaderboardUpdate\x12>\n" +
+	"\ttimeframe\x18\x01 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x129\n" +
+	"\x04rows\x18\x03 \x03(\v2%.leaderboardscoring.v1.LeaderboardRowR\x04rows\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x04R\aversion\x12\x18\n" +
+	"\ainitial\x18\x05 \x01(\bR\ainitial\x12E\n" +
+	"\franking_mode\x18\x06 \x01(\x0e2\".leaderboardscoring.v1.RankingModeR\vrankingModeB\r\n" +
+	"\v_project_id\"\xb4\x01\n" +
+	"\x19GetLeaderboardAsOfRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12/\n" +
+	"\x05as_of\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offsetB\r\n" +
+	"\v_project_id\"\xc7\x01\n" +
+	"\x1aGetLeaderboardAsOfResponse\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12;\n" +
+	"\vsnapshot_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"snapshotAt\x129\n" +
+	"\x04rows\x18\x03 \x03(\v2%.leaderboardscoring.v1.LeaderboardRowR\x04rowsB\r\n" +
+	"\v_project_id\"\xc3\x01\n" +
+	"\x19GetUserRankHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02toB\r\n" +
+	"\v_project_id\"y\n" +
+	"\x10RankHistoryPoint\x12;\n" +
+	"\vsnapshot_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"snapshotAt\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x04R\x04rank\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x04R\x05score\"\xa9\x01\n" +
+	"\x1aGetUserRankHistoryResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12?\n" +
+	"\x06points\x18\x03 \x03(\v2'.leaderboardscoring.v1.RankHistoryPointR\x06pointsB\r\n" +
+	"\v_project_id\"0\n" +
+	"\x15ListUserBadgesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xd6\x01\n" +
+	"\x05Badge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\"\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x19\n" +
+	"\bevent_id\x18\x05 \x01(\tR\aeventId\x129\n" +
+	"\n" +
+	"awarded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tawardedAtB\r\n" +
+	"\v_project_id\"g\n" +
+	"\x16ListUserBadgesResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x124\n" +
+	"\x06badges\x18\x02 \x03(\v2\x1c.leaderboardscoring.v1.BadgeR\x06badges\"/\n" +
+	"\x14GetUserStreakRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc2\x01\n" +
+	"\x15GetUserStreakResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0ecurrent_streak\x18\x02 \x01(\x03R\rcurrentStreak\x12%\n" +
+	"\x0elongest_streak\x18\x03 \x01(\x03R\rlongestStreak\x12&\n" +
+	"\x0flast_active_day\x18\x04 \x01(\tR\rlastActiveDay\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\"\xb9\x01\n" +
+	"\x13ExplainScoreRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12>\n" +
+	"\ttimeframe\x18\x02 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
+	"\n" +
+	"project_id\x18\x03 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x16\n" +
+	"\x06period\x18\x04 \x01(\tR\x06periodB\r\n" +
+	"\v_project_id\"N\n" +
+	"\n" +
+	"ScoreGroup\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x03R\x06points\x12\x16\n" +
+	"\x06events\x18\x03 \x01(\x03R\x06events\"\xef\x03\n" +
+	"\x14ExplainScoreResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12>\n" +
+	"\ttimeframe\x18\x02 \x01(\x0e2 .leaderboardscoring.v1.TimeframeR\ttimeframe\x12\"\n" +
+	"\n" +
+	"project_id\x18\x03 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x03R\x05total\x12\x16\n" +
+	"\x06events\x18\a \x01(\x03R\x06events\x12E\n" +
+	"\rby_event_type\x18\b \x03(\v2!.leaderboardscoring.v1.ScoreGroupR\vbyEventType\x12@\n" +
+	"\n" +
+	"by_project\x18\t \x03(\v2!.leaderboardscoring.v1.ScoreGroupR\tbyProject\x128\n" +
+	"\x06by_day\x18\n" +
+	" \x03(\v2!.leaderboardscoring.v1.ScoreGroupR\x05byDayB\r\n" +
+	"\v_project_id*\xae\x01\n" +
 	"\tTimeframe\x12\x19\n" +
 	"\x15TIMEFRAME_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12TIMEFRAME_ALL_TIME\x10\x01\x12\x14\n" +
 	"\x10TIMEFRAME_YEARLY\x10\x02\x12\x15\n" +
 	"\x11TIMEFRAME_MONTHLY\x10\x03\x12\x14\n" +
 	"\x10TIMEFRAME_WEEKLY\x10\x04\x12\x13\n" +
-	"\x0fTIMEFRAME_DAILY\x10\x052\x8a\x01\n" +
+	"\x0fTIMEFRAME_DAILY\x10\x05\x12\x16\n" +
+	"\x12TIMEFRAME_TRENDING\x10\x06*\x9b\x01\n" +
+	"\vRankingMode\x12\x1c\n" +
+	"\x18RANKING_MODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14RANKING_MODE_ORDINAL\x10\x01\x12\x1c\n" +
+	"\x18RANKING_MODE_COMPETITION\x10\x02\x12\x16\n" +
+	"\x12RANKING_MODE_DENSE\x10\x03\x12\x1e\n" +
+	"\x1aRANKING_MODE_FIRST_REACHED\x10\x042\xb4\x06\n" +
 	"\x19LeaderboardScoringService\x12m\n" +
-	"\x0eGetLeaderboard\x12,.leaderboardscoring.v1.GetLeaderboardRequest\x1a-.leaderboardscoring.v1.GetLeaderboardResponseB&Z$protobuf/golang/leaderboardscoringpbb\x06proto3"
+	"\x0eGetLeaderboard\x12,.leaderboardscoring.v1.GetLeaderboardRequest\x1a-.leaderboardscoring.v1.GetLeaderboardResponse\x12n\n" +
+	"\x10WatchLeaderboard\x12..leaderboardscoring.v1.WatchLeaderboardRequest\x1a(.leaderboardscoring.v1.LeaderboardUpdate0\x01\x12y\n" +
+	"\x12GetLeaderboardAsOf\x120.leaderboardscoring.v1.GetLeaderboardAsOfRequest\x1a1.leaderboardscoring.v1.GetLeaderboardAsOfResponse\x12y\n" +
+	"\x12GetUserRankHistory\x120.leaderboardscoring.v1.GetUserRankHistoryRequest\x1a1.leaderboardscoring.v1.GetUserRankHistoryResponse\x12m\n" +
+	"\x0eListUserBadges\x12,.leaderboardscoring.v1.ListUserBadgesRequest\x1a-.leaderboardscoring.v1.ListUserBadgesResponse\x12j\n" +
+	"\rGetUserStreak\x12+.leaderboardscoring.v1.GetUserStreakRequest\x1a,.leaderboardscoring.v1.GetUserStreakResponse\x12g\n" +
+	"\fExplainScore\x12*.leaderboardscoring.v1.ExplainScoreRequest\x1a+.leaderboardscoring.v1.ExplainScoreResponseB&Z$protobuf/golang/leaderboardscoringpbb\x06proto3"
 
 var (
 	file_leaderboardscoring_proto_rawDescOnce sync.Once
@@ -316,25 +1530,75 @@ func file_leaderboardscoring_proto_rawDescGZIP() []byte {
 	return file_leaderboardscoring_proto_rawDescData
 }
 
-var file_leaderboardscoring_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_leaderboardscoring_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_leaderboardscoring_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_leaderboardscoring_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_leaderboardscoring_proto_goTypes = []any{
-	(Timeframe)(0),                 // 0: leaderboardscoring.v1.Timeframe
-	(*LeaderboardRow)(nil),         // 1: leaderboardscoring.v1.LeaderboardRow
-	(*GetLeaderboardRequest)(nil),  // 2: leaderboardscoring.v1.GetLeaderboardRequest
-	(*GetLeaderboardResponse)(nil), // 3: leaderboardscoring.v1.GetLeaderboardResponse
+	(Timeframe)(0),                     // 0: leaderboardscoring.v1.Timeframe
+	(RankingMode)(0),                   // 1: leaderboardscoring.v1.RankingMode
+	(*LeaderboardRow)(nil),             // 2: leaderboardscoring.v1.LeaderboardRow
+	(*GetLeaderboardRequest)(nil),      // 3: leaderboardscoring.v1.GetLeaderboardRequest
+	(*GetLeaderboardResponse)(nil),     // 4: leaderboardscoring.v1.GetLeaderboardResponse
+	(*WatchLeaderboardRequest)(nil),    // 5: leaderboardscoring.v1.WatchLeaderboardRequest
+	(*LeaderboardUpdate)(nil),          // 6: leaderboardscoring.v1.LeaderboardUpdate
+	(*GetLeaderboardAsOfRequest)(nil),  // 7: leaderboardscoring.v1.GetLeaderboardAsOfRequest
+	(*GetLeaderboardAsOfResponse)(nil), // 8: leaderboardscoring.v1.GetLeaderboardAsOfResponse
+	(*GetUserRankHistoryRequest)(nil),  // 9: leaderboardscoring.v1.GetUserRankHistoryRequest
+	(*RankHistoryPoint)(nil),           // 10: leaderboardscoring.v1.RankHistoryPoint
+	(*GetUserRankHistoryResponse)(nil), // 11: leaderboardscoring.v1.GetUserRankHistoryResponse
+	(*ListUserBadgesRequest)(nil),      // 12: leaderboardscoring.v1.ListUserBadgesRequest
+	(*Badge)(nil),                      // 13: leaderboardscoring.v1.Badge
+	(*ListUserBadgesResponse)(nil),     // 14: leaderboardscoring.v1.ListUserBadgesResponse
+	(*GetUserStreakRequest)(nil),       // 15: leaderboardscoring.v1.GetUserStreakRequest
+	(*GetUserStreakResponse)(nil),      // 16: leaderboardscoring.v1.GetUserStreakResponse
+	(*ExplainScoreRequest)(nil),        // 17: leaderboardscoring.v1.ExplainScoreRequest
+	(*ScoreGroup)(nil),                 // 18: leaderboardscoring.v1.ScoreGroup
+	(*ExplainScoreResponse)(nil),       // 19: leaderboardscoring.v1.ExplainScoreResponse
+	(*timestamppb.Timestamp)(nil),      // 20: google.protobuf.Timestamp
 }
 var file_leaderboardscoring_proto_depIdxs = []int32{
-	0, // 0: leaderboardscoring.v1.GetLeaderboardRequest.timeframe:type_name -> leaderboardscoring.v1.Timeframe
-	0, // 1: leaderboardscoring.v1.GetLeaderboardResponse.timeframe:type_name -> leaderboardscoring.v1.Timeframe
-	1, // 2: leaderboardscoring.v1.GetLeaderboardResponse.rows:type_name -> leaderboardscoring.v1.LeaderboardRow
-	2, // 3: leaderboardscoring.v1.LeaderboardScoringService.GetLeaderboard:input_type -> leaderboardscoring.v1.GetLeaderboardRequest
-	3, // 4: leaderboardscoring.v1.LeaderboardScoringService.GetLeaderboard:output_type -> leaderboardscoring.v1.GetLeaderboardResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: leaderboardscoring.v1.GetLeaderboardRequest.timeframe:type_name -> leaderboardscoring.v1.Timeframe
+	0,  // 1: leaderboardscoring.v1.GetLeaderboardResponse.timeframe:type_name -> leaderboardscoring.v1.Timeframe
+	2,  // 2: leaderboardscoring.v1.GetLeaderboardResponse.rows:type_name -> leaderboardscoring.v1.LeaderboardRow
+	1,  // 3: leaderboardscoring.v1.GetLeaderboardResponse.ranking_mode:type_name -> leaderboardscoring.v1.RankingMode
+	0,  // 4: leaderboardscoring.v1.WatchLeaderboardRequest.timeframe:type_name -> leaderboardscoring.v1.Timeframe
+	0,  // 5: leaderboardscoring.v1.LeaderboardUpdate.timeframe:type_name -> leaderboardscoring.v1.Timeframe
+	2,  // 6: leaderboardscoring.v1.LeaderboardUpdate.rows:type_name -> leaderboardscoring.v1.LeaderboardRow
+	1,  // 7: leaderboardscoring.v1.LeaderboardUpdate.ranking_mode:type_name -> leaderboardscoring.v1.RankingMode
+	20, // 8: leaderboardscoring.v1.GetLeaderboardAsOfRequest.as_of:type_name -> google.protobuf.Timestamp
+	20, // 9: leaderboardscoring.v1.GetLeaderboardAsOfResponse.snapshot_at:type_name -> google.protobuf.Timestamp
+	2,  // 10: leaderboardscoring.v1.GetLeaderboardAsOfResponse.rows:type_name -> leaderboardscoring.v1.LeaderboardRow
+	20, // 11: leaderboardscoring.v1.GetUserRankHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	20, // 12: leaderboardscoring.v1.GetUserRankHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	20, // 13: leaderboardscoring.v1.RankHistoryPoint.snapshot_at:type_name -> google.protobuf.Timestamp
+	10, // 14: leaderboardscoring.v1.GetUserRankHistoryResponse.points:type_name -> leaderboardscoring.v1.RankHistoryPoint
+	20, // 15: leaderboardscoring.v1.Badge.awarded_at:type_name -> google.protobuf.Timestamp
+	13, // 16: leaderboardscoring.v1.ListUserBadgesResponse.badges:type_name -> leaderboardscoring.v1.Badge
+	0,  // 17: leaderboardscoring.v1.ExplainScoreRequest.timeframe:type_name -> leaderboardscoring.v1.Timeframe
+	0,  // 18: leaderboardscoring.v1.ExplainScoreResponse.timeframe:type_name -> leaderboardscoring.v1.Timeframe
+	20, // 19: leaderboardscoring.v1.ExplainScoreResponse.from:type_name -> google.protobuf.Timestamp
+	20, // 20: leaderboardscoring.v1.ExplainScoreResponse.to:type_name -> google.protobuf.Timestamp
+	18, // 21: leaderboardscoring.v1.ExplainScoreResponse.by_event_type:type_name -> leaderboardscoring.v1.ScoreGroup
+	18, // 22: leaderboardscoring.v1.ExplainScoreResponse.by_project:type_name -> leaderboardscoring.v1.ScoreGroup
+	18, // 23: leaderboardscoring.v1.ExplainScoreResponse.by_day:type_name -> leaderboardscoring.v1.ScoreGroup
+	3,  // 24: leaderboardscoring.v1.LeaderboardScoringService.GetLeaderboard:input_type -> leaderboardscoring.v1.GetLeaderboardRequest
+	5,  // 25: leaderboardscoring.v1.LeaderboardScoringService.WatchLeaderboard:input_type -> leaderboardscoring.v1.WatchLeaderboardRequest
+	7,  // 26: leaderboardscoring.v1.LeaderboardScoringService.GetLeaderboardAsOf:input_type -> leaderboardscoring.v1.GetLeaderboardAsOfRequest
+	9,  // 27: leaderboardscoring.v1.LeaderboardScoringService.GetUserRankHistory:input_type -> leaderboardscoring.v1.GetUserRankHistoryRequest
+	12, // 28: leaderboardscoring.v1.LeaderboardScoringService.ListUserBadges:input_type -> leaderboardscoring.v1.ListUserBadgesRequest
+	15, // 29: leaderboardscoring.v1.LeaderboardScoringService.GetUserStreak:input_type -> leaderboardscoring.v1.GetUserStreakRequest
+	17, // 30: leaderboardscoring.v1.LeaderboardScoringService.ExplainScore:input_type -> leaderboardscoring.v1.ExplainScoreRequest
+	4,  // 31: leaderboardscoring.v1.LeaderboardScoringService.GetLeaderboard:output_type -> leaderboardscoring.v1.GetLeaderboardResponse
+	6,  // 32: leaderboardscoring.v1.LeaderboardScoringService.WatchLeaderboard:output_type -> leaderboardscoring.v1.LeaderboardUpdate
+	8,  // 33: leaderboardscoring.v1.LeaderboardScoringService.GetLeaderboardAsOf:output_type -> leaderboardscoring.v1.GetLeaderboardAsOfResponse
+	11, // 34: leaderboardscoring.v1.LeaderboardScoringService.GetUserRankHistory:output_type -> leaderboardscoring.v1.GetUserRankHistoryResponse
+	14, // 35: leaderboardscoring.v1.LeaderboardScoringService.ListUserBadges:output_type -> leaderboardscoring.v1.ListUserBadgesResponse
+	16, // 36: leaderboardscoring.v1.LeaderboardScoringService.GetUserStreak:output_type -> leaderboardscoring.v1.GetUserStreakResponse
+	19, // 37: leaderboardscoring.v1.LeaderboardScoringService.ExplainScore:output_type -> leaderboardscoring.v1.ExplainScoreResponse
+	31, // [31:38] is the sub-list for method output_type
+	24, // [24:31] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_leaderboardscoring_proto_init() }
@@ -344,13 +1608,22 @@ func file_leaderboardscoring_proto_init() {
 	}
 	file_leaderboardscoring_proto_msgTypes[1].OneofWrappers = []any{}
 	file_leaderboardscoring_proto_msgTypes[2].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[3].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[4].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[5].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[6].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[7].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[9].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[11].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[15].OneofWrappers = []any{}
+	file_leaderboardscoring_proto_msgTypes[17].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaderboardscoring_proto_rawDesc), len(file_leaderboardscoring_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LeaderboardScoringService_GetLeaderboard_FullMethodName = "/leaderboardscoring.v1.LeaderboardScoringService/GetLeaderboard"
+	LeaderboardScoringService_GetLeaderboard_FullMethodName     = "/leaderboardscoring.v1.LeaderboardScoringService/GetLeaderboard"
+	LeaderboardScoringService_WatchLeaderboard_FullMethodName   = "/leaderboardscoring.v1.LeaderboardScoringService/WatchLeaderboard"
+	LeaderboardScoringService_GetLeaderboardAsOf_FullMethodName = "/leaderboardscoring.v1.LeaderboardScoringService/GetLeaderboardAsOf"
+	LeaderboardScoringService_GetUserRankHistory_FullMethodName = "/leaderboardscoring.v1.LeaderboardScoringService/GetUserRankHistory"
+	LeaderboardScoringService_ListUserBadges_FullMethodName     = "/leaderboardscoring.v1.LeaderboardScoringService/ListUserBadges"
+	LeaderboardScoringService_GetUserStreak_FullMethodName      = "/leaderboardscoring.v1.LeaderboardScoringService/GetUserStreak"
+	LeaderboardScoringService_ExplainScore_FullMethodName       = "/leaderboardscoring.v1.LeaderboardScoringService/ExplainScore"
 )
 
 // LeaderboardScoringServiceClient is the client API for LeaderboardScoringService service.
@@ -29,6 +35,18 @@ type LeaderboardScoringServiceClient interface {
 	// Fetches a single snapshot of the leaderboard with pagination.
 	// Real-time updates are handled by Centrifugo.
 	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	// Sends the current top rows of a leaderboard, then a new page whenever they change.
+	WatchLeaderboard(ctx context.Context, in *WatchLeaderboardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaderboardUpdate], error)
+	// Fetches a page of a leaderboard as it was at a past time, from the snapshot table.
+	GetLeaderboardAsOf(ctx context.Context, in *GetLeaderboardAsOfRequest, opts ...grpc.CallOption) (*GetLeaderboardAsOfResponse, error)
+	// Fetches the rank and score time series of one user on a leaderboard.
+	GetUserRankHistory(ctx context.Context, in *GetUserRankHistoryRequest, opts ...grpc.CallOption) (*GetUserRankHistoryResponse, error)
+	// Lists the badges a user has earned.
+	ListUserBadges(ctx context.Context, in *ListUserBadgesRequest, opts ...grpc.CallOption) (*ListUserBadgesResponse, error)
+	// Fetches the contribution streak of a user.
+	GetUserStreak(ctx context.Context, in *GetUserStreakRequest, opts ...grpc.CallOption) (*GetUserStreakResponse, error)
+	// Splits the score of a user on a leaderboard by event type, project and day.
+	ExplainScore(ctx context.Context, in *ExplainScoreRequest, opts ...grpc.CallOption) (*ExplainScoreResponse, error)
 }
 
 type leaderboardScoringServiceClient struct {
@@ -49,6 +67,75 @@ func (c *leaderboardScoringServiceClient) GetLeaderboard(ctx context.Context, in
 	return out, nil
 }
 
+func (c *leaderboardScoringServiceClient) WatchLeaderboard(ctx context.Context, in *WatchLeaderboardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaderboardUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LeaderboardScoringService_ServiceDesc.Streams[0], LeaderboardScoringService_WatchLeaderboard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLeaderboardRequest, LeaderboardUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaderboardScoringService_WatchLeaderboardClient = grpc.ServerStreamingClient[LeaderboardUpdate]
+
+func (c *leaderboardScoringServiceClient) GetLeaderboardAsOf(ctx context.Context, in *GetLeaderboardAsOfRequest, opts ...grpc.CallOption) (*GetLeaderboardAsOfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardAsOfResponse)
+	err := c.cc.Invoke(ctx, LeaderboardScoringService_GetLeaderboardAsOf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardScoringServiceClient) GetUserRankHistory(ctx context.Context, in *GetUserRankHistoryRequest, opts ...grpc.CallOption) (*GetUserRankHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserRankHistoryResponse)
+	err := c.cc.Invoke(ctx, LeaderboardScoringService_GetUserRankHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardScoringServiceClient) ListUserBadges(ctx context.Context, in *ListUserBadgesRequest, opts ...grpc.CallOption) (*ListUserBadgesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserBadgesResponse)
+	err := c.cc.Invoke(ctx, LeaderboardScoringService_ListUserBadges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardScoringServiceClient) GetUserStreak(ctx context.Context, in *GetUserStreakRequest, opts ...grpc.CallOption) (*GetUserStreakResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserStreakResponse)
+	err := c.cc.Invoke(ctx, LeaderboardScoringService_GetUserStreak_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardScoringServiceClient) ExplainScore(ctx context.Context, in *ExplainScoreRequest, opts ...grpc.CallOption) (*ExplainScoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainScoreResponse)
+	err := c.cc.Invoke(ctx, LeaderboardScoringService_ExplainScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LeaderboardScoringServiceServer is the server API for LeaderboardScoringService service.
 // All implementations must embed UnimplementedLeaderboardScoringServiceServer
 // for forward compatibility.
@@ -56,6 +143,18 @@ type LeaderboardScoringServiceServer interface {
 	// Fetches a single snapshot of the leaderboard with pagination.
 	// Real-time updates are handled by Centrifugo.
 	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	// Sends the current top rows of a leaderboard, then a new page whenever they change.
+	WatchLeaderboard(*WatchLeaderboardRequest, grpc.ServerStreamingServer[LeaderboardUpdate]) error
+	// Fetches a page of a leaderboard as it was at a past time, from the snapshot table.
+	GetLeaderboardAsOf(context.Context, *GetLeaderboardAsOfRequest) (*GetLeaderboardAsOfResponse, error)
+	// Fetches the rank and score time series of one user on a leaderboard.
+	GetUserRankHistory(context.Context, *GetUserRankHistoryRequest) (*GetUserRankHistoryResponse, error)
+	// Lists the badges a user has earned.
+	ListUserBadges(context.Context, *ListUserBadgesRequest) (*ListUserBadgesResponse, error)
+	// Fetches the contribution streak of a user.
+	GetUserStreak(context.Context, *GetUserStreakRequest) (*GetUserStreakResponse, error)
+	// Splits the score of a user on a leaderboard by event type, project and day.
+	ExplainScore(context.Context, *ExplainScoreRequest) (*ExplainScoreResponse, error)
 	mustEmbedUnimplementedLeaderboardScoringServiceServer()
 }
 
@@ -69,6 +168,24 @@ type UnimplementedLeaderboardScoringServiceServer struct{}
 func (UnimplementedLeaderboardScoringServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
 }
+func (UnimplementedLeaderboardScoringServiceServer) WatchLeaderboard(*WatchLeaderboardRequest, grpc.ServerStreamingServer[LeaderboardUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardScoringServiceServer) GetLeaderboardAsOf(context.Context, *GetLeaderboardAsOfRequest) (*GetLeaderboardAsOfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboardAsOf not implemented")
+}
+func (UnimplementedLeaderboardScoringServiceServer) GetUserRankHistory(context.Context, *GetUserRankHistoryRequest) (*GetUserRankHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserRankHistory not implemented")
+}
+func (UnimplementedLeaderboardScoringServiceServer) ListUserBadges(context.Context, *ListUserBadgesRequest) (*ListUserBadgesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserBadges not implemented")
+}
+func (UnimplementedLeaderboardScoringServiceServer) GetUserStreak(context.Context, *GetUserStreakRequest) (*GetUserStreakResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStreak not implemented")
+}
+func (UnimplementedLeaderboardScoringServiceServer) ExplainScore(context.Context, *ExplainScoreRequest) (*ExplainScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainScore not implemented")
+}
 func (UnimplementedLeaderboardScoringServiceServer) mustEmbedUnimplementedLeaderboardScoringServiceServer() {
 }
 func (UnimplementedLeaderboardScoringServiceServer) testEmbeddedByValue() {}
@@ -109,6 +226,107 @@ func _LeaderboardScoringService_GetLeaderboard_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LeaderboardScoringService_WatchLeaderboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLeaderboardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LeaderboardScoringServiceServer).WatchLeaderboard(m, &grpc.GenericServerStream[WatchLeaderboardRequest, LeaderboardUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaderboardScoringService_WatchLeaderboardServer = grpc.ServerStreamingServer[LeaderboardUpdate]
+
+func _LeaderboardScoringService_GetLeaderboardAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardScoringServiceServer).GetLeaderboardAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardScoringService_GetLeaderboardAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardScoringServiceServer).GetLeaderboardAsOf(ctx, req.(*GetLeaderboardAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardScoringService_GetUserRankHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRankHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardScoringServiceServer).GetUserRankHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardScoringService_GetUserRankHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardScoringServiceServer).GetUserRankHistory(ctx, req.(*GetUserRankHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardScoringService_ListUserBadges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserBadgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardScoringServiceServer).ListUserBadges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardScoringService_ListUserBadges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardScoringServiceServer).ListUserBadges(ctx, req.(*ListUserBadgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardScoringService_GetUserStreak_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserStreakRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardScoringServiceServer).GetUserStreak(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardScoringService_GetUserStreak_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardScoringServiceServer).GetUserStreak(ctx, req.(*GetUserStreakRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardScoringService_ExplainScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardScoringServiceServer).ExplainScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardScoringService_ExplainScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardScoringServiceServer).ExplainScore(ctx, req.(*ExplainScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LeaderboardScoringService_ServiceDesc is the grpc.ServiceDesc for LeaderboardScoringService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -120,7 +338,33 @@ var LeaderboardScoringService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLeaderboard",
 			Handler:    _LeaderboardScoringService_GetLeaderboard_Handler,
 		},
+		{
+			MethodName: "GetLeaderboardAsOf",
+			Handler:    _LeaderboardScoringService_GetLeaderboardAsOf_Handler,
+		},
+		{
+			MethodName: "GetUserRankHistory",
+			Handler:    _LeaderboardScoringService_GetUserRankHistory_Handler,
+		},
+		{
+			MethodName: "ListUserBadges",
+			Handler:    _LeaderboardScoringService_ListUserBadges_Handler,
+		},
+		{
+			MethodName: "GetUserStreak",
+			Handler:    _LeaderboardScoringService_GetUserStreak_Handler,
+		},
+		{
+			MethodName: "ExplainScore",
+			Handler:    _LeaderboardScoringService_ExplainScore_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLeaderboard",
+			Handler:       _LeaderboardScoringService_WatchLeaderboard_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "leaderboardscoring.proto",
 }
@@ -31,6 +31,8 @@ type ContributorStatResponse struct {
 	TotalScore    float64                         `protobuf:"fixed64,3,opt,name=total_score,json=totalScore,proto3" json:"total_score,omitempty"`
 	ProjectsScore map[uint64]float64              `protobuf:"bytes,4,rep,name=projects_score,json=projectsScore,proto3" json:"projects_score,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
 	ScoreHistory  map[uint64]*ProjectScoreHistory `protobuf:"bytes,5,rep,name=score_history,json=scoreHistory,proto3" json:"score_history,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Streak        *ContributorStreak              `protobuf:"bytes,6,opt,name=streak,proto3" json:"streak,omitempty"`
+	Analytics     *ContributorAnalytics           `protobuf:"bytes,7,opt,name=analytics,proto3" json:"analytics,omitempty"`
 }
 
 func (x *ContributorStatResponse) Reset() {
@@ -100,6 +102,189 @@ func (x *ContributorStatResponse) GetScoreHistory() map[uint64]*ProjectScoreHist
 	return nil
 }
 
+func (x *ContributorStatResponse) GetStreak() *ContributorStreak {
+	if x != nil {
+		return x.Streak
+	}
+	return nil
+}
+
+func (x *ContributorStatResponse) GetAnalytics() *ContributorAnalytics {
+	if x != nil {
+		return x.Analytics
+	}
+	return nil
+}
+
+// Whether a contributor is improving. Percentiles are the share of the board, in percent,
+// at or below the contributor's score; velocities are average points per day.
+type ContributorAnalytics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GlobalPercentile      float64            `protobuf:"fixed64,1,opt,name=global_percentile,json=globalPercentile,proto3" json:"global_percentile,omitempty"`
+	ProjectPercentiles    map[uint64]float64 `protobuf:"bytes,2,rep,name=project_percentiles,json=projectPercentiles,proto3" json:"project_percentiles,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	WeeklyRankChange      *int64             `protobuf:"varint,3,opt,name=weekly_rank_change,json=weeklyRankChange,proto3,oneof" json:"weekly_rank_change,omitempty"`            // global places climbed in 7 days, unset without a snapshot that old
+	MonthlyRankChange     *int64             `protobuf:"varint,4,opt,name=monthly_rank_change,json=monthlyRankChange,proto3,oneof" json:"monthly_rank_change,omitempty"`         // global places climbed in 30 days
+	WeeklyVelocity        float64            `protobuf:"fixed64,5,opt,name=weekly_velocity,json=weeklyVelocity,proto3" json:"weekly_velocity,omitempty"`                         // average points per day over the last 7 days
+	MonthlyVelocity       float64            `protobuf:"fixed64,6,opt,name=monthly_velocity,json=monthlyVelocity,proto3" json:"monthly_velocity,omitempty"`                      // average points per day over the last 30 days
+	ProjectedMonthEndRank uint64             `protobuf:"varint,7,opt,name=projected_month_end_rank,json=projectedMonthEndRank,proto3" json:"projected_month_end_rank,omitempty"` // at the pace of the last 7 days, 0 when not on the board
+}
+
+func (x *ContributorAnalytics) Reset() {
+	*x = ContributorAnalytics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContributorAnalytics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributorAnalytics) ProtoMessage() {}
+
+func (x *ContributorAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributorAnalytics.ProtoReflect.Descriptor instead.
+func (*ContributorAnalytics) Descriptor() ([]byte, []int) {
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ContributorAnalytics) GetGlobalPercentile() float64 {
+	if x != nil {
+		return x.GlobalPercentile
+	}
+	return 0
+}
+
+func (x *ContributorAnalytics) GetProjectPercentiles() map[uint64]float64 {
+	if x != nil {
+		return x.ProjectPercentiles
+	}
+	return nil
+}
+
+func (x *ContributorAnalytics) GetWeeklyRankChange() int64 {
+	if x != nil && x.WeeklyRankChange != nil {
+		return *x.WeeklyRankChange
+	}
+	return 0
+}
+
+func (x *ContributorAnalytics) GetMonthlyRankChange() int64 {
+	if x != nil && x.MonthlyRankChange != nil {
+		return *x.MonthlyRankChange
+	}
+	return 0
+}
+
+func (x *ContributorAnalytics) GetWeeklyVelocity() float64 {
+	if x != nil {
+		return x.WeeklyVelocity
+	}
+	return 0
+}
+
+func (x *ContributorAnalytics) GetMonthlyVelocity() float64 {
+	if x != nil {
+		return x.MonthlyVelocity
+	}
+	return 0
+}
+
+func (x *ContributorAnalytics) GetProjectedMonthEndRank() uint64 {
+	if x != nil {
+		return x.ProjectedMonthEndRank
+	}
+	return 0
+}
+
+// Consecutive days, in the contributor's timezone, with a scored event.
+type ContributorStreak struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Current       int64  `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"` // zero once a day was missed
+	Longest       int64  `protobuf:"varint,2,opt,name=longest,proto3" json:"longest,omitempty"`
+	LastActiveDay string `protobuf:"bytes,3,opt,name=last_active_day,json=lastActiveDay,proto3" json:"last_active_day,omitempty"` // local date as YYYY-MM-DD, empty without activity
+	Timezone      string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+}
+
+func (x *ContributorStreak) Reset() {
+	*x = ContributorStreak{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContributorStreak) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributorStreak) ProtoMessage() {}
+
+func (x *ContributorStreak) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributorStreak.ProtoReflect.Descriptor instead.
+func (*ContributorStreak) Descriptor() ([]byte, []int) {
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ContributorStreak) GetCurrent() int64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *ContributorStreak) GetLongest() int64 {
+	if x != nil {
+		return x.Longest
+	}
+	return 0
+}
+
+func (x *ContributorStreak) GetLastActiveDay() string {
+	if x != nil {
+		return x.LastActiveDay
+	}
+	return ""
+}
+
+func (x *ContributorStreak) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type ProjectScoreHistory struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -111,7 +296,7 @@ type ProjectScoreHistory struct {
 func (x *ProjectScoreHistory) Reset() {
 	*x = ProjectScoreHistory{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[1]
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -124,7 +309,7 @@ func (x *ProjectScoreHistory) String() string {
 func (*ProjectScoreHistory) ProtoMessage() {}
 
 func (x *ProjectScoreHistory) ProtoReflect() protoreflect.Message {
-	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[1]
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -137,7 +322,7 @@ func (x *ProjectScoreHistory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProjectScoreHistory.ProtoReflect.Descriptor instead.
 func (*ProjectScoreHistory) Descriptor() ([]byte, []int) {
-	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{1}
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ProjectScoreHistory) GetEntries() []*ScoreEntry {
@@ -160,7 +345,7 @@ type ScoreEntry struct {
 func (x *ScoreEntry) Reset() {
 	*x = ScoreEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[2]
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -173,7 +358,7 @@ func (x *ScoreEntry) String() string {
 func (*ScoreEntry) ProtoMessage() {}
 
 func (x *ScoreEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[2]
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -186,7 +371,7 @@ func (x *ScoreEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScoreEntry.ProtoReflect.Descriptor instead.
 func (*ScoreEntry) Descriptor() ([]byte, []int) {
-	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{2}
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ScoreEntry) GetActivity() string {
@@ -221,7 +406,7 @@ type ContributorStatRequest struct {
 func (x *ContributorStatRequest) Reset() {
 	*x = ContributorStatRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[3]
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -234,7 +419,7 @@ func (x *ContributorStatRequest) String() string {
 func (*ContributorStatRequest) ProtoMessage() {}
 
 func (x *ContributorStatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[3]
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +432,7 @@ func (x *ContributorStatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContributorStatRequest.ProtoReflect.Descriptor instead.
 func (*ContributorStatRequest) Descriptor() ([]byte, []int) {
-	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{3}
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ContributorStatRequest) GetContributorId() uint64 {
@@ -271,7 +456,7 @@ type GetPublicLeaderboardRequest struct {
 func (x *GetPublicLeaderboardRequest) Reset() {
 	*x = GetPublicLeaderboardRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[4]
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -284,7 +469,7 @@ func (x *GetPublicLeaderboardRequest) String() string {
 func (*GetPublicLeaderboardRequest) ProtoMessage() {}
 
 func (x *GetPublicLeaderboardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[4]
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -297,7 +482,7 @@ func (x *GetPublicLeaderboardRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPublicLeaderboardRequest.ProtoReflect.Descriptor instead.
 func (*GetPublicLeaderboardRequest) Descriptor() ([]byte, []int) {
-	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{4}
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetPublicLeaderboardRequest) GetProjectId() uint64 {
@@ -326,14 +511,15 @@ type GetPublicLeaderboardResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProjectId uint64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	Rows      []*PublicLeaderboardRow `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	ProjectId   uint64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Rows        []*PublicLeaderboardRow `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	LastUpdated *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
 }
 
 func (x *GetPublicLeaderboardResponse) Reset() {
 	*x = GetPublicLeaderboardResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[5]
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -346,7 +532,7 @@ func (x *GetPublicLeaderboardResponse) String() string {
 func (*GetPublicLeaderboardResponse) ProtoMessage() {}
 
 func (x *GetPublicLeaderboardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[5]
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,7 +545,7 @@ func (x *GetPublicLeaderboardResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPublicLeaderboardResponse.ProtoReflect.Descriptor instead.
 func (*GetPublicLeaderboardResponse) Descriptor() ([]byte, []int) {
-	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{5}
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetPublicLeaderboardResponse) GetProjectId() uint64 {
@@ -376,20 +562,31 @@ func (x *GetPublicLeaderboardResponse) GetRows() []*PublicLeaderboardRow {
 	return nil
 }
 
+func (x *GetPublicLeaderboardResponse) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+// Anonymous contributors keep their rank, user_id is 0 and display_name a stable pseudonym
 type PublicLeaderboardRow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId uint64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Rank   uint64  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
-	Score  float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	UserId       uint64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Rank         uint64  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Score        float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	DisplayName  string  `protobuf:"bytes,4,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ProfileImage string  `protobuf:"bytes,5,opt,name=profile_image,json=profileImage,proto3" json:"profile_image,omitempty"` // empty for anonymous contributors
+	Anonymous    bool    `protobuf:"varint,6,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
 }
 
 func (x *PublicLeaderboardRow) Reset() {
 	*x = PublicLeaderboardRow{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[6]
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +599,7 @@ func (x *PublicLeaderboardRow) String() string {
 func (*PublicLeaderboardRow) ProtoMessage() {}
 
 func (x *PublicLeaderboardRow) ProtoReflect() protoreflect.Message {
-	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[6]
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +612,7 @@ func (x *PublicLeaderboardRow) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicLeaderboardRow.ProtoReflect.Descriptor instead.
 func (*PublicLeaderboardRow) Descriptor() ([]byte, []int) {
-	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{6}
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PublicLeaderboardRow) GetUserId() uint64 {
@@ -439,6 +636,170 @@ func (x *PublicLeaderboardRow) GetScore() float64 {
 	return 0
 }
 
+func (x *PublicLeaderboardRow) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *PublicLeaderboardRow) GetProfileImage() string {
+	if x != nil {
+		return x.ProfileImage
+	}
+	return ""
+}
+
+func (x *PublicLeaderboardRow) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+// Scores of a contributor in one period of user_project_scores
+type ContributorPeriodScoresRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContributorId uint64 `protobuf:"varint,1,opt,name=contributor_id,json=contributorId,proto3" json:"contributor_id,omitempty"`
+	Timeframe     string `protobuf:"bytes,2,opt,name=timeframe,proto3" json:"timeframe,omitempty"` // daily, weekly, monthly or yearly
+	Period        string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`       // e.g. 2025-06-01, 2025-W23, 2025-06 or 2025, empty for the current period
+}
+
+func (x *ContributorPeriodScoresRequest) Reset() {
+	*x = ContributorPeriodScoresRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContributorPeriodScoresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributorPeriodScoresRequest) ProtoMessage() {}
+
+func (x *ContributorPeriodScoresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributorPeriodScoresRequest.ProtoReflect.Descriptor instead.
+func (*ContributorPeriodScoresRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ContributorPeriodScoresRequest) GetContributorId() uint64 {
+	if x != nil {
+		return x.ContributorId
+	}
+	return 0
+}
+
+func (x *ContributorPeriodScoresRequest) GetTimeframe() string {
+	if x != nil {
+		return x.Timeframe
+	}
+	return ""
+}
+
+func (x *ContributorPeriodScoresRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+type ContributorPeriodScoresResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContributorId uint64             `protobuf:"varint,1,opt,name=contributor_id,json=contributorId,proto3" json:"contributor_id,omitempty"`
+	Timeframe     string             `protobuf:"bytes,2,opt,name=timeframe,proto3" json:"timeframe,omitempty"`
+	Period        string             `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	TotalScore    float64            `protobuf:"fixed64,4,opt,name=total_score,json=totalScore,proto3" json:"total_score,omitempty"`
+	ProjectsScore map[uint64]float64 `protobuf:"bytes,5,rep,name=projects_score,json=projectsScore,proto3" json:"projects_score,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"` // project 0 holds scores not attributed to a project
+}
+
+func (x *ContributorPeriodScoresResponse) Reset() {
+	*x = ContributorPeriodScoresResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContributorPeriodScoresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributorPeriodScoresResponse) ProtoMessage() {}
+
+func (x *ContributorPeriodScoresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboardstat_leaderboardstat_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributorPeriodScoresResponse.ProtoReflect.Descriptor instead.
+func (*ContributorPeriodScoresResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboardstat_leaderboardstat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ContributorPeriodScoresResponse) GetContributorId() uint64 {
+	if x != nil {
+		return x.ContributorId
+	}
+	return 0
+}
+
+func (x *ContributorPeriodScoresResponse) GetTimeframe() string {
+	if x != nil {
+		return x.Timeframe
+	}
+	return ""
+}
+
+func (x *ContributorPeriodScoresResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *ContributorPeriodScoresResponse) GetTotalScore() float64 {
+	if x != nil {
+		return x.TotalScore
+	}
+	return 0
+}
+
+func (x *ContributorPeriodScoresResponse) GetProjectsScore() map[uint64]float64 {
+	if x != nil {
+		return x.ProjectsScore
+	}
+	return nil
+}
+
 var File_leaderboardstat_leaderboardstat_proto protoreflect.FileDescriptor
 
 var file_leaderboardstat_leaderboardstat_proto_rawDesc = []byte{
@@ -447,7 +808,7 @@ var file_leaderboardstat_leaderboardstat_proto_rawDesc = []byte{
 	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
 	0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf0, 0x03, 0x0a, 0x17, 0x43, 0x6f,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf1, 0x04, 0x0a, 0x17, 0x43, 0x6f,
 	0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62,
 	0x75, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63,
@@ -468,17 +829,68 @@ var file_leaderboardstat_leaderboardstat_proto_rawDesc = []byte{
 	0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
 	0x6e, 0x73, 0x65, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x48, 0x69, 0x73, 0x74,
-	0x6f, 0x72, 0x79, 0x1a, 0x40, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x53,
-	0x63, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x65, 0x0a, 0x11, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x48, 0x69,
-	0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
-	0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4c, 0x0a, 0x13,
+	0x6f, 0x72, 0x79, 0x12, 0x3a, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f,
+	0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x12,
+	0x43, 0x0a, 0x09, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x73, 0x74, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72,
+	0x41, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x52, 0x09, 0x61, 0x6e, 0x61, 0x6c, 0x79,
+	0x74, 0x69, 0x63, 0x73, 0x1a, 0x40, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x65, 0x0a, 0x11, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x50,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9e, 0x04,
+	0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x41, 0x6e, 0x61,
+	0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c,
+	0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x10, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x69, 0x6c, 0x65, 0x12, 0x6e, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x3d, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74,
+	0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x12, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x12, 0x77, 0x65, 0x65, 0x6b, 0x6c, 0x79, 0x5f, 0x72, 0x61,
+	0x6e, 0x6b, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x00, 0x52, 0x10, 0x77, 0x65, 0x65, 0x6b, 0x6c, 0x79, 0x52, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x33, 0x0a, 0x13, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c,
+	0x79, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x11, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x52, 0x61,
+	0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0f, 0x77,
+	0x65, 0x65, 0x6b, 0x6c, 0x79, 0x5f, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x77, 0x65, 0x65, 0x6b, 0x6c, 0x79, 0x56, 0x65, 0x6c, 0x6f,
+	0x63, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f,
+	0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f,
+	0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x56, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x12,
+	0x37, 0x0a, 0x18, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x6f, 0x6e,
+	0x74, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x15, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x74,
+	0x68, 0x45, 0x6e, 0x64, 0x52, 0x61, 0x6e, 0x6b, 0x1a, 0x45, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42,
+	0x15, 0x0a, 0x13, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x6c, 0x79, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68,
+	0x6c, 0x79, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x8b,
+	0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x44, 0x61, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x4c, 0x0a, 0x13,
 	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x48, 0x69, 0x73, 0x74,
 	0x6f, 0x72, 0x79, 0x12, 0x35, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
 	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
@@ -502,49 +914,86 @@ var file_leaderboardstat_leaderboardstat_proto_rawDesc = []byte{
 	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
 	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x78, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x50, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
-	0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77,
-	0x73, 0x22, 0x59, 0x0a, 0x14, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x6f, 0x77, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x32, 0xf7, 0x01, 0x0a,
-	0x16, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x68, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x27,
-	0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74,
-	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x73, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x2c, 0x2e, 0x6c, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0xb7, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x50,
 	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xc3, 0x01, 0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x2e, 0x6c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x42, 0x14,
-	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x72, 0x61, 0x6e,
-	0x6b, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x6f, 0x6c, 0x61,
-	0x6e, 0x67, 0x2f, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74,
-	0x61, 0x74, 0xa2, 0x02, 0x03, 0x4c, 0x58, 0x58, 0xaa, 0x02, 0x0f, 0x4c, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0xca, 0x02, 0x0f, 0x4c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0xe2, 0x02, 0x1b, 0x4c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x5c, 0x47,
-	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0f, 0x4c, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f,
+	0x77, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0xbf, 0x01, 0x0a, 0x14, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x6f, 0x77, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f,
+	0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d,
+	0x6f, 0x75, 0x73, 0x22, 0x7d, 0x0a, 0x1e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x6f, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x22, 0xcd, 0x02, 0x0a, 0x1f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x6f, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x6a, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x1a, 0x40, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x32, 0xf8, 0x02, 0x0a, 0x16, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x68, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x27, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12,
+	0x2c, 0x2e, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e,
+	0x47, 0x65, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7f, 0x0a, 0x1a,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x2f, 0x2e, 0x6c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x21, 0x5a,
+	0x1f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67,
+	0x2f, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x74, 0x61, 0x74,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -559,35 +1008,48 @@ func file_leaderboardstat_leaderboardstat_proto_rawDescGZIP() []byte {
 	return file_leaderboardstat_leaderboardstat_proto_rawDescData
 }
 
-var file_leaderboardstat_leaderboardstat_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_leaderboardstat_leaderboardstat_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_leaderboardstat_leaderboardstat_proto_goTypes = []any{
-	(*ContributorStatResponse)(nil),      // 0: leaderboardstat.ContributorStatResponse
-	(*ProjectScoreHistory)(nil),          // 1: leaderboardstat.ProjectScoreHistory
-	(*ScoreEntry)(nil),                   // 2: leaderboardstat.ScoreEntry
-	(*ContributorStatRequest)(nil),       // 3: leaderboardstat.ContributorStatRequest
-	(*GetPublicLeaderboardRequest)(nil),  // 4: leaderboardstat.GetPublicLeaderboardRequest
-	(*GetPublicLeaderboardResponse)(nil), // 5: leaderboardstat.GetPublicLeaderboardResponse
-	(*PublicLeaderboardRow)(nil),         // 6: leaderboardstat.PublicLeaderboardRow
-	nil,                                  // 7: leaderboardstat.ContributorStatResponse.ProjectsScoreEntry
-	nil,                                  // 8: leaderboardstat.ContributorStatResponse.ScoreHistoryEntry
-	(*timestamppb.Timestamp)(nil),        // 9: google.protobuf.Timestamp
+	(*ContributorStatResponse)(nil),         // 0: leaderboardstat.ContributorStatResponse
+	(*ContributorAnalytics)(nil),            // 1: leaderboardstat.ContributorAnalytics
+	(*ContributorStreak)(nil),               // 2: leaderboardstat.ContributorStreak
+	(*ProjectScoreHistory)(nil),             // 3: leaderboardstat.ProjectScoreHistory
+	(*ScoreEntry)(nil),                      // 4: leaderboardstat.ScoreEntry
+	(*ContributorStatRequest)(nil),          // 5: leaderboardstat.ContributorStatRequest
+	(*GetPublicLeaderboardRequest)(nil),     // 6: leaderboardstat.GetPublicLeaderboardRequest
+	(*GetPublicLeaderboardResponse)(nil),    // 7: leaderboardstat.GetPublicLeaderboardResponse
+	(*PublicLeaderboardRow)(nil),            // 8: leaderboardstat.PublicLeaderboardRow
+	(*ContributorPeriodScoresRequest)(nil),  // 9: leaderboardstat.ContributorPeriodScoresRequest
+	(*ContributorPeriodScoresResponse)(nil), // 10: leaderboardstat.ContributorPeriodScoresResponse
+	nil,                                     // 11: leaderboardstat.ContributorStatResponse.ProjectsScoreEntry
+	nil,                                     // 12: leaderboardstat.ContributorStatResponse.ScoreHistoryEntry
+	nil,                                     // 13: leaderboardstat.ContributorAnalytics.ProjectPercentilesEntry
+	nil,                                     // 14: leaderboardstat.ContributorPeriodScoresResponse.ProjectsScoreEntry
+	(*timestamppb.Timestamp)(nil),           // 15: google.protobuf.Timestamp
 }
 var file_leaderboardstat_leaderboardstat_proto_depIdxs = []int32{
-	7, // 0: leaderboardstat.ContributorStatResponse.projects_score:type_name -> leaderboardstat.ContributorStatResponse.ProjectsScoreEntry
-	8, // 1: leaderboardstat.ContributorStatResponse.score_history:type_name -> leaderboardstat.ContributorStatResponse.ScoreHistoryEntry
-	2, // 2: leaderboardstat.ProjectScoreHistory.entries:type_name -> leaderboardstat.ScoreEntry
-	9, // 3: leaderboardstat.ScoreEntry.earned_at:type_name -> google.protobuf.Timestamp
-	6, // 4: leaderboardstat.GetPublicLeaderboardResponse.rows:type_name -> leaderboardstat.PublicLeaderboardRow
-	1, // 5: leaderboardstat.ContributorStatResponse.ScoreHistoryEntry.value:type_name -> leaderboardstat.ProjectScoreHistory
-	3, // 6: leaderboardstat.LeaderboardStatService.GetContributorStats:input_type -> leaderboardstat.ContributorStatRequest
-	4, // 7: leaderboardstat.LeaderboardStatService.GetPublicLeaderboard:input_type -> leaderboardstat.GetPublicLeaderboardRequest
-	0, // 8: leaderboardstat.LeaderboardStatService.GetContributorStats:output_type -> leaderboardstat.ContributorStatResponse
-	5, // 9: leaderboardstat.LeaderboardStatService.GetPublicLeaderboard:output_type -> leaderboardstat.GetPublicLeaderboardResponse
-	8, // [8:10] is the sub-list for method output_type
-	6, // [6:8] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	11, // 0: leaderboardstat.ContributorStatResponse.projects_score:type_name -> leaderboardstat.ContributorStatResponse.ProjectsScoreEntry
+	12, // 1: leaderboardstat.ContributorStatResponse.score_history:type_name -> leaderboardstat.ContributorStatResponse.ScoreHistoryEntry
+	2,  // 2: leaderboardstat.ContributorStatResponse.streak:type_name -> leaderboardstat.ContributorStreak
+	1,  // 3: leaderboardstat.ContributorStatResponse.analytics:type_name -> leaderboardstat.ContributorAnalytics
+	13, // 4: leaderboardstat.ContributorAnalytics.project_percentiles:type_name -> leaderboardstat.ContributorAnalytics.ProjectPercentilesEntry
+	4,  // 5: leaderboardstat.ProjectScoreHistory.entries:type_name -> leaderboardstat.ScoreEntry
+	15, // 6: leaderboardstat.ScoreEntry.earned_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: leaderboardstat.GetPublicLeaderboardResponse.rows:type_name -> leaderboardstat.PublicLeaderboardRow
+	15, // 8: leaderboardstat.GetPublicLeaderboardResponse.last_updated:type_name -> google.protobuf.Timestamp
+	14, // 9: leaderboardstat.ContributorPeriodScoresResponse.projects_score:type_name -> leaderboardstat.ContributorPeriodScoresResponse.ProjectsScoreEntry
+	3,  // 10: leaderboardstat.ContributorStatResponse.ScoreHistoryEntry.value:type_name -> leaderboardstat.ProjectScoreHistory
+	5,  // 11: leaderboardstat.LeaderboardStatService.GetContributorStats:input_type -> leaderboardstat.ContributorStatRequest
+	6,  // 12: leaderboardstat.LeaderboardStatService.GetPublicLeaderboard:input_type -> leaderboardstat.GetPublicLeaderboardRequest
+	9,  // 13: leaderboardstat.LeaderboardStatService.GetContributorPeriodScores:input_type -> leaderboardstat.ContributorPeriodScoresRequest
+	0,  // 14: leaderboardstat.LeaderboardStatService.GetContributorStats:output_type -> leaderboardstat.ContributorStatResponse
+	7,  // 15: leaderboardstat.LeaderboardStatService.GetPublicLeaderboard:output_type -> leaderboardstat.GetPublicLeaderboardResponse
+	10, // 16: leaderboardstat.LeaderboardStatService.GetContributorPeriodScores:output_type -> leaderboardstat.ContributorPeriodScoresResponse
+	14, // [14:17] is the sub-list for method output_type
+	11, // [11:14] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_leaderboardstat_leaderboardstat_proto_init() }
@@ -609,7 +1071,7 @@ func file_leaderboardstat_leaderboardstat_proto_init() {
 			}
 		}
 		file_leaderboardstat_leaderboardstat_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*ProjectScoreHistory); i {
+			switch v := v.(*ContributorAnalytics); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -621,7 +1083,7 @@ func file_leaderboardstat_leaderboardstat_proto_init() {
 			}
 		}
 		file_leaderboardstat_leaderboardstat_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*ScoreEntry); i {
+			switch v := v.(*ContributorStreak); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -633,7 +1095,7 @@ func file_leaderboardstat_leaderboardstat_proto_init() {
 			}
 		}
 		file_leaderboardstat_leaderboardstat_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*ContributorStatRequest); i {
+			switch v := v.(*ProjectScoreHistory); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -645,7 +1107,7 @@ func file_leaderboardstat_leaderboardstat_proto_init() {
 			}
 		}
 		file_leaderboardstat_leaderboardstat_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*GetPublicLeaderboardRequest); i {
+			switch v := v.(*ScoreEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -657,7 +1119,7 @@ func file_leaderboardstat_leaderboardstat_proto_init() {
 			}
 		}
 		file_leaderboardstat_leaderboardstat_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*GetPublicLeaderboardResponse); i {
+			switch v := v.(*ContributorStatRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -669,6 +1131,30 @@ func file_leaderboardstat_leaderboardstat_proto_init() {
 			}
 		}
 		file_leaderboardstat_leaderboardstat_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPublicLeaderboardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leaderboardstat_leaderboardstat_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPublicLeaderboardResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leaderboardstat_leaderboardstat_proto_msgTypes[8].Exporter = func(v any, i int) any {
 			switch v := v.(*PublicLeaderboardRow); i {
 			case 0:
 				return &v.state
@@ -680,14 +1166,39 @@ func file_leaderboardstat_leaderboardstat_proto_init() {
 				return nil
 			}
 		}
+		file_leaderboardstat_leaderboardstat_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ContributorPeriodScoresRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leaderboardstat_leaderboardstat_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ContributorPeriodScoresResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_leaderboardstat_leaderboardstat_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_leaderboardstat_leaderboardstat_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LeaderboardStatService_GetContributorStats_FullMethodName  = "/leaderboardstat.LeaderboardStatService/GetContributorStats"
-	LeaderboardStatService_GetPublicLeaderboard_FullMethodName = "/leaderboardstat.LeaderboardStatService/GetPublicLeaderboard"
+	LeaderboardStatService_GetContributorStats_FullMethodName        = "/leaderboardstat.LeaderboardStatService/GetContributorStats"
+	LeaderboardStatService_GetPublicLeaderboard_FullMethodName       = "/leaderboardstat.LeaderboardStatService/GetPublicLeaderboard"
+	LeaderboardStatService_GetContributorPeriodScores_FullMethodName = "/leaderboardstat.LeaderboardStatService/GetContributorPeriodScores"
 )
 
 // LeaderboardStatServiceClient is the client API for LeaderboardStatService service.
@@ -29,6 +30,7 @@ const (
 type LeaderboardStatServiceClient interface {
 	GetContributorStats(ctx context.Context, in *ContributorStatRequest, opts ...grpc.CallOption) (*ContributorStatResponse, error)
 	GetPublicLeaderboard(ctx context.Context, in *GetPublicLeaderboardRequest, opts ...grpc.CallOption) (*GetPublicLeaderboardResponse, error)
+	GetContributorPeriodScores(ctx context.Context, in *ContributorPeriodScoresRequest, opts ...grpc.CallOption) (*ContributorPeriodScoresResponse, error)
 }
 
 type leaderboardStatServiceClient struct {
@@ -59,12 +61,23 @@ func (c *leaderboardStatServiceClient) GetPublicLeaderboard(ctx context.Context,
 	return out, nil
 }
 
+func (c *leaderboardStatServiceClient) GetContributorPeriodScores(ctx context.Context, in *ContributorPeriodScoresRequest, opts ...grpc.CallOption) (*ContributorPeriodScoresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContributorPeriodScoresResponse)
+	err := c.cc.Invoke(ctx, LeaderboardStatService_GetContributorPeriodScores_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LeaderboardStatServiceServer is the server API for LeaderboardStatService service.
 // All implementations must embed UnimplementedLeaderboardStatServiceServer
 // for forward compatibility.
 type LeaderboardStatServiceServer interface {
 	GetContributorStats(context.Context, *ContributorStatRequest) (*ContributorStatResponse, error)
 	GetPublicLeaderboard(context.Context, *GetPublicLeaderboardRequest) (*GetPublicLeaderboardResponse, error)
+	GetContributorPeriodScores(context.Context, *ContributorPeriodScoresRequest) (*ContributorPeriodScoresResponse, error)
 	mustEmbedUnimplementedLeaderboardStatServiceServer()
 }
 
@@ -81,6 +94,9 @@ func (UnimplementedLeaderboardStatServiceServer) GetContributorStats(context.Con
 func (UnimplementedLeaderboardStatServiceServer) GetPublicLeaderboard(context.Context, *GetPublicLeaderboardRequest) (*GetPublicLeaderboardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPublicLeaderboard not implemented")
 }
+func (UnimplementedLeaderboardStatServiceServer) GetContributorPeriodScores(context.Context, *ContributorPeriodScoresRequest) (*ContributorPeriodScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContributorPeriodScores not implemented")
+}
 func (UnimplementedLeaderboardStatServiceServer) mustEmbedUnimplementedLeaderboardStatServiceServer() {
 }
 func (UnimplementedLeaderboardStatServiceServer) testEmbeddedByValue() {}
@@ -139,6 +155,24 @@ func _LeaderboardStatService_GetPublicLeaderboard_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LeaderboardStatService_GetContributorPeriodScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContributorPeriodScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardStatServiceServer).GetContributorPeriodScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardStatService_GetContributorPeriodScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardStatServiceServer).GetContributorPeriodScores(ctx, req.(*ContributorPeriodScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LeaderboardStatService_ServiceDesc is the grpc.ServiceDesc for LeaderboardStatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,6 +188,10 @@ var LeaderboardStatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPublicLeaderboard",
 			Handler:    _LeaderboardStatService_GetPublicLeaderboard_Handler,
 		},
+		{
+			MethodName: "GetContributorPeriodScores",
+			Handler:    _LeaderboardStatService_GetContributorPeriodScores_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "leaderboardstat/leaderboardstat.proto",
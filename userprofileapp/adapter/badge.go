@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gocasters/rankr/adapter/leaderboardscoring"
+	lbscoring "github.com/gocasters/rankr/leaderboardscoringapp/service/leaderboardscoring"
+	"github.com/gocasters/rankr/userprofileapp/service/userprofile"
+)
+
+type BadgeRPC struct {
+	client *leaderboardscoring.Client
+}
+
+func NewBadgeRPC(client *leaderboardscoring.Client) BadgeRPC {
+	return BadgeRPC{client: client}
+}
+
+// GetBadges fetches the badges the contributor has earned from the leaderboardscoring app
+func (a BadgeRPC) GetBadges(ctx context.Context, userID int64) ([]userprofile.Badge, error) {
+	awards, err := a.client.ListUserBadges(ctx, lbscoring.ListUserBadgesRequest{
+		UserID: strconv.FormatInt(userID, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	badges := make([]userprofile.Badge, 0, len(awards))
+	for _, award := range awards {
+		badges = append(badges, userprofile.Badge{
+			ID:          award.BadgeID,
+			Name:        award.Name,
+			Description: award.Description,
+			ProjectID:   award.ProjectID,
+			AwardedAt:   award.AwardedAt,
+		})
+	}
+
+	return badges, nil
+}
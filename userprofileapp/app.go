@@ -3,6 +3,8 @@ package userprofileapp
 import (
 	"context"
 	"fmt"
+	"github.com/gocasters/rankr/adapter/leaderboardscoring"
+	"github.com/gocasters/rankr/pkg/grpc"
 	"github.com/gocasters/rankr/pkg/httpserver"
 	"github.com/gocasters/rankr/pkg/logger"
 	"github.com/gocasters/rankr/userprofileapp/adapter"
@@ -16,11 +18,12 @@ import (
 )
 
 type Application struct {
-	Validator  userprofile.Validator
-	Service    userprofile.Service
-	Handler    *http.Handler
-	HTTPServer http.Server
-	Config     Config
+	Validator        userprofile.Validator
+	Service          userprofile.Service
+	Handler          *http.Handler
+	HTTPServer       http.Server
+	Config           Config
+	scoringRPCClient *grpc.RPCClient
 }
 
 func Setup(ctx context.Context, cfg Config) (Application, error) {
@@ -28,14 +31,28 @@ func Setup(ctx context.Context, cfg Config) (Application, error) {
 	contributorStatRPC := adapter.NewContributorStatRPC()
 	taskRPC := adapter.NewTaskRPC()
 
+	// badges are read from the leaderboardscoring app
+	scoringRPCClient, err := grpc.NewClient(cfg.LeaderboardScoringRPC, logger.L())
+	if err != nil {
+		return Application{}, fmt.Errorf("failed to create leaderboardscoring RPC client: %w", err)
+	}
+
+	lbScoringClient, err := leaderboardscoring.New(scoringRPCClient)
+	if err != nil {
+		scoringRPCClient.Close()
+		return Application{}, fmt.Errorf("failed to create leaderboardscoring client: %w", err)
+	}
+	badgeRPC := adapter.NewBadgeRPC(lbScoringClient)
+
 	validator := userprofile.NewValidator(contributorInfoRPC)
 
-	service := userprofile.NewService(contributorInfoRPC, taskRPC, contributorStatRPC, validator)
+	service := userprofile.NewService(contributorInfoRPC, taskRPC, contributorStatRPC, badgeRPC, validator)
 
 	handler := http.NewHandler(service)
 
 	HTTPServer, err := httpserver.New(cfg.HTTPServer)
 	if err != nil {
+		scoringRPCClient.Close()
 		logger.L().Error("failed to initialize http server", "error", err)
 		return Application{}, err
 	}
@@ -43,11 +60,12 @@ func Setup(ctx context.Context, cfg Config) (Application, error) {
 	httpServer := http.New(HTTPServer, handler)
 
 	return Application{
-		Validator:  validator,
-		Service:    service,
-		Handler:    handler,
-		HTTPServer: httpServer,
-		Config:     cfg,
+		Validator:        validator,
+		Service:          service,
+		Handler:          handler,
+		HTTPServer:       httpServer,
+		Config:           cfg,
+		scoringRPCClient: scoringRPCClient,
 	}, nil
 }
 
@@ -73,6 +91,12 @@ func (app Application) Start() {
 	}
 
 	wg.Wait()
+
+	if app.scoringRPCClient != nil {
+		app.scoringRPCClient.Close()
+		userProfileLogger().Info("Scoring RPC client closed")
+	}
+
 	userProfileLogger().Info("user profile app stopped")
 }
 
@@ -1,11 +1,13 @@
 package userprofileapp
 
 import (
+	"github.com/gocasters/rankr/pkg/grpc"
 	"github.com/gocasters/rankr/pkg/httpserver"
 	"github.com/gocasters/rankr/pkg/logger"
 )
 
 type Config struct {
-	HTTPServer httpserver.Config `koanf:"http_server"`
-	Logger     logger.Config     `koanf:"logger"`
+	HTTPServer            httpserver.Config `koanf:"http_server"`
+	LeaderboardScoringRPC grpc.ClientConfig `koanf:"leaderboard_scoring_rpc"`
+	Logger                logger.Config     `koanf:"logger"`
 }
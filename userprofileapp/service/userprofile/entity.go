@@ -31,8 +31,18 @@ type ContributorStat struct {
 	ScoreHistory  map[string]int `json:"score_history"`
 }
 
+// Badge is an achievement the contributor has earned
+type Badge struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	AwardedAt   time.Time `json:"awarded_at"`
+}
+
 type Profile struct {
 	ContributorInfo ContributorInfo `json:"contributor_info"`
 	Tasks           []Task          `json:"task"`
 	ContributorStat ContributorStat `json:"contributor_stat"`
+	Badges          []Badge         `json:"badges"`
 }
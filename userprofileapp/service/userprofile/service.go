@@ -18,10 +18,15 @@ type LeaderboardStatRPC interface {
 	GetContributorStat(ctx context.Context, userID int64) (ContributorStat, error)
 }
 
+type BadgeRPC interface {
+	GetBadges(ctx context.Context, userID int64) ([]Badge, error)
+}
+
 type Service struct {
 	contributorInfo ContributorRPC
 	task            TaskRPC
 	leaderboardStat LeaderboardStatRPC
+	badge           BadgeRPC
 	validator       Validator
 }
 
@@ -29,12 +34,14 @@ func NewService(
 	contributorInfo ContributorRPC,
 	task TaskRPC,
 	leaderboardStat LeaderboardStatRPC,
+	badge BadgeRPC,
 	validator Validator,
 ) Service {
 	return Service{
 		contributorInfo: contributorInfo,
 		task:            task,
 		leaderboardStat: leaderboardStat,
+		badge:           badge,
 		validator:       validator,
 	}
 }
@@ -44,6 +51,7 @@ func (s Service) ContributorProfile(ctx context.Context, contributorID int64) (*
 	var contributorInfo ContributorInfo
 	var tasks = make([]Task, 0)
 	var contributorStat ContributorStat
+	var badges = make([]Badge, 0)
 
 	g, gctx := errgroup.WithContext(ctx)
 
@@ -80,6 +88,20 @@ func (s Service) ContributorProfile(ctx context.Context, contributorID int64) (*
 		return nil
 	})
 
+	// badges are optional, the profile is served without them when leaderboardscoring is down
+	g.Go(func() error {
+		bs, err := s.badge.GetBadges(gctx, contributorID)
+		if err != nil {
+			logger.L().Warn("userprofile-get-badges", "contributor_id", contributorID, "error", err)
+
+			return nil
+		}
+
+		badges = bs
+
+		return nil
+	})
+
 	if err := g.Wait(); err != nil {
 		logger.L().Error("userprofile-get-profile", "error", err)
 
@@ -91,6 +113,7 @@ func (s Service) ContributorProfile(ctx context.Context, contributorID int64) (*
 			ContributorInfo: contributorInfo,
 			Tasks:           tasks,
 			ContributorStat: contributorStat,
+			Badges:          badges,
 		},
 	}, nil
 }
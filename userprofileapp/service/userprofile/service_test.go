@@ -3,12 +3,32 @@ package userprofile
 import (
 	"context"
 	"errors"
+	"os"
 	"testing"
 
+	"github.com/gocasters/rankr/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+func TestMain(m *testing.M) {
+	// the logger only accepts relative paths, so it writes below a scratch working directory
+	dir, err := os.MkdirTemp("", "userprofile-test")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	if err := logger.Init(logger.Config{Level: "error", FilePath: "logs/test.log"}); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 // --- Mock types ---
 
 type mockContributorRPC struct {
@@ -38,6 +58,15 @@ func (m *mockLeaderboardStatRPC) GetContributorStat(ctx context.Context, userID
 	return args.Get(0).(ContributorStat), args.Error(1)
 }
 
+type mockBadgeRPC struct {
+	mock.Mock
+}
+
+func (m *mockBadgeRPC) GetBadges(ctx context.Context, userID int64) ([]Badge, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]Badge), args.Error(1)
+}
+
 // --- Tests ---
 
 func TestContributorProfile_Success(t *testing.T) {
@@ -48,6 +77,7 @@ func TestContributorProfile_Success(t *testing.T) {
 	expectedInfo := ContributorInfo{GitHubUsername: "John Doe"}
 	expectedTasks := []Task{{ID: 1}, {ID: 2}}
 	expectedStat := ContributorStat{GlobalRank: 5}
+	expectedBadges := []Badge{{ID: "first-merged-pr", Name: "First merged PR"}}
 
 	// set up mocks
 	mockInfo := new(mockContributorRPC)
@@ -59,10 +89,13 @@ func TestContributorProfile_Success(t *testing.T) {
 	mockStat := new(mockLeaderboardStatRPC)
 	mockStat.On("GetContributorStat", mock.Anything, userID).Return(expectedStat, nil)
 
+	mockBadge := new(mockBadgeRPC)
+	mockBadge.On("GetBadges", mock.Anything, userID).Return(expectedBadges, nil)
+
 	// use real validator (does nothing)
 	validator := NewValidator(nil)
 
-	svc := NewService(mockInfo, mockTask, mockStat, validator)
+	svc := NewService(mockInfo, mockTask, mockStat, mockBadge, validator)
 
 	resp, err := svc.ContributorProfile(ctx, userID)
 
@@ -71,10 +104,12 @@ func TestContributorProfile_Success(t *testing.T) {
 	assert.Equal(t, expectedInfo, resp.Profile.ContributorInfo)
 	assert.Equal(t, expectedTasks, resp.Profile.Tasks)
 	assert.Equal(t, expectedStat, resp.Profile.ContributorStat)
+	assert.Equal(t, expectedBadges, resp.Profile.Badges)
 
 	mockInfo.AssertExpectations(t)
 	mockTask.AssertExpectations(t)
 	mockStat.AssertExpectations(t)
+	mockBadge.AssertExpectations(t)
 }
 
 func TestContributorProfile_Error(t *testing.T) {
@@ -90,9 +125,12 @@ func TestContributorProfile_Error(t *testing.T) {
 	mockStat := new(mockLeaderboardStatRPC)
 	mockStat.On("GetContributorStat", mock.Anything, userID).Return(ContributorStat{}, nil)
 
+	mockBadge := new(mockBadgeRPC)
+	mockBadge.On("GetBadges", mock.Anything, userID).Return([]Badge{}, nil)
+
 	validator := NewValidator(nil)
 
-	svc := NewService(mockInfo, mockTask, mockStat, validator)
+	svc := NewService(mockInfo, mockTask, mockStat, mockBadge, validator)
 
 	resp, err := svc.ContributorProfile(ctx, userID)
 
@@ -100,3 +138,35 @@ func TestContributorProfile_Error(t *testing.T) {
 	assert.Nil(t, resp)
 	mockInfo.AssertExpectations(t)
 }
+
+func TestContributorProfile_BadgeErrorDegrades(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(123)
+
+	expectedInfo := ContributorInfo{GitHubUsername: "John Doe"}
+
+	mockInfo := new(mockContributorRPC)
+	mockInfo.On("GetProfileInfo", mock.Anything, userID).Return(expectedInfo, nil)
+
+	mockTask := new(mockTaskRPC)
+	mockTask.On("GetTasks", mock.Anything, userID).Return([]Task{}, nil)
+
+	mockStat := new(mockLeaderboardStatRPC)
+	mockStat.On("GetContributorStat", mock.Anything, userID).Return(ContributorStat{}, nil)
+
+	mockBadge := new(mockBadgeRPC)
+	mockBadge.On("GetBadges", mock.Anything, userID).Return([]Badge(nil), errors.New("unavailable"))
+
+	validator := NewValidator(nil)
+
+	svc := NewService(mockInfo, mockTask, mockStat, mockBadge, validator)
+
+	resp, err := svc.ContributorProfile(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, expectedInfo, resp.Profile.ContributorInfo)
+	assert.Empty(t, resp.Profile.Badges)
+	assert.NotNil(t, resp.Profile.Badges)
+	mockBadge.AssertExpectations(t)
+}